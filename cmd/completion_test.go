@@ -47,6 +47,14 @@ func TestCompletionCommand(t *testing.T) {
 			r, w, _ := os.Pipe()
 			os.Stdout = w
 
+			// Read concurrently: the bash script is larger than the pipe buffer
+			var buf bytes.Buffer
+			done := make(chan struct{})
+			go func() {
+				_, _ = buf.ReadFrom(r)
+				close(done)
+			}()
+
 			// Run completion command
 			completionCmd.Run(completionCmd, tt.args)
 
@@ -54,9 +62,7 @@ func TestCompletionCommand(t *testing.T) {
 			_ = w.Close()
 			os.Stdout = oldStdout
 
-			// Read captured output
-			var buf bytes.Buffer
-			_, _ = buf.ReadFrom(r)
+			<-done
 			output := buf.String()
 
 			// Verify output is not empty
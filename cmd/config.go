@@ -34,7 +34,8 @@ Available keys:
 
 Precedence (highest to lowest):
   1. ai.package.yaml install.targets (current directory)
-  2. ~/.config/aimgr/aimgr.yaml install.targets (global config)
+  2. targets of the active profile in ~/.config/aimgr/aimgr.yaml
+  3. ~/.config/aimgr/aimgr.yaml install.targets (global config)
 
 The source of the value is displayed in the output.
 
@@ -109,6 +110,10 @@ func configGet(cmd *cobra.Command, args []string) error {
 
 		targets = cfg.Install.Targets
 		source, _ = config.GetConfigPath()
+		if profile, ok := cfg.Profiles[cfg.ActiveProfile]; ok && len(profile.Targets) > 0 {
+			targets = profile.Targets
+			source = fmt.Sprintf("%s (profile %s)", source, cfg.ActiveProfile)
+		}
 	}
 
 	// Display source
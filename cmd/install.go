@@ -39,6 +39,7 @@ var resolveSourcePathForInstallBootstrap = resolveSourcePathForSync
 type installResult struct {
 	resourceType resource.ResourceType
 	name         string
+	sourceName   string // source qualifier of the requested reference, if any
 	success      bool
 	skipped      bool
 	message      string
@@ -132,6 +133,8 @@ Examples:
 	Args:              cobra.ArbitraryArgs, // Allow 0 or more args
 	ValidArgsFunction: completeInstallResources,
 	RunE: func(cmd *cobra.Command, args []string) error {
+		warnProfileMismatch()
+
 		// Handle zero-arg install (from ai.package.yaml)
 		if len(args) == 0 {
 			return installFromManifest()
@@ -189,6 +192,8 @@ Examples:
 			return fmt.Errorf("repository is not initialized at %s; run 'aimgr repo init' or 'aimgr repo apply-manifest <path-or-url>' first", manager.GetRepoPath())
 		}
 
+		autoSyncStaleSources(cmd.Context(), manager)
+
 		repoLock, err := manager.AcquireRepoReadLock(cmd.Context())
 		if err != nil {
 			return fmt.Errorf("failed to acquire repository read lock at %s: %w", manager.RepoLockPath(), err)
@@ -295,6 +300,10 @@ func installFromManifest() error {
 
 	printManifestInstallReadBanner(state.view)
 
+	if state.repoExists {
+		autoSyncStaleSources(context.Background(), state.manager)
+	}
+
 	repoLock, err := acquireManifestInstallRepoLock(state)
 	if err != nil {
 		return err
@@ -649,12 +658,20 @@ func bootstrapManifestSourcesForInstall(manager *repo.Manager, m *manifest.Manif
 }
 
 func syncManifestSourceForInstall(manager *repo.Manager, src *repomanifest.Source) error {
+	if err := checkSourcePolicy(sourcePolicyLocation(src.URL, src.Path)); err != nil {
+		return fmt.Errorf("source '%s': %w", src.Name, err)
+	}
+	orgPolicy, err := loadOrgPolicy()
+	if err != nil {
+		return err
+	}
+
 	sourcePath, err := resolveSourcePathForInstallBootstrap(src, manager)
 	if err != nil {
 		return fmt.Errorf("failed to prepare source '%s' (%s): %w", src.Name, sourceLocationSummary(src), err)
 	}
 
-	discovered, err := discoverImportResourcesByMode(sourcePath, src.Discovery)
+	discovered, err := discoverImportResourcesByMode(sourcePath, src.Discovery, newPluginFetcher(manager.GetRepoPath()))
 	if err != nil {
 		return fmt.Errorf("failed to discover resources for source '%s': %w", src.Name, err)
 	}
@@ -663,6 +680,10 @@ func syncManifestSourceForInstall(manager *repo.Manager, src *repomanifest.Sourc
 	if err != nil {
 		return fmt.Errorf("invalid include filter for source '%s': %w", src.Name, err)
 	}
+	commands, skills, agents, packages, err = applyExcludeFilter(src.Exclude, commands, skills, agents, packages)
+	if err != nil {
+		return fmt.Errorf("invalid exclude filter for source '%s': %w", src.Name, err)
+	}
 
 	allPaths := make([]string, 0, len(commands)+len(skills)+len(agents)+len(packages)+len(discovered.marketplacePackages))
 	for _, cmdRes := range commands {
@@ -707,7 +728,7 @@ func syncManifestSourceForInstall(manager *repo.Manager, src *repomanifest.Sourc
 		sourceType = "git-url"
 	}
 
-	bulkResult, err := manager.AddBulk(allPaths, repo.BulkImportOptions{
+	opts := repo.BulkImportOptions{
 		SourceName:   src.Name,
 		SourceID:     src.ID,
 		ImportMode:   "copy",
@@ -717,15 +738,32 @@ func syncManifestSourceForInstall(manager *repo.Manager, src *repomanifest.Sourc
 		SourceURL:    src.URL,
 		SourceType:   sourceType,
 		Ref:          src.Ref,
-	})
+		Policy:       orgPolicy,
+		Quarantine:   src.Review,
+	}
+	bulkResult, err := manager.AddBulk(allPaths, opts)
 	if err != nil {
 		return fmt.Errorf("failed to sync source '%s': %w", src.Name, err)
 	}
+
+	// Sources under review hold their generated packages in quarantine too.
+	if src.Review {
+		if err := quarantineMarketplacePackages(manager, discovered.marketplacePackages, nil, opts, bulkResult); err != nil {
+			return fmt.Errorf("failed to sync source '%s': %w", src.Name, err)
+		}
+	}
 	if len(bulkResult.Failed) > 0 {
 		return fmt.Errorf("failed to sync source '%s': %d resource(s) could not be imported", src.Name, len(bulkResult.Failed))
 	}
 
+	if src.Review {
+		return nil
+	}
 	for _, pkgInfo := range discovered.marketplacePackages {
+		pkgPath := resource.GetPackagePath(pkgInfo.Package.Name, manager.GetRepoPath())
+		if err := repo.CheckPackagePolicy(pkgPath, pkgInfo.Package, opts, bulkResult); err != nil {
+			return fmt.Errorf("failed to sync source '%s': package %q: %w", src.Name, pkgInfo.Package.Name, err)
+		}
 		if saveErr := resource.SavePackage(pkgInfo.Package, manager.GetRepoPath()); saveErr != nil {
 			return fmt.Errorf("failed to persist generated package %q from source '%s': %w", pkgInfo.Package.Name, src.Name, saveErr)
 		}
@@ -734,6 +772,19 @@ func syncManifestSourceForInstall(manager *repo.Manager, src *repomanifest.Sourc
 	return nil
 }
 
+// describeSourceFilters formats a source's include and exclude filters for
+// mismatch messages.
+func describeSourceFilters(src *repomanifest.Source) string {
+	var parts []string
+	if len(src.Include) > 0 {
+		parts = append(parts, fmt.Sprintf("include filters [%s]", strings.Join(src.Include, ", ")))
+	}
+	if len(src.Exclude) > 0 {
+		parts = append(parts, fmt.Sprintf("exclude filters [%s]", strings.Join(src.Exclude, ", ")))
+	}
+	return strings.Join(parts, " and ")
+}
+
 func failIfReusedSourceIncludeMismatch(manager *repo.Manager, m *manifest.Manifest, reusedSources []*repomanifest.Source) error {
 	if manager == nil || m == nil || len(reusedSources) == 0 {
 		return nil
@@ -747,7 +798,7 @@ func failIfReusedSourceIncludeMismatch(manager *repo.Manager, m *manifest.Manife
 	var mismatchMessages []string
 
 	for _, src := range reusedSources {
-		if src == nil || len(src.Include) == 0 {
+		if src == nil || (len(src.Include) == 0 && len(src.Exclude) == 0) {
 			continue
 		}
 
@@ -756,16 +807,17 @@ func failIfReusedSourceIncludeMismatch(manager *repo.Manager, m *manifest.Manife
 			continue
 		}
 
-		allResources, err := scanSourceResources(sourcePath, src.Discovery)
+		fetcher := newPluginFetcher(manager.GetRepoPath())
+		allResources, err := scanSourceResources(sourcePath, src.Discovery, fetcher)
 		if err != nil {
 			continue
 		}
 
-		filteredResources, err := scanSourceResources(sourcePath, src.Discovery)
+		filteredResources, err := scanSourceResources(sourcePath, src.Discovery, fetcher)
 		if err != nil {
 			continue
 		}
-		if err := applyIncludeFilterToDiscovered(filteredResources, src.Include); err != nil {
+		if err := applyIncludeFilterToDiscovered(filteredResources, src.Include, src.Exclude); err != nil {
 			continue
 		}
 
@@ -789,7 +841,7 @@ func failIfReusedSourceIncludeMismatch(manager *repo.Manager, m *manifest.Manife
 		}
 
 		sort.Strings(sourceMissing)
-		mismatchMessages = append(mismatchMessages, fmt.Sprintf("source '%s' reused with include filters [%s] excludes required resources: %s", src.Name, strings.Join(src.Include, ", "), strings.Join(sourceMissing, ", ")))
+		mismatchMessages = append(mismatchMessages, fmt.Sprintf("source '%s' reused with %s excludes required resources: %s", src.Name, describeSourceFilters(src), strings.Join(sourceMissing, ", ")))
 	}
 
 	if len(mismatchMessages) == 0 {
@@ -841,10 +893,37 @@ func describeManifestRemoteSource(url, subpath string) string {
 	return fmt.Sprintf("url %q (subpath %q)", url, subpath)
 }
 
+// checkResourceSource verifies that a source-qualified reference
+// ("skill/platform:code-review") resolves to the copy provided by that source.
+// Unqualified references (empty sourceName) always pass.
+func checkResourceSource(manager *repo.Manager, resType resource.ResourceType, name, sourceName string) error {
+	if sourceName == "" {
+		return nil
+	}
+
+	meta, err := manager.GetMetadata(name, resType)
+	if err == nil && meta.SourceName == sourceName {
+		return nil
+	}
+
+	ref := fmt.Sprintf("%s/%s", resType, name)
+	if srcMeta, loadErr := sourcemetadata.Load(manager.GetRepoPath()); loadErr == nil {
+		if winner, shadowed := srcMeta.ShadowedBy(sourceName, ref); shadowed {
+			return fmt.Errorf("%s from source %q is shadowed by source %q; raise the priority of %q in ai.repo.yaml to use it", ref, sourceName, winner, sourceName)
+		}
+	}
+
+	provider := "an unknown source"
+	if err == nil && meta.SourceName != "" {
+		provider = fmt.Sprintf("source %q", meta.SourceName)
+	}
+	return fmt.Errorf("%s is provided by %s, not source %q", ref, provider, sourceName)
+}
+
 // processInstall processes installing a single resource
 func processInstall(arg string, installer *install.Installer, manager *repo.Manager) installResult {
 	// Parse resource argument
-	resourceType, name, err := ParseResourceArg(arg)
+	resourceType, sourceName, name, err := ParseQualifiedResourceArg(arg)
 	if err != nil {
 		return installResult{
 			name:    arg,
@@ -856,6 +935,7 @@ func processInstall(arg string, installer *install.Installer, manager *repo.Mana
 	result := installResult{
 		resourceType: resourceType,
 		name:         name,
+		sourceName:   sourceName,
 		toolsAdded:   []tools.Tool{},
 	}
 
@@ -866,6 +946,16 @@ func processInstall(arg string, installer *install.Installer, manager *repo.Mana
 		result.message = fmt.Sprintf("%s '%s' not found in repository. Use 'aimgr list' to see available resources.", resourceType, name)
 		return result
 	}
+	if err := checkResourceSource(manager, resourceType, name, sourceName); err != nil {
+		result.success = false
+		result.message = err.Error()
+		return result
+	}
+	if err := checkInstallPolicy(manager, resourceType, name); err != nil {
+		result.success = false
+		result.message = err.Error()
+		return result
+	}
 
 	// Check if already installed
 	if !installForceFlag && installer.IsInstalled(name, resourceType) {
@@ -1008,6 +1098,9 @@ func installPackageWithWriter(packageName string, installer *install.Installer,
 	if err != nil {
 		return fmt.Errorf("package '%s' not found in repository: %w", packageName, err)
 	}
+	if err := checkInstallPolicy(manager, resource.PackageType, pkg.Name); err != nil {
+		return err
+	}
 
 	fmt.Fprintf(w, "Installing package: %s\n", pkg.Name)
 	fmt.Fprintf(w, "Description: %s\n\n", pkg.Description)
@@ -1018,8 +1111,8 @@ func installPackageWithWriter(packageName string, installer *install.Installer,
 
 	// Install each resource
 	for _, ref := range pkg.Resources {
-		// Parse type/name format
-		resType, resName, err := resource.ParseResourceReference(ref)
+		// Parse type/name format (optionally source-qualified)
+		resType, sourceName, resName, err := resource.ParseQualifiedResourceReference(ref)
 		if err != nil {
 			errors = append(errors, fmt.Sprintf("%s: %v", ref, err))
 			continue
@@ -1032,6 +1125,14 @@ func installPackageWithWriter(packageName string, installer *install.Installer,
 			missing++
 			continue
 		}
+		if err := checkResourceSource(manager, resType, resName, sourceName); err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", ref, err))
+			continue
+		}
+		if err := checkInstallPolicy(manager, resType, resName); err != nil {
+			errors = append(errors, fmt.Sprintf("%s: %v", ref, err))
+			continue
+		}
 
 		// Check if already installed
 		if !installForceFlag && installer.IsInstalled(resName, resType) {
@@ -1122,7 +1223,7 @@ func updateManifest(projectPath string, resource string) error {
 }
 
 // updateManifestFromResults batches ai.package.yaml updates from install results.
-// Only successful and skipped resources are added.
+// Only successful and skipped resources are added, keeping source qualifiers.
 func updateManifestFromResults(projectPath string, results []installResult) error {
 	// Skip if --no-save is set, or if not saving, or if installing from manifest
 	if installNoSaveFlag || !installSaveFlag || installingFromManifest {
@@ -1137,7 +1238,11 @@ func updateManifestFromResults(projectPath string, results []installResult) erro
 		if result.resourceType == "" || result.name == "" {
 			continue
 		}
-		resources = append(resources, fmt.Sprintf("%s/%s", result.resourceType, result.name))
+		name := result.name
+		if result.sourceName != "" {
+			name = result.sourceName + resource.SourceQualifierSeparator + name
+		}
+		resources = append(resources, fmt.Sprintf("%s/%s", result.resourceType, name))
 	}
 
 	if len(resources) == 0 {
@@ -9,6 +9,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -800,6 +801,7 @@ func TestUpdateManifestFromResults_BatchesEligibleResults(t *testing.T) {
 		{resourceType: resource.Skill, name: "pdf-processing", success: true},
 		{resourceType: resource.Command, name: "test-command", skipped: true},
 		{resourceType: resource.Agent, name: "code-reviewer", success: false},
+		{resourceType: resource.Skill, sourceName: "platform", name: "code-review", success: true},
 		{name: "bad-entry", success: true}, // missing type should be ignored
 	}
 
@@ -817,8 +819,11 @@ func TestUpdateManifestFromResults_BatchesEligibleResults(t *testing.T) {
 		t.Fatalf("failed to load manifest: %v", err)
 	}
 
-	if len(m.Resources) != 2 {
-		t.Fatalf("manifest resources = %v, want exactly 2 entries", m.Resources)
+	if len(m.Resources) != 3 {
+		t.Fatalf("manifest resources = %v, want exactly 3 entries", m.Resources)
+	}
+	if !slices.Contains(m.Resources, "skill/platform:code-review") {
+		t.Fatalf("manifest should keep the source qualifier of skill/platform:code-review: %v", m.Resources)
 	}
 	if !m.Has("skill/pdf-processing") {
 		t.Fatalf("manifest missing skill/pdf-processing: %v", m.Resources)
@@ -4,6 +4,10 @@ import (
 	"strings"
 	"testing"
 
+	resmeta "github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/sourcemetadata"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
 )
 
@@ -109,3 +113,58 @@ func TestParseTargetFlag(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckResourceSource(t *testing.T) {
+	repoPath := t.TempDir()
+	manager := repo.NewManagerWithPath(repoPath)
+
+	if err := resmeta.Save(&resmeta.ResourceMetadata{Name: "code-review", Type: resource.Skill}, repoPath, "platform"); err != nil {
+		t.Fatalf("failed to save resource metadata: %v", err)
+	}
+	srcMeta, _ := sourcemetadata.Load(repoPath)
+	srcMeta.SetShadowed("team", map[string]string{"skill/code-review": "platform"})
+	if err := srcMeta.Save(repoPath); err != nil {
+		t.Fatalf("failed to save source metadata: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		sourceName string
+		wantErr    string
+	}{
+		{name: "unqualified", sourceName: ""},
+		{name: "winning source", sourceName: "platform"},
+		{name: "shadowed source", sourceName: "team", wantErr: `shadowed by source "platform"`},
+		{name: "unrelated source", sourceName: "other", wantErr: `provided by source "platform", not source "other"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkResourceSource(manager, resource.Skill, "code-review", tt.sourceName)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("checkResourceSource() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("checkResourceSource() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseQualifiedResourceArg(t *testing.T) {
+	resType, sourceName, name, err := ParseQualifiedResourceArg("skills/platform:code-review")
+	if err != nil || resType != resource.Skill || sourceName != "platform" || name != "code-review" {
+		t.Fatalf("ParseQualifiedResourceArg() = (%v, %q, %q, %v)", resType, sourceName, name, err)
+	}
+
+	if _, _, _, err := ParseQualifiedResourceArg("skill/:code-review"); err == nil {
+		t.Fatal("expected error for empty source qualifier")
+	}
+
+	if _, name, err := ParseResourceArg("skill/platform:code-review"); err != nil || name != "code-review" {
+		t.Fatalf("ParseResourceArg() should drop the qualifier, got (%q, %v)", name, err)
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/pattern"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/sourcemetadata"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -25,7 +27,9 @@ var listCmd = &cobra.Command{
 
 The list command shows resources available in the global repository:
   - NAME: Resource reference (e.g., skill/pdf-processing, command/test)
-  - SOURCE: Source name the resource came from (e.g., ai-tools, anthropics-skills)
+  - SOURCE: Source name the resource came from (e.g., ai-tools, anthropics-skills).
+    When several sources provide the resource, the lower-priority sources it
+    shadows are listed too, e.g. "platform (shadows team)"
   - DESCRIPTION: Brief description of the resource
 
 This is a pure repository view showing what resources are available.
@@ -99,6 +103,8 @@ See also:
 			return nil
 		}
 
+		autoSyncStaleSources(cmd.Context(), manager)
+
 		repoLock, err := manager.AcquireRepoReadLock(cmd.Context())
 		if err != nil {
 			return fmt.Errorf("failed to acquire repository read lock at %s: %w", manager.RepoLockPath(), err)
@@ -111,6 +117,8 @@ See also:
 			return err
 		}
 
+		shadowed := loadShadowedResources(manager.GetRepoPath())
+
 		// Handle pattern-based filtering
 		if len(args) > 0 {
 			// Parse pattern to check if it's a package pattern
@@ -218,11 +226,11 @@ See also:
 			// Format output (no packages when pattern is used for resources)
 			switch formatFlag {
 			case "json":
-				return outputWithPackagesJSON(filtered, nil)
+				return outputWithPackagesJSON(filtered, nil, shadowed)
 			case "yaml":
-				return outputWithPackagesYAML(filtered, nil)
+				return outputWithPackagesYAML(filtered, nil, shadowed)
 			case "table":
-				return outputWithPackagesTable(manager, filtered, nil)
+				return outputWithPackagesTable(manager, filtered, nil, shadowed)
 			default:
 				return fmt.Errorf("invalid format: %s (must be 'table', 'json', or 'yaml')", formatFlag)
 			}
@@ -274,18 +282,72 @@ See also:
 		// Format output based on --format flag
 		switch formatFlag {
 		case "json":
-			return outputWithPackagesJSON(resources, packages)
+			return outputWithPackagesJSON(resources, packages, shadowed)
 		case "yaml":
-			return outputWithPackagesYAML(resources, packages)
+			return outputWithPackagesYAML(resources, packages, shadowed)
 		case "table":
-			return outputWithPackagesTable(manager, resources, packages)
+			return outputWithPackagesTable(manager, resources, packages, shadowed)
 		default:
 			return fmt.Errorf("invalid format: %s (must be 'table', 'json', or 'yaml')", formatFlag)
 		}
 	},
 }
 
-func outputWithPackagesTable(manager *repo.Manager, resources []resource.Resource, packages []repo.PackageInfo) error {
+// shadowedResource describes a source whose copy of a resource is hidden by a
+// higher-priority source.
+type shadowedResource struct {
+	Resource   string `json:"resource" yaml:"resource"`
+	Source     string `json:"source" yaml:"source"`
+	ShadowedBy string `json:"shadowed_by" yaml:"shadowed_by"`
+}
+
+// loadShadowedResources reads shadowing recorded by the last sync.
+// Missing or unreadable source metadata yields no entries.
+func loadShadowedResources(repoPath string) []shadowedResource {
+	metadata, err := sourcemetadata.Load(repoPath)
+	if err != nil {
+		return nil
+	}
+
+	var entries []shadowedResource
+	for sourceName, state := range metadata.Sources {
+		if state == nil {
+			continue
+		}
+		for ref, winner := range state.Shadowed {
+			entries = append(entries, shadowedResource{Resource: ref, Source: sourceName, ShadowedBy: winner})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Resource != entries[j].Resource {
+			return entries[i].Resource < entries[j].Resource
+		}
+		return entries[i].Source < entries[j].Source
+	})
+	return entries
+}
+
+// listSourceLabel renders the SOURCE column, appending the sources shadowed by
+// the winning source (e.g. "platform (shadows team)").
+func listSourceLabel(manager *repo.Manager, name string, resType resource.ResourceType, shadowedBy map[string][]string) string {
+	sourceName := "-"
+	meta, err := manager.GetMetadata(name, resType)
+	if err == nil && meta.SourceName != "" {
+		sourceName = meta.SourceName
+	}
+	if losers := shadowedBy[fmt.Sprintf("%s/%s", resType, name)]; len(losers) > 0 {
+		sourceName = fmt.Sprintf("%s (shadows %s)", sourceName, strings.Join(losers, ", "))
+	}
+	return sourceName
+}
+
+func outputWithPackagesTable(manager *repo.Manager, resources []resource.Resource, packages []repo.PackageInfo, shadowed []shadowedResource) error {
+	shadowedBy := make(map[string][]string)
+	for _, entry := range shadowed {
+		shadowedBy[entry.Resource] = append(shadowedBy[entry.Resource], entry.Source)
+	}
+
 	// Group resources by type
 	commands := []resource.Resource{}
 	skills := []resource.Resource{}
@@ -315,11 +377,7 @@ func outputWithPackagesTable(manager *repo.Manager, resources []resource.Resourc
 
 	// Add commands
 	for _, cmd := range commands {
-		meta, err := manager.GetMetadata(cmd.Name, cmd.Type)
-		sourceName := "-"
-		if err == nil && meta.SourceName != "" {
-			sourceName = meta.SourceName
-		}
+		sourceName := listSourceLabel(manager, cmd.Name, cmd.Type, shadowedBy)
 		table.AddRow(fmt.Sprintf("command/%s", cmd.Name), sourceName, cmd.Description)
 	}
 
@@ -330,11 +388,7 @@ func outputWithPackagesTable(manager *repo.Manager, resources []resource.Resourc
 
 	// Add skills
 	for _, skill := range skills {
-		meta, err := manager.GetMetadata(skill.Name, skill.Type)
-		sourceName := "-"
-		if err == nil && meta.SourceName != "" {
-			sourceName = meta.SourceName
-		}
+		sourceName := listSourceLabel(manager, skill.Name, skill.Type, shadowedBy)
 		table.AddRow(fmt.Sprintf("skill/%s", skill.Name), sourceName, skill.Description)
 	}
 
@@ -345,11 +399,7 @@ func outputWithPackagesTable(manager *repo.Manager, resources []resource.Resourc
 
 	// Add agents
 	for _, agent := range agents {
-		meta, err := manager.GetMetadata(agent.Name, agent.Type)
-		sourceName := "-"
-		if err == nil && meta.SourceName != "" {
-			sourceName = meta.SourceName
-		}
+		sourceName := listSourceLabel(manager, agent.Name, agent.Type, shadowedBy)
 		table.AddRow(fmt.Sprintf("agent/%s", agent.Name), sourceName, agent.Description)
 	}
 
@@ -360,11 +410,7 @@ func outputWithPackagesTable(manager *repo.Manager, resources []resource.Resourc
 
 	// Add packages
 	for _, pkg := range packages {
-		meta, err := manager.GetMetadata(pkg.Name, resource.PackageType)
-		sourceName := "-"
-		if err == nil && meta.SourceName != "" {
-			sourceName = meta.SourceName
-		}
+		sourceName := listSourceLabel(manager, pkg.Name, resource.PackageType, shadowedBy)
 		countStr := fmt.Sprintf("%d resources", pkg.ResourceCount)
 		fullDesc := fmt.Sprintf("%s %s", countStr, pkg.Description)
 		table.AddRow(fmt.Sprintf("package/%s", pkg.Name), sourceName, fullDesc)
@@ -393,11 +439,14 @@ func outputPackagesTable(packages []repo.PackageInfo) error {
 	return table.Format(output.Table)
 }
 
-func outputWithPackagesJSON(resources []resource.Resource, packages []repo.PackageInfo) error {
+func outputWithPackagesJSON(resources []resource.Resource, packages []repo.PackageInfo, shadowed []shadowedResource) error {
 	output := map[string]interface{}{
 		"resources": resources,
 		"packages":  packages,
 	}
+	if len(shadowed) > 0 {
+		output["shadowed"] = shadowed
+	}
 	encoder := json.NewEncoder(os.Stdout)
 	encoder.SetIndent("", "  ")
 	return encoder.Encode(output)
@@ -409,11 +458,14 @@ func outputPackagesJSON(packages []repo.PackageInfo) error {
 	return encoder.Encode(packages)
 }
 
-func outputWithPackagesYAML(resources []resource.Resource, packages []repo.PackageInfo) error {
+func outputWithPackagesYAML(resources []resource.Resource, packages []repo.PackageInfo, shadowed []shadowedResource) error {
 	output := map[string]interface{}{
 		"resources": resources,
 		"packages":  packages,
 	}
+	if len(shadowed) > 0 {
+		output["shadowed"] = shadowed
+	}
 	encoder := yaml.NewEncoder(os.Stdout)
 	defer func() { _ = encoder.Close() }()
 	return encoder.Encode(output)
@@ -426,7 +478,7 @@ func outputPackagesYAML(packages []repo.PackageInfo) error {
 }
 
 func outputTable(manager *repo.Manager, resources []resource.Resource) error {
-	return outputWithPackagesTable(manager, resources, nil)
+	return outputWithPackagesTable(manager, resources, nil, nil)
 }
 
 func outputJSON(resources []resource.Resource) error {
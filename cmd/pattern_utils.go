@@ -70,8 +70,17 @@ func ExpandPattern(mgr *repo.Manager, resourceArg string) ([]string, error) {
 
 // ParseResourceArg parses a resource argument in the format "type/name".
 // Supports both singular and plural type names (e.g., "skill" and "skills").
+// A source qualifier ("type/source:name") is accepted and dropped.
 // Returns the resource type, name, and any error.
 func ParseResourceArg(arg string) (resource.ResourceType, string, error) {
+	resourceType, _, name, err := ParseQualifiedResourceArg(arg)
+	return resourceType, name, err
+}
+
+// ParseQualifiedResourceArg parses a resource argument in the format
+// "type/name" or "type/source:name" (e.g., skill/platform:code-review).
+// Returns the resource type, source name (empty when unqualified), name, and any error.
+func ParseQualifiedResourceArg(arg string) (resource.ResourceType, string, string, error) {
 	if logger != nil {
 		logger.Debug("parsing resource argument",
 			"argument", arg,
@@ -92,7 +101,7 @@ func ParseResourceArg(arg string) (resource.ResourceType, string, error) {
 				"count", len(parts),
 				"reason", "expected exactly 2 parts (type/name)")
 		}
-		return "", "", fmt.Errorf("invalid format: must be 'type/name' (e.g., skill/my-skill, command/my-command, agent/my-agent)")
+		return "", "", "", fmt.Errorf("invalid format: must be 'type/name' (e.g., skill/my-skill, command/my-command, agent/my-agent)")
 	}
 
 	typeStr := strings.TrimSpace(parts[0])
@@ -108,7 +117,7 @@ func ParseResourceArg(arg string) (resource.ResourceType, string, error) {
 		if logger != nil {
 			logger.Debug("parsing failed: empty name")
 		}
-		return "", "", fmt.Errorf("resource name cannot be empty")
+		return "", "", "", fmt.Errorf("resource name cannot be empty")
 	}
 
 	// Parse and validate type
@@ -119,16 +128,22 @@ func ParseResourceArg(arg string) (resource.ResourceType, string, error) {
 				"type_string", typeStr,
 				"error", err.Error())
 		}
-		return "", "", err
+		return "", "", "", err
+	}
+
+	sourceName, name := resource.SplitSourceQualifier(name)
+	if strings.Contains(parts[1], resource.SourceQualifierSeparator) && (sourceName == "" || name == "" || strings.Contains(sourceName, "/")) {
+		return "", "", "", fmt.Errorf("invalid source qualifier in '%s': expected 'type/source:name' (e.g., skill/platform:code-review)", arg)
 	}
 
 	if logger != nil {
 		logger.Debug("parsing succeeded",
 			"type", resourceType,
+			"source", sourceName,
 			"name", name)
 	}
 
-	return resourceType, name, nil
+	return resourceType, sourceName, name, nil
 }
 
 // FormatResourceArg formats a resource as "type/name".
@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/policy"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/spf13/cobra"
+)
+
+var (
+	policyCheckFormatFlag string
+	policyCheckPolicyFlag string
+)
+
+// remotePolicyCache keeps policies fetched over HTTP(S) for the lifetime of
+// the process, so a sync of many sources downloads the policy once.
+var remotePolicyCache = map[string]*policy.Policy{}
+
+// policyCmd represents the policy command group.
+var policyCmd = &cobra.Command{
+	Use:   "policy",
+	Short: "Inspect and audit the organization policy",
+	Long: `Inspect and audit the organization policy.
+
+The policy file is referenced from aimgr.yaml by path or HTTP(S) URL:
+
+  policy: https://config.example.com/aimgr-policy.yaml
+
+It is enforced by 'repo add', 'repo sync', 'repo apply-manifest' and
+'install'.`,
+}
+
+var policyCheckCmd = &cobra.Command{
+	Use:   "check",
+	Short: "Audit the repository against the organization policy",
+	Long: `Audit every source and resource in the repository against the organization
+policy: allowed sources, forbidden allowed-tools entries and models, required
+licenses and the blocklist.
+
+The policy configured in aimgr.yaml is used unless --policy names another file
+or URL, e.g. to try out a draft before rolling it out.
+
+Exit status:
+  0 - No violations
+  1 - At least one violation
+  2 - No policy configured, invalid policy or usage error
+
+Examples:
+  aimgr policy check
+  aimgr policy check --policy ./draft-policy.yaml
+  aimgr policy check --format json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runPolicyCheck,
+}
+
+func init() {
+	rootCmd.AddCommand(policyCmd)
+	policyCmd.AddCommand(policyCheckCmd)
+
+	policyCheckCmd.Flags().StringVar(&policyCheckFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	policyCheckCmd.Flags().StringVar(&policyCheckPolicyFlag, "policy", "", "Policy file or URL (default: policy from aimgr.yaml)")
+	_ = policyCheckCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+}
+
+// loadOrgPolicy loads the policy configured in aimgr.yaml. It returns nil
+// when no policy is configured.
+func loadOrgPolicy() (*policy.Policy, error) {
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	location, err := cfg.PolicyLocation()
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy location: %w", err)
+	}
+	if location == "" {
+		return nil, nil
+	}
+	return loadPolicyFrom(location)
+}
+
+func loadPolicyFrom(location string) (*policy.Policy, error) {
+	remote := strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://")
+	if cached, ok := remotePolicyCache[location]; ok && remote {
+		return cached, nil
+	}
+	p, err := policy.Load(location)
+	if err != nil {
+		return nil, err
+	}
+	if remote {
+		remotePolicyCache[location] = p
+	}
+	return p, nil
+}
+
+// sourcePolicyLocation returns the location of a source as checked against
+// allowedSources: the URL of a remote source, or "local:" and the absolute
+// path of a local one.
+func sourcePolicyLocation(url, localPath string) string {
+	if url != "" {
+		return url
+	}
+	if localPath == "" {
+		return ""
+	}
+	if abs, err := filepath.Abs(localPath); err == nil {
+		localPath = abs
+	}
+	return "local:" + localPath
+}
+
+// checkSourcePolicy refuses a source location the organization policy does
+// not allow.
+func checkSourcePolicy(location string) error {
+	p, err := loadOrgPolicy()
+	if err != nil {
+		return err
+	}
+	if v := p.CheckSource(location); v != nil {
+		return fmt.Errorf("blocked by policy %s: %s", p.Location, v.Message)
+	}
+	return nil
+}
+
+// checkInstallPolicy refuses to install a repository resource that violates
+// the organization policy, including resources imported before the policy
+// was introduced.
+func checkInstallPolicy(manager *repo.Manager, resType resource.ResourceType, name string) error {
+	p, err := loadOrgPolicy()
+	if err != nil || p == nil {
+		return err
+	}
+
+	var violations []policy.Violation
+	if resType == resource.PackageType {
+		if v := p.CheckRef(resType, name); v != nil {
+			violations = append(violations, *v)
+		}
+	} else {
+		res, err := manager.Get(name, resType)
+		if err != nil {
+			return err
+		}
+		violations, err = p.CheckResource(res)
+		if err != nil {
+			return err
+		}
+	}
+	if meta, err := manager.GetMetadata(name, resType); err == nil {
+		if v := p.CheckSource(meta.SourceURL); v != nil {
+			violations = append(violations, *v)
+		}
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("blocked by policy %s: %s", p.Location, policy.Summarize(violations))
+}
+
+// policyCheckReport is the result of 'aimgr policy check'.
+type policyCheckReport struct {
+	Policy     string             `json:"policy" yaml:"policy"`
+	Sources    int                `json:"sources_checked" yaml:"sources_checked"`
+	Resources  int                `json:"resources_checked" yaml:"resources_checked"`
+	Violations []policy.Violation `json:"violations" yaml:"violations"`
+}
+
+func runPolicyCheck(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(policyCheckFormatFlag)
+	if err != nil {
+		return newOperationalFailureError(err)
+	}
+
+	var p *policy.Policy
+	if policyCheckPolicyFlag != "" {
+		p, err = loadPolicyFrom(policyCheckPolicyFlag)
+	} else {
+		p, err = loadOrgPolicy()
+	}
+	if err != nil {
+		return newOperationalFailureError(err)
+	}
+	if p == nil {
+		return newOperationalFailureError(errors.New("no policy configured: set 'policy' in aimgr.yaml or pass --policy"))
+	}
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return newOperationalFailureError(err)
+	}
+	repoLock, err := manager.AcquireRepoReadLock(cmd.Context())
+	if err != nil {
+		return newOperationalFailureError(fmt.Errorf("failed to acquire repository read lock at %s: %w", manager.RepoLockPath(), err))
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	report, err := auditRepoPolicy(manager, p)
+	if err != nil {
+		return newOperationalFailureError(err)
+	}
+
+	if format == output.Table {
+		displayPolicyCheckReport(report)
+	} else if err := output.FormatOutput(report, format); err != nil {
+		return err
+	}
+
+	if len(report.Violations) > 0 {
+		return newCompletedWithFindingsError(fmt.Sprintf("policy check found %d violation(s)", len(report.Violations)))
+	}
+	return nil
+}
+
+// auditRepoPolicy checks the manifest sources, then every resource and
+// package. A resource's own source is checked only when it is not one of the
+// manifest sources, so a disallowed source is reported once.
+func auditRepoPolicy(manager *repo.Manager, p *policy.Policy) (*policyCheckReport, error) {
+	report := &policyCheckReport{Policy: p.Location, Violations: []policy.Violation{}}
+	checkedSources := make(map[string]bool)
+	checkSource := func(location string) {
+		id := policy.SourceIdentity(location)
+		if location == "" || checkedSources[id] {
+			return
+		}
+		checkedSources[id] = true
+		report.Sources++
+		if v := p.CheckSource(location); v != nil {
+			report.Violations = append(report.Violations, *v)
+		}
+	}
+
+	manifest, err := repomanifest.Load(manager.GetRepoPath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", repomanifest.ManifestFileName, err)
+	}
+	for _, src := range manifest.Sources {
+		if src.URL != "" {
+			checkSource(src.URL)
+		} else {
+			checkSource(src.Path)
+		}
+	}
+
+	resources, err := manager.List(nil)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(resources, func(i, j int) bool {
+		if resources[i].Type != resources[j].Type {
+			return resources[i].Type < resources[j].Type
+		}
+		return resources[i].Name < resources[j].Name
+	})
+	for i := range resources {
+		res := &resources[i]
+		report.Resources++
+		violations, err := p.CheckResource(res)
+		if err != nil {
+			return nil, err
+		}
+		report.Violations = append(report.Violations, violations...)
+		if meta, err := manager.GetMetadata(res.Name, res.Type); err == nil {
+			checkSource(meta.SourceURL)
+		}
+	}
+
+	packages, err := manager.ListPackages()
+	if err != nil {
+		return nil, err
+	}
+	for _, pkg := range packages {
+		report.Resources++
+		if v := p.CheckRef(resource.PackageType, pkg.Name); v != nil {
+			report.Violations = append(report.Violations, *v)
+		}
+	}
+
+	return report, nil
+}
+
+func displayPolicyCheckReport(report *policyCheckReport) {
+	if len(report.Violations) > 0 {
+		table := output.NewTable("RULE", "SUBJECT", "MESSAGE")
+		table.WithResponsive().WithDynamicColumn(2).WithMinColumnWidths(18, 24, 30)
+		for _, v := range report.Violations {
+			subject := v.Resource
+			if subject == "" {
+				subject = v.Source
+			}
+			table.AddRow(v.Rule, subject, v.Message)
+		}
+		_ = table.Format(output.Table)
+		fmt.Println()
+	}
+
+	status := statusIconOK
+	if len(report.Violations) > 0 {
+		status = statusIconFail
+	}
+	fmt.Printf("%s Checked %d source(s) and %d resource(s): %d violation(s)\n",
+		status, report.Sources, report.Resources, len(report.Violations))
+	fmt.Printf("  policy: %s\n", report.Policy)
+}
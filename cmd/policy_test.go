@@ -0,0 +1,88 @@
+//go:build integration
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/policy"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+)
+
+func TestAuditRepoPolicy(t *testing.T) {
+	repoDir := t.TempDir()
+	manager := repo.NewManagerWithPath(repoDir)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("Failed to initialize repo: %v", err)
+	}
+
+	writeCommand := func(name, content, sourceURL string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repoDir, "commands", name+".md"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		meta := &metadata.ResourceMetadata{Name: name, Type: resource.Command, SourceType: "github", SourceURL: sourceURL}
+		if err := metadata.Save(meta, repoDir, "github"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeCommand("review", "---\ndescription: Review\nallowed-tools: Read, Grep\n---\nReview\n", "https://github.com/my-org/tools")
+	writeCommand("shell", "---\ndescription: Shell\nallowed-tools: Bash(*)\n---\nRun\n", "https://github.com/my-org/tools")
+	writeCommand("stray", "---\ndescription: Stray\n---\nStray\n", "https://github.com/someone/else")
+	writeCommand("stray-two", "---\ndescription: Stray two\n---\nStray\n", "https://github.com/someone/else.git")
+
+	p, err := policy.Parse([]byte("allowedSources: [github.com/my-org/*]\nforbiddenTools: [Bash]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.Location = "test-policy.yaml"
+
+	report, err := auditRepoPolicy(manager, p)
+	if err != nil {
+		t.Fatalf("auditRepoPolicy() error = %v", err)
+	}
+	if report.Resources != 4 || report.Sources != 2 {
+		t.Errorf("checked %d resources and %d sources, want 4 and 2", report.Resources, report.Sources)
+	}
+
+	rules := map[string]int{}
+	for _, v := range report.Violations {
+		rules[v.Rule]++
+	}
+	if rules[policy.RuleForbiddenTool] != 1 || rules[policy.RuleSourceNotAllowed] != 1 || len(report.Violations) != 2 {
+		t.Errorf("violations = %+v, want one forbidden tool and one disallowed source", report.Violations)
+	}
+}
+
+func TestSourcePolicyLocation_NormalizesLocalPaths(t *testing.T) {
+	base := t.TempDir()
+	t.Chdir(base)
+	if err := os.MkdirAll(filepath.Join(base, "team", "skills"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := policy.Parse([]byte("allowedSources: [\"local:" + filepath.ToSlash(base) + "/team/*\"]\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// repo add, repo sync and repo apply-manifest all check the same location
+	// for a relative path.
+	location := sourcePolicyLocation("", filepath.Join("team", "skills"))
+	if want := "local:" + filepath.Join(base, "team", "skills"); location != want {
+		t.Errorf("sourcePolicyLocation() = %q, want %q", location, want)
+	}
+	if v := p.CheckSource(location); v != nil {
+		t.Errorf("CheckSource(%q) = %v, want allowed", location, v)
+	}
+	if v := p.CheckSource(sourcePolicyLocation("", "elsewhere")); v == nil {
+		t.Error("expected a local path outside allowedSources to be refused")
+	}
+	if got := sourcePolicyLocation("https://github.com/my-org/tools", "ignored"); got != "https://github.com/my-org/tools" {
+		t.Errorf("remote location = %q, want the URL", got)
+	}
+}
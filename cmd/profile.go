@@ -0,0 +1,431 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/adrg/xdg"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/manifest"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileListFormatFlag   string
+	profileUsePinFlag       bool
+	profileUseProjectPath   string
+	profileCreatePathFlag   string
+	profileCreateTargetFlag string
+	profileCreateUseFlag    bool
+)
+
+// Where the active profile of an invocation was selected from.
+const (
+	profileSourceFlag   = "--profile"
+	profileSourceEnv    = config.ProfileEnvVar
+	profileSourcePin    = manifest.ManifestFileName
+	profileSourceConfig = config.DefaultConfigFileName
+)
+
+// profileSelection records how selectActiveProfile chose the profile for
+// this invocation, and the profile pinned by the project, if any.
+var profileSelection struct {
+	source  string
+	pinned  string
+	pinPath string
+}
+
+// profileInfo describes one profile in 'profile list' output.
+type profileInfo struct {
+	Name    string   `json:"name" yaml:"name"`
+	Path    string   `json:"path" yaml:"path"`
+	Targets []string `json:"targets,omitempty" yaml:"targets,omitempty"`
+	Active  bool     `json:"active" yaml:"active"`
+}
+
+// profileListOutput is the structured output of 'profile list'.
+type profileListOutput struct {
+	Active   string        `json:"active,omitempty" yaml:"active,omitempty"`
+	Source   string        `json:"source,omitempty" yaml:"source,omitempty"`
+	Profiles []profileInfo `json:"profiles" yaml:"profiles"`
+}
+
+// profileCmd represents the profile command group
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage named repository profiles",
+	Long: `Manage named repository profiles.
+
+A profile is a repository with its own default install targets, defined in
+aimgr.yaml:
+
+  profile: internal          # default profile (set by 'aimgr profile use')
+  profiles:
+    internal:
+      path: ~/ai/internal
+    client:
+      path: ~/ai/client
+      targets: [claude]
+
+The active profile is chosen by, in order: the --profile flag, the
+AIMGR_PROFILE environment variable, a 'profile:' pin in the project's
+ai.package.yaml, and the default profile in aimgr.yaml. Without a profile,
+repo.path is used. AIMGR_REPO_PATH still overrides the repository path.`,
+}
+
+// profileListCmd represents the profile list command
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List profiles and show the active one",
+	Args:  cobra.NoArgs,
+	RunE:  runProfileList,
+}
+
+// profileUseCmd represents the profile use command
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Set the default profile or pin one in a project",
+	Long: `Set the default profile in aimgr.yaml.
+
+With --pin, the profile is pinned in the project's ai.package.yaml instead, so
+every aimgr command run in that project uses it unless --profile or
+AIMGR_PROFILE selects another one.
+
+Examples:
+  aimgr profile use client
+  aimgr profile use client --pin
+  aimgr profile use client --pin --project-path ~/src/client-app`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProfileNames,
+	RunE:              runProfileUse,
+}
+
+// profileCreateCmd represents the profile create command
+var profileCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Define a new profile in aimgr.yaml",
+	Long: `Define a new profile in aimgr.yaml.
+
+Without --path, the repository lives at ~/.local/share/ai-config/profiles/<name>.
+Without --target, install.targets is used while the profile is active.
+
+Examples:
+  aimgr profile create client --path ~/ai/client --target claude
+  aimgr profile create internal --use`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProfileCreate,
+}
+
+// selectActiveProfile chooses the profile for this invocation before any
+// command runs, so every config.LoadGlobal and repository path lookup uses it.
+func selectActiveProfile(cmd *cobra.Command, args []string) error {
+	projectPath := ""
+	if flag := cmd.Flags().Lookup("project-path"); flag != nil {
+		projectPath = flag.Value.String()
+	}
+	if projectPath == "" {
+		projectPath, _ = os.Getwd()
+	}
+
+	profileSelection.source = ""
+	profileSelection.pinned, profileSelection.pinPath = projectProfilePin(projectPath)
+
+	name := ""
+	switch {
+	case profileFlag != "":
+		name, profileSelection.source = profileFlag, profileSourceFlag
+	case os.Getenv(config.ProfileEnvVar) != "":
+		name, profileSelection.source = os.Getenv(config.ProfileEnvVar), profileSourceEnv
+	case profileSelection.pinned != "":
+		name, profileSelection.source = profileSelection.pinned, profileSourcePin
+	}
+	config.SelectProfile(name)
+
+	if name == "" {
+		return nil
+	}
+	// Fail early: repository path lookups fall back to the default
+	// repository when the config cannot be loaded.
+	if _, err := config.LoadGlobal(); errors.Is(err, config.ErrUnknownProfile) {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%s: %w", profileSelection.source, err)
+	}
+	// AIMGR_REPO_PATH overrides the repository of pinned and default profiles,
+	// but an explicitly requested profile must not silently use another one.
+	explicit := profileSelection.source == profileSourceFlag || profileSelection.source == profileSourceEnv
+	if repoPath := os.Getenv("AIMGR_REPO_PATH"); repoPath != "" && explicit {
+		cmd.SilenceUsage = true
+		return fmt.Errorf("%s selects profile %q, but AIMGR_REPO_PATH=%s overrides its repository; unset one of them", profileSelection.source, name, repoPath)
+	}
+	return nil
+}
+
+// projectProfilePin returns the profile pinned by the project's manifests and
+// the file that pins it.
+func projectProfilePin(projectPath string) (string, string) {
+	view, err := manifest.LoadProjectManifests(projectPath)
+	if err != nil || !view.HasAny() || view.Effective.Profile == "" {
+		return "", ""
+	}
+	if view.Local != nil && view.Local.Profile != "" {
+		return view.Local.Profile, view.LocalPath
+	}
+	return view.Base.Profile, view.BasePath
+}
+
+// activeProfileSource describes where the active profile came from.
+func activeProfileSource(cfg *config.Config) string {
+	if profileSelection.source != "" {
+		return profileSelection.source
+	}
+	if cfg.ActiveProfile != "" {
+		return profileSourceConfig
+	}
+	return ""
+}
+
+// profileMismatch returns a message when the project pins a profile other
+// than the active one, or "" when they agree.
+func profileMismatch(cfg *config.Config) string {
+	if profileSelection.pinned == "" || profileSelection.pinned == cfg.ActiveProfile {
+		return ""
+	}
+	return fmt.Sprintf("%s pins profile %q, but profile %q is active (from %s)",
+		manifest.ManifestFileName, profileSelection.pinned, cfg.ActiveProfile, activeProfileSource(cfg))
+}
+
+// warnProfileMismatch prints a warning when the project pins a profile other
+// than the active one.
+func warnProfileMismatch() {
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return
+	}
+	if msg := profileMismatch(cfg); msg != "" {
+		fmt.Fprintf(os.Stderr, "⚠ Warning: %s; using %s.\n", msg, cfg.EffectiveRepoPath())
+	}
+}
+
+// otherProfileForTarget returns the profile, other than the active one,
+// whose repository contains target.
+func otherProfileForTarget(target string) (string, bool) {
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return "", false
+	}
+	for _, name := range cfg.ProfileNames() {
+		if name != cfg.ActiveProfile && pathWithin(target, cfg.Profiles[name].Path) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// pathWithin reports whether path is root or inside it.
+func pathWithin(path, root string) bool {
+	root = filepath.Clean(root)
+	path = filepath.Clean(path)
+	return path == root || strings.HasPrefix(path, root+string(filepath.Separator))
+}
+
+func runProfileList(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(profileListFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return err
+	}
+
+	out := profileListOutput{Active: cfg.ActiveProfile, Source: activeProfileSource(cfg), Profiles: []profileInfo{}}
+	for _, name := range cfg.ProfileNames() {
+		profile := cfg.Profiles[name]
+		out.Profiles = append(out.Profiles, profileInfo{
+			Name:    name,
+			Path:    profile.Path,
+			Targets: profile.Targets,
+			Active:  name == cfg.ActiveProfile,
+		})
+	}
+
+	if format != output.Table {
+		return output.FormatOutput(out, format)
+	}
+
+	if len(out.Profiles) == 0 {
+		fmt.Println("No profiles defined.")
+		fmt.Println("\nCreate one with: aimgr profile create <name> --path <repo-dir>")
+		return nil
+	}
+
+	table := output.NewTable("", "NAME", "PATH", "TARGETS")
+	for _, info := range out.Profiles {
+		marker := ""
+		if info.Active {
+			marker = "*"
+		}
+		targets := strings.Join(info.Targets, ", ")
+		if targets == "" {
+			targets = "(install.targets)"
+		}
+		table.AddRow(marker, info.Name, info.Path, targets)
+	}
+	if err := table.Format(format); err != nil {
+		return err
+	}
+
+	if out.Active == "" {
+		fmt.Println("\nNo profile is active; using repo.path or the default repository.")
+	} else {
+		fmt.Printf("\nActive profile: %s (from %s)\n", out.Active, out.Source)
+	}
+	if os.Getenv("AIMGR_REPO_PATH") != "" {
+		fmt.Printf("⚠ AIMGR_REPO_PATH is set and overrides the profile's repository path.\n")
+	}
+	if msg := profileMismatch(cfg); msg != "" {
+		fmt.Printf("⚠ %s\n", msg)
+	}
+	return nil
+}
+
+func runProfileUse(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	cfg, err := config.LoadGlobalForEdit()
+	if err != nil {
+		return err
+	}
+	if _, ok := cfg.Profiles[name]; !ok {
+		return fmt.Errorf("%w %q; create it with 'aimgr profile create %s --path <repo-dir>'", config.ErrUnknownProfile, name, name)
+	}
+
+	if profileUsePinFlag {
+		projectPath := profileUseProjectPath
+		if projectPath == "" {
+			projectPath, err = os.Getwd()
+			if err != nil {
+				return fmt.Errorf("failed to get current directory: %w", err)
+			}
+		}
+		manifestPath := filepath.Join(projectPath, manifest.ManifestFileName)
+		m, err := manifest.LoadOrCreate(manifestPath)
+		if err != nil {
+			return fmt.Errorf("failed to load %s: %w", manifestPath, err)
+		}
+		m.Profile = name
+		if err := m.Save(manifestPath); err != nil {
+			return fmt.Errorf("failed to save %s: %w", manifestPath, err)
+		}
+		fmt.Printf("✓ Pinned profile %s in %s\n", name, manifestPath)
+		fmt.Println("  Run 'aimgr repair' to reinstall the project's resources from this profile's repository.")
+		return nil
+	}
+
+	cfg.Profile = name
+	configPath, err := saveGlobalConfig(cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("✓ Default profile set to %s in %s\n", name, configPath)
+	if profileSelection.source == profileSourceFlag || profileSelection.source == profileSourceEnv || profileSelection.source == profileSourcePin {
+		fmt.Printf("⚠ This invocation selects a profile via %s, which takes precedence.\n", profileSelection.source)
+	}
+	return nil
+}
+
+func runProfileCreate(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	if err := config.ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	cfg, err := config.LoadGlobalForEdit()
+	if err != nil {
+		return err
+	}
+	if _, exists := cfg.Profiles[name]; exists {
+		return fmt.Errorf("profile %q already exists", name)
+	}
+
+	path := profileCreatePathFlag
+	if path == "" {
+		path = filepath.Join(xdg.DataHome, "ai-config", "profiles", name)
+	}
+	targets := splitAndTrim(profileCreateTargetFlag)
+	for _, target := range targets {
+		if _, err := tools.ParseTool(target); err != nil {
+			return fmt.Errorf("invalid tool '%s': %w\nValid tools: claude, opencode, copilot", target, err)
+		}
+	}
+
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]config.Profile)
+	}
+	cfg.Profiles[name] = config.Profile{Path: path, Targets: targets}
+	if profileCreateUseFlag {
+		cfg.Profile = name
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid profile: %w", err)
+	}
+
+	configPath, err := saveGlobalConfig(cfg)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Created profile %s (%s) in %s\n", name, cfg.Profiles[name].Path, configPath)
+	if profileCreateUseFlag {
+		fmt.Printf("✓ Default profile set to %s\n", name)
+	}
+	fmt.Printf("\nInitialize its repository with: aimgr --profile %s repo init\n", name)
+	return nil
+}
+
+func saveGlobalConfig(cfg *config.Config) (string, error) {
+	configPath, err := config.GlobalConfigPath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(configPath), 0755); err != nil {
+		return "", fmt.Errorf("creating config directory: %w", err)
+	}
+	if err := saveConfig(configPath, cfg); err != nil {
+		return "", fmt.Errorf("saving config: %w", err)
+	}
+	return configPath, nil
+}
+
+func completeProfileNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cfg, err := config.LoadGlobalForEdit()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return cfg.ProfileNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	rootCmd.AddCommand(profileCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileCreateCmd)
+
+	profileListCmd.Flags().StringVar(&profileListFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	_ = profileListCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+
+	profileUseCmd.Flags().BoolVar(&profileUsePinFlag, "pin", false, "Pin the profile in the project's ai.package.yaml instead of setting the default")
+	profileUseCmd.Flags().StringVar(&profileUseProjectPath, "project-path", "", "Project directory for --pin (default: current directory)")
+
+	profileCreateCmd.Flags().StringVar(&profileCreatePathFlag, "path", "", "Repository path for the profile (default: ~/.local/share/ai-config/profiles/<name>)")
+	profileCreateCmd.Flags().StringVar(&profileCreateTargetFlag, "target", "", "Default install targets while the profile is active (comma-separated)")
+	profileCreateCmd.Flags().BoolVar(&profileCreateUseFlag, "use", false, "Also make it the default profile")
+}
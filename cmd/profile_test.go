@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adrg/xdg"
+	"github.com/spf13/cobra"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/manifest"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+)
+
+func TestSelectActiveProfile_Precedence(t *testing.T) {
+	xdgConfigDir := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", xdgConfigDir)
+	oldFlag := profileFlag
+	t.Cleanup(func() {
+		profileFlag = oldFlag
+		profileSelection.source, profileSelection.pinned, profileSelection.pinPath = "", "", ""
+		config.SelectProfile("")
+		xdg.Reload()
+	})
+
+	configDir := filepath.Join(xdgConfigDir, "aimgr")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+	configYAML := `install:
+  targets: [claude]
+profile: default
+profiles:
+  default:
+    path: /repos/default
+  flag:
+    path: /repos/flag
+  env:
+    path: /repos/env
+  pinned:
+    path: /repos/pinned
+`
+	if err := os.WriteFile(filepath.Join(configDir, config.DefaultConfigFileName), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	xdg.Reload()
+
+	pinnedProject := t.TempDir()
+	pin := "profile: pinned\nresources: []\n"
+	if err := os.WriteFile(filepath.Join(pinnedProject, manifest.ManifestFileName), []byte(pin), 0644); err != nil {
+		t.Fatalf("failed to write project manifest: %v", err)
+	}
+	plainProject := t.TempDir()
+
+	tests := []struct {
+		name       string
+		flag       string
+		env        string
+		repoPath   string // AIMGR_REPO_PATH
+		project    string
+		wantActive string
+		wantSource string
+		wantRepo   string
+		wantErr    string
+	}{
+		{name: "flag overrides env and pin", flag: "flag", env: "env", project: pinnedProject, wantActive: "flag", wantSource: profileSourceFlag, wantRepo: "/repos/flag"},
+		{name: "env overrides pin", env: "env", project: pinnedProject, wantActive: "env", wantSource: profileSourceEnv, wantRepo: "/repos/env"},
+		{name: "pin overrides default", project: pinnedProject, wantActive: "pinned", wantSource: profileSourcePin, wantRepo: "/repos/pinned"},
+		{name: "default profile", project: plainProject, wantActive: "default", wantSource: profileSourceConfig, wantRepo: "/repos/default"},
+		{name: "AIMGR_REPO_PATH overrides a pinned profile repository", repoPath: "/repos/override", project: pinnedProject, wantActive: "pinned", wantSource: profileSourcePin, wantRepo: "/repos/override"},
+		{name: "AIMGR_REPO_PATH conflicts with the profile flag", flag: "flag", repoPath: "/repos/override", project: plainProject, wantErr: profileSourceFlag + ` selects profile "flag", but AIMGR_REPO_PATH`},
+		{name: "AIMGR_REPO_PATH conflicts with AIMGR_PROFILE", env: "env", repoPath: "/repos/override", project: plainProject, wantErr: profileSourceEnv + ` selects profile "env", but AIMGR_REPO_PATH`},
+		{name: "unknown flag profile", flag: "missing", env: "env", project: plainProject, wantErr: profileSourceFlag + ": unknown profile"},
+		{name: "unknown env profile", env: "missing", project: pinnedProject, wantErr: profileSourceEnv + ": unknown profile"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(config.ProfileEnvVar, tt.env)
+			t.Setenv("AIMGR_REPO_PATH", tt.repoPath)
+			profileFlag = tt.flag
+
+			cmd := &cobra.Command{Use: "test"}
+			cmd.Flags().String("project-path", tt.project, "")
+
+			err := selectActiveProfile(cmd, nil)
+			if tt.wantErr != "" {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Fatalf("selectActiveProfile() error = %v, want %q", err, tt.wantErr)
+				}
+				if strings.HasSuffix(tt.wantErr, "unknown profile") && !errors.Is(err, config.ErrUnknownProfile) {
+					t.Fatalf("selectActiveProfile() error = %v, want ErrUnknownProfile", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("selectActiveProfile() error = %v", err)
+			}
+
+			cfg, err := config.LoadGlobal()
+			if err != nil {
+				t.Fatalf("LoadGlobal() error = %v", err)
+			}
+			if cfg.ActiveProfile != tt.wantActive {
+				t.Errorf("active profile = %q, want %q", cfg.ActiveProfile, tt.wantActive)
+			}
+			if got := activeProfileSource(cfg); got != tt.wantSource {
+				t.Errorf("profile source = %q, want %q", got, tt.wantSource)
+			}
+			if got := repo.ResolveRepoPath(); got != tt.wantRepo {
+				t.Errorf("repository path = %q, want %q", got, tt.wantRepo)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package cmd
+
+import "github.com/spf13/cobra"
+
+// projectCmd represents the project command group.
+var projectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Inspect the resources installed in a project",
+	Long: `Inspect the resources installed in a project.
+
+Installing, verifying and repairing a project are top-level commands
+('aimgr install', 'aimgr verify', 'aimgr repair'); the commands in this
+group report on what a project's installed resources add up to.`,
+}
+
+func init() {
+	rootCmd.AddCommand(projectCmd)
+}
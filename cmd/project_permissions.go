@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/install"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/permissions"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
+	"github.com/spf13/cobra"
+)
+
+var (
+	projectPermissionsFormatFlag string
+	projectPermissionsPathFlag   string
+)
+
+var projectPermissionsCmd = &cobra.Command{
+	Use:   "permissions",
+	Short: "Summarize the tool permissions granted by installed resources",
+	Long: `Summarize the tool permissions granted by everything installed in a project,
+so reviewers can audit what its commands, skills and agents may do.
+
+Permissions come from the allowed-tools field of commands and skills and the
+tools field of agents, in Claude Code syntax:
+
+  Read                     the Read tool, unrestricted
+  Bash(git diff:*)         shell commands starting with "git diff"
+  Edit(src/**)             edits to paths matching src/**
+  WebFetch(domain:go.dev)  fetches from go.dev
+  mcp__github              every tool of the "github" MCP server
+  mcp__github__get_issue   one MCP tool
+
+Grants are grouped by tool, most sensitive capability first (all, shell,
+file-write, network, mcp, subagents, file-read, other). An agent without a
+tools field inherits every tool of the session and is listed under "all".
+
+Exit status:
+  0 - Summary printed
+  1 - At least one entry is not valid permission syntax
+  2 - Usage or operational error
+
+Examples:
+  aimgr project permissions
+  aimgr project permissions --project-path ~/src/app
+  aimgr project permissions --format json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runProjectPermissions,
+}
+
+func init() {
+	projectCmd.AddCommand(projectPermissionsCmd)
+	projectPermissionsCmd.Flags().StringVar(&projectPermissionsFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	projectPermissionsCmd.Flags().StringVar(&projectPermissionsPathFlag, "project-path", "", "Project directory path (default: current directory)")
+	_ = projectPermissionsCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+}
+
+// resourcePermissionIssue is an invalid permission entry of a resource.
+type resourcePermissionIssue struct {
+	Resource          string `json:"resource" yaml:"resource"`
+	permissions.Issue `yaml:",inline"`
+}
+
+// projectPermissionsReport is the result of 'aimgr project permissions'.
+type projectPermissionsReport struct {
+	Project   string                    `json:"project" yaml:"project"`
+	Resources int                       `json:"resources_checked" yaml:"resources_checked"`
+	Tools     []permissions.ToolSummary `json:"tools" yaml:"tools"`
+	Grants    []permissions.Grant       `json:"grants" yaml:"grants"`
+	Invalid   []resourcePermissionIssue `json:"invalid,omitempty" yaml:"invalid,omitempty"`
+}
+
+func runProjectPermissions(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(projectPermissionsFormatFlag)
+	if err != nil {
+		return newOperationalFailureError(err)
+	}
+
+	projectPath := projectPermissionsPathFlag
+	if projectPath == "" {
+		if projectPath, err = os.Getwd(); err != nil {
+			return newOperationalFailureError(fmt.Errorf("failed to get current directory: %w", err))
+		}
+	}
+
+	installed, err := listInstalledResources(projectPath)
+	if err != nil {
+		return newOperationalFailureError(err)
+	}
+	report, err := buildProjectPermissionsReport(projectPath, installed)
+	if err != nil {
+		return newOperationalFailureError(err)
+	}
+
+	if format == output.Table {
+		displayProjectPermissions(report)
+	} else if err := output.FormatOutput(report, format); err != nil {
+		return err
+	}
+
+	if len(report.Invalid) > 0 {
+		return newCompletedWithFindingsError(fmt.Sprintf("%d invalid permission entr(ies)", len(report.Invalid)))
+	}
+	return nil
+}
+
+// listInstalledResources returns the resources aimgr installed into the
+// project's tool directories, read from their install targets.
+func listInstalledResources(projectPath string) ([]resource.Resource, error) {
+	detected, err := tools.DetectExistingTools(projectPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to detect tools: %w", err)
+	}
+	if len(detected) == 0 {
+		return nil, nil
+	}
+	installer, err := install.NewInstallerWithTargets(projectPath, detected)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create installer: %w", err)
+	}
+	installed, err := installer.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installed resources: %w", err)
+	}
+
+	var healthy []resource.Resource
+	for _, res := range installed {
+		if res.Health == resource.HealthBroken || res.Type == resource.PackageType {
+			continue
+		}
+		healthy = append(healthy, res)
+	}
+	sort.Slice(healthy, func(i, j int) bool {
+		return FormatResourceArg(&healthy[i]) < FormatResourceArg(&healthy[j])
+	})
+	return healthy, nil
+}
+
+func buildProjectPermissionsReport(projectPath string, installed []resource.Resource) (*projectPermissionsReport, error) {
+	report := &projectPermissionsReport{
+		Project: projectPath,
+		Tools:   []permissions.ToolSummary{},
+		Grants:  []permissions.Grant{},
+	}
+	for i := range installed {
+		res := &installed[i]
+		grants, issues, err := resourcePermissions(res)
+		if err != nil {
+			return nil, err
+		}
+		report.Resources++
+		report.Grants = append(report.Grants, grants...)
+		for _, issue := range issues {
+			report.Invalid = append(report.Invalid, resourcePermissionIssue{Resource: FormatResourceArg(res), Issue: issue})
+		}
+	}
+	report.Tools = permissions.Summarize(report.Grants)
+	return report, nil
+}
+
+// resourcePermissions reads the permissions a command, skill or agent
+// grants. Agents without a tools field inherit every tool, as do OpenCode
+// agents whose tools map only switches individual tools off or on.
+func resourcePermissions(res *resource.Resource) ([]permissions.Grant, []permissions.Issue, error) {
+	path := res.Path
+	if res.Type == resource.Skill {
+		path = filepath.Join(res.Path, "SKILL.md")
+	}
+	frontmatter, _, err := resource.ParseFrontmatter(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", FormatResourceArg(res), err)
+	}
+
+	ref := FormatResourceArg(res)
+	fields := []string{"allowed-tools"}
+	if res.Type == resource.Agent {
+		fields = append(fields, "tools")
+	}
+
+	var grants []permissions.Grant
+	var issues []permissions.Issue
+	for _, field := range fields {
+		value, ok := frontmatter[field]
+		if !ok {
+			continue
+		}
+		if _, isMap := value.(map[string]interface{}); isMap && field == "tools" {
+			grants = append(grants, permissions.Grant{Spec: permissions.AllTools(), Resource: ref, Field: field})
+			continue
+		}
+		specs, fieldIssues := permissions.ParseField(field, value)
+		for _, spec := range specs {
+			grants = append(grants, permissions.Grant{Spec: spec, Resource: ref, Field: field})
+		}
+		issues = append(issues, fieldIssues...)
+	}
+	if res.Type == resource.Agent {
+		if _, ok := frontmatter["tools"]; !ok {
+			grants = append(grants, permissions.Grant{Spec: permissions.AllTools(), Resource: ref, Field: "tools"})
+		}
+	}
+	return grants, issues, nil
+}
+
+func displayProjectPermissions(report *projectPermissionsReport) {
+	if len(report.Tools) > 0 {
+		table := output.NewTable("CAPABILITY", "TOOL", "SCOPE", "GRANTED BY")
+		table.WithResponsive().WithDynamicColumn(3).WithMinColumnWidths(10, 12, 24, 24)
+		for _, t := range report.Tools {
+			table.AddRow(string(t.Capability), t.Tool, toolSummaryScope(t), strings.Join(t.GrantedBy, ", "))
+		}
+		_ = table.Format(output.Table)
+		fmt.Println()
+	}
+
+	if len(report.Invalid) > 0 {
+		fmt.Printf("✗ Invalid Permission Entries (%d):\n", len(report.Invalid))
+		for _, issue := range report.Invalid {
+			fmt.Printf("  - %s: %s: %s\n", issue.Resource, issue.Field, issue.Message)
+		}
+		fmt.Println()
+	}
+
+	status := statusIconOK
+	if len(report.Invalid) > 0 {
+		status = statusIconFail
+	}
+	fmt.Printf("%s Checked %d installed resource(s): %d tool(s) granted, %d invalid entr(ies)\n",
+		status, report.Resources, len(report.Tools), len(report.Invalid))
+	fmt.Printf("  project: %s\n", report.Project)
+}
+
+// toolSummaryScope describes the aggregate scope of a tool for the table.
+func toolSummaryScope(t permissions.ToolSummary) string {
+	switch {
+	case t.Capability == permissions.CapabilityAll:
+		return "every tool (no tools restriction)"
+	case t.Unrestricted && strings.HasPrefix(t.Tool, "mcp__"):
+		return "every tool of the server"
+	case t.Unrestricted:
+		return "any use"
+	default:
+		return strings.Join(t.Scopes, "; ")
+	}
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/permissions"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/test/testutil"
+)
+
+func TestBuildProjectPermissionsReport(t *testing.T) {
+	dir := t.TempDir()
+	reviewPath := filepath.Join(dir, "commands", "review.md")
+	testutil.WriteFile(t, reviewPath, "---\ndescription: Review\nallowed-tools: Read, Bash(git diff:*), Bash()\n---\nReview.\n")
+	writerPath := filepath.Join(dir, "agents", "writer.md")
+	testutil.WriteFile(t, writerPath, "---\ndescription: Writer\ntools: Read, Edit(docs/**)\n---\nWrite.\n")
+	generalPath := filepath.Join(dir, "agents", "general.md")
+	testutil.WriteFile(t, generalPath, "---\ndescription: General\n---\nAnything.\n")
+
+	installed := []resource.Resource{
+		{Name: "review", Type: resource.Command, Path: reviewPath},
+		{Name: "writer", Type: resource.Agent, Path: writerPath},
+		{Name: "general", Type: resource.Agent, Path: generalPath},
+	}
+	report, err := buildProjectPermissionsReport(dir, installed)
+	if err != nil {
+		t.Fatalf("buildProjectPermissionsReport() error = %v", err)
+	}
+
+	if report.Resources != 3 {
+		t.Errorf("Resources = %d, want 3", report.Resources)
+	}
+	if len(report.Invalid) != 1 || report.Invalid[0].Resource != "command/review" || report.Invalid[0].Entry != "Bash()" {
+		t.Errorf("Invalid = %+v, want Bash() of command/review", report.Invalid)
+	}
+
+	byTool := map[string]permissions.ToolSummary{}
+	for _, s := range report.Tools {
+		byTool[s.Tool] = s
+	}
+	if all, ok := byTool["*"]; !ok || len(all.GrantedBy) != 1 || all.GrantedBy[0] != "agent/general" {
+		t.Errorf("all-tools summary = %+v, want granted by agent/general", all)
+	}
+	if read := byTool["Read"]; !read.Unrestricted || len(read.GrantedBy) != 2 {
+		t.Errorf("Read summary = %+v, want unrestricted from two resources", read)
+	}
+	if bash := byTool["Bash"]; bash.Unrestricted || len(bash.Scopes) != 1 {
+		t.Errorf("Bash summary = %+v, want one scope", bash)
+	}
+}
+
+func TestRunResourceValidate_PermissionDiagnostics(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "commands", "deploy.md")
+	testutil.WriteFile(t, path, "---\ndescription: Deploy\nallowed-tools: Bash(kubectl apply:*, Deployer\n---\nDeploy.\n")
+
+	result := runResourceValidate(path, resourceValidateOptions{format: "table"})
+	codes := map[string]string{}
+	for _, d := range result.Output.Diagnostics {
+		codes[d.Code] = d.Severity
+	}
+	if codes["invalid_permission"] != "error" {
+		t.Errorf("expected invalid_permission error, got %v", codes)
+	}
+	if result.ExitCode == 0 {
+		t.Errorf("expected non-zero exit code for invalid permission")
+	}
+
+	testutil.WriteFile(t, path, "---\ndescription: Deploy\nallowed-tools: Read, Deployer\n---\nDeploy.\n")
+	result = runResourceValidate(path, resourceValidateOptions{format: "table"})
+	codes = map[string]string{}
+	for _, d := range result.Output.Diagnostics {
+		codes[d.Code] = d.Severity
+	}
+	if codes["unknown_tool"] != "warning" || codes["invalid_permission"] != "" {
+		t.Errorf("expected only unknown_tool warning, got %v", codes)
+	}
+}
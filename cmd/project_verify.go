@@ -7,10 +7,14 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/install"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/manifest"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/modifications"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resourcediff"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
@@ -23,10 +27,18 @@ var projectVerifyCmd = &cobra.Command{
 
 This command checks for common installation issues:
   - Broken symlinks (target doesn't exist)
-  - Symlinks pointing to wrong repository
+  - Symlinks pointing to wrong repository (or another profile's repository)
+  - ai.package.yaml pinning a profile other than the active one
   - Resources in ai.package.yaml that aren't installed
   - Undeclared content in owned directories (not in ai.package.yaml)
 
+With --integrity, the repository copy each installed resource links to is
+also hashed and compared with the per-file manifest recorded at import, and
+tool-specific variants in .modifications are compared with freshly generated
+ones.
+Modified, added or removed files are reported as errors and make the command
+exit with status 1, so CI can fail on tampered resources.
+
 Use 'aimgr repair' to reconcile project resources to ai.package.yaml.
 
 Examples:
@@ -34,7 +46,9 @@ Examples:
   aimgr verify --project-path ~/project  # Check specific directory
   aimgr repair                           # Reconcile owned directories to ai.package.yaml
   aimgr verify --format json             # JSON output for scripts
+  aimgr verify --integrity               # Also detect tampered resource files
 `,
+	SilenceUsage: true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		// Get project path
 		projectPath := verifyProjectPath
@@ -109,6 +123,22 @@ Examples:
 			issues = deduplicateIssues(issues, manifestIssues)
 		}
 
+		// Check the project's profile pin against the active profile
+		issues = append(issues, checkProfilePin()...)
+
+		// Compare installed content with the recorded manifests
+		tampered := false
+		if verifyIntegrityFlag {
+			integrityIssues, err := checkInstalledIntegrity(projectPath, detectedTools, manager)
+			if err != nil {
+				return newOperationalFailureError(fmt.Errorf("integrity check failed: %w", err))
+			}
+			for _, issue := range integrityIssues {
+				tampered = tampered || issue.Severity == "error"
+			}
+			issues = append(issues, integrityIssues...)
+		}
+
 		// Report results
 		if len(issues) == 0 {
 			return displayNoIssues(parsedFormat)
@@ -125,6 +155,12 @@ Examples:
 		// Show fix suggestion only for table format
 		if parsedFormat == output.Table {
 			fmt.Println("\nRun 'aimgr repair' to reconcile these issues")
+			if tampered {
+				fmt.Println("Run 'aimgr repo sync --local-changes take-upstream' to restore modified resources")
+			}
+		}
+		if tampered {
+			return newCompletedWithFindingsError("installed resources failed the integrity check")
 		}
 		return nil
 	},
@@ -133,7 +169,7 @@ Examples:
 type VerifyIssue struct {
 	Resource    string
 	Tool        string
-	IssueType   string // issueTypeBroken, issueTypeWrongRepo, issueTypeNotInstalled, issueTypeUndeclared
+	IssueType   string // issueTypeBroken, issueTypeWrongRepo, issueTypeWrongProfile, issueTypeNotInstalled, ...
 	Description string
 	Path        string
 	Severity    string // "error", "warning"
@@ -143,9 +179,13 @@ type VerifyIssue struct {
 const (
 	issueTypeBroken       = "broken"
 	issueTypeWrongRepo    = "wrong-repo"
+	issueTypeWrongProfile = "wrong-profile"
+	issueTypeProfilePin   = "profile-mismatch"
 	issueTypeNotInstalled = "not-installed"
 	issueTypeUndeclared   = "undeclared"
 	issueTypeUnreadable   = "unreadable"
+	issueTypeModified     = "modified"
+	issueTypeNoManifest   = "no-manifest"
 )
 
 // deduplicateIssues merges manifest issues into existing issues, dropping any
@@ -324,7 +364,17 @@ func verifySymlink(symlinkPath, resourceName, tool, repoPath string) *VerifyIssu
 	}
 
 	// Check if points to correct repo
-	if !strings.HasPrefix(target, repoPath) {
+	if !pathWithin(target, repoPath) {
+		if profile, ok := otherProfileForTarget(target); ok {
+			return &VerifyIssue{
+				Resource:    resourceName,
+				Tool:        tool,
+				IssueType:   issueTypeWrongProfile,
+				Description: fmt.Sprintf("Installed from profile %q repository: %s (expected: %s)", profile, target, repoPath),
+				Path:        symlinkPath,
+				Severity:    "warning",
+			}
+		}
 		return &VerifyIssue{
 			Resource:    resourceName,
 			Tool:        tool,
@@ -338,6 +388,162 @@ func verifySymlink(symlinkPath, resourceName, tool, repoPath string) *VerifyIssu
 	return nil
 }
 
+// checkInstalledIntegrity checks the repository copies of the installed
+// resources against their recorded manifests. Installed resources are
+// symlinks into the repository or into .modifications; tool variants there
+// are checked against a variant freshly generated from the repository copy.
+func checkInstalledIntegrity(projectPath string, detectedTools []tools.Tool, manager *repo.Manager) ([]VerifyIssue, error) {
+	installer, err := install.NewInstallerWithTargets(projectPath, detectedTools)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create installer: %w", err)
+	}
+	installed, err := installer.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installed resources: %w", err)
+	}
+
+	// Resources whose main file no longer parses are missing from the
+	// installer's list; the declared ones are picked up from the manifest.
+	seen := make(map[string]bool, len(installed))
+	for _, res := range installed {
+		seen[formatResourceReference(res.Type, res.Name)] = true
+	}
+	if mf, _, err := loadEffectiveProjectManifest(projectPath); err == nil && mf != nil {
+		refs, _ := expandManifestRefs(mf, manager.GetRepoPath())
+		for _, ref := range refs {
+			resType, resName, err := resource.ParseResourceReference(ref)
+			if err != nil || seen[ref] || !isResourceInstalledInTools(string(resType), resName, projectPath, detectedTools) {
+				continue
+			}
+			seen[ref] = true
+			installed = append(installed, resource.Resource{Name: resName, Type: resType})
+		}
+	}
+
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	variants := modifications.NewGenerator(manager.GetRepoPath(), cfg.Mappings, nil)
+	variantDir, err := os.MkdirTemp("", "aimgr-verify-modifications-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(variantDir)
+	}()
+
+	var issues []VerifyIssue
+	for _, res := range installed {
+		// Resources unknown to the repository are reported as broken or
+		// wrong-repo symlinks already.
+		if _, err := manager.GetMetadata(res.Name, res.Type); err != nil {
+			continue
+		}
+		if _, err := os.Stat(manager.GetPath(res.Name, res.Type)); err != nil {
+			continue
+		}
+		variantIssues, err := checkVariantIntegrity(projectPath, detectedTools, manager, variants, variantDir, res)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, variantIssues...)
+		report, err := manager.VerifyIntegrity(res.Name, res.Type)
+		if err != nil {
+			return nil, err
+		}
+		switch report.Status {
+		case repo.IntegrityModified:
+			issues = append(issues, VerifyIssue{
+				Resource:    formatResourceReference(res.Type, res.Name),
+				IssueType:   issueTypeModified,
+				Description: describeIntegrityChanges(*report),
+				Path:        report.Path,
+				Severity:    "error",
+			})
+		case repo.IntegrityNoManifest:
+			issues = append(issues, VerifyIssue{
+				Resource:    formatResourceReference(res.Type, res.Name),
+				IssueType:   issueTypeNoManifest,
+				Description: describeIntegrityChanges(*report),
+				Path:        report.Path,
+				Severity:    "warning",
+			})
+		}
+	}
+	return issues, nil
+}
+
+// checkVariantIntegrity compares the .modifications variants an installed
+// resource links to with variants regenerated from the repository copy. The
+// import manifest only covers the repository copy, so edits to a variant are
+// caught here.
+func checkVariantIntegrity(projectPath string, detectedTools []tools.Tool, manager *repo.Manager, variants *modifications.Generator, variantDir string, installed resource.Resource) ([]VerifyIssue, error) {
+	res, err := manager.Get(installed.Name, installed.Type)
+	if err != nil {
+		return nil, nil
+	}
+
+	var issues []VerifyIssue
+	for _, tool := range detectedTools {
+		toolName := tool.String()
+		variantPath := variants.GetModificationPath(res, toolName)
+		if variantPath == "" || !isResourceInstalledInTools(string(res.Type), res.Name, projectPath, []tools.Tool{tool}) {
+			continue
+		}
+
+		expected := resourcediff.FileSet{}
+		generatedPath, err := variants.Render(res, toolName, variantDir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to regenerate %s variant of %s: %w", toolName, formatResourceReference(res.Type, res.Name), err)
+		}
+		if generatedPath != "" {
+			if expected, err = resourcediff.LoadPath(generatedPath); err != nil {
+				return nil, err
+			}
+		}
+		actual, err := resourcediff.LoadPath(variantPath)
+		if err != nil {
+			return nil, err
+		}
+
+		changes := resourcediff.NewManifest(expected).Check(actual)
+		if changes.Empty() {
+			continue
+		}
+		issues = append(issues, VerifyIssue{
+			Resource:    formatResourceReference(res.Type, res.Name),
+			Tool:        toolName,
+			IssueType:   issueTypeModified,
+			Description: "Tool variant differs from the repository copy: " + describeIntegrityChanges(repo.IntegrityReport{ManifestChanges: changes}),
+			Path:        variantPath,
+			Severity:    "error",
+		})
+	}
+	return issues, nil
+}
+
+// checkProfilePin reports a project whose ai.package.yaml pins a profile
+// other than the active one.
+func checkProfilePin() []VerifyIssue {
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return nil
+	}
+	msg := profileMismatch(cfg)
+	if msg == "" {
+		return nil
+	}
+	return []VerifyIssue{{
+		Resource:    "profile/" + profileSelection.pinned,
+		Tool:        "any",
+		IssueType:   issueTypeProfilePin,
+		Description: msg,
+		Path:        profileSelection.pinPath,
+		Severity:    "warning",
+	}}
+}
+
 func checkManifestSync(projectPath string, detectedTools []tools.Tool, repoPath string) ([]VerifyIssue, error) {
 	mf, view, err := loadEffectiveProjectManifest(projectPath)
 	if err != nil {
@@ -777,6 +983,8 @@ var (
 	verifyProjectPath string
 	verifyFixFlag     bool
 	verifyFormatFlag2 string
+
+	verifyIntegrityFlag bool
 )
 
 func init() {
@@ -785,6 +993,7 @@ func init() {
 	projectVerifyCmd.Flags().BoolVar(&verifyFixFlag, "fix", false, "Run repair reconciliation (deprecated, use 'aimgr repair')")
 	_ = projectVerifyCmd.Flags().MarkDeprecated("fix", "use 'aimgr repair' (reconcile project resources)")
 	projectVerifyCmd.Flags().StringVar(&verifyFormatFlag2, "format", "table", "Output format (table|json|yaml)")
+	projectVerifyCmd.Flags().BoolVar(&verifyIntegrityFlag, "integrity", false, "Also compare installed resource files with the SHA-256 manifest recorded at import")
 
 	// Register completion functions
 	_ = projectVerifyCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
@@ -6,8 +6,13 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/adrg/xdg"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/install"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/manifest"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
 )
@@ -1503,3 +1508,75 @@ func TestFindUndeclaredResources(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckInstalledIntegrity_ChecksToolVariants(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	xdgConfigDir := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", xdgConfigDir)
+	t.Cleanup(xdg.Reload)
+	if err := os.MkdirAll(filepath.Join(xdgConfigDir, "aimgr"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	configYAML := "install:\n  targets: [opencode]\nmappings:\n  command:\n    model:\n      opus-4:\n        opencode: langdock/claude-opus-4\n"
+	if err := os.WriteFile(filepath.Join(xdgConfigDir, "aimgr", config.DefaultConfigFileName), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	xdg.Reload()
+
+	repoDir := t.TempDir()
+	manager := repo.NewManagerWithPath(repoDir)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+	commandPath := filepath.Join(t.TempDir(), "commands", "deploy.md")
+	if err := os.MkdirAll(filepath.Dir(commandPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(commandPath, []byte("---\ndescription: Deploy\nmodel: opus-4\n---\n# Deploy\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := manager.AddBulk([]string{commandPath}, repo.BulkImportOptions{ImportMode: "copy"}); err != nil {
+		t.Fatalf("AddBulk() error = %v", err)
+	}
+	syncRegenerateModifications(manager, repoDir)
+
+	projectDir := t.TempDir()
+	targets := []tools.Tool{tools.OpenCode}
+	installer, err := install.NewInstallerWithTargets(projectDir, targets)
+	if err != nil {
+		t.Fatalf("NewInstallerWithTargets() error = %v", err)
+	}
+	if err := installer.InstallCommand("deploy", manager); err != nil {
+		t.Fatalf("InstallCommand() error = %v", err)
+	}
+
+	issues, err := checkInstalledIntegrity(projectDir, targets, manager)
+	if err != nil {
+		t.Fatalf("checkInstalledIntegrity() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("checkInstalledIntegrity() = %+v before edits, want none", issues)
+	}
+
+	// The installed symlink points at the variant, not the repository copy.
+	variantPath := filepath.Join(repoDir, ".modifications", "opencode", "commands", "deploy.md")
+	if err := os.WriteFile(variantPath, []byte("---\ndescription: Deploy\nmodel: langdock/claude-opus-4\n---\n# Deploy\nrm -rf /\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, err = checkInstalledIntegrity(projectDir, targets, manager)
+	if err != nil {
+		t.Fatalf("checkInstalledIntegrity() error = %v", err)
+	}
+	if len(issues) != 1 || issues[0].IssueType != issueTypeModified || issues[0].Tool != "opencode" || issues[0].Path != variantPath {
+		t.Fatalf("checkInstalledIntegrity() = %+v, want the opencode variant reported as modified", issues)
+	}
+	if !strings.Contains(issues[0].Description, "modified: deploy.md") {
+		t.Errorf("description = %q, want it to list deploy.md", issues[0].Description)
+	}
+}
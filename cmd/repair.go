@@ -97,6 +97,8 @@ Use --dry-run to preview all planned actions without changing files.
 			return err
 		}
 
+		warnProfileMismatch()
+
 		manager, err := NewManagerWithLogLevel()
 		if err != nil {
 			return err
@@ -420,7 +422,10 @@ func inspectPath(path, repoPath string) (string, error) {
 	if !filepath.IsAbs(target) {
 		target = filepath.Clean(filepath.Join(filepath.Dir(path), target))
 	}
-	if !strings.HasPrefix(target, repoPath) {
+	if !pathWithin(target, repoPath) {
+		if _, ok := otherProfileForTarget(target); ok {
+			return issueTypeWrongProfile, nil
+		}
 		return "wrong-repo", nil
 	}
 	return "healthy", nil
@@ -6,9 +6,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/discovery"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/marketplace"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
@@ -16,6 +18,7 @@ import (
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/secrets"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/source"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/sourcemetadata"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/workspace"
@@ -30,17 +33,41 @@ var (
 	skipExistingFlag bool
 	dryRunFlag       bool
 	filterFlags      []string
+	excludeFlags     []string
 	addFormatFlag    string
 	nameFlag         string
 	discoveryFlag    string
 	refFlag          string
 	subpathFlag      string
+	reviewFlag       bool
 
 	// syncSilentMode suppresses all fmt.Printf output in importFromLocalPathWithMode
 	// and printImportResults. Set to true by runSync() to collect results silently.
 	syncSilentMode bool
 )
 
+// importOptions holds the per-source settings of an import that go beyond
+// the filter: what to drop, how to treat local edits and whether to review.
+type importOptions struct {
+	// exclude drops matching resources after the include filter has been
+	// applied. Set from --exclude by repo add and per source by repo sync.
+	exclude []string
+
+	// shadowed lists resource references ("type/name") that the import must
+	// skip because a higher-priority source provides them, mapped to that
+	// source's name. Set per source by repo sync.
+	shadowed map[string]string
+
+	// localChanges is the policy for forced re-imports of resources that were
+	// edited inside the repository (config.LocalChanges*). Empty keeps the
+	// plain overwrite behavior of repo add --force.
+	localChanges string
+
+	// review holds new and changed resources in the quarantine for
+	// 'repo review' instead of importing them.
+	review bool
+}
+
 // repoAddCmd represents the add command
 var repoAddCmd = &cobra.Command{
 	Use:   "add <source>",
@@ -149,7 +176,21 @@ Examples:
 
   Filters are persisted in ai.repo.yaml (source.include) and respected by repo sync.
   Multiple --filter flags may be specified; a resource is imported if ANY pattern matches.
-  Re-adding an existing source with --filter replaces its include list; without --filter clears it.`,
+  Re-adding an existing source with --filter replaces its include list; without --filter clears it.
+
+  # Hold new resources for review before they can be installed:
+  aimgr repo add gh:third-party/skills --review
+  aimgr repo review
+
+  The review setting is persisted in ai.repo.yaml (source.review), so later
+  syncs of the source quarantine its changes too.
+
+  # Exclude resources after filtering (repeatable):
+  aimgr repo add gh:owner/repo --exclude 'skill/experimental-*'
+  aimgr repo add gh:owner/repo --filter 'skill/*' --exclude skill/draft
+
+  Exclude patterns use the same syntax as --filter and are applied after it;
+  they are persisted in ai.repo.yaml (source.exclude) and respected by repo sync.`,
 	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 		return nil, cobra.ShellCompDirectiveDefault
 	},
@@ -195,6 +236,10 @@ Run 'aimgr repo add --help' for full documentation`)
 			return err
 		}
 
+		if err := checkSourcePolicy(sourcePolicyLocation(parsed.URL, parsed.LocalPath)); err != nil {
+			return err
+		}
+
 		// Create manager
 		manager, err := NewManagerWithLogLevel()
 		if err != nil {
@@ -213,6 +258,8 @@ Run 'aimgr repo add --help' for full documentation`)
 			return err
 		}
 
+		opts := importOptions{exclude: excludeFlags, review: reviewFlag}
+
 		// Auto-detect: URL or local path?
 		isRemote := parsed.Type == source.GitHub || parsed.Type == source.GitURL
 
@@ -222,7 +269,7 @@ Run 'aimgr repo add --help' for full documentation`)
 		if isRemote {
 			// Remote source: always use copy mode
 			importMode = "copy"
-			addErr = addBulkFromGitHub(parsed, manager)
+			addErr = addBulkFromGitHub(parsed, manager, opts)
 		} else {
 			// Local source: always use symlink mode
 			importMode = "symlink"
@@ -235,7 +282,7 @@ Run 'aimgr repo add --help' for full documentation`)
 			tempSource := repomanifest.Source{Path: absPath}
 			sourceID := repomanifest.GenerateSourceID(&tempSource)
 
-			addErr = addBulkFromLocalWithMode(parsed.LocalPath, manager, filterFlags, sourceID, importMode, "")
+			addErr = addBulkFromLocalWithMode(parsed.LocalPath, manager, filterFlags, sourceID, importMode, "", opts)
 		}
 
 		// If add operation failed or in dry-run mode, return early
@@ -244,7 +291,7 @@ Run 'aimgr repo add --help' for full documentation`)
 		}
 
 		// Add source to manifest after successful add
-		if err := addSourceToManifest(manager, parsed, filterFlags, discoveryFlag); err != nil {
+		if err := addSourceToManifest(manager, parsed, filterFlags, excludeFlags, discoveryFlag, reviewFlag); err != nil {
 			// Don't fail the entire operation if manifest tracking fails
 			fmt.Fprintf(os.Stderr, "Warning: Failed to track source in manifest: %v\n", err)
 		} else {
@@ -270,11 +317,13 @@ func init() {
 	repoAddCmd.Flags().BoolVar(&skipExistingFlag, "skip-existing", false, "Skip conflicts silently")
 	repoAddCmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Preview without adding")
 	repoAddCmd.Flags().StringArrayVar(&filterFlags, "filter", nil, "Filter resources by pattern, repeatable (e.g., --filter skill/pdf --filter 'skill/web*')")
+	repoAddCmd.Flags().StringArrayVar(&excludeFlags, "exclude", nil, "Exclude resources by pattern after --filter, repeatable (e.g., --exclude 'skill/experimental-*')")
 	repoAddCmd.Flags().StringVar(&addFormatFlag, "format", "table", "Output format: table, json, yaml")
 	repoAddCmd.Flags().StringVar(&nameFlag, "name", "", "Override auto-generated source name")
 	repoAddCmd.Flags().StringVar(&discoveryFlag, "discovery", repomanifest.DiscoveryModeAuto, "Discovery mode: auto, marketplace, generic")
 	repoAddCmd.Flags().StringVar(&refFlag, "ref", "", "Preferred explicit git ref (remote sources only). Do not mix with inline @ref syntax")
 	repoAddCmd.Flags().StringVar(&subpathFlag, "subpath", "", "Preferred explicit repository subpath (remote sources only). Do not mix with inline subpath syntax")
+	repoAddCmd.Flags().BoolVar(&reviewFlag, "review", false, "Hold new and changed resources in quarantine until approved with 'aimgr repo review'")
 	_ = repoAddCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
 }
 
@@ -462,6 +511,51 @@ func applyFilter(filterPatterns []string, commands, skills, agents []*resource.R
 	return filteredCommands, filteredSkills, filteredAgents, filteredPackages, nil
 }
 
+// applyExcludeFilter removes discovered resources matching any of the exclude
+// patterns. Empty/nil excludePatterns returns all resources unchanged.
+func applyExcludeFilter(excludePatterns []string, commands, skills, agents []*resource.Resource, packages []*resource.Package) ([]*resource.Resource, []*resource.Resource, []*resource.Resource, []*resource.Package, error) {
+	if len(excludePatterns) == 0 {
+		return commands, skills, agents, packages, nil
+	}
+
+	mm, err := pattern.NewMultiMatcher(excludePatterns)
+	if err != nil {
+		return nil, nil, nil, nil, fmt.Errorf("invalid exclude pattern: %w", err)
+	}
+
+	keep := func(resources []*resource.Resource) []*resource.Resource {
+		var kept []*resource.Resource
+		for _, res := range resources {
+			if !mm.Match(res) {
+				kept = append(kept, res)
+			}
+		}
+		return kept
+	}
+
+	var keptPackages []*resource.Package
+	for _, pkg := range packages {
+		pkgRes := &resource.Resource{Type: resource.PackageType, Name: pkg.Name}
+		if !mm.Match(pkgRes) {
+			keptPackages = append(keptPackages, pkg)
+		}
+	}
+
+	return keep(commands), keep(skills), keep(agents), keptPackages, nil
+}
+
+// describeImportFilters formats include and exclude patterns for progress output.
+func describeImportFilters(include, exclude []string) string {
+	var parts []string
+	if len(include) > 0 {
+		parts = append(parts, strings.Join(include, ", "))
+	}
+	if len(exclude) > 0 {
+		parts = append(parts, "excluding "+strings.Join(exclude, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
 type discoveredImportResources struct {
 	commands            []*resource.Resource
 	skills              []*resource.Resource
@@ -471,6 +565,84 @@ type discoveredImportResources struct {
 	marketplaceConfig   *marketplace.MarketplaceConfig
 	marketplacePath     string
 	marketplacePackages []*marketplace.PackageInfo
+	skippedPlugins      []marketplace.SkippedPlugin
+}
+
+// remotePluginsUnresolved reports whether any remote marketplace plugin could
+// not be fetched, in which case its resources are unknown rather than absent.
+func (d *discoveredImportResources) remotePluginsUnresolved() bool {
+	for _, skipped := range d.skippedPlugins {
+		if skipped.FetchFailed {
+			return true
+		}
+	}
+	return false
+}
+
+// skippedPluginWarnings formats skipped marketplace plugins as warning lines.
+func skippedPluginWarnings(skipped []marketplace.SkippedPlugin) []string {
+	warnings := make([]string, 0, len(skipped))
+	for _, s := range skipped {
+		warnings = append(warnings, fmt.Sprintf("marketplace plugin %q (%s) skipped: %s", s.Name, s.Source, s.Reason))
+	}
+	return warnings
+}
+
+// workspacePluginFetcher fetches remote marketplace plugin sources into the
+// repository workspace cache, reusing one checkout per URL and ref.
+type workspacePluginFetcher struct {
+	repoPath string
+	wsMgr    workspaceManager
+	fetched  map[string]string
+	// signers, when set, is the signers file the checked-out commit of
+	// every fetched plugin must be signed by.
+	signers string
+}
+
+// commitVerifier checks the signature of a workspace cache's checkout.
+type commitVerifier interface {
+	VerifyCommit(url string, signers string) (*workspace.Signature, error)
+}
+
+func newPluginFetcher(repoPath string) *workspacePluginFetcher {
+	return &workspacePluginFetcher{repoPath: repoPath, fetched: make(map[string]string)}
+}
+
+// FetchPlugin implements marketplace.PluginFetcher.
+func (f *workspacePluginFetcher) FetchPlugin(remote *marketplace.RemoteSource) (string, error) {
+	if f.wsMgr == nil {
+		wsMgr, err := newWorkspaceManager(f.repoPath)
+		if err != nil {
+			return "", fmt.Errorf("failed to create workspace manager: %w", err)
+		}
+		f.wsMgr = wsMgr
+	}
+
+	cloneURL := remote.CloneURL()
+	key := cloneURL + "@" + remote.Ref
+	root, ok := f.fetched[key]
+	if !ok {
+		var err error
+		root, err = prepareRemoteSourcePath(f.wsMgr, cloneURL, remote.Ref)
+		if err != nil {
+			return "", err
+		}
+		if f.signers != "" {
+			verifier, ok := f.wsMgr.(commitVerifier)
+			if !ok {
+				return "", fmt.Errorf("refusing plugin source %s: commit signatures cannot be verified", cloneURL)
+			}
+			if _, err := verifier.VerifyCommit(cloneURL, f.signers); err != nil {
+				return "", fmt.Errorf("refusing plugin source %s: %w", cloneURL, err)
+			}
+		}
+		f.fetched[key] = root
+	}
+
+	if remote.Path != "" {
+		return filepath.Join(root, filepath.FromSlash(remote.Path)), nil
+	}
+	return root, nil
 }
 
 func ensureNormalizedMarketplacePathExists(localPath string) error {
@@ -517,7 +689,10 @@ func marketplaceSourceBasePath(localPath, marketplacePath string) string {
 	return cleanLocalPath
 }
 
-func discoverImportResourcesByMode(localPath, discoveryMode string) (*discoveredImportResources, error) {
+// discoverImportResourcesByMode discovers importable resources in localPath.
+// fetcher resolves remote marketplace plugin sources; when nil, remote plugins
+// are reported as skipped.
+func discoverImportResourcesByMode(localPath, discoveryMode string, fetcher marketplace.PluginFetcher) (*discoveredImportResources, error) {
 	discoveryMode = repomanifest.NormalizeDiscoveryMode(discoveryMode)
 	if err := repomanifest.ValidateDiscoveryMode(discoveryMode); err != nil {
 		return nil, err
@@ -573,16 +748,7 @@ func discoverImportResourcesByMode(localPath, discoveryMode string) (*discovered
 		result.marketplaceConfig = marketplaceConfig
 		result.marketplacePath = marketplacePath
 
-		basePath := marketplaceSourceBasePath(localPath, marketplacePath)
-		marketplacePackages, genErr := marketplace.GeneratePackages(marketplaceConfig, basePath)
-		if genErr != nil {
-			return fmt.Errorf("failed to generate packages from marketplace: %w", genErr)
-		}
-		if len(marketplacePackages) == 0 {
-			return fmt.Errorf("marketplace discovered at %s but no plugin resources were resolvable", marketplacePath)
-		}
-		result.marketplacePackages = marketplacePackages
-		return nil
+		return generateMarketplacePackages(result, localPath, fetcher)
 	}
 
 	switch discoveryMode {
@@ -602,15 +768,9 @@ func discoverImportResourcesByMode(localPath, discoveryMode string) (*discovered
 		if marketplaceConfig != nil {
 			result.marketplaceConfig = marketplaceConfig
 			result.marketplacePath = marketplacePath
-			basePath := marketplaceSourceBasePath(localPath, marketplacePath)
-			marketplacePackages, genErr := marketplace.GeneratePackages(marketplaceConfig, basePath)
-			if genErr != nil {
-				return nil, fmt.Errorf("failed to generate packages from marketplace: %w", genErr)
-			}
-			if len(marketplacePackages) == 0 {
-				return nil, fmt.Errorf("marketplace discovered at %s but no plugin resources were resolvable", marketplacePath)
+			if err := generateMarketplacePackages(result, localPath, fetcher); err != nil {
+				return nil, err
 			}
-			result.marketplacePackages = marketplacePackages
 			break
 		}
 		if err := discoverGeneric(); err != nil {
@@ -621,6 +781,26 @@ func discoverImportResourcesByMode(localPath, discoveryMode string) (*discovered
 	return result, nil
 }
 
+// generateMarketplacePackages generates packages for the discovered marketplace
+// and records plugins that could not be turned into packages.
+func generateMarketplacePackages(result *discoveredImportResources, localPath string, fetcher marketplace.PluginFetcher) error {
+	basePath := marketplaceSourceBasePath(localPath, result.marketplacePath)
+	generated, err := marketplace.GeneratePackagesWithFetcher(result.marketplaceConfig, basePath, fetcher)
+	if err != nil {
+		return fmt.Errorf("failed to generate packages from marketplace: %w", err)
+	}
+	result.skippedPlugins = generated.Skipped
+	if len(generated.Packages) == 0 {
+		if len(generated.Skipped) > 0 {
+			return fmt.Errorf("marketplace discovered at %s but no plugin resources were resolvable:\n  %s",
+				result.marketplacePath, strings.Join(skippedPluginWarnings(generated.Skipped), "\n  "))
+		}
+		return fmt.Errorf("marketplace discovered at %s but no plugin resources were resolvable", result.marketplacePath)
+	}
+	result.marketplacePackages = generated.Packages
+	return nil
+}
+
 // importFromLocalPath performs the core import logic from a local directory.
 // This function is used by both local imports and remote imports (after cloning to workspace).
 // It discovers resources, applies filters, and imports them into the repository.
@@ -633,15 +813,13 @@ func importFromLocalPath(
 	sourceType string, // "local", "github", "git-url", "test"
 	ref string, // Git ref (empty for local/test)
 ) error {
-	_, err := importFromLocalPathWithMode(localPath, manager, filter, sourceURL, sourceType, ref, "copy", repomanifest.DiscoveryModeAuto, "", "")
+	_, err := importFromLocalPathWithMode(localPath, manager, filter, sourceURL, sourceType, ref, "copy", repomanifest.DiscoveryModeAuto, "", "", importOptions{})
 	return err
 }
 
 // importFromLocalPathWithMode is the same as importFromLocalPath but allows specifying import mode.
 // It returns the BulkOperationResult for the caller to handle. When syncSilentMode is true,
 // all progress/result output is suppressed so the caller (runSync) can format it uniformly.
-//
-//nolint:gocyclo // Multi-phase import pipeline (discover, filter, import, metadata, git) that must stay cohesive for correctness.
 func importFromLocalPathWithMode(
 	localPath string, // Local directory to import from
 	manager *repo.Manager, // Repository manager
@@ -653,8 +831,30 @@ func importFromLocalPathWithMode(
 	discoveryMode string, // "auto", "marketplace", "generic"
 	sourceName string, // Explicit source name from manifest (empty = derive from URL)
 	sourceID string, // Source ID for metadata tracking (empty = none)
+	opts importOptions, // Excludes, shadowed resources, local-changes policy and review
 ) (*output.BulkOperationResult, error) {
-	discovered, err := discoverImportResourcesByMode(localPath, discoveryMode)
+	return importFromLocalPathWithFetcher(localPath, manager, filter, sourceURL, sourceType, ref, importMode, discoveryMode, sourceName, sourceID, newPluginFetcher(manager.GetRepoPath()), opts)
+}
+
+// importFromLocalPathWithFetcher is importFromLocalPathWithMode with the
+// fetcher used for remote marketplace plugin sources.
+//
+//nolint:gocyclo // Multi-phase import pipeline (discover, filter, import, metadata, git) that must stay cohesive for correctness.
+func importFromLocalPathWithFetcher(
+	localPath string,
+	manager *repo.Manager,
+	filter []string,
+	sourceURL string,
+	sourceType string,
+	ref string,
+	importMode string,
+	discoveryMode string,
+	sourceName string,
+	sourceID string,
+	fetcher marketplace.PluginFetcher, // Fetcher for remote marketplace plugins
+	importOpts importOptions, // Excludes, shadowed resources, local-changes policy and review
+) (*output.BulkOperationResult, error) {
+	discovered, err := discoverImportResourcesByMode(localPath, discoveryMode, fetcher)
 	if err != nil {
 		return nil, err
 	}
@@ -687,19 +887,24 @@ func importFromLocalPathWithMode(
 	// When syncSilentMode is true, all printing is suppressed regardless of format.
 	isHumanFormat := !syncSilentMode && (addFormatFlag == "" || addFormatFlag == "table")
 
-	// Apply filter if specified
-	if len(filter) > 0 {
+	// Apply include filter, then exclude filter, if specified
+	if len(filter) > 0 || len(importOpts.exclude) > 0 {
 		var err error
 		commands, skills, agents, packages, err = applyFilter(filter, commands, skills, agents, packages)
 		if err != nil {
 			return nil, err
 		}
+		commands, skills, agents, packages, err = applyExcludeFilter(importOpts.exclude, commands, skills, agents, packages)
+		if err != nil {
+			return nil, err
+		}
 
 		// Check if filter matched any resources
+		filterDescription := describeImportFilters(filter, importOpts.exclude)
 		filteredTotal := len(commands) + len(skills) + len(agents) + len(packages)
 		if filteredTotal == 0 && len(marketplacePackages) == 0 {
 			if isHumanFormat {
-				fmt.Printf("⚠ Warning: Filter '%s' matched 0 resources (found %d total)\n\n", strings.Join(filter, ", "), totalResources)
+				fmt.Printf("⚠ Warning: Filter '%s' matched 0 resources (found %d total)\n\n", filterDescription, totalResources)
 			}
 			return nil, nil
 		}
@@ -708,7 +913,7 @@ func importFromLocalPathWithMode(
 		if isHumanFormat {
 			fmt.Printf("Found: %d commands, %d skills, %d agents, %d packages", origCommandCount, origSkillCount, origAgentCount, origPackageCount)
 			if filteredTotal < totalResources {
-				fmt.Printf(" (filtered to %d matching '%s')\n", filteredTotal, strings.Join(filter, ", "))
+				fmt.Printf(" (filtered to %d matching '%s')\n", filteredTotal, filterDescription)
 			} else {
 				fmt.Println()
 			}
@@ -719,6 +924,20 @@ func importFromLocalPathWithMode(
 		}
 	}
 
+	// Drop resources shadowed by a higher-priority source
+	var shadowedResults []output.ResourceResult
+	if len(importOpts.shadowed) > 0 {
+		var dropped []output.ResourceResult
+		commands, dropped = dropShadowedResources(commands, importOpts.shadowed)
+		shadowedResults = append(shadowedResults, dropped...)
+		skills, dropped = dropShadowedResources(skills, importOpts.shadowed)
+		shadowedResults = append(shadowedResults, dropped...)
+		agents, dropped = dropShadowedResources(agents, importOpts.shadowed)
+		shadowedResults = append(shadowedResults, dropped...)
+		packages, dropped = dropShadowedPackages(packages, importOpts.shadowed)
+		shadowedResults = append(shadowedResults, dropped...)
+	}
+
 	// Display marketplace info if found
 	if marketplaceConfig != nil {
 		if isHumanFormat {
@@ -737,6 +956,9 @@ func importFromLocalPathWithMode(
 			for _, pkgInfo := range marketplacePackages {
 				fmt.Printf("  ✓ %s (%d resources)\n", pkgInfo.Package.Name, len(pkgInfo.Package.Resources))
 			}
+			for _, skipped := range discovered.skippedPlugins {
+				fmt.Printf("  ⚠ skipped %s (%s): %s\n", skipped.Name, skipped.Source, skipped.Reason)
+			}
 		}
 	}
 
@@ -777,6 +999,10 @@ func importFromLocalPathWithMode(
 			if err != nil {
 				continue // Skip invalid references
 			}
+			if winner, shadowed := importOpts.shadowed[resRef]; shadowed {
+				shadowedResults = append(shadowedResults, shadowedResult(resType, resName, winner))
+				continue
+			}
 
 			// Find the resource file in the plugin source directory
 			resPath, err := findResourceInPath(pkgInfo.SourcePath, resType, resName)
@@ -786,6 +1012,20 @@ func importFromLocalPathWithMode(
 		}
 	}
 
+	checkWarnings := preImportWarnings(commands, skills, agents)
+	preWarnings := skippedPluginWarnings(discovered.skippedPlugins)
+	for _, group := range checkWarnings {
+		preWarnings = append(preWarnings, group.warnings...)
+	}
+	secretScanner, secretsPolicy, err := newImportSecretScanner(localPath)
+	if err != nil {
+		return nil, err
+	}
+	orgPolicy, err := loadOrgPolicy()
+	if err != nil {
+		return nil, err
+	}
+
 	// Import using bulk add
 	opts := repo.BulkImportOptions{
 		SourceName:   sourceName,
@@ -797,43 +1037,86 @@ func importFromLocalPathWithMode(
 		SourceURL:    sourceURL,
 		SourceType:   sourceType,
 		Ref:          ref,
+		LocalChanges: importOpts.localChanges,
+		Quarantine:   importOpts.review,
+
+		Secrets:       secretScanner,
+		SecretsPolicy: secretsPolicy,
+		Policy:        orgPolicy,
 	}
 
 	bulkResult, err := manager.AddBulk(allPaths, opts)
 	if err != nil && !skipExistingFlag {
 		// Convert to output type and print partial results before error (only when not silent)
 		bulkOpResult := output.FromBulkImportResult(bulkResult)
+		bulkOpResult.Skipped = append(bulkOpResult.Skipped, shadowedResults...)
+		bulkOpResult.Warnings = slices.Concat(preWarnings, bulkOpResult.Warnings)
 		if !syncSilentMode {
-			printImportResults(bulkResult)
+			printBulkOperationResult(bulkOpResult)
 		}
 		return bulkOpResult, err
 	}
 
-	// Save marketplace-generated packages if not in dry-run mode
-	if !dryRunFlag {
-		for _, pkgInfo := range marketplacePackages {
-			// Save package to repository
-			if err := resource.SavePackage(pkgInfo.Package, manager.GetRepoPath()); err != nil {
-				if !syncSilentMode {
-					fmt.Printf("⚠ Warning: Failed to save package %s: %v\n", pkgInfo.Package.Name, err)
-				}
-				continue
+	// Under review, marketplace-generated packages wait in quarantine too.
+	if importOpts.review && len(marketplacePackages) > 0 {
+		if err := quarantineMarketplacePackages(manager, marketplacePackages, importOpts.shadowed, opts, bulkResult); err != nil {
+			return output.FromBulkImportResult(bulkResult), err
+		}
+	}
+
+	// Save marketplace-generated packages allowed by the policy, if not in
+	// dry-run mode. Under review they were quarantined above.
+	for _, pkgInfo := range marketplacePackages {
+		if winner, shadowed := importOpts.shadowed["package/"+pkgInfo.Package.Name]; shadowed {
+			shadowedResults = append(shadowedResults, shadowedResult(resource.PackageType, pkgInfo.Package.Name, winner))
+			continue
+		}
+		if importOpts.review {
+			continue
+		}
+		pkgPath := resource.GetPackagePath(pkgInfo.Package.Name, manager.GetRepoPath())
+		if err := repo.CheckPackagePolicy(pkgPath, pkgInfo.Package, opts, bulkResult); err != nil || dryRunFlag {
+			continue
+		}
+		if err := resource.SavePackage(pkgInfo.Package, manager.GetRepoPath()); err != nil {
+			if !syncSilentMode {
+				fmt.Printf("⚠ Warning: Failed to save package %s: %v\n", pkgInfo.Package.Name, err)
 			}
+			continue
 		}
 	}
 
 	// Convert bulk result to output type
 	bulkOpResult := output.FromBulkImportResult(bulkResult)
+	bulkOpResult.Skipped = append(bulkOpResult.Skipped, shadowedResults...)
+	secretWarnings := importWarningGroup{
+		title:    "Possible Secrets",
+		tip:      "Set repo.secrets.policy: block in aimgr.yaml to refuse such imports.",
+		warnings: bulkOpResult.Warnings,
+	}
+	bulkOpResult.Warnings = slices.Concat(preWarnings, bulkOpResult.Warnings)
 
 	// Print discovery errors if any (only for human-readable, non-silent format)
 	if isHumanFormat && len(discoveryErrors) > 0 {
 		printDiscoveryErrors(discoveryErrors)
 		fmt.Println()
 	}
+	if isHumanFormat {
+		for _, group := range checkWarnings {
+			if len(group.warnings) > 0 {
+				group.print()
+				fmt.Println()
+			}
+		}
+	}
 
 	// Print results (suppressed in syncSilentMode — caller handles output)
 	if !syncSilentMode {
-		printImportResults(bulkResult)
+		printBulkOperationResult(bulkOpResult)
+	}
+	if isHumanFormat && len(secretWarnings.warnings) > 0 {
+		fmt.Println()
+		secretWarnings.print()
 	}
 
 	// Exit with error if there were failures
@@ -844,6 +1127,79 @@ func importFromLocalPathWithMode(
 	return bulkOpResult, nil
 }
 
+// quarantineMarketplacePackages holds generated marketplace packages in the
+// quarantine instead of saving them. Like saved packages they are copies, so
+// they are written to a temporary directory and imported from there.
+func quarantineMarketplacePackages(manager *repo.Manager, packages []*marketplace.PackageInfo, shadowed map[string]string, opts repo.BulkImportOptions, result *repo.BulkImportResult) error {
+	tmpDir, err := os.MkdirTemp("", "aimgr-marketplace-packages-")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(tmpDir)
+	}()
+
+	var paths []string
+	for _, pkgInfo := range packages {
+		if _, ok := shadowed["package/"+pkgInfo.Package.Name]; ok {
+			continue
+		}
+		if err := resource.SavePackage(pkgInfo.Package, tmpDir); err != nil {
+			return fmt.Errorf("failed to write package %s: %w", pkgInfo.Package.Name, err)
+		}
+		paths = append(paths, resource.GetPackagePath(pkgInfo.Package.Name, tmpDir))
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+
+	opts.ImportMode = "copy"
+	pkgResult, err := manager.AddBulk(paths, opts)
+	if pkgResult != nil {
+		repo.MergeBulkResults(result, pkgResult)
+	}
+	return err
+}
+
+// shadowedResult reports a resource skipped because another source shadows it.
+func shadowedResult(resType resource.ResourceType, name, winner string) output.ResourceResult {
+	return output.ResourceResult{
+		Name:    name,
+		Type:    string(resType),
+		Message: fmt.Sprintf("shadowed by source %q (higher priority)", winner),
+	}
+}
+
+// dropShadowedResources removes resources listed in shadowed and returns them
+// as skipped results.
+func dropShadowedResources(resources []*resource.Resource, shadowed map[string]string) ([]*resource.Resource, []output.ResourceResult) {
+	kept := make([]*resource.Resource, 0, len(resources))
+	var dropped []output.ResourceResult
+	for _, res := range resources {
+		if winner, ok := shadowed[fmt.Sprintf("%s/%s", res.Type, res.Name)]; ok {
+			dropped = append(dropped, shadowedResult(res.Type, res.Name, winner))
+			continue
+		}
+		kept = append(kept, res)
+	}
+	return kept, dropped
+}
+
+// dropShadowedPackages removes packages listed in shadowed and returns them
+// as skipped results.
+func dropShadowedPackages(packages []*resource.Package, shadowed map[string]string) ([]*resource.Package, []output.ResourceResult) {
+	kept := make([]*resource.Package, 0, len(packages))
+	var dropped []output.ResourceResult
+	for _, pkg := range packages {
+		if winner, ok := shadowed["package/"+pkg.Name]; ok {
+			dropped = append(dropped, shadowedResult(resource.PackageType, pkg.Name, winner))
+			continue
+		}
+		kept = append(kept, pkg)
+	}
+	return kept, dropped
+}
+
 // addBulkFromLocal handles bulk add from a local folder or single file
 func addBulkFromLocal(localPath string, manager *repo.Manager) error {
 	return addBulkFromLocalWithFilter(localPath, manager, filterFlags)
@@ -851,12 +1207,12 @@ func addBulkFromLocal(localPath string, manager *repo.Manager) error {
 
 // addBulkFromLocalWithFilter handles bulk add from a local folder or single file with a custom filter
 func addBulkFromLocalWithFilter(localPath string, manager *repo.Manager, filter []string) error {
-	return addBulkFromLocalWithMode(localPath, manager, filter, "", "copy", "")
+	return addBulkFromLocalWithMode(localPath, manager, filter, "", "copy", "", importOptions{})
 }
 
 // addBulkFromLocalWithMode handles bulk add from a local folder or single file with custom filter and import mode.
 // If sourceName is non-empty it is used as-is; otherwise the name is derived from --name flag or filepath.Base.
-func addBulkFromLocalWithMode(localPath string, manager *repo.Manager, filter []string, sourceID string, importMode string, sourceName string) error {
+func addBulkFromLocalWithMode(localPath string, manager *repo.Manager, filter []string, sourceID string, importMode string, sourceName string, opts importOptions) error {
 	// Validate path and check if it's a file or directory
 	info, err := os.Stat(localPath)
 	if err != nil {
@@ -881,7 +1237,7 @@ func addBulkFromLocalWithMode(localPath string, manager *repo.Manager, filter []
 				sourceName = filepath.Base(filepath.Dir(absPath))
 			}
 
-			_, importErr := importFromLocalPathWithMode(localPath, manager, filter, sourceURL, sourceType, "", importMode, discoveryFlag, sourceName, sourceID)
+			_, importErr := importFromLocalPathWithMode(localPath, manager, filter, sourceURL, sourceType, "", importMode, discoveryFlag, sourceName, sourceID, opts)
 			return importErr
 		}
 		return addSingleResource(localPath, manager)
@@ -903,6 +1259,9 @@ func addBulkFromLocalWithMode(localPath string, manager *repo.Manager, filter []
 		if len(filter) > 0 {
 			fmt.Printf("  Filter: %s\n", strings.Join(filter, ", "))
 		}
+		if len(opts.exclude) > 0 {
+			fmt.Printf("  Exclude: %s\n", strings.Join(opts.exclude, ", "))
+		}
 		fmt.Println()
 	}
 
@@ -921,21 +1280,21 @@ func addBulkFromLocalWithMode(localPath string, manager *repo.Manager, filter []
 
 	// Call common import function with import mode
 	var importErr error
-	_, importErr = importFromLocalPathWithMode(localPath, manager, filter, sourceURL, sourceType, "", importMode, discoveryFlag, sourceName, sourceID)
+	_, importErr = importFromLocalPathWithMode(localPath, manager, filter, sourceURL, sourceType, "", importMode, discoveryFlag, sourceName, sourceID, opts)
 	return importErr
 }
 
 // addBulkFromGitHub handles bulk add from a GitHub repository
-func addBulkFromGitHub(parsed *source.ParsedSource, manager *repo.Manager) error {
+func addBulkFromGitHub(parsed *source.ParsedSource, manager *repo.Manager, opts importOptions) error {
 	// Compute source ID from URL before import
 	tempSource := repomanifest.Source{URL: parsed.URL, Subpath: parsed.Subpath}
 	sourceID := repomanifest.GenerateSourceID(&tempSource)
 
-	return addBulkFromGitHubWithFilter(parsed, manager, filterFlags, sourceID)
+	return addBulkFromGitHubWithFilter(parsed, manager, filterFlags, sourceID, opts)
 }
 
 // addBulkFromGitHubWithFilter handles bulk add from a GitHub repository with a custom filter
-func addBulkFromGitHubWithFilter(parsed *source.ParsedSource, manager *repo.Manager, filter []string, sourceID string) error {
+func addBulkFromGitHubWithFilter(parsed *source.ParsedSource, manager *repo.Manager, filter []string, sourceID string, opts importOptions) error {
 	// Clone repository to workspace
 	cloneURL, err := source.GetCloneURL(parsed)
 	if err != nil {
@@ -943,7 +1302,7 @@ func addBulkFromGitHubWithFilter(parsed *source.ParsedSource, manager *repo.Mana
 	}
 
 	// Create workspace manager
-	workspaceManager, err := workspace.NewManager(manager.GetRepoPath())
+	workspaceManager, err := newWorkspaceManager(manager.GetRepoPath())
 	if err != nil {
 		return fmt.Errorf("failed to create workspace manager: %w", err)
 	}
@@ -980,6 +1339,9 @@ func addBulkFromGitHubWithFilter(parsed *source.ParsedSource, manager *repo.Mana
 		if len(filter) > 0 {
 			fmt.Printf("  Filter: %s\n", strings.Join(filter, ", "))
 		}
+		if len(opts.exclude) > 0 {
+			fmt.Printf("  Exclude: %s\n", strings.Join(opts.exclude, ", "))
+		}
 		fmt.Println()
 	}
 
@@ -1008,7 +1370,7 @@ func addBulkFromGitHubWithFilter(parsed *source.ParsedSource, manager *repo.Mana
 	}
 
 	// Call common import function with workspace path
-	_, err = importFromLocalPathWithMode(searchPath, manager, filter, parsed.URL, sourceType, parsed.Ref, "copy", discoveryFlag, sourceName, sourceID)
+	_, err = importFromLocalPathWithMode(searchPath, manager, filter, parsed.URL, sourceType, parsed.Ref, "copy", discoveryFlag, sourceName, sourceID, opts)
 	return err
 }
 
@@ -1332,9 +1694,10 @@ func formatGitHubShortURL(parsed *source.ParsedSource) string {
 }
 
 // addSourceToManifest adds the source to ai.repo.yaml manifest.
-// include contains the filter patterns to persist (nil/empty = no include filter).
-// If the source already exists, its Include field is replaced (REPLACE semantics).
-func addSourceToManifest(manager *repo.Manager, parsed *source.ParsedSource, include []string, discoveryMode string) error {
+// include and exclude contain the filter patterns to persist (nil/empty = no filter).
+// review records whether later syncs quarantine the source's changes.
+// If the source already exists, its Include, Exclude and Review fields are replaced (REPLACE semantics).
+func addSourceToManifest(manager *repo.Manager, parsed *source.ParsedSource, include, exclude []string, discoveryMode string, review bool) error {
 	// Load existing manifest
 	manifest, err := repomanifest.LoadForMutation(manager.GetRepoPath())
 	if err != nil {
@@ -1351,6 +1714,8 @@ func addSourceToManifest(manager *repo.Manager, parsed *source.ParsedSource, inc
 		Name:      nameFlag, // Will be auto-generated if empty
 		Discovery: discoveryMode,
 		Include:   include,
+		Exclude:   exclude,
+		Review:    review,
 	}
 
 	// Set path or URL based on source type
@@ -1398,9 +1763,11 @@ func addSourceToManifest(manager *repo.Manager, parsed *source.ParsedSource, inc
 			}
 		}
 
-		// Update Include with new values (replace, not merge)
+		// Update Include/Exclude with new values (replace, not merge)
 		existing.Include = include
+		existing.Exclude = exclude
 		existing.Discovery = discoveryMode
+		existing.Review = review
 		// Save manifest with updated include
 		if err := manifest.Save(manager.GetRepoPath()); err != nil {
 			return fmt.Errorf("failed to save manifest: %w", err)
@@ -1467,6 +1834,20 @@ func printImportResults(result *repo.BulkImportResult) {
 	}
 }
 
+// printBulkOperationResult prints an already converted result (including any
+// warnings) in the format selected by --format.
+func printBulkOperationResult(result *output.BulkOperationResult) {
+	format, err := output.ParseFormat(addFormatFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v, using table format\n", err)
+		format = output.Table
+	}
+
+	if err := output.FormatBulkResult(result, format); err != nil {
+		fmt.Fprintf(os.Stderr, "Error formatting output: %v\n", err)
+	}
+}
+
 // printDiscoveryErrors prints discovery errors with helpful suggestions
 func printDiscoveryErrors(errors []discovery.DiscoveryError) {
 	if len(errors) == 0 {
@@ -1507,6 +1888,96 @@ func printDiscoveryErrors(errors []discovery.DiscoveryError) {
 	fmt.Println("       If a skipped file is only documentation, no action is needed. If it is meant to be imported, fix the issue above and re-run the import.")
 }
 
+// importWarningGroup is one kind of issue found in the resources of an
+// import, printed under a common heading.
+type importWarningGroup struct {
+	title    string
+	tip      string
+	warnings []string
+}
+
+// print prints the group's warnings under its heading.
+func (g importWarningGroup) print() {
+	fmt.Printf("⚠ %s (%d):\n", g.title, len(g.warnings))
+	for _, warning := range g.warnings {
+		fmt.Printf("  - %s\n", warning)
+	}
+	fmt.Printf("  Tip: %s\n", g.tip)
+}
+
+// preImportWarnings checks the resources about to be imported for broken
+// relative links and unreferenced bundled files, and the scripts of skills
+// for missing exec bits, CRLF line endings and missing interpreters. Issues
+// are warnings: the resources are still imported as they are in the source.
+// Resources that fail to load are skipped here; the import reports them.
+func preImportWarnings(commands, skills, agents []*resource.Resource) []importWarningGroup {
+	links := importWarningGroup{title: "Link Issues", tip: "Run 'aimgr resource validate <path>' on a resource for details."}
+	scripts := importWarningGroup{title: "Script Issues", tip: "Run 'aimgr repo repair' to make imported scripts with a shebang executable."}
+	for _, group := range [][]*resource.Resource{commands, skills, agents} {
+		for _, res := range group {
+			if issues, err := resource.CheckResourceLinks(res); err == nil {
+				links.warnings = appendResourceIssues(links.warnings, res, issues)
+			}
+			if res.Type != resource.Skill {
+				continue
+			}
+			if issues, err := resource.CheckResourceScripts(res); err == nil {
+				scripts.warnings = appendResourceIssues(scripts.warnings, res, issues)
+			}
+		}
+	}
+	return []importWarningGroup{links, scripts}
+}
+
+// appendResourceIssues appends issues of res as "type/name: issue" lines.
+func appendResourceIssues[T fmt.Stringer](warnings []string, res *resource.Resource, issues []T) []string {
+	for _, issue := range issues {
+		warnings = append(warnings, fmt.Sprintf("%s/%s: %s", res.Type, res.Name, issue))
+	}
+	return warnings
+}
+
+// newImportSecretScanner builds the secret scanner for a source from
+// repo.secrets in aimgr.yaml. It returns a nil scanner when scanning is off.
+func newImportSecretScanner(sourceRoot string) (*secrets.Scanner, string, error) {
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load config: %w", err)
+	}
+	secretsCfg := cfg.Repo.Secrets
+	policy := secretsCfg.PolicyOrDefault()
+	if policy == config.SecretsOff {
+		return nil, policy, nil
+	}
+
+	// Direct marketplace file inputs are scanned relative to their directory.
+	if info, err := os.Stat(sourceRoot); err == nil && !info.IsDir() {
+		sourceRoot = filepath.Dir(sourceRoot)
+	}
+
+	allowlistPath, err := secretsCfg.AllowlistPath()
+	if err != nil {
+		return nil, "", fmt.Errorf("repo.secrets.allowlist: %w", err)
+	}
+	allowlist, err := secrets.LoadAllowlist(allowlistPath, filepath.Join(sourceRoot, secrets.AllowlistFileName))
+	if err != nil {
+		return nil, "", err
+	}
+	patterns := make([]secrets.Pattern, 0, len(secretsCfg.Patterns))
+	for _, p := range secretsCfg.Patterns {
+		patterns = append(patterns, secrets.Pattern{ID: p.ID, Regex: p.Regex})
+	}
+	scanner, err := secrets.NewScanner(sourceRoot, secrets.Options{
+		Patterns:         patterns,
+		EntropyThreshold: secretsCfg.EntropyThreshold,
+		Allowlist:        allowlist,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return scanner, policy, nil
+}
+
 func formatDiscoveryErrorDisplay(err error) (label string, message string, suggestions []string) {
 	var validationErr *resource.ValidationError
 	if errors.As(err, &validationErr) {
@@ -11,6 +11,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/adrg/xdg"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
@@ -61,7 +64,7 @@ func TestAddBulkFromGitHub_NonAmbiguousLegacyInlineRefSubpathWorksInDryRun(t *te
 
 	withRepoAddFlagsReset(t, func() {
 		dryRunFlag = true
-		if err := addBulkFromGitHub(parsed, manager); err != nil {
+		if err := addBulkFromGitHub(parsed, manager, importOptions{}); err != nil {
 			t.Fatalf("expected addBulkFromGitHub dry-run to succeed for legacy non-ambiguous shorthand, got: %v", err)
 		}
 	})
@@ -125,7 +128,7 @@ func TestAddBulkFromGitHub_GenericRemoteExplicitSubpathGenericDiscovery_SkillLay
 			withRepoAddFlagsReset(t, func() {
 				discoveryFlag = repomanifest.DiscoveryModeGeneric
 				nameFlag = tt.sourceName
-				if err := addBulkFromGitHub(parsed, manager); err != nil {
+				if err := addBulkFromGitHub(parsed, manager, importOptions{}); err != nil {
 					t.Fatalf("addBulkFromGitHub failed: %v", err)
 				}
 			})
@@ -157,7 +160,7 @@ func TestAddSourceToManifest_PersistsDiscoveryMode(t *testing.T) {
 	}
 
 	withRepoAddFlagsReset(t, func() {
-		if err := addSourceToManifest(manager, parsed, nil, repomanifest.DiscoveryModeMarketplace); err != nil {
+		if err := addSourceToManifest(manager, parsed, nil, nil, repomanifest.DiscoveryModeMarketplace, false); err != nil {
 			t.Fatalf("addSourceToManifest failed: %v", err)
 		}
 	})
@@ -189,7 +192,7 @@ func TestAddSourceToManifest_StoresNormalizedRepoBackedMarketplaceSource(t *test
 	}
 
 	withRepoAddFlagsReset(t, func() {
-		if err := addSourceToManifest(manager, parsed, nil, repomanifest.DiscoveryModeAuto); err != nil {
+		if err := addSourceToManifest(manager, parsed, nil, nil, repomanifest.DiscoveryModeAuto, false); err != nil {
 			t.Fatalf("addSourceToManifest failed: %v", err)
 		}
 	})
@@ -230,7 +233,7 @@ func TestAddSourceToManifest_RemoteCanonicalReuseKeepsExistingName(t *testing.T)
 
 	withRepoAddFlagsReset(t, func() {
 		nameFlag = "primary-alias"
-		if err := addSourceToManifest(manager, firstParsed, []string{"skill/*"}, repomanifest.DiscoveryModeAuto); err != nil {
+		if err := addSourceToManifest(manager, firstParsed, []string{"skill/*"}, nil, repomanifest.DiscoveryModeAuto, false); err != nil {
 			t.Fatalf("first addSourceToManifest failed: %v", err)
 		}
 	})
@@ -251,7 +254,7 @@ func TestAddSourceToManifest_RemoteCanonicalReuseKeepsExistingName(t *testing.T)
 
 	withRepoAddFlagsReset(t, func() {
 		nameFlag = "second-alias"
-		if err := addSourceToManifest(manager, secondParsed, []string{"command/*"}, repomanifest.DiscoveryModeGeneric); err != nil {
+		if err := addSourceToManifest(manager, secondParsed, []string{"command/*"}, nil, repomanifest.DiscoveryModeGeneric, false); err != nil {
 			t.Fatalf("second addSourceToManifest failed: %v", err)
 		}
 	})
@@ -314,14 +317,14 @@ func TestAddSourceToManifest_RemoteCanonicalReuseDistinctSubpaths(t *testing.T)
 
 	withRepoAddFlagsReset(t, func() {
 		nameFlag = "skills-source"
-		if err := addSourceToManifest(manager, firstParsed, nil, repomanifest.DiscoveryModeAuto); err != nil {
+		if err := addSourceToManifest(manager, firstParsed, nil, nil, repomanifest.DiscoveryModeAuto, false); err != nil {
 			t.Fatalf("failed adding first subpath source: %v", err)
 		}
 	})
 
 	withRepoAddFlagsReset(t, func() {
 		nameFlag = "agents-source"
-		if err := addSourceToManifest(manager, secondParsed, nil, repomanifest.DiscoveryModeAuto); err != nil {
+		if err := addSourceToManifest(manager, secondParsed, nil, nil, repomanifest.DiscoveryModeAuto, false); err != nil {
 			t.Fatalf("failed adding second subpath source: %v", err)
 		}
 	})
@@ -369,7 +372,7 @@ func TestAddBulkFromGitHub_UsesCanonicalSourceIDWithSubpath(t *testing.T) {
 
 	withRepoAddFlagsReset(t, func() {
 		addFormatFlag = "json"
-		if err := addBulkFromGitHub(parsed, manager); err != nil {
+		if err := addBulkFromGitHub(parsed, manager, importOptions{}); err != nil {
 			t.Fatalf("addBulkFromGitHub failed: %v", err)
 		}
 	})
@@ -498,6 +501,7 @@ func TestImportFromLocalPathWithMode_DiscoveryModes(t *testing.T) {
 					tt.discoveryMode,
 					"test-source",
 					"src-test",
+					importOptions{},
 				)
 				if tt.expectImportError != "" {
 					if err == nil || !strings.Contains(err.Error(), tt.expectImportError) {
@@ -539,6 +543,49 @@ func TestImportFromLocalPathWithMode_DiscoveryModes(t *testing.T) {
 	}
 }
 
+func TestImportFromLocalPathWithMode_PolicyBlocksMarketplacePackages(t *testing.T) {
+	sourceDir := createSourceWithMarketplaceAndLooseResources(t)
+
+	xdgConfigDir := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", xdgConfigDir)
+	t.Cleanup(xdg.Reload)
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policyPath, []byte("blocklist: [package/market-plugin]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(xdgConfigDir, "aimgr"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	configYAML := "install:\n  targets: [claude]\npolicy: " + policyPath + "\n"
+	if err := os.WriteFile(filepath.Join(xdgConfigDir, "aimgr", config.DefaultConfigFileName), []byte(configYAML), 0644); err != nil {
+		t.Fatal(err)
+	}
+	xdg.Reload()
+
+	withRepoAddFlagsReset(t, func() {
+		repoPath := t.TempDir()
+		manager := repo.NewManagerWithPath(repoPath)
+		if err := manager.Init(); err != nil {
+			t.Fatalf("failed to init repo: %v", err)
+		}
+
+		result, err := importFromLocalPathWithMode(sourceDir, manager, nil, "file://"+sourceDir, string(source.Local), "", "symlink", repomanifest.DiscoveryModeAuto, "test-source", "src-test", importOptions{})
+		if err == nil || !strings.Contains(err.Error(), "failed to import 1 resource(s)") {
+			t.Fatalf("import error = %v, want the blocklisted package to fail", err)
+		}
+		if len(result.Failed) != 1 || result.Failed[0].Name != "market-plugin" || !strings.Contains(result.Failed[0].Message, "blocked by policy") {
+			t.Errorf("failed = %+v, want package/market-plugin blocked by policy", result.Failed)
+		}
+		if _, err := os.Stat(resource.GetPackagePath("market-plugin", repoPath)); !os.IsNotExist(err) {
+			t.Errorf("blocklisted package must not be saved, stat error = %v", err)
+		}
+		if plugin, _ := manager.Get("plugin-command", resource.Command); plugin == nil {
+			t.Error("resources of the package are not blocklisted and should be imported")
+		}
+	})
+}
+
 func TestImportFromLocalPathWithMode_MarketplaceRequirementsAndZeroResolvable(t *testing.T) {
 	t.Run("marketplace mode requires marketplace file", func(t *testing.T) {
 		withRepoAddFlagsReset(t, func() {
@@ -556,7 +603,7 @@ func TestImportFromLocalPathWithMode_MarketplaceRequirementsAndZeroResolvable(t
 				t.Fatalf("failed to init repo: %v", err)
 			}
 
-			_, err := importFromLocalPathWithMode(sourceDir, manager, nil, "file://"+sourceDir, string(source.Local), "", "symlink", repomanifest.DiscoveryModeMarketplace, "test-source", "src-test")
+			_, err := importFromLocalPathWithMode(sourceDir, manager, nil, "file://"+sourceDir, string(source.Local), "", "symlink", repomanifest.DiscoveryModeMarketplace, "test-source", "src-test", importOptions{})
 			if err == nil {
 				t.Fatal("expected marketplace-mode error when marketplace.json is missing")
 			}
@@ -578,7 +625,7 @@ func TestImportFromLocalPathWithMode_MarketplaceRequirementsAndZeroResolvable(t
 						t.Fatalf("failed to init repo: %v", err)
 					}
 
-					_, err := importFromLocalPathWithMode(sourceDir, manager, nil, "file://"+sourceDir, string(source.Local), "", "symlink", mode, "test-source", "src-test")
+					_, err := importFromLocalPathWithMode(sourceDir, manager, nil, "file://"+sourceDir, string(source.Local), "", "symlink", mode, "test-source", "src-test", importOptions{})
 					if err == nil {
 						t.Fatalf("expected zero-resolvable error for mode %q", mode)
 					}
@@ -597,7 +644,7 @@ func TestDiscoverImportResourcesByMode_MissingNormalizedMarketplacePath(t *testi
 
 	for _, mode := range []string{repomanifest.DiscoveryModeAuto, repomanifest.DiscoveryModeMarketplace} {
 		t.Run(mode, func(t *testing.T) {
-			_, err := discoverImportResourcesByMode(missingMarketplacePath, mode)
+			_, err := discoverImportResourcesByMode(missingMarketplacePath, mode, nil)
 			if err == nil {
 				t.Fatalf("expected missing normalized marketplace path error for mode %q", mode)
 			}
@@ -628,7 +675,7 @@ func TestAddBulkFromLocalWithMode_DirectMarketplaceFileInput(t *testing.T) {
 		}
 
 		discoveryFlag = repomanifest.DiscoveryModeAuto
-		if err := addBulkFromLocalWithMode(marketplaceFile, manager, nil, "src-local-file", "symlink", "file-source"); err != nil {
+		if err := addBulkFromLocalWithMode(marketplaceFile, manager, nil, "src-local-file", "symlink", "file-source", importOptions{}); err != nil {
 			t.Fatalf("addBulkFromLocalWithMode failed for direct marketplace file: %v", err)
 		}
 
@@ -667,6 +714,7 @@ func TestImportFromLocalPathWithMode_RepoMarketplaceSubpathFileInput(t *testing.
 			repomanifest.DiscoveryModeAuto,
 			"remote-file-source",
 			"src-remote-file",
+			importOptions{},
 		)
 		if err != nil {
 			t.Fatalf("importFromLocalPathWithMode failed for repo marketplace subpath file: %v", err)
@@ -741,7 +789,7 @@ func TestAddBulkFromLocalWithMode_DirectPluginDirectoryMarketplaceFileInput(t *t
 				}
 
 				discoveryFlag = repomanifest.DiscoveryModeAuto
-				if err := addBulkFromLocalWithMode(marketplaceFile, manager, nil, "src-local-file", "symlink", "file-source"); err != nil {
+				if err := addBulkFromLocalWithMode(marketplaceFile, manager, nil, "src-local-file", "symlink", "file-source", importOptions{}); err != nil {
 					t.Fatalf("addBulkFromLocalWithMode failed for direct plugin-dir marketplace file: %v", err)
 				}
 
@@ -792,6 +840,7 @@ func TestImportFromLocalPathWithMode_RepoPluginDirectoryMarketplaceSubpathFileIn
 					repomanifest.DiscoveryModeAuto,
 					"remote-file-source",
 					"src-remote-file",
+					importOptions{},
 				)
 				if err != nil {
 					t.Fatalf("importFromLocalPathWithMode failed for repo plugin-dir marketplace subpath file (%s): %v", tt.subpath, err)
@@ -917,6 +966,7 @@ func TestImportFromLocalPathWithMode_PluginDirectoryMarketplaceUsesRepoRootBase(
 					repomanifest.DiscoveryModeMarketplace,
 					"test-source",
 					"src-test",
+					importOptions{},
 				)
 				if err != nil {
 					t.Fatalf("importFromLocalPathWithMode failed: %v", err)
@@ -989,6 +1039,7 @@ func TestImportFromLocalPathWithMode_MarketplaceImportsReferencedDotAgentFiles(t
 			repomanifest.DiscoveryModeMarketplace,
 			"test-source",
 			"src-test",
+			importOptions{},
 		)
 		if err != nil {
 			t.Fatalf("importFromLocalPathWithMode failed: %v", err)
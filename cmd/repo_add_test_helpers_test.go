@@ -9,6 +9,7 @@ func withRepoAddFlagsReset(t *testing.T, fn func()) {
 	originalSkip := skipExistingFlag
 	originalDryRun := dryRunFlag
 	originalFilters := append([]string(nil), filterFlags...)
+	originalExcludes := append([]string(nil), excludeFlags...)
 	originalFormat := addFormatFlag
 	originalName := nameFlag
 	originalDiscovery := discoveryFlag
@@ -20,6 +21,7 @@ func withRepoAddFlagsReset(t *testing.T, fn func()) {
 	skipExistingFlag = false
 	dryRunFlag = false
 	filterFlags = nil
+	excludeFlags = nil
 	addFormatFlag = "table"
 	nameFlag = ""
 	discoveryFlag = "auto"
@@ -32,6 +34,7 @@ func withRepoAddFlagsReset(t *testing.T, fn func()) {
 		skipExistingFlag = originalSkip
 		dryRunFlag = originalDryRun
 		filterFlags = originalFilters
+		excludeFlags = originalExcludes
 		addFormatFlag = originalFormat
 		nameFlag = originalName
 		discoveryFlag = originalDiscovery
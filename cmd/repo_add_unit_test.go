@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -10,6 +11,7 @@ import (
 	"testing"
 
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/discovery"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/marketplace"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
@@ -162,7 +164,7 @@ func TestAddSourceToManifest_StoresExplicitRefAndSubpathForRemote(t *testing.T)
 	}
 
 	withRepoAddFlagsReset(t, func() {
-		if err := addSourceToManifest(manager, parsed, nil, repomanifest.DiscoveryModeAuto); err != nil {
+		if err := addSourceToManifest(manager, parsed, nil, nil, repomanifest.DiscoveryModeAuto, false); err != nil {
 			t.Fatalf("addSourceToManifest failed: %v", err)
 		}
 	})
@@ -187,6 +189,49 @@ func TestAddSourceToManifest_StoresExplicitRefAndSubpathForRemote(t *testing.T)
 	}
 }
 
+func TestAddSourceToManifest_PersistsReview(t *testing.T) {
+	repoPath := t.TempDir()
+	t.Setenv("AIMGR_REPO_PATH", repoPath)
+
+	manager := repo.NewManagerWithPath(repoPath)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	parsed, err := source.ParseSource("gh:third-party/skills")
+	if err != nil {
+		t.Fatalf("failed to parse source: %v", err)
+	}
+
+	withRepoAddFlagsReset(t, func() {
+		if err := addSourceToManifest(manager, parsed, nil, nil, repomanifest.DiscoveryModeAuto, true); err != nil {
+			t.Fatalf("addSourceToManifest failed: %v", err)
+		}
+	})
+
+	manifest, err := repomanifest.Load(repoPath)
+	if err != nil {
+		t.Fatalf("failed to load manifest: %v", err)
+	}
+	if len(manifest.Sources) != 1 || !manifest.Sources[0].Review {
+		t.Fatalf("expected one source with review enabled, got %+v", manifest.Sources)
+	}
+
+	// Re-adding without --review replaces the setting like the filters.
+	withRepoAddFlagsReset(t, func() {
+		if err := addSourceToManifest(manager, parsed, nil, nil, repomanifest.DiscoveryModeAuto, false); err != nil {
+			t.Fatalf("addSourceToManifest failed: %v", err)
+		}
+	})
+	manifest, err = repomanifest.Load(repoPath)
+	if err != nil {
+		t.Fatalf("failed to load manifest: %v", err)
+	}
+	if manifest.Sources[0].Review {
+		t.Fatal("expected review to be cleared on re-add")
+	}
+}
+
 func TestRepoAdd_DiscoveryFlagValidation(t *testing.T) {
 	withRepoAddFlagsReset(t, func() {
 		discoveryFlag = "bogus"
@@ -572,6 +617,7 @@ func TestImportFromLocalPathWithMode_GenericDiscoveryWarningMatrix_SkillsReadmeI
 			repomanifest.DiscoveryModeGeneric,
 			"test-source",
 			"src-test",
+			importOptions{},
 		)
 
 		_ = w.Close()
@@ -649,3 +695,209 @@ func TestFormatDiscoveryErrorDisplay_NoFrontmatterDetectionContract(t *testing.T
 		}
 	})
 }
+
+type stubPluginFetcher struct {
+	dir string
+	err error
+}
+
+func (f stubPluginFetcher) FetchPlugin(remote *marketplace.RemoteSource) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	return filepath.Join(f.dir, filepath.FromSlash(remote.Path)), nil
+}
+
+func writeRemotePluginMarketplace(t *testing.T) string {
+	t.Helper()
+	sourceDir := t.TempDir()
+	manifest := `{"name": "mp", "plugins": [
+  {"name": "review", "description": "Remote review", "source": {"source": "github", "repo": "acme/tools", "path": "review"}}
+]}`
+	if err := os.WriteFile(filepath.Join(sourceDir, "marketplace.json"), []byte(manifest), 0644); err != nil {
+		t.Fatalf("failed to write marketplace.json: %v", err)
+	}
+	return sourceDir
+}
+
+func TestDiscoverImportResourcesByMode_RemoteMarketplacePlugin(t *testing.T) {
+	sourceDir := writeRemotePluginMarketplace(t)
+
+	checkout := t.TempDir()
+	cmdDir := filepath.Join(checkout, "review", "commands")
+	if err := os.MkdirAll(cmdDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cmdDir, "review.md"), []byte("---\ndescription: Review\n---\n# Review"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	discovered, err := discoverImportResourcesByMode(sourceDir, repomanifest.DiscoveryModeAuto, stubPluginFetcher{dir: checkout})
+	if err != nil {
+		t.Fatalf("discoverImportResourcesByMode() error = %v", err)
+	}
+	if len(discovered.marketplacePackages) != 1 || discovered.marketplacePackages[0].SourcePath != filepath.Join(checkout, "review") {
+		t.Fatalf("marketplacePackages = %+v, want remote review plugin", discovered.marketplacePackages)
+	}
+	if len(discovered.skippedPlugins) != 0 {
+		t.Errorf("skippedPlugins = %+v, want none", discovered.skippedPlugins)
+	}
+}
+
+func TestDiscoverImportResourcesByMode_ReportsSkippedRemotePlugin(t *testing.T) {
+	sourceDir := writeRemotePluginMarketplace(t)
+
+	_, err := discoverImportResourcesByMode(sourceDir, repomanifest.DiscoveryModeAuto, stubPluginFetcher{err: errors.New("authentication required")})
+	if err == nil {
+		t.Fatal("expected error when the only plugin cannot be fetched")
+	}
+	for _, want := range []string{`marketplace plugin "review" (acme/tools//review) skipped`, "authentication required"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q missing %q", err.Error(), want)
+		}
+	}
+}
+
+func TestScanSourceResources_FailsWhenRemotePluginUnfetched(t *testing.T) {
+	sourceDir := writeRemotePluginMarketplace(t)
+
+	localDir := filepath.Join(sourceDir, "plugins", "local", "commands")
+	if err := os.MkdirAll(localDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(localDir, "local.md"), []byte("---\ndescription: Local\n---\n# Local"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	manifest := `{"name": "mp", "plugins": [
+  {"name": "local", "description": "Local", "source": "./plugins/local"},
+  {"name": "review", "description": "Remote review", "source": {"source": "github", "repo": "acme/tools"}}
+]}`
+	if err := os.WriteFile(filepath.Join(sourceDir, "marketplace.json"), []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// A partial scan would make prune treat the remote plugin's resources as removed.
+	_, err := scanSourceResources(sourceDir, repomanifest.DiscoveryModeAuto, stubPluginFetcher{err: errors.New("offline")})
+	if err == nil || !strings.Contains(err.Error(), "could not be fetched") {
+		t.Fatalf("scanSourceResources() error = %v, want fetch failure", err)
+	}
+}
+
+func TestApplyExcludeFilter_DropsMatchingResourcesAfterInclude(t *testing.T) {
+	skills := []*resource.Resource{
+		{Type: resource.Skill, Name: "pdf"},
+		{Type: resource.Skill, Name: "experimental-ocr"},
+		{Type: resource.Skill, Name: "experimental-web"},
+	}
+	commands := []*resource.Resource{{Type: resource.Command, Name: "build"}}
+	packages := []*resource.Package{{Name: "experimental-bundle"}}
+
+	commands, skills, agents, packages, err := applyFilter([]string{"skill/*", "package/*"}, commands, skills, nil, packages)
+	if err != nil {
+		t.Fatalf("applyFilter() error = %v", err)
+	}
+	commands, skills, agents, packages, err = applyExcludeFilter([]string{"skill/experimental-*", "package/experimental-*"}, commands, skills, agents, packages)
+	if err != nil {
+		t.Fatalf("applyExcludeFilter() error = %v", err)
+	}
+
+	if len(commands) != 0 || len(agents) != 0 || len(packages) != 0 {
+		t.Fatalf("expected only skills to remain, got commands=%d agents=%d packages=%d", len(commands), len(agents), len(packages))
+	}
+	if len(skills) != 1 || skills[0].Name != "pdf" {
+		t.Fatalf("expected only skill/pdf to remain, got %v", skills)
+	}
+
+	if _, _, _, _, err := applyExcludeFilter([]string{"skill/[invalid"}, nil, skills, nil, nil); err == nil {
+		t.Fatal("expected error for invalid exclude pattern")
+	}
+}
+
+func TestApplyIncludeFilterToDiscovered_AppliesExcludeAfterInclude(t *testing.T) {
+	discovered := map[resource.ResourceType]map[string]bool{
+		resource.Skill:   {"pdf": true, "experimental-ocr": true},
+		resource.Command: {"build": true},
+	}
+
+	if err := applyIncludeFilterToDiscovered(discovered, nil, []string{"skill/experimental-*", "command/*"}); err != nil {
+		t.Fatalf("applyIncludeFilterToDiscovered() error = %v", err)
+	}
+
+	if _, ok := discovered[resource.Command]; ok {
+		t.Fatalf("expected excluded command type to be removed, got %v", discovered)
+	}
+	if !discovered[resource.Skill]["pdf"] || discovered[resource.Skill]["experimental-ocr"] {
+		t.Fatalf("unexpected skills after exclude: %v", discovered[resource.Skill])
+	}
+
+	if err := applyIncludeFilterToDiscovered(discovered, []string{"skill/*"}, []string{"skill/[invalid"}); err == nil {
+		t.Fatal("expected error for invalid exclude pattern")
+	}
+	if !discovered[resource.Skill]["pdf"] {
+		t.Fatal("invalid patterns must not modify the discovered set")
+	}
+}
+
+func TestAddSourceToManifest_ReplacesExcludeOnReAdd(t *testing.T) {
+	repoPath := t.TempDir()
+	t.Setenv("AIMGR_REPO_PATH", repoPath)
+
+	manager := repo.NewManagerWithPath(repoPath)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	parsed, err := source.ParseSource("gh:owner/repo")
+	if err != nil {
+		t.Fatalf("failed to parse source: %v", err)
+	}
+
+	withRepoAddFlagsReset(t, func() {
+		if err := addSourceToManifest(manager, parsed, nil, []string{"skill/experimental-*"}, repomanifest.DiscoveryModeAuto, false); err != nil {
+			t.Fatalf("addSourceToManifest failed: %v", err)
+		}
+		if err := addSourceToManifest(manager, parsed, nil, []string{"skill/draft"}, repomanifest.DiscoveryModeAuto, false); err != nil {
+			t.Fatalf("addSourceToManifest (re-add) failed: %v", err)
+		}
+	})
+
+	manifest, err := repomanifest.Load(repoPath)
+	if err != nil {
+		t.Fatalf("failed to load manifest: %v", err)
+	}
+	if len(manifest.Sources) != 1 {
+		t.Fatalf("expected 1 source, got %d", len(manifest.Sources))
+	}
+	if got := strings.Join(manifest.Sources[0].Exclude, ","); got != "skill/draft" {
+		t.Fatalf("exclude after re-add = %q, want %q", got, "skill/draft")
+	}
+}
+
+func TestPreImportWarnings_GroupsLinkAndScriptIssues(t *testing.T) {
+	skillDir := filepath.Join(t.TempDir(), "pdf")
+	if err := os.MkdirAll(filepath.Join(skillDir, "scripts"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	skillMD := "---\nname: pdf\ndescription: PDF tools\n---\n# PDF\n\nSee [the guide](references/missing.md) and run `scripts/run.sh`.\n"
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(skillMD), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "scripts", "run.sh"), []byte("#!/bin/sh\necho ok\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	skill, err := resource.LoadSkill(skillDir)
+	if err != nil {
+		t.Fatalf("LoadSkill() error = %v", err)
+	}
+
+	groups := preImportWarnings(nil, []*resource.Resource{skill}, nil)
+	if len(groups) != 2 || groups[0].title != "Link Issues" || groups[1].title != "Script Issues" {
+		t.Fatalf("preImportWarnings() = %+v, want link and script groups", groups)
+	}
+	if len(groups[0].warnings) != 1 || !strings.HasPrefix(groups[0].warnings[0], "skill/pdf: ") || !strings.Contains(groups[0].warnings[0], "references/missing.md") {
+		t.Errorf("link warnings = %v, want the broken link of skill/pdf", groups[0].warnings)
+	}
+	if len(groups[1].warnings) != 1 || !strings.Contains(groups[1].warnings[0], "scripts/run.sh") {
+		t.Errorf("script warnings = %v, want the non-executable script", groups[1].warnings)
+	}
+}
@@ -111,6 +111,9 @@ func runApplyManifest(cmd *cobra.Command, args []string) error {
 	if err != nil {
 		return err
 	}
+	if err := checkApplySourcesPolicy(incoming); err != nil {
+		return err
+	}
 
 	current, err := repomanifest.LoadForMutation(mgr.GetRepoPath())
 	if err != nil {
@@ -153,6 +156,25 @@ func runApplyManifest(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// checkApplySourcesPolicy refuses a manifest with sources the organization
+// policy does not allow, listing all of them.
+func checkApplySourcesPolicy(incoming *repomanifest.Manifest) error {
+	p, err := loadOrgPolicy()
+	if err != nil || p == nil {
+		return err
+	}
+	var denied []string
+	for _, src := range incoming.Sources {
+		if v := p.CheckSource(sourcePolicyLocation(src.URL, src.Path)); v != nil {
+			denied = append(denied, fmt.Sprintf("%s: %s", src.Name, v.Message))
+		}
+	}
+	if len(denied) > 0 {
+		return fmt.Errorf("manifest has %d source(s) blocked by policy %s:\n  - %s", len(denied), p.Location, strings.Join(denied, "\n  - "))
+	}
+	return nil
+}
+
 func conflictMessages(report *repomanifest.ApplyMergeReport) []string {
 	if report == nil {
 		return nil
@@ -188,5 +210,5 @@ func init() {
 	repoCmd.AddCommand(repoApplyManifestCmd)
 
 	repoApplyManifestCmd.Flags().BoolVar(&repoApplyDryRunFlag, "dry-run", false, "Preview merge actions without writing ai.repo.yaml")
-	repoApplyManifestCmd.Flags().StringVar(&repoApplyIncludeModeFlag, "include-mode", string(repomanifest.IncludeMergeReplace), "Include handling for same-location sources: replace or preserve")
+	repoApplyManifestCmd.Flags().StringVar(&repoApplyIncludeModeFlag, "include-mode", string(repomanifest.IncludeMergeReplace), "Include/exclude filter handling for same-location sources: replace or preserve")
 }
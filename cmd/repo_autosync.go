@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/sourcemetadata"
+)
+
+// autoSyncStaleSources syncs manifest sources whose last sync is older than
+// the repo.autoSync max age configured in aimgr.yaml. It does nothing unless
+// auto-sync is enabled. Problems such as being offline are printed as warnings
+// on stderr so the calling command continues with the resources already in
+// the repository.
+//
+// Callers must not hold the repository lock: auto-sync takes the write lock
+// for the duration of the sync and releases it before returning.
+func autoSyncStaleSources(ctx context.Context, manager *repo.Manager) {
+	cfg, err := config.LoadGlobal()
+	if err != nil || !cfg.Repo.AutoSync.Enabled {
+		return
+	}
+
+	for _, warning := range runAutoSync(ctx, manager, cfg.Repo.AutoSync) {
+		fmt.Fprintf(os.Stderr, "⚠ Warning: auto-sync: %s\n", warning)
+	}
+}
+
+// staleSources returns the sources that have never been synced or whose last
+// sync is older than their configured max age.
+func staleSources(m *repomanifest.Manifest, metadata *sourcemetadata.SourceMetadata, policy config.AutoSyncConfig, now time.Time) []*repomanifest.Source {
+	if m == nil {
+		return nil
+	}
+
+	var stale []*repomanifest.Source
+	for _, src := range m.Sources {
+		if src == nil {
+			continue
+		}
+		var lastSynced time.Time
+		if metadata != nil {
+			if state, ok := metadata.Sources[src.Name]; ok {
+				lastSynced = state.LastSynced
+			}
+		}
+		if lastSynced.IsZero() || now.Sub(lastSynced) > policy.MaxAgeFor(src.Name) {
+			stale = append(stale, src)
+		}
+	}
+
+	return stale
+}
+
+// runAutoSync performs the auto-sync and returns warnings for the caller to
+// report. It never fails the calling command.
+func runAutoSync(ctx context.Context, manager *repo.Manager, policy config.AutoSyncConfig) []string {
+	repoPath := manager.GetRepoPath()
+	initialized, err := repoInitialized(repoPath)
+	if err != nil || !initialized {
+		return nil
+	}
+
+	m, err := repomanifest.Load(repoPath)
+	if err != nil {
+		return []string{fmt.Sprintf("skipped, failed to load manifest: %v", err)}
+	}
+	if len(staleSources(m, loadSyncMetadata(repoPath), policy, time.Now())) == 0 {
+		return nil
+	}
+
+	repoLock, err := manager.AcquireRepoWriteLock(ctx)
+	if err != nil {
+		return []string{fmt.Sprintf("skipped, failed to acquire repository lock at %s: %v", manager.RepoLockPath(), err)}
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	state, err := prepareSyncRunState(manager)
+	if err != nil {
+		return []string{fmt.Sprintf("skipped: %v", err)}
+	}
+
+	// Re-check under the lock: a concurrent sync may already have refreshed
+	// the sources we saw as stale.
+	stale := staleSources(state.manifest, state.metadata, policy, time.Now())
+	if len(stale) == 0 {
+		return nil
+	}
+	state.manifest = &repomanifest.Manifest{Version: state.manifest.Version, Sources: stale}
+	// Keep the sync quiet; stdout belongs to the calling command.
+	state.mode = syncOutputMode{format: output.JSON}
+
+	restoreFlags := applySyncOperationFlags()
+	defer restoreFlags()
+
+	sourceResults, internalResult, warnings := syncManifestSources(state, manager)
+	warnings = append(state.warnings, warnings...)
+
+	if internalResult.sourcesProcessed > 0 || state.fetchFailuresRecorded {
+		warnings = append(warnings, syncSaveMetadata(manager, state.metadata)...)
+	}
+	if internalResult.sourcesProcessed > 0 {
+		syncRegenerateModifications(manager, state.repoPath)
+	}
+
+	var refreshed []string
+	for _, sr := range sourceResults {
+		if sr.Failed {
+			warnings = append(warnings, fmt.Sprintf("could not refresh source %s, using previously synced resources: %s", sr.Name, sr.Error))
+			continue
+		}
+		refreshed = append(refreshed, sr.Name)
+	}
+	if len(refreshed) > 0 {
+		fmt.Fprintf(os.Stderr, "Auto-sync: refreshed %d stale source(s): %s\n", len(refreshed), strings.Join(refreshed, ", "))
+	}
+
+	return warnings
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/sourcemetadata"
+)
+
+func TestStaleSources(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	m := &repomanifest.Manifest{Version: 1, Sources: []*repomanifest.Source{
+		{Name: "fresh", Path: "/tmp/fresh"},
+		{Name: "old", Path: "/tmp/old"},
+		{Name: "never", Path: "/tmp/never"},
+		{Name: "strict", Path: "/tmp/strict"},
+	}}
+	metadata := &sourcemetadata.SourceMetadata{Version: 1, Sources: map[string]*sourcemetadata.SourceState{
+		"fresh":  {LastSynced: now.Add(-2 * time.Hour)},
+		"old":    {LastSynced: now.Add(-48 * time.Hour)},
+		"strict": {LastSynced: now.Add(-2 * time.Hour)},
+	}}
+	policy := config.AutoSyncConfig{Enabled: true, MaxAge: "24h", Sources: map[string]string{"strict": "1h"}}
+
+	var names []string
+	for _, src := range staleSources(m, metadata, policy, now) {
+		names = append(names, src.Name)
+	}
+
+	if got := strings.Join(names, ","); got != "old,never,strict" {
+		t.Fatalf("staleSources() = %s, want old,never,strict", got)
+	}
+}
+
+func TestRunAutoSync_SyncsStaleSourcesOnly(t *testing.T) {
+	// Metadata is committed to the repository's git history.
+	t.Setenv("GIT_AUTHOR_NAME", "Test User")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test User")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	repoPath := t.TempDir()
+	manager := repo.NewManagerWithPath(repoPath)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	staleDir := writeAutoSyncSource(t, "stale-cmd")
+	freshDir := writeAutoSyncSource(t, "fresh-cmd")
+
+	m := &repomanifest.Manifest{Version: 1, Sources: []*repomanifest.Source{
+		{Name: "stale-source", Path: staleDir},
+		{Name: "fresh-source", Path: freshDir},
+	}}
+	if err := m.Save(repoPath); err != nil {
+		t.Fatalf("failed to save manifest: %v", err)
+	}
+
+	metadata := &sourcemetadata.SourceMetadata{Version: 1, Sources: map[string]*sourcemetadata.SourceState{
+		"fresh-source": {LastSynced: time.Now().Add(-time.Minute)},
+	}}
+	if err := metadata.Save(repoPath); err != nil {
+		t.Fatalf("failed to save metadata: %v", err)
+	}
+
+	policy := config.AutoSyncConfig{Enabled: true, MaxAge: "1h"}
+	out := captureOutput(t, func() {
+		if warnings := runAutoSync(context.Background(), manager, policy); len(warnings) != 0 {
+			t.Fatalf("unexpected warnings: %v", warnings)
+		}
+	})
+
+	if _, err := manager.Get("stale-cmd", resource.Command); err != nil {
+		t.Fatalf("expected stale source to be synced: %v", err)
+	}
+	if _, err := manager.Get("fresh-cmd", resource.Command); err == nil {
+		t.Fatal("fresh source must not be synced")
+	}
+	if out.Stdout != "" {
+		t.Fatalf("auto-sync must not write to stdout, got:\n%s", out.Stdout)
+	}
+
+	updated, err := sourcemetadata.Load(repoPath)
+	if err != nil {
+		t.Fatalf("failed to load metadata: %v", err)
+	}
+	if state := updated.Get("stale-source"); state == nil || state.LastSynced.IsZero() {
+		t.Fatalf("expected last_synced to be recorded for stale-source, got %+v", state)
+	}
+}
+
+func TestRunAutoSync_UnreachableSourceWarnsInsteadOfFailing(t *testing.T) {
+	repoPath := t.TempDir()
+	manager := repo.NewManagerWithPath(repoPath)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	m := &repomanifest.Manifest{Version: 1, Sources: []*repomanifest.Source{
+		{Name: "offline", Path: filepath.Join(t.TempDir(), "missing")},
+	}}
+	if err := m.Save(repoPath); err != nil {
+		t.Fatalf("failed to save manifest: %v", err)
+	}
+
+	var warnings []string
+	captureOutput(t, func() {
+		warnings = runAutoSync(context.Background(), manager, config.AutoSyncConfig{Enabled: true})
+	})
+
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "could not refresh source offline") {
+		t.Fatalf("expected a refresh warning for the offline source, got %v", warnings)
+	}
+}
+
+func writeAutoSyncSource(t *testing.T, commandName string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "commands"), 0755); err != nil {
+		t.Fatalf("failed to create commands dir: %v", err)
+	}
+	content := "---\ndescription: Auto-sync test command\n---\n# " + commandName
+	if err := os.WriteFile(filepath.Join(dir, "commands", commandName+".md"), []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write command: %v", err)
+	}
+
+	return dir
+}
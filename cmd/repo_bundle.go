@@ -0,0 +1,756 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/bundle"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/pattern"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/source"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/sourcemetadata"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/workspace"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	bundleCreateFormatFlag       string
+	bundleImportForceFlag        bool
+	bundleImportSkipExistingFlag bool
+	bundleImportDryRunFlag       bool
+	bundleImportReviewFlag       bool
+	bundleImportFormatFlag       string
+)
+
+// bundleCreateOutput is the structured output of repo bundle create.
+type bundleCreateOutput struct {
+	File      string   `json:"file" yaml:"file"`
+	Resources []string `json:"resources" yaml:"resources"`
+	Sources   []string `json:"sources" yaml:"sources"`
+	Caches    []string `json:"caches" yaml:"caches"`
+	Warnings  []string `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+// bundleSourceChange reports how one bundled source was merged into ai.repo.yaml.
+type bundleSourceChange struct {
+	Name    string `json:"name" yaml:"name"`
+	Action  string `json:"action" yaml:"action"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// bundleImportOutput is the structured output of repo bundle import.
+type bundleImportOutput struct {
+	Resources *output.BulkOperationResult `json:"resources" yaml:"resources"`
+	Sources   []bundleSourceChange        `json:"sources" yaml:"sources"`
+	Caches    []string                    `json:"caches_installed" yaml:"caches_installed"`
+	DryRun    bool                        `json:"dry_run" yaml:"dry_run"`
+}
+
+// repoBundleCmd represents the repo bundle command group
+var repoBundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Export and import offline repository bundles",
+	Long: `Export resources into a self-contained archive and restore it into another
+repository, for machines without network access to the original sources.
+
+A bundle contains the selected resources, their metadata, an ai.repo.yaml with
+the sources that provided them, and the workspace caches of those sources.`,
+}
+
+// repoBundleCreateCmd represents the repo bundle create command
+var repoBundleCreateCmd = &cobra.Command{
+	Use:   "create <file> [pattern]...",
+	Short: "Write selected resources into an offline bundle",
+	Long: `Write a bundle archive (.tar.gz) with the selected resources.
+
+Without patterns every resource in the repository is bundled. Patterns use the
+same syntax as 'aimgr repo list' (e.g. "skill/*", "command/api-*"). Selecting a
+package also bundles the resources it references.
+
+The bundle includes:
+  - resource content and .metadata entries
+  - an ai.repo.yaml with the remote sources that provided the resources
+  - the workspace caches of those sources, so they can be synced offline
+
+Local path sources are machine-specific and are left out of the bundled
+ai.repo.yaml; their resources are still bundled.
+
+Examples:
+  aimgr repo bundle create offline.tar.gz
+  aimgr repo bundle create platform.tar.gz "skill/*" package/web-dev`,
+	Args:              cobra.MinimumNArgs(1),
+	SilenceUsage:      true,
+	ValidArgsFunction: completeBundleCreateArgs,
+	RunE:              runRepoBundleCreate,
+}
+
+// repoBundleImportCmd represents the repo bundle import command
+var repoBundleImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Restore resources from an offline bundle",
+	Long: `Restore a bundle created with 'aimgr repo bundle create' into this repository.
+
+Bundled sources are merged into ai.repo.yaml with 'repo apply-manifest'
+semantics (existing include/exclude filters are preserved); conflicting sources
+abort the import before anything is written. Resources keep the source they
+were originally imported from.
+
+Resource conflicts are handled like 'aimgr repo add': existing resources cause
+a failure unless --force (overwrite) or --skip-existing (keep) is given. The
+organization policy, secret scanning and the local-changes policy apply as
+well. Resources of sources marked for review, or all resources with --review,
+are held in the quarantine until approved with 'aimgr repo review'.
+
+Bundled workspace caches are installed when this repository has no cache for
+the same URL yet, so later syncs can work without network access.
+
+Examples:
+  aimgr repo bundle import offline.tar.gz
+  aimgr repo bundle import offline.tar.gz --skip-existing
+  aimgr repo bundle import offline.tar.gz --review
+  aimgr repo bundle import offline.tar.gz --dry-run`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return nil, cobra.ShellCompDirectiveDefault
+	},
+	RunE: runRepoBundleImport,
+}
+
+func completeBundleCreateArgs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return completeResourcesWithOptions(completionOptions{includePackages: true})(cmd, args, toComplete)
+}
+
+func runRepoBundleCreate(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(bundleCreateFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	bundlePath := args[0]
+	patterns := args[1:]
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return err
+	}
+
+	repoLock, repoExists, err := acquireRepoReadLockIfRepoExists(cmd.Context(), manager)
+	if err != nil {
+		return err
+	}
+	if !repoExists {
+		return missingRepoPathError(manager.GetRepoPath())
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	selected, err := selectBundleResources(manager, patterns)
+	if err != nil {
+		return err
+	}
+	if len(selected) == 0 {
+		return fmt.Errorf("no resources to bundle")
+	}
+
+	result, err := writeBundle(manager, bundlePath, selected)
+	if err != nil {
+		_ = os.Remove(bundlePath)
+		return err
+	}
+
+	if format != output.Table {
+		return output.FormatOutput(result, format)
+	}
+
+	fmt.Printf("✓ Bundle written to %s\n", result.File)
+	fmt.Printf("  Resources: %d\n", len(result.Resources))
+	fmt.Printf("  Sources:   %s\n", summarizeResourceRefs(result.Sources, 3))
+	fmt.Printf("  Caches:    %d\n", len(result.Caches))
+	for _, warning := range result.Warnings {
+		fmt.Printf("⚠ Warning: %s\n", warning)
+	}
+
+	return nil
+}
+
+// selectBundleResources returns the resources matching patterns (all when
+// none are given), plus the members of any selected package.
+func selectBundleResources(manager *repo.Manager, patterns []string) ([]resource.Resource, error) {
+	all, err := manager.List(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list resources: %w", err)
+	}
+	if len(patterns) == 0 {
+		return all, nil
+	}
+
+	selected := make(map[string]bool)
+	for _, p := range patterns {
+		matcher, err := pattern.NewMatcher(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern '%s': %w", p, err)
+		}
+		matched := false
+		for i := range all {
+			if matcher.Match(&all[i]) {
+				selected[FormatResourceArg(&all[i])] = true
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("no resources match '%s'", p)
+		}
+	}
+
+	for i := range all {
+		res := &all[i]
+		if res.Type != resource.PackageType || !selected[FormatResourceArg(res)] {
+			continue
+		}
+		pkg, err := resource.LoadPackage(manager.GetPath(res.Name, resource.PackageType))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load package %s: %w", res.Name, err)
+		}
+		for _, ref := range pkg.Resources {
+			resType, name, err := resource.ParseResourceReference(ref)
+			if err != nil {
+				continue
+			}
+			selected[fmt.Sprintf("%s/%s", resType, name)] = true
+		}
+	}
+
+	var resources []resource.Resource
+	for i := range all {
+		if selected[FormatResourceArg(&all[i])] {
+			resources = append(resources, all[i])
+		}
+	}
+
+	return resources, nil
+}
+
+// writeBundle writes the selected resources, their metadata, the sources that
+// provided them and those sources' workspace caches to bundlePath.
+func writeBundle(manager *repo.Manager, bundlePath string, selected []resource.Resource) (*bundleCreateOutput, error) {
+	repoPath := manager.GetRepoPath()
+	result := &bundleCreateOutput{File: bundlePath, Resources: []string{}, Sources: []string{}, Caches: []string{}}
+
+	manifest, err := repomanifest.Load(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load manifest: %w", err)
+	}
+
+	w, err := bundle.Create(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	closed := false
+	defer func() {
+		if !closed {
+			_ = w.Close()
+		}
+	}()
+
+	bm := &bundle.Manifest{Version: bundle.FormatVersion, CreatedAt: time.Now().UTC()}
+	var usedSources []*repomanifest.Source
+
+	for i := range selected {
+		res := &selected[i]
+		ref := FormatResourceArg(res)
+
+		contentPath := manager.GetPath(res.Name, res.Type)
+		archivePath, err := repoRelativeArchivePath(repoPath, contentPath)
+		if err != nil {
+			return nil, err
+		}
+		if err := w.AddPath(archivePath, contentPath); err != nil {
+			return nil, err
+		}
+
+		metadataPath, sourceID, sourceName := bundleResourceMetadata(res, repoPath)
+		if metadataPath != "" {
+			metadataArchivePath, err := repoRelativeArchivePath(repoPath, metadataPath)
+			if err != nil {
+				return nil, err
+			}
+			if err := w.AddPath(metadataArchivePath, metadataPath); err != nil {
+				return nil, err
+			}
+		}
+
+		entry := bundle.Resource{Ref: ref, Path: archivePath}
+		if src := findManifestSource(manifest, sourceID, sourceName); src != nil {
+			entry.Source = src.Name
+			if !slices.Contains(usedSources, src) {
+				usedSources = append(usedSources, src)
+			}
+		}
+		bm.Resources = append(bm.Resources, entry)
+		result.Resources = append(result.Resources, ref)
+	}
+
+	wsMgr, err := workspace.NewManager(repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workspace manager: %w", err)
+	}
+
+	bundled := &repomanifest.Manifest{Version: 1}
+	for _, src := range usedSources {
+		if src.URL == "" {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("source %q is a local path and is not included in the bundled ai.repo.yaml", src.Name))
+			continue
+		}
+		bundled.Sources = append(bundled.Sources, src)
+		result.Sources = append(result.Sources, src.Name)
+
+		cache, err := bundleSourceCache(wsMgr, src, bm.Caches)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("source %q: %v", src.Name, err))
+			continue
+		}
+		if cache == nil {
+			continue
+		}
+		cachePath, _ := wsMgr.CachedPath(cache.URL)
+		if err := w.AddPath(cache.Path, cachePath); err != nil {
+			return nil, err
+		}
+		bm.Caches = append(bm.Caches, *cache)
+		result.Caches = append(result.Caches, cache.URL)
+	}
+
+	if len(bundled.Sources) > 0 {
+		data, err := yaml.Marshal(bundled)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal bundled manifest: %w", err)
+		}
+		if err := w.AddBytes(repomanifest.ManifestFileName, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.WriteManifest(bm); err != nil {
+		return nil, err
+	}
+	closed = true
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// bundleSourceCache returns the cache entry to bundle for a remote source, or
+// nil when the cache is already bundled. Sources without a cache are reported
+// as an error because syncing them will need network access.
+func bundleSourceCache(wsMgr *workspace.Manager, src *repomanifest.Source, bundledCaches []bundle.Cache) (*bundle.Cache, error) {
+	parsed, err := parsedRemoteSourceForManifestEntry(src)
+	if err != nil {
+		return nil, fmt.Errorf("invalid source URL: %w", err)
+	}
+	cloneURL, err := source.GetCloneURL(parsed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get clone URL: %w", err)
+	}
+
+	for _, cache := range bundledCaches {
+		if cache.URL == cloneURL {
+			return nil, nil
+		}
+	}
+
+	cachePath, ok := wsMgr.CachedPath(cloneURL)
+	if !ok {
+		return nil, fmt.Errorf("no workspace cache found; syncing it will need network access")
+	}
+
+	return &bundle.Cache{
+		URL:  cloneURL,
+		Ref:  parsed.Ref,
+		Path: path.Join(bundle.CachesDir, filepath.Base(cachePath)),
+	}, nil
+}
+
+// bundleResourceMetadata returns the metadata file of a resource (empty if
+// none) and the source it records.
+func bundleResourceMetadata(res *resource.Resource, repoPath string) (metadataPath, sourceID, sourceName string) {
+	if res.Type == resource.PackageType {
+		meta, err := metadata.LoadPackageMetadata(res.Name, repoPath)
+		if err != nil {
+			return "", "", ""
+		}
+		return metadata.GetPackageMetadataPath(res.Name, repoPath), meta.SourceID, meta.SourceName
+	}
+
+	meta, err := metadata.Load(res.Name, res.Type, repoPath)
+	if err != nil {
+		return "", "", ""
+	}
+	return metadata.GetMetadataPath(res.Name, res.Type, repoPath), meta.SourceID, meta.SourceName
+}
+
+func repoRelativeArchivePath(repoPath, p string) (string, error) {
+	rel, err := filepath.Rel(repoPath, p)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", p, err)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+func runRepoBundleImport(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(bundleImportFormatFlag)
+	if err != nil {
+		return err
+	}
+	if bundleImportForceFlag && bundleImportSkipExistingFlag {
+		return fmt.Errorf("--force and --skip-existing cannot be used together")
+	}
+
+	bundlePath, err := filepath.Abs(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid bundle path: %w", err)
+	}
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return err
+	}
+
+	repoLock, err := manager.AcquireRepoWriteLock(cmd.Context())
+	if err != nil {
+		return wrapLockAcquireError(manager.RepoLockPath(), err)
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	if err := maybeHoldAfterRepoLock(cmd.Context(), "bundle-import"); err != nil {
+		return err
+	}
+
+	repoPath := manager.GetRepoPath()
+
+	if !bundleImportDryRunFlag {
+		if err := manager.Init(); err != nil {
+			return fmt.Errorf("failed to initialize repository for bundle import: %w", err)
+		}
+	}
+	staging, err := os.MkdirTemp("", "aimgr-bundle-import-")
+	if err != nil {
+		return fmt.Errorf("failed to create staging directory: %w", err)
+	}
+	defer func() {
+		_ = os.RemoveAll(staging)
+	}()
+
+	bm, err := bundle.Extract(bundlePath, staging)
+	if err != nil {
+		return err
+	}
+
+	merged, report, err := mergeBundleSources(repoPath, staging)
+	if err != nil {
+		return err
+	}
+	sources := merged
+	if sources == nil {
+		if sources, err = repomanifest.Load(repoPath); err != nil {
+			return fmt.Errorf("failed to load manifest: %w", err)
+		}
+	}
+
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	importOpts := importOptions{localChanges: cfg.Repo.LocalChangesPolicy(), review: bundleImportReviewFlag}
+	importResult, importErr := importBundleResources(manager, bm, staging, bundlePath, sources, importOpts)
+
+	result := &bundleImportOutput{
+		Resources: output.FromBulkImportResult(importResult),
+		Sources:   []bundleSourceChange{},
+		Caches:    []string{},
+		DryRun:    bundleImportDryRunFlag,
+	}
+	trimBundleStagingPaths(result.Resources, staging)
+	if report != nil {
+		for _, change := range report.Changes {
+			result.Sources = append(result.Sources, bundleSourceChange{Name: change.Name, Action: string(change.Action), Message: change.Message})
+		}
+	}
+
+	// A failed import leaves ai.repo.yaml and the workspace caches untouched.
+	if !bundleImportDryRunFlag && importErr == nil {
+		if report != nil && (report.Added() > 0 || report.Updated() > 0) {
+			if err := saveBundleSources(manager, merged, report); err != nil {
+				return err
+			}
+		}
+
+		installed, warnings := installBundleCaches(repoPath, bm, staging)
+		result.Caches = installed
+		result.Resources.Warnings = append(result.Resources.Warnings, warnings...)
+	}
+	if !bundleImportDryRunFlag && (len(importResult.Added) > 0 || len(importResult.Updated) > 0) {
+		syncRegenerateModifications(manager, repoPath)
+	}
+
+	if format != output.Table {
+		if err := output.FormatOutput(result, format); err != nil {
+			return err
+		}
+		return importErr
+	}
+
+	if report != nil {
+		printApplyReport(report, bundleImportDryRunFlag)
+		fmt.Println()
+	}
+	if err := output.FormatBulkResult(result.Resources, format); err != nil {
+		return err
+	}
+	if len(result.Caches) > 0 {
+		fmt.Printf("\n✓ Installed %d workspace cache(s)\n", len(result.Caches))
+	}
+	if bundleImportDryRunFlag {
+		fmt.Println("\nDry-run complete: no changes were written")
+	}
+
+	return importErr
+}
+
+// trimBundleStagingPaths reports resource paths relative to the bundle
+// instead of the temporary staging directory.
+func trimBundleStagingPaths(result *output.BulkOperationResult, staging string) {
+	for _, results := range [][]output.ResourceResult{result.Added, result.Updated, result.Skipped, result.Failed} {
+		for i := range results {
+			if rel, err := filepath.Rel(staging, results[i].Path); err == nil && !strings.HasPrefix(rel, "..") {
+				results[i].Path = filepath.ToSlash(rel)
+			}
+		}
+	}
+}
+
+// mergeBundleSources merges the bundled ai.repo.yaml (if any) into the
+// repository manifest. Conflicts abort the import before anything is written.
+func mergeBundleSources(repoPath, staging string) (*repomanifest.Manifest, *repomanifest.ApplyMergeReport, error) {
+	bundledManifestPath := filepath.Join(staging, repomanifest.ManifestFileName)
+	if _, err := os.Stat(bundledManifestPath); os.IsNotExist(err) {
+		return nil, nil, nil
+	}
+
+	incoming, err := repomanifest.LoadForApply(bundledManifestPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid bundled manifest: %w", err)
+	}
+	current, err := repomanifest.LoadForMutation(repoPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load local manifest: %w", err)
+	}
+	for _, src := range incoming.Sources {
+		if err := checkSourcePolicy(sourcePolicyLocation(src.URL, src.Path)); err != nil {
+			return nil, nil, fmt.Errorf("bundled source '%s': %w", src.Name, err)
+		}
+		// A bundle cannot turn off review for a source this repository reviews.
+		if existing, ok := current.GetSource(src.Name); ok && existing.Review {
+			src.Review = true
+		}
+	}
+
+	merged, report, err := repomanifest.MergeForApply(current, incoming, repomanifest.ApplyMergeOptions{
+		IncludeMode: repomanifest.IncludeMergePreserve,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if report.HasConflicts() {
+		return nil, nil, fmt.Errorf("bundle sources have %d conflict(s) with ai.repo.yaml; resolve conflicts and retry:\n  - %s", report.Conflicts(), strings.Join(conflictMessages(report), "\n  - "))
+	}
+
+	return merged, report, nil
+}
+
+// importBundleResources imports bundled resources grouped by their original
+// source, so metadata keeps pointing at that source instead of the bundle.
+// Groups whose source is marked for review in sources are quarantined.
+func importBundleResources(manager *repo.Manager, bm *bundle.Manifest, staging, bundlePath string, sources *repomanifest.Manifest, importOpts importOptions) (*repo.BulkImportResult, error) {
+	type provenance struct {
+		name, id, url, sourceType, ref string
+	}
+
+	combined := &repo.BulkImportResult{Added: []string{}, Updated: []string{}, Skipped: []string{}, Failed: []repo.ImportError{}}
+	var order []provenance
+	groups := make(map[provenance][]string)
+	for _, entry := range bm.Resources {
+		resType, name, err := parseBundleResourceRef(entry.Ref)
+		if err != nil {
+			return combined, fmt.Errorf("invalid bundle resource %q: %w", entry.Ref, err)
+		}
+
+		key := provenance{url: "file://" + bundlePath, sourceType: "file"}
+		if resType == resource.PackageType {
+			if meta, err := metadata.LoadPackageMetadata(name, staging); err == nil {
+				key = provenance{meta.SourceName, meta.SourceID, meta.SourceURL, meta.SourceType, meta.SourceRef}
+			}
+		} else if meta, err := metadata.Load(name, resType, staging); err == nil {
+			key = provenance{meta.SourceName, meta.SourceID, meta.SourceURL, meta.SourceType, meta.Ref}
+		}
+
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], filepath.Join(staging, filepath.FromSlash(entry.Path)))
+	}
+
+	// Every origin must be allowed before anything is imported.
+	for _, key := range order {
+		if err := checkSourcePolicy(key.url); err != nil {
+			return combined, fmt.Errorf("bundled resources from %s: %w", key.url, err)
+		}
+	}
+
+	secretScanner, secretsPolicy, err := newImportSecretScanner(staging)
+	if err != nil {
+		return combined, err
+	}
+	orgPolicy, err := loadOrgPolicy()
+	if err != nil {
+		return combined, err
+	}
+
+	for _, key := range order {
+		src, _ := sources.GetSource(key.name)
+		groupResult, err := manager.AddBulk(groups[key], repo.BulkImportOptions{
+			SourceName:   key.name,
+			SourceID:     key.id,
+			ImportMode:   "copy",
+			Force:        bundleImportForceFlag,
+			SkipExisting: bundleImportSkipExistingFlag,
+			DryRun:       bundleImportDryRunFlag,
+			SourceURL:    key.url,
+			SourceType:   key.sourceType,
+			Ref:          key.ref,
+			LocalChanges: importOpts.localChanges,
+			Quarantine:   importOpts.review || (src != nil && src.Review),
+
+			Secrets:       secretScanner,
+			SecretsPolicy: secretsPolicy,
+			Policy:        orgPolicy,
+		})
+		if groupResult != nil {
+			repo.MergeBulkResults(combined, groupResult)
+		}
+		if err != nil {
+			return combined, err
+		}
+	}
+
+	if len(combined.Failed) > 0 {
+		return combined, fmt.Errorf("failed to import %d resource(s)", len(combined.Failed))
+	}
+	return combined, nil
+}
+
+// parseBundleResourceRef parses a bundle manifest reference, which is
+// "type/name" for resources and "package/name" for packages.
+func parseBundleResourceRef(ref string) (resource.ResourceType, string, error) {
+	if name, ok := strings.CutPrefix(ref, "package/"); ok {
+		if name == "" {
+			return "", "", fmt.Errorf("invalid resource format: %q (expected package/name)", ref)
+		}
+		return resource.PackageType, name, nil
+	}
+	return resource.ParseResourceReference(ref)
+}
+
+// saveBundleSources persists merged sources and records newly added ones in
+// source metadata, like repo add does for a new source.
+func saveBundleSources(manager *repo.Manager, merged *repomanifest.Manifest, report *repomanifest.ApplyMergeReport) error {
+	repoPath := manager.GetRepoPath()
+	if err := merged.Save(repoPath); err != nil {
+		return fmt.Errorf("failed to save merged manifest: %w", err)
+	}
+
+	sourceMeta, err := sourcemetadata.Load(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to load source metadata: %w", err)
+	}
+	for _, change := range report.Changes {
+		if change.Action != repomanifest.ApplyActionAdd {
+			continue
+		}
+		if _, exists := sourceMeta.Sources[change.Name]; !exists {
+			sourceMeta.Sources[change.Name] = &sourcemetadata.SourceState{Added: time.Now()}
+		}
+	}
+	if err := sourceMeta.Save(repoPath); err != nil {
+		return fmt.Errorf("failed to save source metadata: %w", err)
+	}
+
+	if err := manager.CommitChangesForPaths("aimgr: import bundle sources", []string{
+		repomanifest.ManifestFileName,
+		filepath.Join(".metadata", "sources.json"),
+	}); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to commit manifest: %v\n", err)
+	}
+
+	return nil
+}
+
+// installBundleCaches clones bundled workspace caches into place. Existing
+// caches are kept. It returns the URLs of installed caches and warnings.
+func installBundleCaches(repoPath string, bm *bundle.Manifest, staging string) ([]string, []string) {
+	installed := []string{}
+	var warnings []string
+
+	wsMgr, err := workspace.NewManager(repoPath)
+	if err != nil {
+		return installed, []string{fmt.Sprintf("failed to create workspace manager: %v", err)}
+	}
+
+	for _, cache := range bm.Caches {
+		ok, err := wsMgr.ImportCache(cache.URL, cache.Ref, filepath.Join(staging, filepath.FromSlash(cache.Path)))
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("failed to install workspace cache for %s: %v", cache.URL, err))
+			continue
+		}
+		if ok {
+			installed = append(installed, cache.URL)
+		}
+	}
+
+	return installed, warnings
+}
+
+func init() {
+	repoCmd.AddCommand(repoBundleCmd)
+	repoBundleCmd.AddCommand(repoBundleCreateCmd)
+	repoBundleCmd.AddCommand(repoBundleImportCmd)
+
+	repoBundleCreateCmd.Flags().StringVar(&bundleCreateFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	_ = repoBundleCreateCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+
+	repoBundleImportCmd.Flags().BoolVarP(&bundleImportForceFlag, "force", "f", false, "Overwrite existing resources")
+	repoBundleImportCmd.Flags().BoolVar(&bundleImportSkipExistingFlag, "skip-existing", false, "Skip resources that already exist")
+	repoBundleImportCmd.Flags().BoolVar(&bundleImportDryRunFlag, "dry-run", false, "Preview without importing")
+	repoBundleImportCmd.Flags().BoolVar(&bundleImportReviewFlag, "review", false, "Hold new and changed resources in quarantine until approved with 'aimgr repo review'")
+	repoBundleImportCmd.Flags().StringVar(&bundleImportFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	_ = repoBundleImportCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+}
@@ -0,0 +1,361 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/adrg/xdg"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/bundle"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/source"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/workspace"
+)
+
+func TestBundle_CreateAndImportPreservesSourcesAndCaches(t *testing.T) {
+	// Imports are committed to the repositories' git history.
+	t.Setenv("GIT_AUTHOR_NAME", "Test User")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test User")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	srcRepo := t.TempDir()
+	srcManager := repo.NewManagerWithPath(srcRepo)
+	if err := srcManager.Init(); err != nil {
+		t.Fatalf("failed to init source repo: %v", err)
+	}
+
+	remote := &repomanifest.Source{Name: "team-tools", URL: "https://github.com/example/team-tools"}
+	local := &repomanifest.Source{Name: "my-local", Path: t.TempDir()}
+	m := &repomanifest.Manifest{Version: 1, Sources: []*repomanifest.Source{remote, local}}
+	if err := m.Save(srcRepo); err != nil {
+		t.Fatalf("failed to save manifest: %v", err)
+	}
+	m, err := repomanifest.Load(srcRepo)
+	if err != nil {
+		t.Fatalf("failed to reload manifest: %v", err)
+	}
+	remote, _ = m.GetSource("team-tools")
+
+	remoteDir := writeAutoSyncSource(t, "review")
+	result, err := srcManager.AddBulk([]string{filepath.Join(remoteDir, "commands", "review.md")}, repo.BulkImportOptions{
+		SourceName: remote.Name, SourceID: remote.ID, SourceURL: remote.URL, SourceType: "github", ImportMode: "copy",
+	})
+	if err != nil || len(result.Failed) > 0 {
+		t.Fatalf("failed to import remote command: %v %v", err, result.Failed)
+	}
+	localDir := writeAutoSyncSource(t, "scratch")
+	if _, err := srcManager.AddBulk([]string{filepath.Join(localDir, "commands", "scratch.md")}, repo.BulkImportOptions{
+		SourceName: "my-local", ImportMode: "copy",
+	}); err != nil {
+		t.Fatalf("failed to import local command: %v", err)
+	}
+
+	// Fake a workspace cache for the remote source.
+	parsed, err := parsedRemoteSourceForManifestEntry(remote)
+	if err != nil {
+		t.Fatalf("failed to parse source: %v", err)
+	}
+	cloneURL, err := source.GetCloneURL(parsed)
+	if err != nil {
+		t.Fatalf("failed to get clone URL: %v", err)
+	}
+	cacheDir := filepath.Join(srcRepo, ".workspace", workspace.ComputeHash(cloneURL))
+	if output, err := exec.Command("git", "init", "--quiet", cacheDir).CombinedOutput(); err != nil {
+		t.Fatalf("failed to create cache: %v\n%s", err, output)
+	}
+
+	selected, err := selectBundleResources(srcManager, nil)
+	if err != nil {
+		t.Fatalf("selectBundleResources() error = %v", err)
+	}
+	bundlePath := filepath.Join(t.TempDir(), "offline.tar.gz")
+	created, err := writeBundle(srcManager, bundlePath, selected)
+	if err != nil {
+		t.Fatalf("writeBundle() error = %v", err)
+	}
+	if strings.Join(created.Sources, ",") != "team-tools" || len(created.Caches) != 1 {
+		t.Fatalf("unexpected bundle content: %+v", created)
+	}
+	if len(created.Warnings) != 1 || !strings.Contains(created.Warnings[0], `"my-local" is a local path`) {
+		t.Fatalf("expected a warning for the local source, got %v", created.Warnings)
+	}
+
+	// Restore into a fresh repository.
+	dstRepo := t.TempDir()
+	dstManager := repo.NewManagerWithPath(dstRepo)
+	if err := dstManager.Init(); err != nil {
+		t.Fatalf("failed to init destination repo: %v", err)
+	}
+	staging := filepath.Join(dstRepo, ".workspace", ".bundle-import-test")
+	bm, err := bundle.Extract(bundlePath, staging)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	merged, report, err := mergeBundleSources(dstRepo, staging)
+	if err != nil {
+		t.Fatalf("mergeBundleSources() error = %v", err)
+	}
+	if report.Added() != 1 {
+		t.Fatalf("expected one added source, got %+v", report.Changes)
+	}
+	imported, err := importBundleResources(dstManager, bm, staging, bundlePath, merged, importOptions{})
+	if err != nil {
+		t.Fatalf("importBundleResources() error = %v", err)
+	}
+	if len(imported.Added) != 2 {
+		t.Fatalf("expected 2 imported resources, got %+v", imported)
+	}
+	if err := saveBundleSources(dstManager, merged, report); err != nil {
+		t.Fatalf("saveBundleSources() error = %v", err)
+	}
+	installed, warnings := installBundleCaches(dstRepo, bm, staging)
+	if len(installed) != 1 || len(warnings) != 0 {
+		t.Fatalf("installBundleCaches() = %v, %v", installed, warnings)
+	}
+
+	meta, err := dstManager.GetMetadata("review", resource.Command)
+	if err != nil {
+		t.Fatalf("failed to load metadata: %v", err)
+	}
+	if meta.SourceName != "team-tools" || meta.SourceURL != remote.URL {
+		t.Errorf("imported metadata should keep the original source, got %+v", meta)
+	}
+	dstManifest, err := repomanifest.Load(dstRepo)
+	if err != nil {
+		t.Fatalf("failed to load destination manifest: %v", err)
+	}
+	if _, ok := dstManifest.GetSource("team-tools"); !ok || len(dstManifest.Sources) != 1 {
+		t.Errorf("expected only team-tools in destination manifest, got %+v", dstManifest.Sources)
+	}
+	wsMgr, _ := workspace.NewManager(dstRepo)
+	if _, ok := wsMgr.CachedPath(cloneURL); !ok {
+		t.Error("expected the bundled workspace cache to be installed")
+	}
+
+	// A second import conflicts like repo add unless --skip-existing is set.
+	if _, err := importBundleResources(dstManager, bm, staging, bundlePath, merged, importOptions{}); err == nil {
+		t.Fatal("expected re-import to fail on existing resources")
+	}
+	bundleImportSkipExistingFlag = true
+	defer func() { bundleImportSkipExistingFlag = false }()
+	skipped, err := importBundleResources(dstManager, bm, staging, bundlePath, merged, importOptions{})
+	if err != nil || len(skipped.Skipped) != 2 {
+		t.Fatalf("expected re-import with --skip-existing to skip 2 resources, got %+v (%v)", skipped, err)
+	}
+}
+
+func TestSelectBundleResources_PatternsAndPackageMembers(t *testing.T) {
+	repoPath := t.TempDir()
+	manager := repo.NewManagerWithPath(repoPath)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	for _, name := range []string{"alpha", "beta", "gamma"} {
+		dir := writeAutoSyncSource(t, name)
+		if _, err := manager.AddBulk([]string{filepath.Join(dir, "commands", name+".md")}, repo.BulkImportOptions{ImportMode: "copy"}); err != nil {
+			t.Fatalf("failed to import %s: %v", name, err)
+		}
+	}
+	pkg := &resource.Package{Name: "starter", Description: "Starter kit", Resources: []string{"command/gamma"}}
+	if err := resource.SavePackage(pkg, repoPath); err != nil {
+		t.Fatalf("failed to save package: %v", err)
+	}
+
+	selected, err := selectBundleResources(manager, []string{"command/alpha", "package/starter"})
+	if err != nil {
+		t.Fatalf("selectBundleResources() error = %v", err)
+	}
+	var refs []string
+	for i := range selected {
+		refs = append(refs, FormatResourceArg(&selected[i]))
+	}
+	if got := strings.Join(refs, ","); got != "command/alpha,command/gamma,package/starter" {
+		t.Errorf("selected = %s", got)
+	}
+
+	if _, err := selectBundleResources(manager, []string{"skill/missing"}); err == nil {
+		t.Error("expected an error for a pattern without matches")
+	}
+}
+
+func TestBundle_RoundTripsPackages(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "Test User")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test User")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	srcRepo := t.TempDir()
+	srcManager := repo.NewManagerWithPath(srcRepo)
+	if err := srcManager.Init(); err != nil {
+		t.Fatalf("failed to init source repo: %v", err)
+	}
+	dir := writeAutoSyncSource(t, "review")
+	if _, err := srcManager.AddBulk([]string{filepath.Join(dir, "commands", "review.md")}, repo.BulkImportOptions{ImportMode: "copy"}); err != nil {
+		t.Fatalf("failed to import command: %v", err)
+	}
+	pkg := &resource.Package{Name: "starter", Description: "Starter kit", Resources: []string{"command/review"}}
+	if err := resource.SavePackage(pkg, srcRepo); err != nil {
+		t.Fatalf("failed to save package: %v", err)
+	}
+
+	selected, err := selectBundleResources(srcManager, []string{"package/starter"})
+	if err != nil {
+		t.Fatalf("selectBundleResources() error = %v", err)
+	}
+	bundlePath := filepath.Join(t.TempDir(), "starter.tar.gz")
+	if _, err := writeBundle(srcManager, bundlePath, selected); err != nil {
+		t.Fatalf("writeBundle() error = %v", err)
+	}
+
+	dstRepo := t.TempDir()
+	dstManager := repo.NewManagerWithPath(dstRepo)
+	if err := dstManager.Init(); err != nil {
+		t.Fatalf("failed to init destination repo: %v", err)
+	}
+	staging := filepath.Join(dstRepo, ".workspace", ".bundle-import-test")
+	bm, err := bundle.Extract(bundlePath, staging)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	imported, err := importBundleResources(dstManager, bm, staging, bundlePath, nil, importOptions{})
+	if err != nil {
+		t.Fatalf("importBundleResources() error = %v", err)
+	}
+	if len(imported.Added) != 2 || imported.PackageCount != 1 {
+		t.Fatalf("expected the command and the package to be imported, got %+v", imported)
+	}
+	if _, err := resource.LoadPackage(dstManager.GetPath("starter", resource.PackageType)); err != nil {
+		t.Errorf("package not restored: %v", err)
+	}
+
+	// Invalid references fail without a nil result.
+	bm.Resources = append(bm.Resources, bundle.Resource{Ref: "package/"})
+	if result, err := importBundleResources(dstManager, bm, staging, bundlePath, nil, importOptions{}); err == nil || result == nil {
+		t.Errorf("importBundleResources() = %v, %v; want an error and a non-nil result", result, err)
+	}
+}
+
+func TestImportBundleResources_ReviewAndSourcePolicy(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "Test User")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test User")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	xdgConfigDir := t.TempDir()
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", xdgConfigDir)
+	t.Cleanup(xdg.Reload)
+	writeConfig := func(extra string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Join(xdgConfigDir, "aimgr"), 0755); err != nil {
+			t.Fatal(err)
+		}
+		configYAML := "install:\n  targets: [claude]\n" + extra
+		if err := os.WriteFile(filepath.Join(xdgConfigDir, "aimgr", config.DefaultConfigFileName), []byte(configYAML), 0644); err != nil {
+			t.Fatal(err)
+		}
+		xdg.Reload()
+	}
+	writeConfig("")
+
+	srcRepo := t.TempDir()
+	srcManager := repo.NewManagerWithPath(srcRepo)
+	if err := srcManager.Init(); err != nil {
+		t.Fatalf("failed to init source repo: %v", err)
+	}
+	dir := writeAutoSyncSource(t, "vendored")
+	if _, err := srcManager.AddBulk([]string{filepath.Join(dir, "commands", "vendored.md")}, repo.BulkImportOptions{
+		ImportMode: "copy",
+		SourceName: "third-party",
+		SourceURL:  "https://github.com/example/third-party",
+		SourceType: "github",
+	}); err != nil {
+		t.Fatalf("failed to import command: %v", err)
+	}
+	selected, err := selectBundleResources(srcManager, nil)
+	if err != nil {
+		t.Fatalf("selectBundleResources() error = %v", err)
+	}
+	bundlePath := filepath.Join(t.TempDir(), "vendored.tar.gz")
+	if _, err := writeBundle(srcManager, bundlePath, selected); err != nil {
+		t.Fatalf("writeBundle() error = %v", err)
+	}
+
+	dstRepo := t.TempDir()
+	dstManager := repo.NewManagerWithPath(dstRepo)
+	if err := dstManager.Init(); err != nil {
+		t.Fatalf("failed to init destination repo: %v", err)
+	}
+	staging := t.TempDir()
+	bm, err := bundle.Extract(bundlePath, staging)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+
+	// A source reviewed in this repository keeps its resources in quarantine.
+	sources := &repomanifest.Manifest{Version: 1, Sources: []*repomanifest.Source{{
+		Name:   "third-party",
+		URL:    "https://github.com/example/third-party",
+		Review: true,
+	}}}
+	result, err := importBundleResources(dstManager, bm, staging, bundlePath, sources, importOptions{})
+	if err != nil {
+		t.Fatalf("importBundleResources() error = %v", err)
+	}
+	if len(result.Added) != 0 || len(result.Quarantined) != 1 || result.Quarantined[0].ResourceRef() != "command/vendored" {
+		t.Fatalf("expected command/vendored to be quarantined, got %+v", result)
+	}
+	if res, _ := dstManager.Get("vendored", resource.Command); res != nil {
+		t.Error("quarantined resource must not be imported")
+	}
+
+	// Origins outside allowedSources are refused before anything is imported.
+	policyPath := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(policyPath, []byte("allowedSources: [github.com/my-org/*]\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeConfig("policy: " + policyPath + "\n")
+	if _, err := importBundleResources(dstManager, bm, staging, bundlePath, nil, importOptions{}); err == nil || !strings.Contains(err.Error(), "blocked by policy") {
+		t.Fatalf("importBundleResources() error = %v, want a source policy error", err)
+	}
+	if res, _ := dstManager.Get("vendored", resource.Command); res != nil {
+		t.Error("resource from a disallowed source must not be imported")
+	}
+}
+
+func TestMergeBundleSources_KeepsLocalReview(t *testing.T) {
+	repoPath := t.TempDir()
+	local := &repomanifest.Manifest{Version: 1, Sources: []*repomanifest.Source{{
+		Name:   "third-party",
+		URL:    "https://github.com/example/third-party",
+		Review: true,
+	}}}
+	if err := local.Save(repoPath); err != nil {
+		t.Fatalf("failed to save manifest: %v", err)
+	}
+	staging := t.TempDir()
+	bundled := &repomanifest.Manifest{Version: 1, Sources: []*repomanifest.Source{{
+		Name: "third-party",
+		URL:  "https://github.com/example/third-party",
+	}}}
+	if err := bundled.Save(staging); err != nil {
+		t.Fatalf("failed to save bundled manifest: %v", err)
+	}
+
+	merged, _, err := mergeBundleSources(repoPath, staging)
+	if err != nil {
+		t.Fatalf("mergeBundleSources() error = %v", err)
+	}
+	if src, ok := merged.GetSource("third-party"); !ok || !src.Review {
+		t.Errorf("a bundle must not turn off review for a local source, got %+v", merged.Sources)
+	}
+}
@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/workspace"
+	"github.com/spf13/cobra"
+)
+
+var (
+	cacheGCDryRunFlag  bool
+	cacheGCMaxSizeFlag string
+	cacheGCMaxAgeFlag  string
+	cacheGCFormatFlag  string
+)
+
+// repoCacheCmd represents the repo cache command group
+var repoCacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage workspace caches of remote sources",
+	Long: `Manage the git clones kept in .workspace/ for remote sources.
+
+Use 'aimgr repo prune' to remove caches no source references any more, and
+'aimgr repo cache gc' to keep the remaining caches within a size and age budget.`,
+}
+
+// repoCacheGCCmd represents the repo cache gc command
+var repoCacheGCCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "Evict workspace caches over the configured budget",
+	Long: `Evict workspace caches that exceed the cache budget.
+
+Caches not used for longer than the max age are removed first. If the
+remaining caches are still larger than the max size, the least recently used
+ones are removed until they fit. Evicted caches are cloned again the next time
+their source is synced.
+
+The budget comes from repo.cache in aimgr.yaml:
+
+  repo:
+    cache:
+      maxSize: 5GB
+      maxAge: 30d
+
+--max-size and --max-age override it for one run. The same budget is applied
+automatically at the end of 'aimgr repo sync'. Without any budget, gc only
+reports the size of each cache.
+
+Examples:
+  aimgr repo cache gc --dry-run
+  aimgr repo cache gc --max-size 2GB
+  aimgr repo cache gc --max-age 14d --format json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE:         runRepoCacheGC,
+}
+
+func init() {
+	repoCmd.AddCommand(repoCacheCmd)
+	repoCacheCmd.AddCommand(repoCacheGCCmd)
+	repoCacheGCCmd.Flags().BoolVar(&cacheGCDryRunFlag, "dry-run", false, "Show what would be evicted without removing anything")
+	repoCacheGCCmd.Flags().StringVar(&cacheGCMaxSizeFlag, "max-size", "", "Maximum total cache size (e.g. 500MB, 5GB), overrides repo.cache.maxSize")
+	repoCacheGCCmd.Flags().StringVar(&cacheGCMaxAgeFlag, "max-age", "", "Maximum time since a cache was used (e.g. 72h, 30d), overrides repo.cache.maxAge")
+	repoCacheGCCmd.Flags().StringVar(&cacheGCFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	_ = repoCacheGCCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+}
+
+// resolveCacheBudget returns the cache budget from aimgr.yaml with the
+// --max-size and --max-age overrides applied.
+func resolveCacheBudget(maxSize, maxAge string) (config.CacheConfig, error) {
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return config.CacheConfig{}, err
+	}
+	budget := cfg.Repo.Cache
+	if maxSize != "" {
+		budget.MaxSize = maxSize
+	}
+	if maxAge != "" {
+		budget.MaxAge = maxAge
+	}
+	if err := budget.Validate(); err != nil {
+		return config.CacheConfig{}, err
+	}
+	return budget, nil
+}
+
+func runRepoCacheGC(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(cacheGCFormatFlag)
+	if err != nil {
+		return err
+	}
+	budget, err := resolveCacheBudget(cacheGCMaxSizeFlag, cacheGCMaxAgeFlag)
+	if err != nil {
+		return err
+	}
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return err
+	}
+	repoExists, err := repoPathExists(manager.GetRepoPath())
+	if err != nil {
+		return err
+	}
+	if !repoExists {
+		return missingRepoPathError(manager.GetRepoPath())
+	}
+
+	repoLock, err := manager.AcquireRepoWriteLock(cmd.Context())
+	if err != nil {
+		return wrapLockAcquireError(manager.RepoLockPath(), err)
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	result, err := runCacheGC(manager, budget, cacheGCDryRunFlag)
+	if err != nil {
+		return err
+	}
+
+	if format != output.Table {
+		return output.FormatOutput(result, format)
+	}
+	return printCacheGCResult(result, budget)
+}
+
+// runCacheGC applies a cache budget to the repository's workspace caches.
+// Callers must hold the repository write lock.
+func runCacheGC(manager *repo.Manager, budget config.CacheConfig, dryRun bool) (*workspace.GCResult, error) {
+	wsMgr, err := workspace.NewManager(manager.GetRepoPath())
+	if err != nil {
+		return nil, err
+	}
+	result, err := wsMgr.GC(workspace.GCOptions{
+		MaxSize: budget.MaxSizeBytes(),
+		MaxAge:  budget.MaxIdleAge(),
+		DryRun:  dryRun,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect workspace caches: %w", err)
+	}
+	return result, nil
+}
+
+func printCacheGCResult(result *workspace.GCResult, budget config.CacheConfig) error {
+	if len(result.Caches) == 0 {
+		fmt.Println("No workspace caches found.")
+		return nil
+	}
+
+	table := output.NewTable("SOURCE", "REF", "SIZE", "LAST USED", "ACTION")
+	for _, cache := range result.Caches {
+		url := cache.URL
+		if url == "" {
+			url = cache.Hash
+		}
+		lastUsed := "never"
+		if !cache.LastAccessed.IsZero() {
+			lastUsed = formatCacheAge(time.Since(cache.LastAccessed))
+		}
+		action := "keep"
+		switch cache.Evicted {
+		case workspace.EvictIdle:
+			action = "evict (idle)"
+		case workspace.EvictSize:
+			action = "evict (size)"
+		}
+		table.AddRow(url, cache.Ref, formatSize(cache.SizeBytes), lastUsed, action)
+	}
+	if err := table.Format(output.Table); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	if !budget.Enabled() {
+		fmt.Printf("Total: %s in %d cache(s). No cache budget configured (set repo.cache in aimgr.yaml or pass --max-size/--max-age).\n",
+			formatSize(result.TotalBytes), len(result.Caches))
+		return nil
+	}
+	verb := "Evicted"
+	if result.DryRun {
+		verb = "[DRY RUN] Would evict"
+	}
+	fmt.Printf("%s %d cache(s), freeing %s (%s → %s)\n", verb, result.EvictCount,
+		formatSize(result.EvictBytes), formatSize(result.TotalBytes), formatSize(result.RemainBytes))
+	for _, failure := range result.Failed {
+		fmt.Printf("  ✗ failed to evict %s\n", failure)
+	}
+	return nil
+}
+
+// formatCacheAge formats how long ago a cache was used.
+func formatCacheAge(age time.Duration) string {
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age.Minutes()))
+	case age < 48*time.Hour:
+		return fmt.Sprintf("%dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(age.Hours()/24))
+	}
+}
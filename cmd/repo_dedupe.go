@@ -0,0 +1,434 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/dedupe"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resourcediff"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dedupeFormatFlag         string
+	dedupeThresholdFlag      float64
+	dedupeUpdatePackagesFlag bool
+	dedupeAddExcludesFlag    bool
+	dedupeDryRunFlag         bool
+	dedupeForceFlag          bool
+)
+
+// dedupeReport is the result of 'aimgr repo dedupe'.
+type dedupeReport struct {
+	Threshold      float64               `json:"threshold" yaml:"threshold"`
+	Resources      int                   `json:"resources_checked" yaml:"resources_checked"`
+	Groups         []dedupe.Group        `json:"groups" yaml:"groups"`
+	PackageUpdates []dedupePackageUpdate `json:"package_updates,omitempty" yaml:"package_updates,omitempty"`
+	SourceExcludes []dedupeSourceExclude `json:"source_excludes,omitempty" yaml:"source_excludes,omitempty"`
+	// NotExcludable lists redundant copies that do not come from a source in
+	// ai.repo.yaml, so no exclude can drop them.
+	NotExcludable []string `json:"not_excludable,omitempty" yaml:"not_excludable,omitempty"`
+	DryRun        bool     `json:"dry_run,omitempty" yaml:"dry_run,omitempty"`
+}
+
+// dedupePackageUpdate rewrites a package's references to redundant copies.
+type dedupePackageUpdate struct {
+	Package  string            `json:"package" yaml:"package"`
+	Replaced map[string]string `json:"replaced" yaml:"replaced"` // redundant -> kept
+
+	resources []string
+}
+
+// dedupeSourceExclude adds exclude patterns to a source in ai.repo.yaml.
+type dedupeSourceExclude struct {
+	Source  string   `json:"source" yaml:"source"`
+	Exclude []string `json:"exclude" yaml:"exclude"`
+}
+
+var repoDedupeCmd = &cobra.Command{
+	Use:   "dedupe [pattern]...",
+	Short: "Find duplicate and near-duplicate resources",
+	Long: `Find resources that duplicate each other, typically the same skill imported
+from several sources under different names.
+
+Exact duplicates have identical content apart from their name. Near
+duplicates are compared on normalized text (lowercased words, frontmatter
+name dropped); --threshold sets the minimum similarity, from 0 to 1. Only
+resources of the same type are compared.
+
+For each group, dedupe suggests the copy to keep: the one from the source with
+the highest priority in ai.repo.yaml, then the one most packages reference,
+then the most recently updated.
+
+Fixes are opt-in and shown for confirmation before they are applied; with
+--format json or yaml, pass --force to apply them or --dry-run to preview them:
+  --update-packages  point package references at the kept copies
+  --add-excludes     add the redundant copies to the exclude list of their
+                     source in ai.repo.yaml; 'repo sync --prune' then removes
+                     them from the repository
+
+Examples:
+  aimgr repo dedupe
+  aimgr repo dedupe 'skill/*' --threshold 0.9
+  aimgr repo dedupe --update-packages --add-excludes --dry-run
+  aimgr repo dedupe --update-packages --force --format json`,
+	Args: cobra.ArbitraryArgs,
+	ValidArgsFunction: completeResourcesWithOptions(completionOptions{
+		multiArg: true,
+	}),
+	SilenceUsage: true,
+	RunE:         runRepoDedupe,
+}
+
+func init() {
+	repoCmd.AddCommand(repoDedupeCmd)
+	repoDedupeCmd.Flags().StringVar(&dedupeFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	repoDedupeCmd.Flags().Float64Var(&dedupeThresholdFlag, "threshold", dedupe.DefaultThreshold, "Minimum similarity of near duplicates (0-1)")
+	repoDedupeCmd.Flags().BoolVar(&dedupeUpdatePackagesFlag, "update-packages", false, "Point package references at the kept copies")
+	repoDedupeCmd.Flags().BoolVar(&dedupeAddExcludesFlag, "add-excludes", false, "Exclude redundant copies from their sources in ai.repo.yaml")
+	repoDedupeCmd.Flags().BoolVar(&dedupeDryRunFlag, "dry-run", false, "Show the fixes without applying them")
+	repoDedupeCmd.Flags().BoolVar(&dedupeForceFlag, "force", false, "Apply fixes without confirmation")
+	_ = repoDedupeCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+}
+
+func runRepoDedupe(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(dedupeFormatFlag)
+	if err != nil {
+		return err
+	}
+	if dedupeThresholdFlag <= 0 || dedupeThresholdFlag > 1 {
+		return fmt.Errorf("--threshold must be greater than 0 and at most 1, got %v", dedupeThresholdFlag)
+	}
+	fixing := dedupeUpdatePackagesFlag || dedupeAddExcludesFlag
+	if fixing && format != output.Table && !dedupeForceFlag && !dedupeDryRunFlag {
+		return fmt.Errorf("--format %s cannot ask for confirmation: use --force to apply the fixes or --dry-run to preview them", format)
+	}
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return err
+	}
+	if err := ensureRepoInitialized(manager); err != nil {
+		return operationalMissingManifestError(cmd, err)
+	}
+	lock := manager.AcquireRepoReadLock
+	if fixing && !dedupeDryRunFlag {
+		lock = manager.AcquireRepoWriteLock
+	}
+	repoLock, err := lock(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to acquire repository lock at %s: %w", manager.RepoLockPath(), err)
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	selected, err := selectRepoResources(manager, args)
+	if err != nil {
+		return err
+	}
+	loadManifest := repomanifest.Load
+	if dedupeAddExcludesFlag && !dedupeDryRunFlag {
+		loadManifest = repomanifest.LoadForMutation
+	}
+	manifest, err := loadManifest(manager.GetRepoPath())
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %w", repomanifest.ManifestFileName, err)
+	}
+	packages, err := loadRepoPackages(manager)
+	if err != nil {
+		return err
+	}
+
+	candidates, err := buildDedupeCandidates(manager, manifest, packages, selected)
+	if err != nil {
+		return err
+	}
+	report := &dedupeReport{
+		Threshold: dedupeThresholdFlag,
+		Resources: len(candidates),
+		Groups:    dedupe.Find(candidates, dedupeThresholdFlag),
+		DryRun:    fixing && dedupeDryRunFlag,
+	}
+	if report.Groups == nil {
+		report.Groups = []dedupe.Group{}
+	}
+	if dedupeUpdatePackagesFlag {
+		report.PackageUpdates = planPackageUpdates(report.Groups, packages)
+	}
+	if dedupeAddExcludesFlag {
+		report.SourceExcludes, report.NotExcludable = planSourceExcludes(report.Groups, manifest)
+	}
+	hasChanges := len(report.PackageUpdates) > 0 || len(report.SourceExcludes) > 0
+
+	if format == output.Table {
+		displayDedupeReport(report)
+		if !hasChanges {
+			return nil
+		}
+		if dedupeDryRunFlag {
+			fmt.Printf("\n[DRY RUN] Would update %d package(s) and %d source(s)\n", len(report.PackageUpdates), len(report.SourceExcludes))
+			return nil
+		}
+		if !dedupeForceFlag {
+			fmt.Printf("\nUpdate %d package(s) and %d source(s)? [y/N] ", len(report.PackageUpdates), len(report.SourceExcludes))
+			response, err := bufio.NewReader(os.Stdin).ReadString('\n')
+			if err != nil {
+				return fmt.Errorf("failed to read input: %w", err)
+			}
+			response = strings.ToLower(strings.TrimSpace(response))
+			if response != "y" && response != "yes" {
+				fmt.Println("Cancelled.")
+				return nil
+			}
+		}
+	}
+
+	if hasChanges && !dedupeDryRunFlag {
+		if err := applyDedupeFixes(manager, manifest, packages, report); err != nil {
+			return err
+		}
+	}
+
+	if format != output.Table {
+		return output.FormatOutput(report, format)
+	}
+	if hasChanges {
+		fmt.Printf("%s Updated %d package(s) and %d source(s)\n", statusIconOK, len(report.PackageUpdates), len(report.SourceExcludes))
+		if len(report.SourceExcludes) > 0 {
+			fmt.Println("Run 'aimgr repo sync --prune' to remove the excluded copies from the repository.")
+		}
+	}
+	return nil
+}
+
+// loadRepoPackages loads every package of the repository.
+func loadRepoPackages(manager *repo.Manager) ([]*resource.Package, error) {
+	infos, err := manager.ListPackages()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list packages: %w", err)
+	}
+	packages := make([]*resource.Package, 0, len(infos))
+	for _, info := range infos {
+		pkg, err := manager.GetPackage(info.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load package %s: %w", info.Name, err)
+		}
+		packages = append(packages, pkg)
+	}
+	return packages, nil
+}
+
+// buildDedupeCandidates loads the content of the selected resources with
+// their source, source priority and package references.
+func buildDedupeCandidates(manager *repo.Manager, manifest *repomanifest.Manifest, packages []*resource.Package, selected []resource.Resource) ([]dedupe.Candidate, error) {
+	priorities := make(map[string]int, len(manifest.Sources))
+	for _, src := range manifest.Sources {
+		priorities[src.Name] = src.Priority
+	}
+	packageRefs := make(map[string]int)
+	for _, pkg := range packages {
+		for _, ref := range pkg.Resources {
+			if resType, name, err := resource.ParseResourceReference(ref); err == nil {
+				packageRefs[string(resType)+"/"+name]++
+			}
+		}
+	}
+
+	candidates := make([]dedupe.Candidate, 0, len(selected))
+	for i := range selected {
+		res := &selected[i]
+		files, err := resourcediff.LoadPath(res.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", FormatResourceArg(res), err)
+		}
+		candidate := dedupe.Candidate{
+			Type:        res.Type,
+			Name:        res.Name,
+			Files:       files,
+			MainFile:    filepath.Base(res.Path),
+			PackageRefs: packageRefs[FormatResourceArg(res)],
+		}
+		if res.Type == resource.Skill {
+			candidate.MainFile = "SKILL.md"
+		}
+		if meta, err := manager.GetMetadata(res.Name, res.Type); err == nil {
+			candidate.Source = meta.SourceName
+			candidate.SourcePriority = priorities[meta.SourceName]
+			candidate.Updated = meta.LastUpdated
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+// planPackageUpdates rewrites package references to redundant copies so they
+// point at the kept copy, dropping references that become duplicates.
+func planPackageUpdates(groups []dedupe.Group, packages []*resource.Package) []dedupePackageUpdate {
+	canonical := make(map[string]string)
+	for _, g := range groups {
+		for _, ref := range g.Redundant() {
+			canonical[ref] = g.Keep
+		}
+	}
+
+	var updates []dedupePackageUpdate
+	for _, pkg := range packages {
+		update := dedupePackageUpdate{Package: pkg.Name, Replaced: map[string]string{}}
+		seen := make(map[string]bool, len(pkg.Resources))
+		for _, ref := range pkg.Resources {
+			target := ref
+			if resType, name, err := resource.ParseResourceReference(ref); err == nil {
+				if keep, ok := canonical[string(resType)+"/"+name]; ok {
+					target = keep
+					update.Replaced[ref] = keep
+				}
+			}
+			if !seen[target] {
+				seen[target] = true
+				update.resources = append(update.resources, target)
+			}
+		}
+		if len(update.Replaced) > 0 {
+			updates = append(updates, update)
+		}
+	}
+	return updates
+}
+
+// planSourceExcludes adds each redundant copy to the exclude list of the
+// source it was imported from. Copies without a tracked source are returned
+// separately.
+func planSourceExcludes(groups []dedupe.Group, manifest *repomanifest.Manifest) ([]dedupeSourceExclude, []string) {
+	bySource := make(map[string][]string)
+	var untracked []string
+	for _, g := range groups {
+		for _, m := range g.Members {
+			if m.Keep {
+				continue
+			}
+			src, ok := manifest.GetSource(m.Source)
+			if m.Source == "" || !ok {
+				untracked = append(untracked, m.Resource)
+				continue
+			}
+			if !slices.Contains(src.Exclude, m.Resource) {
+				bySource[src.Name] = append(bySource[src.Name], m.Resource)
+			}
+		}
+	}
+
+	excludes := make([]dedupeSourceExclude, 0, len(bySource))
+	for name, refs := range bySource {
+		sort.Strings(refs)
+		excludes = append(excludes, dedupeSourceExclude{Source: name, Exclude: refs})
+	}
+	sort.Slice(excludes, func(i, j int) bool { return excludes[i].Source < excludes[j].Source })
+	sort.Strings(untracked)
+	return excludes, untracked
+}
+
+// applyDedupeFixes saves the planned package and manifest changes and
+// commits them.
+func applyDedupeFixes(manager *repo.Manager, manifest *repomanifest.Manifest, packages []*resource.Package, report *dedupeReport) error {
+	repoPath := manager.GetRepoPath()
+	var paths []string
+
+	byName := make(map[string]*resource.Package, len(packages))
+	for _, pkg := range packages {
+		byName[pkg.Name] = pkg
+	}
+	for _, update := range report.PackageUpdates {
+		pkg := byName[update.Package]
+		pkg.Resources = update.resources
+		if err := resource.SavePackage(pkg, repoPath); err != nil {
+			return fmt.Errorf("failed to update package %s: %w", pkg.Name, err)
+		}
+		paths = append(paths, resource.GetPackagePath(pkg.Name, repoPath))
+	}
+
+	if len(report.SourceExcludes) > 0 {
+		for _, exclude := range report.SourceExcludes {
+			src, _ := manifest.GetSource(exclude.Source)
+			src.Exclude = append(src.Exclude, exclude.Exclude...)
+		}
+		if err := manifest.Save(repoPath); err != nil {
+			return fmt.Errorf("failed to save %s: %w", repomanifest.ManifestFileName, err)
+		}
+		paths = append(paths, repomanifest.ManifestFileName)
+	}
+
+	if err := manager.CommitChangesForPaths("aimgr: deduplicate resources", paths); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Failed to commit deduplication: %v\n", err)
+	}
+	return nil
+}
+
+func displayDedupeReport(report *dedupeReport) {
+	if len(report.Groups) == 0 {
+		fmt.Printf("%s No duplicates among %d resource(s) (threshold %.2f)\n", statusIconOK, report.Resources, report.Threshold)
+		return
+	}
+
+	table := output.NewTable("GROUP", "RESOURCE", "SOURCE", "MATCH", "SUGGESTION")
+	table.WithResponsive().WithDynamicColumn(4).WithMinColumnWidths(5, 20, 12, 8, 20)
+	exact := 0
+	for i, g := range report.Groups {
+		if g.Match == dedupe.MatchExact {
+			exact++
+		}
+		for _, m := range g.Members {
+			source := m.Source
+			if source == "" {
+				source = "-"
+			}
+			match, suggestion := "-", "redundant"
+			if m.Keep {
+				suggestion = "keep (" + g.Reason + ")"
+			} else if m.Similarity == 1 {
+				match = dedupe.MatchExact
+			} else {
+				match = fmt.Sprintf("%.0f%%", m.Similarity*100)
+			}
+			table.AddRow(fmt.Sprintf("%d", i+1), m.Resource, source, match, suggestion)
+		}
+	}
+	_ = table.Format(output.Table)
+	fmt.Printf("\nFound %d duplicate group(s) among %d resource(s): %d exact, %d similar (threshold %.2f)\n",
+		len(report.Groups), report.Resources, exact, len(report.Groups)-exact, report.Threshold)
+
+	if len(report.PackageUpdates) > 0 {
+		fmt.Println("\nPackage references to update:")
+		for _, update := range report.PackageUpdates {
+			refs := make([]string, 0, len(update.Replaced))
+			for ref := range update.Replaced {
+				refs = append(refs, ref)
+			}
+			sort.Strings(refs)
+			for _, ref := range refs {
+				fmt.Printf("  package/%s: %s -> %s\n", update.Package, ref, update.Replaced[ref])
+			}
+		}
+	}
+	if len(report.SourceExcludes) > 0 {
+		fmt.Printf("\nExcludes to add in %s:\n", repomanifest.ManifestFileName)
+		for _, exclude := range report.SourceExcludes {
+			fmt.Printf("  %s: %s\n", exclude.Source, strings.Join(exclude.Exclude, ", "))
+		}
+	}
+	if len(report.NotExcludable) > 0 {
+		fmt.Printf("\nNo exclude added (not from a source in %s): %s\n", repomanifest.ManifestFileName, strings.Join(report.NotExcludable, ", "))
+	}
+	if !dedupeUpdatePackagesFlag && !dedupeAddExcludesFlag {
+		fmt.Println("Run with --update-packages and/or --add-excludes to apply the suggestions.")
+	}
+}
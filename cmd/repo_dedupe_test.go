@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/dedupe"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+)
+
+func TestPlanDedupeFixes(t *testing.T) {
+	groups := []dedupe.Group{{
+		Type:  resource.Skill,
+		Match: dedupe.MatchExact,
+		Keep:  "skill/pdf-tools",
+		Members: []dedupe.Member{
+			{Resource: "skill/pdf-tools", Source: "team", Keep: true},
+			{Resource: "skill/pdf", Source: "community"},
+			{Resource: "skill/pdf-copy"},
+		},
+	}}
+
+	packages := []*resource.Package{
+		{Name: "docs", Resources: []string{"skill/pdf", "skill/pdf-tools", "command/deploy"}},
+		{Name: "ops", Resources: []string{"skill/community:pdf-copy"}},
+		{Name: "other", Resources: []string{"command/deploy"}},
+	}
+	updates := planPackageUpdates(groups, packages)
+	if len(updates) != 2 {
+		t.Fatalf("planPackageUpdates() = %+v, want updates for docs and ops", updates)
+	}
+	if want := []string{"skill/pdf-tools", "command/deploy"}; !reflect.DeepEqual(updates[0].resources, want) {
+		t.Errorf("docs resources = %v, want %v", updates[0].resources, want)
+	}
+	if updates[1].Replaced["skill/community:pdf-copy"] != "skill/pdf-tools" {
+		t.Errorf("ops replaced = %v", updates[1].Replaced)
+	}
+
+	manifest := &repomanifest.Manifest{Version: 1, Sources: []*repomanifest.Source{
+		{Name: "team", URL: "https://github.com/example/team"},
+		{Name: "community", URL: "https://github.com/example/community", Exclude: []string{"agent/old"}},
+	}}
+	excludes, untracked := planSourceExcludes(groups, manifest)
+	if want := []dedupeSourceExclude{{Source: "community", Exclude: []string{"skill/pdf"}}}; !reflect.DeepEqual(excludes, want) {
+		t.Errorf("planSourceExcludes() = %+v, want %+v", excludes, want)
+	}
+	if want := []string{"skill/pdf-copy"}; !reflect.DeepEqual(untracked, want) {
+		t.Errorf("untracked = %v, want %v", untracked, want)
+	}
+}
+
+func TestRunRepoDedupe_StructuredFixesRequireForceOrDryRun(t *testing.T) {
+	oldFormat, oldUpdate, oldExcludes := dedupeFormatFlag, dedupeUpdatePackagesFlag, dedupeAddExcludesFlag
+	oldDryRun, oldForce, oldThreshold := dedupeDryRunFlag, dedupeForceFlag, dedupeThresholdFlag
+	t.Cleanup(func() {
+		dedupeFormatFlag, dedupeUpdatePackagesFlag, dedupeAddExcludesFlag = oldFormat, oldUpdate, oldExcludes
+		dedupeDryRunFlag, dedupeForceFlag, dedupeThresholdFlag = oldDryRun, oldForce, oldThreshold
+	})
+	dedupeFormatFlag, dedupeUpdatePackagesFlag, dedupeAddExcludesFlag = "json", false, true
+	dedupeDryRunFlag, dedupeForceFlag, dedupeThresholdFlag = false, false, dedupe.DefaultThreshold
+
+	err := runRepoDedupe(repoDedupeCmd, nil)
+	if err == nil || !strings.Contains(err.Error(), "--force") || !strings.Contains(err.Error(), "--dry-run") {
+		t.Fatalf("runRepoDedupe() error = %v, want --force or --dry-run to be required", err)
+	}
+}
@@ -8,8 +8,10 @@ import (
 	"time"
 
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/permissions"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tokens"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -26,6 +28,11 @@ type DescribeResourceOutput struct {
 	License     string                     `json:"license,omitempty" yaml:"license,omitempty"`
 	Metadata    *metadata.ResourceMetadata `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 	Location    string                     `json:"location" yaml:"location"`
+	Tokens      *tokens.Breakdown          `json:"tokens,omitempty" yaml:"tokens,omitempty"`
+	Permissions []permissions.Grant        `json:"permissions,omitempty" yaml:"permissions,omitempty"`
+	// PermissionIssues are allowed-tools or tools entries that are not valid
+	// permission syntax.
+	PermissionIssues []permissions.Issue `json:"permission_issues,omitempty" yaml:"permission_issues,omitempty"`
 	// Type-specific fields
 	Compatibility   []string                  `json:"compatibility,omitempty" yaml:"compatibility,omitempty"`       // skill only
 	HasScripts      *bool                     `json:"has_scripts,omitempty" yaml:"has_scripts,omitempty"`           // skill only
@@ -212,6 +219,8 @@ func describeSkillDetails(manager *repo.Manager, res *resource.Resource, metadat
 	if len(features) > 0 {
 		fmt.Printf("Features: %s\n", strings.Join(features, ", "))
 	}
+	printTokenEstimate(res, skillPath)
+	printPermissions(res, skillPath)
 
 	fmt.Println()
 
@@ -224,6 +233,7 @@ func describeSkillDetails(manager *repo.Manager, res *resource.Resource, metadat
 		}
 		fmt.Printf("First Installed: %s\n", formatTimestamp(meta.FirstInstalled))
 		fmt.Printf("Last Updated: %s\n", formatTimestamp(meta.LastUpdated))
+		printReviewApproval(meta)
 		fmt.Println()
 	} else {
 		fmt.Println("Metadata: Not available")
@@ -250,6 +260,50 @@ func printResourceHeader(typeName string, res *resource.Resource) {
 	}
 }
 
+// printTokenEstimate prints the estimated token cost of a resource. Estimation
+// errors are not fatal to describe and leave the line out.
+func printTokenEstimate(res *resource.Resource, path string) {
+	estimate, err := estimateResourceTokens(res, path)
+	if err != nil {
+		return
+	}
+	line := fmt.Sprintf("Tokens (est.): description %d, body %d", estimate.Description, estimate.Body)
+	if res.Type == resource.Skill {
+		line += fmt.Sprintf(", references %d (%d files)", estimate.References, estimate.ReferenceFiles)
+	}
+	fmt.Printf("%s, total %d\n", line, estimate.Total)
+}
+
+// estimateResourceTokens estimates a resource stored at path in the
+// repository.
+func estimateResourceTokens(res *resource.Resource, path string) (tokens.Breakdown, error) {
+	located := *res
+	located.Path = path
+	return tokens.EstimateResource(&located)
+}
+
+// printPermissions prints the parsed allowed-tools and tools entries of a
+// resource stored at path, one per line with its capability and scope.
+func printPermissions(res *resource.Resource, path string) {
+	located := *res
+	located.Path = path
+	grants, issues, err := resourcePermissions(&located)
+	if err != nil || (len(grants) == 0 && len(issues) == 0) {
+		return
+	}
+	fmt.Println("Permissions:")
+	for _, g := range grants {
+		entry := g.String()
+		if g.Capability == permissions.CapabilityAll {
+			entry = "(all tools)"
+		}
+		fmt.Printf("  %-10s %-28s %s\n", g.Capability, entry, g.Scope())
+	}
+	for _, issue := range issues {
+		fmt.Printf("  %s invalid %s entry: %s\n", statusIconFail, issue.Field, issue.Message)
+	}
+}
+
 // printMetadataBlock prints metadata source info or "not available"
 func printMetadataBlock(metadataAvailable bool, meta *metadata.ResourceMetadata) {
 	fmt.Println()
@@ -261,6 +315,7 @@ func printMetadataBlock(metadataAvailable bool, meta *metadata.ResourceMetadata)
 		}
 		fmt.Printf("First Installed: %s\n", formatTimestamp(meta.FirstInstalled))
 		fmt.Printf("Last Updated: %s\n", formatTimestamp(meta.LastUpdated))
+		printReviewApproval(meta)
 		fmt.Println()
 	} else {
 		fmt.Println("Metadata: Not available")
@@ -290,6 +345,8 @@ func describeCommandDetails(manager *repo.Manager, res *resource.Resource, metad
 	if len(command.AllowedTools) > 0 {
 		fmt.Printf("Allowed Tools: %s\n", strings.Join(command.AllowedTools, ", "))
 	}
+	printTokenEstimate(res, commandPath)
+	printPermissions(res, commandPath)
 
 	printMetadataBlock(metadataAvailable, meta)
 	fmt.Printf("Location: %s\n", commandPath)
@@ -319,6 +376,8 @@ func describeAgentDetails(manager *repo.Manager, res *resource.Resource, metadat
 	if len(agent.Capabilities) > 0 {
 		fmt.Printf("Capabilities: %s\n", strings.Join(agent.Capabilities, ", "))
 	}
+	printTokenEstimate(res, agentPath)
+	printPermissions(res, agentPath)
 
 	printMetadataBlock(metadataAvailable, meta)
 	fmt.Printf("Location: %s\n", agentPath)
@@ -610,6 +669,18 @@ func outputDescribePackageYAML(manager *repo.Manager, res *resource.Resource, pk
 }
 
 // formatTimestamp formats a timestamp in a human-readable format
+// printReviewApproval prints the approval of a resource imported through
+// repo review.
+func printReviewApproval(meta *metadata.ResourceMetadata) {
+	if meta.Review == nil {
+		return
+	}
+	fmt.Printf("Approved: by %s on %s\n", meta.Review.Reviewer, formatTimestamp(meta.Review.ApprovedAt))
+	if meta.Review.Note != "" {
+		fmt.Printf("Review Note: %s\n", meta.Review.Note)
+	}
+}
+
 func formatTimestamp(t time.Time) string {
 	// Format: "Jan 2, 2006 at 3:04pm (MST)"
 	return t.Format("Jan 2, 2006 at 3:04pm (MST)")
@@ -656,6 +727,18 @@ func buildDescribeOutput(manager *repo.Manager, res *resource.Resource, resource
 		output.Metadata = meta
 	}
 
+	if resourceType != resource.PackageType {
+		if estimate, err := estimateResourceTokens(res, output.Location); err == nil {
+			output.Tokens = &estimate
+		}
+		located := *res
+		located.Path = output.Location
+		if grants, issues, err := resourcePermissions(&located); err == nil {
+			output.Permissions = grants
+			output.PermissionIssues = issues
+		}
+	}
+
 	// Add type-specific fields
 	switch resourceType {
 	case resource.Skill:
@@ -0,0 +1,381 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resourcediff"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/source"
+	"github.com/spf13/cobra"
+)
+
+var (
+	repoDiffUpstreamFlag bool
+	repoDiffRefFlag      string
+	repoDiffOfflineFlag  bool
+	repoDiffFormatFlag   string
+)
+
+// repoDiffOutput is the structured output for JSON/YAML formats.
+type repoDiffOutput struct {
+	Resource string `json:"resource" yaml:"resource"`
+	// Old and New describe the compared versions ("a/" and "b/" in diffs).
+	Old                 string `json:"old" yaml:"old"`
+	New                 string `json:"new" yaml:"new"`
+	resourcediff.Result `yaml:",inline"`
+}
+
+// diffSide is one version of a resource being compared.
+type diffSide struct {
+	label string
+	files resourcediff.FileSet
+}
+
+// repoDiffCmd represents the repo diff command
+var repoDiffCmd = &cobra.Command{
+	Use:   "diff <resource>",
+	Short: "Show changes to a resource against upstream or between git refs",
+	Long: `Show a unified diff of every file in a resource (SKILL.md, scripts,
+references, ...) so you can see exactly what a sync would change, or what
+changed between two points in the repository's history.
+
+By default (--upstream) the repository copy ("a/") is compared with the
+resource in its source ("b/"). Remote sources are refreshed in the workspace
+cache first; use --offline to compare with the cached checkout as-is.
+
+With --ref, the resource is compared between git refs of the repository
+(see 'aimgr repo log'):
+  --ref A       compare revision A with the current repository copy
+  --ref A..B    compare revision A with revision B
+
+Changes to frontmatter fields of the main file (SKILL.md, or the command or
+agent file) such as model or allowed-tools are summarised separately from
+body changes.
+
+Examples:
+  aimgr repo diff skill/pdf-processing
+  aimgr repo diff command/review --offline
+  aimgr repo diff agent/reviewer --ref HEAD~1
+  aimgr repo diff skill/pdf-processing --ref a1b2c3d..HEAD --format json`,
+	Args: cobra.ExactArgs(1),
+	ValidArgsFunction: completeResourcesWithOptions(completionOptions{
+		includePackages: true,
+	}),
+	SilenceUsage: true,
+	RunE:         runRepoDiff,
+}
+
+func runRepoDiff(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(repoDiffFormatFlag)
+	if err != nil {
+		return err
+	}
+	if repoDiffUpstreamFlag && repoDiffRefFlag != "" {
+		return fmt.Errorf("--upstream and --ref cannot be used together")
+	}
+	if repoDiffOfflineFlag && repoDiffRefFlag != "" {
+		return fmt.Errorf("--offline only applies to upstream comparisons")
+	}
+
+	resType, name, err := ParseResourceArg(args[0])
+	if err != nil {
+		return err
+	}
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return err
+	}
+
+	repoLock, repoExists, err := acquireRepoReadLockIfRepoExists(cmd.Context(), manager)
+	if err != nil {
+		return err
+	}
+	if !repoExists {
+		return missingRepoPathError(manager.GetRepoPath())
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	repoPath := manager.GetRepoPath()
+	resPath := manager.GetPath(name, resType)
+	relPath, err := filepath.Rel(repoPath, resPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", resPath, err)
+	}
+	relPath = filepath.ToSlash(relPath)
+
+	var oldSide, newSide *diffSide
+	if repoDiffRefFlag != "" {
+		oldSide, newSide, err = refDiffSides(manager, repoDiffRefFlag, resPath, relPath)
+	} else {
+		oldSide, newSide, err = upstreamDiffSides(manager, resType, name, resPath)
+	}
+	if err != nil {
+		return err
+	}
+
+	// Diff labels use repository-relative paths, e.g. "a/skills/pdf/SKILL.md".
+	prefix, mainFile := relPath, "SKILL.md"
+	if resType != resource.Skill {
+		prefix, mainFile = path.Dir(relPath), path.Base(relPath)
+		newSide.files = rekeySingleFile(newSide.files, mainFile)
+	}
+	if resType == resource.PackageType {
+		mainFile = ""
+	}
+
+	result := resourcediff.Compare(oldSide.files, newSide.files, "a/"+prefix, "b/"+prefix, mainFile)
+	ref := fmt.Sprintf("%s/%s", resType, name)
+
+	if format != output.Table {
+		return output.FormatOutput(repoDiffOutput{Resource: ref, Old: oldSide.label, New: newSide.label, Result: *result}, format)
+	}
+
+	printResourceDiff(ref, oldSide, newSide, result)
+	return nil
+}
+
+// refDiffSides loads the resource at the revisions named by spec ("A" or
+// "A..B"). With a single revision the current repository copy is the new side.
+func refDiffSides(manager *repo.Manager, spec, resPath, relPath string) (*diffSide, *diffSide, error) {
+	from, to, isRange := strings.Cut(spec, "..")
+	if from == "" || (isRange && to == "") {
+		return nil, nil, fmt.Errorf("invalid --ref %q: expected <ref> or <ref>..<ref>", spec)
+	}
+
+	oldFiles, err := manager.ResourceFilesAt(from, relPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	oldSide := &diffSide{label: "revision " + from, files: oldFiles}
+
+	if isRange {
+		newFiles, err := manager.ResourceFilesAt(to, relPath)
+		if err != nil {
+			return nil, nil, err
+		}
+		return oldSide, &diffSide{label: "revision " + to, files: newFiles}, nil
+	}
+
+	newFiles, err := loadResourceFilesIfExists(resPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	return oldSide, &diffSide{label: "repository", files: newFiles}, nil
+}
+
+// upstreamDiffSides loads the repository copy and the copy of the resource in
+// the source it was imported from.
+func upstreamDiffSides(manager *repo.Manager, resType resource.ResourceType, name, resPath string) (*diffSide, *diffSide, error) {
+	if resType == resource.PackageType {
+		return nil, nil, fmt.Errorf("upstream diff is not supported for packages; use --ref to compare package revisions")
+	}
+
+	if _, err := os.Stat(resPath); err != nil {
+		return nil, nil, fmt.Errorf("resource '%s/%s' not found in repository", resType, name)
+	}
+	repoFiles, err := resourcediff.LoadPath(resPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	repoPath := manager.GetRepoPath()
+	meta, err := manager.GetMetadata(name, resType)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load metadata for %s/%s: %w", resType, name, err)
+	}
+	manifest, err := repomanifest.Load(repoPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to load %s: %w", repomanifest.ManifestFileName, err)
+	}
+	src := findManifestSource(manifest, meta.SourceID, meta.SourceName)
+	if src == nil {
+		return nil, nil, fmt.Errorf("%s/%s is not tracked by a source in %s; use --ref to compare repository revisions", resType, name, repomanifest.ManifestFileName)
+	}
+
+	sourcePath, err := resolveSourcePathForDiff(src, repoPath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to prepare source '%s': %w", src.Name, err)
+	}
+
+	label := "upstream " + src.Name
+	if src.Ref != "" {
+		label += "@" + src.Ref
+	}
+
+	upstreamPath, err := findUpstreamResource(sourcePath, src.Discovery, repoPath, resType, name)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover resources in source '%s': %w", src.Name, err)
+	}
+	upstreamFiles := resourcediff.FileSet{}
+	if upstreamPath != "" {
+		if upstreamFiles, err = resourcediff.LoadPath(upstreamPath); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return &diffSide{label: "repository", files: repoFiles}, &diffSide{label: label, files: upstreamFiles}, nil
+}
+
+// resolveSourcePathForDiff returns the checkout of a source. Remote sources
+// are refreshed unless --offline is set, in which case the workspace cache is
+// used as-is.
+func resolveSourcePathForDiff(src *repomanifest.Source, repoPath string) (string, error) {
+	if src.URL == "" {
+		if src.Path == "" {
+			return "", fmt.Errorf("source must have either URL or Path")
+		}
+		return filepath.Abs(src.Path)
+	}
+
+	wsMgr, err := newWorkspaceManager(repoPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to create workspace manager: %w", err)
+	}
+	parsed, err := parsedRemoteSourceForManifestEntry(src)
+	if err != nil {
+		return "", fmt.Errorf("invalid source URL: %w", err)
+	}
+	cloneURL, err := source.GetCloneURL(parsed)
+	if err != nil {
+		return "", fmt.Errorf("failed to get clone URL: %w", err)
+	}
+
+	var sourcePath string
+	if repoDiffOfflineFlag {
+		cachePath, ok := wsMgr.CachedPath(cloneURL)
+		if !ok {
+			return "", fmt.Errorf("no cached checkout for %s; run without --offline to fetch it", src.URL)
+		}
+		sourcePath = cachePath
+	} else {
+		if sourcePath, err = prepareRemoteSourcePath(wsMgr, cloneURL, parsed.Ref); err != nil {
+			return "", err
+		}
+	}
+
+	if parsed.Subpath != "" {
+		sourcePath = filepath.Join(sourcePath, parsed.Subpath)
+	}
+	return sourcePath, nil
+}
+
+// findUpstreamResource locates a resource in a source checkout the same way
+// sync discovers it. An empty path means the source no longer provides it.
+func findUpstreamResource(sourcePath, discoveryMode, repoPath string, resType resource.ResourceType, name string) (string, error) {
+	discovered, err := discoverImportResourcesByMode(sourcePath, discoveryMode, newPluginFetcher(repoPath))
+	if err != nil {
+		return "", err
+	}
+
+	var candidates []*resource.Resource
+	switch resType {
+	case resource.Command:
+		candidates = discovered.commands
+	case resource.Skill:
+		candidates = discovered.skills
+	case resource.Agent:
+		candidates = discovered.agents
+	}
+	for _, res := range candidates {
+		if res.Name == name {
+			return res.Path, nil
+		}
+	}
+
+	ref := fmt.Sprintf("%s/%s", resType, name)
+	for _, pkgInfo := range discovered.marketplacePackages {
+		for _, resRef := range pkgInfo.Package.Resources {
+			if resRef != ref {
+				continue
+			}
+			if resPath, err := findResourceInPath(pkgInfo.SourcePath, resType, name); err == nil {
+				return resPath, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// loadResourceFilesIfExists loads a resource from disk, returning an empty
+// set when it does not exist.
+func loadResourceFilesIfExists(path string) (resourcediff.FileSet, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return resourcediff.FileSet{}, nil
+	}
+	return resourcediff.LoadPath(path)
+}
+
+// rekeySingleFile names the only file of a single-file resource like the
+// repository copy, so e.g. an upstream "reviewer.agent.md" is compared with
+// the repository's "reviewer.md" instead of showing as removed and added.
+func rekeySingleFile(files resourcediff.FileSet, name string) resourcediff.FileSet {
+	if len(files) != 1 {
+		return files
+	}
+	for key, data := range files {
+		if key != name {
+			return resourcediff.FileSet{name: data}
+		}
+	}
+	return files
+}
+
+func printResourceDiff(ref string, oldSide, newSide *diffSide, result *resourcediff.Result) {
+	fmt.Printf("Comparing %s: %s (a/) vs %s (b/)\n", ref, oldSide.label, newSide.label)
+
+	if result.Empty() {
+		fmt.Println("\nNo differences.")
+		return
+	}
+
+	if len(result.Frontmatter) > 0 {
+		fmt.Println("\nFrontmatter:")
+		for _, change := range result.Frontmatter {
+			switch change.Kind {
+			case resourcediff.FieldAdded:
+				fmt.Printf("  + %s: %s\n", change.Field, change.New)
+			case resourcediff.FieldRemoved:
+				fmt.Printf("  - %s: %s\n", change.Field, change.Old)
+			default:
+				fmt.Printf("  ~ %s: %s -> %s\n", change.Field, change.Old, change.New)
+			}
+		}
+	}
+	if result.BodyChanged {
+		fmt.Println("\nBody: changed")
+	} else {
+		fmt.Println("\nBody: unchanged")
+	}
+
+	added, removed, modified := result.Counts()
+	fmt.Printf("Files: %d modified, %d added, %d removed\n", modified, added, removed)
+
+	for _, file := range result.Files {
+		fmt.Println()
+		if file.Binary {
+			fmt.Printf("Binary file %s (%s)\n", file.Path, file.Status)
+			continue
+		}
+		fmt.Print(file.Unified)
+	}
+}
+
+func init() {
+	repoCmd.AddCommand(repoDiffCmd)
+	repoDiffCmd.Flags().BoolVar(&repoDiffUpstreamFlag, "upstream", false, "Compare the repository copy with its source (default)")
+	repoDiffCmd.Flags().StringVar(&repoDiffRefFlag, "ref", "", "Compare git revisions of the repository: <ref> (vs current) or <ref>..<ref>")
+	repoDiffCmd.Flags().BoolVar(&repoDiffOfflineFlag, "offline", false, "Use the cached source checkout without fetching")
+	repoDiffCmd.Flags().StringVar(&repoDiffFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	_ = repoDiffCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resourcediff"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/source"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/workspace"
+)
+
+func TestUpstreamDiffSides_ComparesWithCachedSource(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "Test User")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test User")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	repoPath := t.TempDir()
+	manager := repo.NewManagerWithPath(repoPath)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	m := &repomanifest.Manifest{Version: 1, Sources: []*repomanifest.Source{
+		{Name: "team-tools", URL: "https://github.com/example/team-tools"},
+	}}
+	if err := m.Save(repoPath); err != nil {
+		t.Fatalf("failed to save manifest: %v", err)
+	}
+	m, err := repomanifest.Load(repoPath)
+	if err != nil {
+		t.Fatalf("failed to reload manifest: %v", err)
+	}
+	src, _ := m.GetSource("team-tools")
+
+	sourceDir := writeAutoSyncSource(t, "review")
+	if _, err := manager.AddBulk([]string{filepath.Join(sourceDir, "commands", "review.md")}, repo.BulkImportOptions{
+		SourceName: src.Name, SourceID: src.ID, SourceURL: src.URL, SourceType: "github", ImportMode: "copy",
+	}); err != nil {
+		t.Fatalf("failed to import command: %v", err)
+	}
+
+	// Fake an updated workspace cache for the source.
+	parsed, err := parsedRemoteSourceForManifestEntry(src)
+	if err != nil {
+		t.Fatalf("failed to parse source: %v", err)
+	}
+	cloneURL, err := source.GetCloneURL(parsed)
+	if err != nil {
+		t.Fatalf("failed to get clone URL: %v", err)
+	}
+	cacheDir := filepath.Join(repoPath, ".workspace", workspace.ComputeHash(cloneURL))
+	if err := os.MkdirAll(filepath.Join(cacheDir, ".git"), 0755); err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(cacheDir, "commands"), 0755); err != nil {
+		t.Fatalf("failed to create cache commands: %v", err)
+	}
+	updated := "---\ndescription: Auto-sync test command\nmodel: opus\n---\n# review"
+	if err := os.WriteFile(filepath.Join(cacheDir, "commands", "review.md"), []byte(updated), 0644); err != nil {
+		t.Fatalf("failed to write cached command: %v", err)
+	}
+
+	repoDiffOfflineFlag = true
+	defer func() { repoDiffOfflineFlag = false }()
+
+	resPath := manager.GetPath("review", resource.Command)
+	oldSide, newSide, err := upstreamDiffSides(manager, resource.Command, "review", resPath)
+	if err != nil {
+		t.Fatalf("upstreamDiffSides() error = %v", err)
+	}
+	if newSide.label != "upstream team-tools" {
+		t.Errorf("new side label = %q", newSide.label)
+	}
+
+	result := resourcediff.Compare(oldSide.files, newSide.files, "a/commands", "b/commands", "review.md")
+	if len(result.Files) != 1 || result.Files[0].Status != resourcediff.StatusModified {
+		t.Fatalf("expected review.md to be modified, got %+v", result.Files)
+	}
+	if len(result.Frontmatter) != 1 || result.Frontmatter[0].Field != "model" || result.BodyChanged {
+		t.Errorf("expected only a model change, got %+v (body changed: %v)", result.Frontmatter, result.BodyChanged)
+	}
+	if !strings.Contains(result.Files[0].Unified, "+model: opus") {
+		t.Errorf("unexpected diff:\n%s", result.Files[0].Unified)
+	}
+
+	// A resource the source no longer provides shows all files as removed.
+	if err := os.Remove(filepath.Join(cacheDir, "commands", "review.md")); err != nil {
+		t.Fatalf("failed to remove cached command: %v", err)
+	}
+	_, newSide, err = upstreamDiffSides(manager, resource.Command, "review", resPath)
+	if err != nil {
+		t.Fatalf("upstreamDiffSides() error = %v", err)
+	}
+	if len(newSide.files) != 0 {
+		t.Errorf("expected no upstream files, got %v", newSide.files)
+	}
+}
+
+func TestRefDiffSides_InvalidSpec(t *testing.T) {
+	manager := repo.NewManagerWithPath(t.TempDir())
+	for _, spec := range []string{"..HEAD", "HEAD.."} {
+		if _, _, err := refDiffSides(manager, spec, "", "commands/x.md"); err == nil {
+			t.Errorf("expected an error for --ref %q", spec)
+		}
+	}
+}
+
+func TestRekeySingleFile(t *testing.T) {
+	files := rekeySingleFile(resourcediff.FileSet{"reviewer.agent.md": []byte("x")}, "reviewer.md")
+	if _, ok := files["reviewer.md"]; !ok || len(files) != 1 {
+		t.Errorf("expected the file to be renamed, got %v", files)
+	}
+
+	multi := resourcediff.FileSet{"a.md": nil, "b.md": nil}
+	if got := rekeySingleFile(multi, "a.md"); len(got) != 2 {
+		t.Errorf("multi-file sets must be left alone, got %v", got)
+	}
+}
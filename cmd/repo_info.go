@@ -27,9 +27,18 @@ type repoInfoSourceOutput struct {
 	Mode       string   `json:"mode" yaml:"mode"`
 	LastSynced string   `json:"last_synced" yaml:"last_synced"`
 	Include    []string `json:"include,omitempty" yaml:"include,omitempty"`
+	Exclude    []string `json:"exclude,omitempty" yaml:"exclude,omitempty"`
 
 	Overridden bool   `json:"overridden,omitempty" yaml:"overridden,omitempty"`
 	RestoreTo  string `json:"restore_to,omitempty" yaml:"restore_to,omitempty"`
+
+	LastFetchError     string `json:"last_fetch_error,omitempty" yaml:"last_fetch_error,omitempty"`
+	CredentialProvider string `json:"credential_provider,omitempty" yaml:"credential_provider,omitempty"`
+
+	Signers         string `json:"signers,omitempty" yaml:"signers,omitempty"`
+	VerifiedSigner  string `json:"verified_signer,omitempty" yaml:"verified_signer,omitempty"`
+	SignatureFormat string `json:"signature_format,omitempty" yaml:"signature_format,omitempty"`
+	VerifiedCommit  string `json:"verified_commit,omitempty" yaml:"verified_commit,omitempty"`
 }
 
 // repoInfoOutput is the structured output for JSON/YAML formats.
@@ -232,6 +241,7 @@ func buildRepoInfoOutput(
 				Mode:       src.GetMode(),
 				LastSynced: lastSynced,
 				Include:    src.Include,
+				Exclude:    src.Exclude,
 			}
 			if src.OverrideOriginalURL != "" {
 				entry.Overridden = true
@@ -241,6 +251,18 @@ func buildRepoInfoOutput(
 					Subpath: src.OverrideOriginalSubpath,
 				})
 			}
+			if state, ok := metadata.Sources[src.Name]; ok && state.LastFetchError != "" {
+				entry.LastFetchError = state.LastFetchError
+				entry.CredentialProvider = credentialProviderForDisplay(state.FetchCredentialProvider)
+			}
+			if src.Verify != nil {
+				entry.Signers = src.Verify.Signers
+			}
+			if state, ok := metadata.Sources[src.Name]; ok && state.VerifiedSigner != "" {
+				entry.VerifiedSigner = state.VerifiedSigner
+				entry.SignatureFormat = state.SignatureFormat
+				entry.VerifiedCommit = state.VerifiedCommit
+			}
 			result.Sources = append(result.Sources, entry)
 		}
 	}
@@ -282,24 +304,34 @@ func formatBytes(bytes int64) string {
 // short lists (≤ 3 patterns and ≤ 30 chars combined), or a count summary like
 // "3 filters" for longer ones.
 func formatInclude(include []string) string {
-	if len(include) == 0 {
-		return "all"
+	return formatFilterPatterns(include, "all")
+}
+
+// formatExclude formats the exclude filter list like formatInclude, showing
+// "-" when nothing is excluded.
+func formatExclude(exclude []string) string {
+	return formatFilterPatterns(exclude, "-")
+}
+
+func formatFilterPatterns(patterns []string, empty string) string {
+	if len(patterns) == 0 {
+		return empty
 	}
 	// Summarise if there are more than 3 patterns or combined text is too wide (> 30 chars)
-	joined := strings.Join(include, ", ")
-	if len(include) > 3 || len(joined) > 30 {
-		return fmt.Sprintf("%d filters", len(include))
+	joined := strings.Join(patterns, ", ")
+	if len(patterns) > 3 || len(joined) > 30 {
+		return fmt.Sprintf("%d filters", len(patterns))
 	}
 	return joined
 }
 
 // renderSourcesTable renders sources as a table
 func renderSourcesTable(sources []*repomanifest.Source, metadata *sourcemetadata.SourceMetadata) error {
-	// Create table with columns: NAME, TYPE, LOCATION, MODE, LAST SYNCED, INCLUDE, OVERRIDE
-	table := output.NewTable("NAME", "TYPE", "LOCATION", "MODE", "LAST SYNCED", "INCLUDE", "OVERRIDE")
+	// Create table with columns: NAME, TYPE, LOCATION, MODE, LAST SYNCED, INCLUDE, EXCLUDE, OVERRIDE
+	table := output.NewTable("NAME", "TYPE", "LOCATION", "MODE", "LAST SYNCED", "INCLUDE", "EXCLUDE", "OVERRIDE")
 	table.WithResponsive().
-		WithDynamicColumn(2).                          // LOCATION column stretches
-		WithMinColumnWidths(20, 8, 30, 10, 12, 10, 12) // NAME, TYPE, LOCATION, MODE, LAST SYNCED, INCLUDE, OVERRIDE
+		WithDynamicColumn(2).                              // LOCATION column stretches
+		WithMinColumnWidths(20, 8, 30, 10, 12, 10, 10, 12) // NAME, TYPE, LOCATION, MODE, LAST SYNCED, INCLUDE, EXCLUDE, OVERRIDE
 
 	// Add row for each source
 	for _, source := range sources {
@@ -327,8 +359,9 @@ func renderSourcesTable(sources []*repomanifest.Source, metadata *sourcemetadata
 			lastSynced = formatTimeSince(state.LastSynced)
 		}
 
-		// Format include filters
+		// Format include/exclude filters
 		includeDisplay := formatInclude(source.Include)
+		excludeDisplay := formatExclude(source.Exclude)
 
 		overrideDisplay := "-"
 		if source.OverrideOriginalURL != "" {
@@ -347,12 +380,76 @@ func renderSourcesTable(sources []*repomanifest.Source, metadata *sourcemetadata
 			mode,
 			lastSynced,
 			includeDisplay,
+			excludeDisplay,
 			overrideDisplay,
 		)
 	}
 
 	// Render the table
-	return table.Format(output.Table)
+	if err := table.Format(output.Table); err != nil {
+		return err
+	}
+
+	renderFetchFailures(sources, metadata)
+	renderSignatureVerification(sources, metadata)
+	return nil
+}
+
+// renderFetchFailures prints the last fetch failure of each remote source,
+// including which credential provider was in effect, so authentication
+// problems with private sources can be diagnosed without re-running sync.
+func renderFetchFailures(sources []*repomanifest.Source, metadata *sourcemetadata.SourceMetadata) {
+	printedHeader := false
+	for _, source := range sources {
+		state, ok := metadata.Sources[source.Name]
+		if !ok || state.LastFetchError == "" {
+			continue
+		}
+		if !printedHeader {
+			fmt.Println()
+			fmt.Println("Fetch failures (last sync):")
+			printedHeader = true
+		}
+		firstLine := strings.SplitN(state.LastFetchError, "\n", 2)[0]
+		fmt.Printf("  %s %s\n", statusIconFail, source.Name)
+		fmt.Printf("      credentials: %s\n", credentialProviderForDisplay(state.FetchCredentialProvider))
+		fmt.Printf("      error:       %s\n", firstLine)
+	}
+}
+
+// renderSignatureVerification prints who signed the last synced commit of
+// each source that requires signed commits.
+func renderSignatureVerification(sources []*repomanifest.Source, metadata *sourcemetadata.SourceMetadata) {
+	printedHeader := false
+	for _, source := range sources {
+		if source.Verify == nil {
+			continue
+		}
+		if !printedHeader {
+			fmt.Println()
+			fmt.Println("Signature verification:")
+			printedHeader = true
+		}
+		state, ok := metadata.Sources[source.Name]
+		if !ok || state.VerifiedSigner == "" {
+			fmt.Printf("  %s %s: not verified yet (signers: %s)\n", statusIconFail, source.Name, source.Verify.Signers)
+			continue
+		}
+		commit := state.VerifiedCommit
+		if len(commit) > 12 {
+			commit = commit[:12]
+		}
+		fmt.Printf("  %s %s: signed by %s (%s), commit %s\n", statusIconOK, source.Name, state.VerifiedSigner, state.SignatureFormat, commit)
+	}
+}
+
+// credentialProviderForDisplay renders a recorded credential provider,
+// falling back to git's own credential setup when none was configured.
+func credentialProviderForDisplay(provider string) string {
+	if provider == "" {
+		return "none (git credential helper)"
+	}
+	return provider
 }
 
 // sourceLocationForDisplay keeps source-identity formatting consistent across
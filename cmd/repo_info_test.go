@@ -67,6 +67,18 @@ func TestFormatInclude(t *testing.T) {
 	}
 }
 
+func TestFormatExclude(t *testing.T) {
+	if got := formatExclude(nil); got != "-" {
+		t.Errorf("formatExclude(nil) = %q, want %q", got, "-")
+	}
+	if got := formatExclude([]string{"skill/experimental-*"}); got != "skill/experimental-*" {
+		t.Errorf("formatExclude(single) = %q, want pattern", got)
+	}
+	if got := formatExclude([]string{"a", "b", "c", "d"}); got != "4 filters" {
+		t.Errorf("formatExclude(many) = %q, want %q", got, "4 filters")
+	}
+}
+
 func TestRenderSourcesTableIncludeColumn(t *testing.T) {
 	// Create metadata (no sync times needed for this test)
 	metadata := &sourcemetadata.SourceMetadata{
@@ -84,6 +96,7 @@ func TestRenderSourcesTableIncludeColumn(t *testing.T) {
 			Name:    "with-filter",
 			URL:     "https://github.com/user/other",
 			Include: []string{"skills/*", "commands/*"},
+			Exclude: []string{"skills/draft"},
 		},
 	}
 
@@ -111,7 +124,7 @@ func TestRenderSourcesTableIncludeColumn(t *testing.T) {
 		}
 	})
 
-	for _, expected := range []string{"INCLUDE", "all", "skills/*"} {
+	for _, expected := range []string{"INCLUDE", "all", "skills/*", "EXCLUDE", "skills/draft"} {
 		if !strings.Contains(output.Stdout, expected) {
 			t.Fatalf("expected sources table output to contain %q, got:\n%s", expected, output.Stdout)
 		}
@@ -507,6 +520,53 @@ func TestRenderSourcesTable_ShowsOverrideRestoreTarget(t *testing.T) {
 	}
 }
 
+func TestRenderSourcesTable_ShowsFetchFailureCredentialProvider(t *testing.T) {
+	sources := []*repomanifest.Source{
+		{Name: "private-skills", URL: "https://gitlab.example.com/team/skills"},
+		{Name: "healthy", URL: "https://github.com/example/tools"},
+	}
+	metadata := &sourcemetadata.SourceMetadata{Version: 1, Sources: map[string]*sourcemetadata.SourceState{
+		"private-skills": {
+			LastFetchError:          "failed to download repository: git clone failed: exit status 128\nOutput: HTTP Basic: Access denied",
+			FetchCredentialProvider: "env:GITLAB_TOKEN",
+		},
+	}}
+
+	output := captureOutput(t, func() {
+		if err := renderSourcesTable(sources, metadata); err != nil {
+			t.Fatalf("renderSourcesTable() failed: %v", err)
+		}
+	})
+
+	for _, expected := range []string{"Fetch failures", "private-skills", "credentials: env:GITLAB_TOKEN", "failed to download repository"} {
+		if !strings.Contains(output.Stdout, expected) {
+			t.Fatalf("expected table output to contain %q, got:\n%s", expected, output.Stdout)
+		}
+	}
+	if strings.Contains(output.Stdout, "Access denied") {
+		t.Fatalf("expected only the first error line in table output, got:\n%s", output.Stdout)
+	}
+}
+
+func TestBuildRepoInfoOutput_FetchFailureWithoutConfiguredProvider(t *testing.T) {
+	manifest := &repomanifest.Manifest{Version: 1, Sources: []*repomanifest.Source{{
+		Name: "private-skills",
+		URL:  "https://gitlab.example.com/team/skills",
+	}}}
+	metadata := &sourcemetadata.SourceMetadata{Version: 1, Sources: map[string]*sourcemetadata.SourceState{
+		"private-skills": {LastFetchError: "failed to download repository"},
+	}}
+
+	out := buildRepoInfoOutput("/tmp/repo", 0, 0, 0, 0, 0, manifest, metadata)
+	got := out.Sources[0]
+	if got.LastFetchError != "failed to download repository" {
+		t.Fatalf("LastFetchError = %q", got.LastFetchError)
+	}
+	if got.CredentialProvider != "none (git credential helper)" {
+		t.Fatalf("CredentialProvider = %q, want git credential helper fallback", got.CredentialProvider)
+	}
+}
+
 func TestRepoInfo_JSON_IncludesOverrideState(t *testing.T) {
 	repoDir := t.TempDir()
 	t.Setenv("AIMGR_REPO_PATH", repoDir)
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/spf13/cobra"
+)
+
+var (
+	repoLogLimitFlag  int
+	repoLogFormatFlag string
+)
+
+// repoLogOutput is the structured output for JSON/YAML formats.
+type repoLogOutput struct {
+	Resource   string              `json:"resource,omitempty" yaml:"resource,omitempty"`
+	Operations []repo.HistoryEntry `json:"operations" yaml:"operations"`
+}
+
+// repoLogCmd represents the repo log command
+var repoLogCmd = &cobra.Command{
+	Use:   "log [resource]",
+	Short: "Show aimgr operations from repository history",
+	Long: `Show aimgr operations (add, sync, remove, rollback, ...) recorded in the
+repository's git history, newest first, with the resources each one changed.
+
+Pass a resource reference to only show operations that changed it. Use the
+commit from this list with 'aimgr repo rollback' to restore an earlier state.
+
+Examples:
+  aimgr repo log
+  aimgr repo log skill/pdf-processing
+  aimgr repo log --limit 5 --format json`,
+	Args: cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeResourcesWithOptions(completionOptions{
+		includePackages: true,
+	}),
+	RunE: runRepoLog,
+}
+
+func runRepoLog(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(repoLogFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	var resourceRef string
+	if len(args) == 1 {
+		resType, name, err := ParseResourceArg(args[0])
+		if err != nil {
+			return err
+		}
+		resourceRef = fmt.Sprintf("%s/%s", resType, name)
+	}
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return err
+	}
+
+	repoLock, repoExists, err := acquireRepoReadLockIfRepoExists(cmd.Context(), manager)
+	if err != nil {
+		return err
+	}
+	if !repoExists {
+		return missingRepoPathError(manager.GetRepoPath())
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	entries, err := manager.History(resourceRef, repoLogLimitFlag)
+	if err != nil {
+		return err
+	}
+
+	if format != output.Table {
+		return output.FormatOutput(repoLogOutput{Resource: resourceRef, Operations: entries}, format)
+	}
+
+	if len(entries) == 0 {
+		if resourceRef != "" {
+			fmt.Printf("No aimgr operations found for %s.\n", resourceRef)
+		} else {
+			fmt.Println("No aimgr operations found in repository history.")
+		}
+		return nil
+	}
+
+	table := output.NewTable("COMMIT", "DATE", "OPERATION", "RESOURCES")
+	table.WithResponsive().
+		WithDynamicColumn(2).
+		WithMinColumnWidths(9, 16, 30, 20)
+	for _, entry := range entries {
+		table.AddRow(
+			entry.ShortCommit,
+			entry.Time.Local().Format("2006-01-02 15:04"),
+			strings.TrimPrefix(entry.Message, "aimgr: "),
+			summarizeResourceRefs(entry.Resources, 3),
+		)
+	}
+	if err := table.Format(format); err != nil {
+		return err
+	}
+
+	fmt.Println("\nRestore an earlier state with: aimgr repo rollback <commit>")
+	return nil
+}
+
+// summarizeResourceRefs lists up to limit references and counts the rest.
+func summarizeResourceRefs(refs []string, limit int) string {
+	if len(refs) == 0 {
+		return "-"
+	}
+	if len(refs) <= limit {
+		return strings.Join(refs, ", ")
+	}
+	return fmt.Sprintf("%s (+%d more)", strings.Join(refs[:limit], ", "), len(refs)-limit)
+}
+
+func init() {
+	repoCmd.AddCommand(repoLogCmd)
+	repoLogCmd.Flags().IntVar(&repoLogLimitFlag, "limit", 20, "Maximum number of operations to show (0 = all)")
+	repoLogCmd.Flags().StringVar(&repoLogFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	_ = repoLogCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+}
@@ -5,8 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/modifications"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
 	"github.com/spf13/cobra"
 )
@@ -16,8 +19,9 @@ type RepoRepairResult struct {
 	Status string          `json:"status" yaml:"status"`
 	Error  *CommandFailure `json:"error,omitempty" yaml:"error,omitempty"`
 	// Fixed issues
-	MetadataCreated []ResourceIssue `json:"metadata_created,omitempty" yaml:"metadata_created,omitempty"`
-	OrphanedRemoved []MetadataIssue `json:"orphaned_removed,omitempty" yaml:"orphaned_removed,omitempty"`
+	MetadataCreated  []ResourceIssue   `json:"metadata_created,omitempty" yaml:"metadata_created,omitempty"`
+	OrphanedRemoved  []MetadataIssue   `json:"orphaned_removed,omitempty" yaml:"orphaned_removed,omitempty"`
+	ScriptModesFixed []ScriptModeIssue `json:"script_modes_fixed,omitempty" yaml:"script_modes_fixed,omitempty"`
 	// Unfixable issues (reported but not auto-fixed)
 	TypeMismatches          []TypeMismatch `json:"type_mismatches,omitempty" yaml:"type_mismatches,omitempty"`
 	PackagesWithMissingRefs []PackageIssue `json:"packages_with_missing_refs,omitempty" yaml:"packages_with_missing_refs,omitempty"`
@@ -28,6 +32,12 @@ type RepoRepairResult struct {
 	UnfixableCount int `json:"unfixable_count" yaml:"unfixable_count"`
 }
 
+// ScriptModeIssue is a skill script with a shebang but no exec bit.
+type ScriptModeIssue struct {
+	Name string `json:"name" yaml:"name"`
+	File string `json:"file" yaml:"file"` // relative to the skill directory
+}
+
 const (
 	repoRepairStatusClean                 = "clean"
 	repoRepairStatusCompletedWithFindings = "completed_with_findings"
@@ -52,6 +62,9 @@ what it can. Issues that cannot be auto-fixed are reported with guidance.
 Fixable issues (auto-repaired):
   - Resources without metadata → creates missing metadata
   - Orphaned metadata (resource gone) → removes orphaned metadata file
+  - Skill scripts with a shebang but no exec bit → makes them executable
+    (skills imported from local sources are symlinks into the source and
+    are left alone; fix those in the source)
 
 Unfixable issues (reported with guidance):
   - Type mismatches between resource and metadata
@@ -166,7 +179,17 @@ Examples:
 			// In dry-run mode, populate "would fix" lists without touching the filesystem
 			result.MetadataCreated = verifyResult.ResourcesWithoutMetadata
 			result.OrphanedRemoved = verifyResult.OrphanedMetadata
-			result.FixedCount = len(result.MetadataCreated) + len(result.OrphanedRemoved)
+			result.ScriptModesFixed, err = repairScriptModes(manager, true)
+			if err != nil {
+				if outErr := outputRepoRepairOperationalFailure(parsedFormat, err); outErr != nil {
+					return outErr
+				}
+				if parsedFormat == output.JSON {
+					return newSuppressedOperationalFailureError(err)
+				}
+				return newOperationalFailureError(err)
+			}
+			result.FixedCount = len(result.MetadataCreated) + len(result.OrphanedRemoved) + len(result.ScriptModesFixed)
 			setRepoRepairStatusForCompletedResult(result)
 			if err := outputRepoRepairResults(result, parsedFormat); err != nil {
 				return err
@@ -206,7 +229,19 @@ Examples:
 			result.OrphanedRemoved = append(result.OrphanedRemoved, issue)
 		}
 
-		result.FixedCount = len(result.MetadataCreated) + len(result.OrphanedRemoved)
+		// Apply fixes: make skill scripts with a shebang executable
+		result.ScriptModesFixed, err = repairScriptModes(manager, false)
+		if err != nil {
+			if outErr := outputRepoRepairOperationalFailure(parsedFormat, err); outErr != nil {
+				return outErr
+			}
+			if parsedFormat == output.JSON {
+				return newSuppressedOperationalFailureError(err)
+			}
+			return newOperationalFailureError(err)
+		}
+
+		result.FixedCount = len(result.MetadataCreated) + len(result.OrphanedRemoved) + len(result.ScriptModesFixed)
 		setRepoRepairStatusForCompletedResult(result)
 		if err := outputRepoRepairResults(result, parsedFormat); err != nil {
 			return err
@@ -219,6 +254,44 @@ Examples:
 	},
 }
 
+// repairScriptModes makes scripts with a shebang executable in skills
+// stored in the repository and in their tool-specific variants. Skills that
+// are symlinks into a local source are skipped: their files belong to the
+// source, not the repository.
+func repairScriptModes(manager *repo.Manager, dryRun bool) ([]ScriptModeIssue, error) {
+	skillType := resource.Skill
+	skills, err := manager.List(&skillType)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixed []ScriptModeIssue
+	for _, skill := range skills {
+		skillPath := manager.GetPath(skill.Name, resource.Skill)
+		info, err := os.Lstat(skillPath)
+		if err != nil || info.Mode()&os.ModeSymlink != 0 {
+			continue
+		}
+		files, err := resource.FixScriptModes(skillPath, dryRun)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			fixed = append(fixed, ScriptModeIssue{Name: skill.Name, File: file})
+		}
+		if len(files) == 0 || dryRun {
+			continue
+		}
+		variants, _ := filepath.Glob(filepath.Join(manager.GetRepoPath(), modifications.ModificationsDirName, "*", "skills", skill.Name))
+		for _, variant := range variants {
+			if _, err := resource.FixScriptModes(variant, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return fixed, nil
+}
+
 func setRepoRepairStatusForCompletedResult(result *RepoRepairResult) {
 	if result == nil {
 		return
@@ -320,6 +393,23 @@ func displayRepoRepairResults(result *RepoRepairResult) {
 		}
 	}
 
+	// Fixed: script modes
+	if len(result.ScriptModesFixed) > 0 {
+		hasOutput = true
+		if result.DryRun {
+			for _, issue := range result.ScriptModesFixed {
+				fmt.Printf("  Would make %s executable in %s\n",
+					issue.File, formatResourceReference(resource.Skill, issue.Name))
+			}
+		} else {
+			fmt.Printf("✓ Made %d skill script(s) executable:\n", len(result.ScriptModesFixed))
+			for _, issue := range result.ScriptModesFixed {
+				fmt.Printf("  • %s: %s\n", formatResourceReference(resource.Skill, issue.Name), issue.File)
+			}
+			fmt.Println()
+		}
+	}
+
 	// Unfixable: type mismatches
 	if len(result.TypeMismatches) > 0 {
 		hasOutput = true
@@ -386,6 +386,59 @@ func TestRepoRepair_DryRun(t *testing.T) {
 	}
 }
 
+// -----------------------------------------------------------------------
+// TestRepoRepair_ScriptModes — skill script with shebang but no exec bit
+// -----------------------------------------------------------------------
+
+func TestRepoRepair_ScriptModes(t *testing.T) {
+	manager, cleanup := setupRepairTestRepo(t)
+	defer cleanup()
+
+	repoPath := manager.GetRepoPath()
+	skillDir := filepath.Join(repoPath, "skills", "tools")
+	if err := os.MkdirAll(filepath.Join(skillDir, "scripts"), 0755); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("---\nname: tools\ndescription: Tools\n---\n"), 0644); err != nil {
+		t.Fatalf("Failed to write SKILL.md: %v", err)
+	}
+	scriptPath := filepath.Join(skillDir, "scripts", "run.sh")
+	if err := os.WriteFile(scriptPath, []byte("#!/bin/sh\necho hi\n"), 0644); err != nil {
+		t.Fatalf("Failed to write script: %v", err)
+	}
+	variantScript := filepath.Join(repoPath, ".modifications", "opencode", "skills", "tools", "scripts", "run.sh")
+	if err := os.MkdirAll(filepath.Dir(variantScript), 0755); err != nil {
+		t.Fatalf("Failed to create variant: %v", err)
+	}
+	if err := os.WriteFile(variantScript, []byte("#!/bin/sh\necho hi\n"), 0644); err != nil {
+		t.Fatalf("Failed to write variant script: %v", err)
+	}
+
+	planned, err := repairScriptModes(manager, true)
+	if err != nil {
+		t.Fatalf("repairScriptModes(dryRun) failed: %v", err)
+	}
+	if len(planned) != 1 || planned[0].Name != "tools" || planned[0].File != "scripts/run.sh" {
+		t.Fatalf("repairScriptModes(dryRun) = %+v, want tools scripts/run.sh", planned)
+	}
+	if info, _ := os.Stat(scriptPath); info.Mode().Perm() != 0644 {
+		t.Errorf("Dry-run changed script mode to %v", info.Mode().Perm())
+	}
+
+	fixed, err := repairScriptModes(manager, false)
+	if err != nil {
+		t.Fatalf("repairScriptModes failed: %v", err)
+	}
+	if len(fixed) != 1 {
+		t.Fatalf("repairScriptModes = %+v, want one fix", fixed)
+	}
+	for _, p := range []string{scriptPath, variantScript} {
+		if info, _ := os.Stat(p); info.Mode().Perm() != 0755 {
+			t.Errorf("%s mode = %v, want 0755", p, info.Mode().Perm())
+		}
+	}
+}
+
 // -----------------------------------------------------------------------
 // TestRepoRepair_MixedIssues — some fixable, some not
 // -----------------------------------------------------------------------
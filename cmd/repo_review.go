@@ -0,0 +1,466 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/lint"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/pattern"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resourcediff"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/secrets"
+	"github.com/spf13/cobra"
+)
+
+var (
+	reviewFormatFlag   string
+	reviewAllFlag      bool
+	reviewDryRunFlag   bool
+	reviewReviewerFlag string
+	reviewNoteFlag     string
+	reviewReasonFlag   string
+)
+
+// reviewItem is a quarantined resource with everything a reviewer needs.
+type reviewItem struct {
+	repo.QuarantineEntry `yaml:",inline"`
+	Diff                 *resourcediff.Result `json:"diff,omitempty" yaml:"diff,omitempty"`
+	Lint                 []lint.Finding       `json:"lint" yaml:"lint"`
+	Secrets              []secrets.Finding    `json:"secrets" yaml:"secrets"`
+
+	oldSide, newSide *diffSide
+}
+
+// repoReviewCmd represents the repo review command
+var repoReviewCmd = &cobra.Command{
+	Use:   "review [pattern]...",
+	Short: "Review resources held in quarantine",
+	Long: `Review resources imported with 'repo add --review' or 'repo sync --review'.
+
+Such imports hold new and changed resources in the repository's quarantine
+(.quarantine/) instead of importing them, so they cannot be installed yet.
+Without arguments, review lists the quarantined resources with their lint and
+secret-scan finding counts. With patterns, it shows each matching resource in
+full: a diff against the repository copy (or all files, for a new resource),
+lint findings and possible secrets.
+
+Approve a resource to import it, or reject it to discard it:
+
+  aimgr repo review approve <pattern>...
+  aimgr repo review reject <pattern>... --reason "..."
+
+Approved resources are copied into the repository, even from local sources,
+and the approval (reviewer, time, content digest) is recorded in their
+metadata. A rejected version is remembered and skipped by later reviewed
+syncs; a different upstream version is quarantined again.
+
+Lint findings use the .aimgr-lint.yaml of the repository, if any; secrets are
+scanned with repo.secrets from aimgr.yaml.
+
+Examples:
+  aimgr repo review
+  aimgr repo review skill/pdf-processing
+  aimgr repo review 'command/*' --format json`,
+	Args:              cobra.ArbitraryArgs,
+	ValidArgsFunction: completeQuarantinedResources,
+	SilenceUsage:      true,
+	RunE:              runRepoReview,
+}
+
+var repoReviewApproveCmd = &cobra.Command{
+	Use:   "approve [pattern]...",
+	Short: "Import quarantined resources into the repository",
+	Long: `Import quarantined resources into the repository and record the approval
+in their metadata. The organization policy and the local-changes policy still
+apply; a resource whose import fails stays in quarantine.
+
+Examples:
+  aimgr repo review approve skill/pdf-processing
+  aimgr repo review approve 'command/*' --note "checked allowed-tools"
+  aimgr repo review approve --all`,
+	ValidArgsFunction: completeQuarantinedResources,
+	SilenceUsage:      true,
+	RunE:              runRepoReviewApprove,
+}
+
+var repoReviewRejectCmd = &cobra.Command{
+	Use:   "reject [pattern]...",
+	Short: "Discard quarantined resources",
+	Long: `Discard quarantined resources. The rejected version is remembered, so
+reviewed syncs skip it until the upstream content changes.
+
+Examples:
+  aimgr repo review reject agent/deployer --reason "runs kubectl without restriction"
+  aimgr repo review reject --all`,
+	ValidArgsFunction: completeQuarantinedResources,
+	SilenceUsage:      true,
+	RunE:              runRepoReviewReject,
+}
+
+func init() {
+	repoCmd.AddCommand(repoReviewCmd)
+	repoReviewCmd.AddCommand(repoReviewApproveCmd, repoReviewRejectCmd)
+
+	repoReviewCmd.Flags().StringVar(&reviewFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	_ = repoReviewCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+
+	for _, c := range []*cobra.Command{repoReviewApproveCmd, repoReviewRejectCmd} {
+		c.Flags().BoolVar(&reviewAllFlag, "all", false, "Apply to every quarantined resource")
+		c.Flags().BoolVar(&reviewDryRunFlag, "dry-run", false, "Show what would be done without changing anything")
+		c.Flags().StringVar(&reviewReviewerFlag, "reviewer", "", "Reviewer name to record (default: current user)")
+	}
+	repoReviewApproveCmd.Flags().StringVar(&reviewNoteFlag, "note", "", "Note to record with the approval")
+	repoReviewApproveCmd.Flags().StringVar(&reviewFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	_ = repoReviewApproveCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+	repoReviewRejectCmd.Flags().StringVar(&reviewReasonFlag, "reason", "", "Reason to record with the rejection")
+}
+
+func runRepoReview(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(reviewFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return err
+	}
+	repoLock, repoExists, err := acquireRepoReadLockIfRepoExists(cmd.Context(), manager)
+	if err != nil {
+		return err
+	}
+	if !repoExists {
+		return missingRepoPathError(manager.GetRepoPath())
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	entries, err := selectQuarantined(manager, args, len(args) == 0)
+	if err != nil {
+		return err
+	}
+	items, err := buildReviewItems(manager, entries)
+	if err != nil {
+		return err
+	}
+
+	if format != output.Table {
+		if items == nil {
+			items = []reviewItem{}
+		}
+		return output.FormatOutput(items, format)
+	}
+	if len(items) == 0 {
+		fmt.Println("No resources awaiting review.")
+		return nil
+	}
+	if len(args) == 0 {
+		printReviewSummary(items)
+		return nil
+	}
+	for i := range items {
+		if i > 0 {
+			fmt.Println()
+		}
+		printReviewItem(&items[i])
+	}
+	return nil
+}
+
+func runRepoReviewApprove(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(reviewFormatFlag)
+	if err != nil {
+		return err
+	}
+	manager, entries, unlock, err := openQuarantineForDecision(cmd, args)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+	orgPolicy, err := loadOrgPolicy()
+	if err != nil {
+		return err
+	}
+
+	approval := metadata.Review{
+		Reviewer:   reviewerName(),
+		ApprovedAt: time.Now().UTC().Truncate(time.Second),
+		Note:       reviewNoteFlag,
+	}
+	result, err := manager.ApproveQuarantined(entries, approval, repo.BulkImportOptions{
+		DryRun:       reviewDryRunFlag,
+		LocalChanges: cfg.Repo.LocalChangesPolicy(),
+		Policy:       orgPolicy,
+	})
+	if result != nil {
+		if fmtErr := output.FormatBulkResult(output.FromBulkImportResult(result), format); fmtErr != nil {
+			return fmtErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+	if len(result.Failed) > 0 {
+		return fmt.Errorf("failed to approve %d resource(s); they remain in quarantine", len(result.Failed))
+	}
+	return nil
+}
+
+func runRepoReviewReject(cmd *cobra.Command, args []string) error {
+	manager, entries, unlock, err := openQuarantineForDecision(cmd, args)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := manager.RejectQuarantined(entries, reviewerName(), reviewReasonFlag, reviewDryRunFlag); err != nil {
+		return err
+	}
+	verb := "Rejected"
+	if reviewDryRunFlag {
+		verb = "Would reject"
+	}
+	for _, entry := range entries {
+		fmt.Printf("%s %s %s\n", statusIconOK, verb, entry.ResourceRef())
+	}
+	return nil
+}
+
+// openQuarantineForDecision takes the repository write lock and selects the
+// quarantined resources named by args (or all, with --all).
+func openQuarantineForDecision(cmd *cobra.Command, args []string) (*repo.Manager, []repo.QuarantineEntry, func(), error) {
+	if len(args) == 0 && !reviewAllFlag {
+		return nil, nil, nil, fmt.Errorf("specify resources to %s, or use --all", cmd.Name())
+	}
+	if len(args) > 0 && reviewAllFlag {
+		return nil, nil, nil, fmt.Errorf("--all cannot be combined with patterns")
+	}
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	repoLock, err := manager.AcquireRepoWriteLock(cmd.Context())
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("failed to acquire repository lock at %s: %w", manager.RepoLockPath(), err)
+	}
+	unlock := func() { _ = repoLock.Unlock() }
+
+	entries, err := selectQuarantined(manager, args, reviewAllFlag)
+	if err != nil {
+		unlock()
+		return nil, nil, nil, err
+	}
+	if len(entries) == 0 {
+		unlock()
+		return nil, nil, nil, fmt.Errorf("no resources awaiting review")
+	}
+	return manager, entries, unlock, nil
+}
+
+// selectQuarantined returns the quarantined resources matching patterns, or
+// all of them. A pattern that matches nothing is an error.
+func selectQuarantined(manager *repo.Manager, patterns []string, all bool) ([]repo.QuarantineEntry, error) {
+	entries, err := manager.ListQuarantine()
+	if err != nil {
+		return nil, err
+	}
+	if all {
+		return entries, nil
+	}
+
+	var selected []repo.QuarantineEntry
+	picked := make(map[string]bool)
+	for _, p := range patterns {
+		matcher, err := pattern.NewMatcher(p)
+		if err != nil {
+			return nil, err
+		}
+		matched := false
+		for _, entry := range entries {
+			if !matcher.Match(&resource.Resource{Name: entry.Name, Type: entry.Type}) {
+				continue
+			}
+			matched = true
+			if !picked[entry.ResourceRef()] {
+				picked[entry.ResourceRef()] = true
+				selected = append(selected, entry)
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("no quarantined resource matches %q (see 'aimgr repo review')", p)
+		}
+	}
+	return selected, nil
+}
+
+// buildReviewItems diffs each quarantined resource against the repository
+// and collects its lint and secret-scan findings.
+func buildReviewItems(manager *repo.Manager, entries []repo.QuarantineEntry) ([]reviewItem, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	root := manager.QuarantineRoot()
+
+	var lintCfg *lint.Config
+	configPath, err := lint.FindConfig(root)
+	if err != nil {
+		return nil, err
+	}
+	if configPath != "" {
+		if lintCfg, err = lint.LoadConfig(configPath); err != nil {
+			return nil, err
+		}
+	}
+	report, err := lint.Lint(root, lintCfg)
+	if err != nil {
+		return nil, err
+	}
+	scanner, _, err := newImportSecretScanner(root)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]reviewItem, 0, len(entries))
+	for _, entry := range entries {
+		item := reviewItem{QuarantineEntry: entry, Lint: []lint.Finding{}, Secrets: []secrets.Finding{}}
+		quarantinePath := manager.QuarantinePath(entry.Name, entry.Type)
+		relPath, err := filepath.Rel(root, quarantinePath)
+		if err != nil {
+			return nil, err
+		}
+		relPath = filepath.ToSlash(relPath)
+
+		for _, f := range report.Findings {
+			if f.Resource == entry.ResourceRef() || (f.Resource == "" && (f.File == relPath || strings.HasPrefix(f.File, relPath+"/"))) {
+				item.Lint = append(item.Lint, f)
+			}
+		}
+		if scanner != nil {
+			found, err := scanner.ScanPath(quarantinePath)
+			if err != nil {
+				return nil, err
+			}
+			item.Secrets = append(item.Secrets, found...)
+		}
+
+		newFiles, err := resourcediff.LoadPath(quarantinePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read quarantined %s: %w", entry.ResourceRef(), err)
+		}
+		oldFiles, err := loadResourceFilesIfExists(manager.GetPath(entry.Name, entry.Type))
+		if err != nil {
+			return nil, err
+		}
+		prefix, mainFile := relPath, "SKILL.md"
+		if entry.Type != resource.Skill {
+			prefix, mainFile = path.Dir(relPath), path.Base(relPath)
+			oldFiles = rekeySingleFile(oldFiles, mainFile)
+		}
+		item.Diff = resourcediff.Compare(oldFiles, newFiles, "a/"+prefix, "b/"+prefix, mainFile)
+		item.oldSide = &diffSide{label: "repository", files: oldFiles}
+		item.newSide = &diffSide{label: "quarantine", files: newFiles}
+		if entry.Change == repo.QuarantineNew {
+			item.oldSide.label = "nothing (new resource)"
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func printReviewSummary(items []reviewItem) {
+	table := output.NewTable("RESOURCE", "CHANGE", "SOURCE", "LINT", "SECRETS")
+	table.WithResponsive().WithDynamicColumn(2).WithMinColumnWidths(24, 8, 16, 5, 7)
+	for _, item := range items {
+		source := item.SourceName
+		if source == "" {
+			source = item.SourceURL
+		}
+		table.AddRow(item.ResourceRef(), item.Change, source, fmt.Sprintf("%d", len(item.Lint)), fmt.Sprintf("%d", len(item.Secrets)))
+	}
+	_ = table.Format(output.Table)
+	fmt.Printf("\n%d resource(s) awaiting review.\n", len(items))
+	fmt.Println("Run 'aimgr repo review <pattern>' for details, then 'aimgr repo review approve|reject <pattern>'.")
+}
+
+func printReviewItem(item *reviewItem) {
+	fmt.Printf("=== %s (%s) ===\n", item.ResourceRef(), item.Change)
+	source := item.SourceName
+	if item.SourceURL != "" {
+		source = fmt.Sprintf("%s (%s)", source, item.SourceURL)
+	}
+	if item.Ref != "" {
+		source += " @ " + item.Ref
+	}
+	fmt.Printf("Source: %s\n", strings.TrimSpace(source))
+	fmt.Printf("Quarantined: %s\n\n", formatTimestamp(item.QuarantinedAt.Local()))
+
+	if len(item.Secrets) > 0 {
+		fmt.Printf("⚠ Possible Secrets (%d):\n", len(item.Secrets))
+		for _, f := range item.Secrets {
+			fmt.Printf("  - %s\n", f)
+		}
+		fmt.Println()
+	}
+	if len(item.Lint) > 0 {
+		fmt.Printf("Lint Findings (%d):\n", len(item.Lint))
+		for _, f := range item.Lint {
+			location := f.File
+			if f.Line > 0 {
+				location = fmt.Sprintf("%s:%d", f.File, f.Line)
+			}
+			fmt.Printf("  - %s %s [%s] %s\n", f.Severity, location, f.Rule, f.Message)
+		}
+		fmt.Println()
+	}
+
+	printResourceDiff(item.ResourceRef(), item.oldSide, item.newSide, item.Diff)
+}
+
+// reviewerName returns --reviewer, or the current user.
+func reviewerName() string {
+	if reviewReviewerFlag != "" {
+		return reviewReviewerFlag
+	}
+	if u, err := user.Current(); err == nil && u.Username != "" {
+		return u.Username
+	}
+	if name := os.Getenv("USER"); name != "" {
+		return name
+	}
+	return "unknown"
+}
+
+// completeQuarantinedResources completes references of quarantined resources.
+func completeQuarantinedResources(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	entries, err := manager.ListQuarantine()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var refs []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.ResourceRef(), toComplete) {
+			refs = append(refs, entry.ResourceRef())
+		}
+	}
+	return refs, cobra.ShellCompDirectiveNoFileComp
+}
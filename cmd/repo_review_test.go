@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/test/testutil"
+)
+
+func TestBuildReviewItems_DiffAndSecrets(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "Test User")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test User")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	repoPath := t.TempDir()
+	manager := repo.NewManagerWithPath(repoPath)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	upstream := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(upstream, "commands"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	reviewPath := filepath.Join(upstream, "commands", "review.md")
+	deployPath := filepath.Join(upstream, "commands", "deploy.md")
+	testutil.WriteFile(t, reviewPath, "---\ndescription: Review code\n---\nReview the diff.\n")
+	testutil.WriteFile(t, deployPath, "---\ndescription: Deploy\n---\nUse key AKIA"+"IOSFODNN7EXAMPLE.\n")
+
+	// review.md is already in the repository; the upstream version changes it.
+	if _, err := manager.AddBulk([]string{reviewPath}, repo.BulkImportOptions{ImportMode: "copy"}); err != nil {
+		t.Fatalf("AddBulk() error = %v", err)
+	}
+	testutil.WriteFile(t, reviewPath, "---\ndescription: Review code\n---\nReview the whole change.\n")
+
+	opts := repo.BulkImportOptions{ImportMode: "copy", Force: true, Quarantine: true, SourceName: "upstream"}
+	if _, err := manager.AddBulk([]string{reviewPath, deployPath}, opts); err != nil {
+		t.Fatalf("AddBulk() with quarantine error = %v", err)
+	}
+
+	entries, err := selectQuarantined(manager, []string{"command/*"}, false)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("selectQuarantined() = %v, %v; want 2 entries", entries, err)
+	}
+	if _, err := selectQuarantined(manager, []string{"skill/*"}, false); err == nil {
+		t.Error("selectQuarantined() with an unmatched pattern should fail")
+	}
+
+	items, err := buildReviewItems(manager, entries)
+	if err != nil {
+		t.Fatalf("buildReviewItems() error = %v", err)
+	}
+	deploy, review := items[0], items[1]
+	if deploy.Change != repo.QuarantineNew || len(deploy.Secrets) != 1 {
+		t.Errorf("deploy item = %+v, want a new resource with one secret finding", deploy)
+	}
+	if review.Change != repo.QuarantineChanged || len(review.Secrets) != 0 {
+		t.Errorf("review item = %+v, want a changed resource without secrets", review)
+	}
+	if review.Diff == nil || len(review.Diff.Files) != 1 || !strings.Contains(review.Diff.Files[0].Unified, "+Review the whole change.") {
+		t.Errorf("review diff = %+v, want the changed body", review.Diff)
+	}
+}
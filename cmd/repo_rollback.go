@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/spf13/cobra"
+)
+
+var (
+	rollbackLastFlag   bool
+	rollbackDryRunFlag bool
+	rollbackFormatFlag string
+)
+
+// repoRollbackCmd represents the repo rollback command
+var repoRollbackCmd = &cobra.Command{
+	Use:   "rollback <commit|--last>",
+	Short: "Restore repository resources to an earlier state",
+	Long: `Restore resource content and .metadata to their state at a commit from
+'aimgr repo log'. The restore is recorded as a new commit, so a rollback can
+itself be rolled back. ai.repo.yaml is not changed: configured sources are kept,
+and the next 'aimgr repo sync' imports their current content again.
+
+Use --last to undo the most recent aimgr operation that changed resources.
+Commits that only update metadata, such as the sync timestamps written after
+every 'repo sync', are skipped.
+
+Tool-specific .modifications are regenerated after the rollback. Resources that
+the rollback removes may still be installed in projects; run 'aimgr repair' there.
+
+Examples:
+  aimgr repo rollback --last
+  aimgr repo rollback 3f2a9c1
+  aimgr repo rollback 3f2a9c1 --dry-run`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if rollbackLastFlag && len(args) > 0 {
+			return fmt.Errorf("specify either a commit or --last, not both")
+		}
+		if !rollbackLastFlag && len(args) != 1 {
+			return fmt.Errorf("requires a commit argument or --last")
+		}
+		return nil
+	},
+	RunE: runRepoRollback,
+}
+
+func runRepoRollback(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(rollbackFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return err
+	}
+	if err := ensureRepoInitialized(manager); err != nil {
+		return err
+	}
+
+	repoLock, err := manager.AcquireRepoWriteLock(cmd.Context())
+	if err != nil {
+		return wrapLockAcquireError(manager.RepoLockPath(), err)
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	if err := maybeHoldAfterRepoLock(cmd.Context(), "rollback"); err != nil {
+		return err
+	}
+
+	target := ""
+	if len(args) == 1 {
+		target = args[0]
+	}
+	if rollbackLastFlag {
+		target, err = manager.ResolveLastOperation()
+		if err != nil {
+			return err
+		}
+	}
+
+	result, err := manager.Rollback(target, rollbackDryRunFlag)
+	if err != nil {
+		return err
+	}
+
+	if result.Committed {
+		syncRegenerateModifications(manager, manager.GetRepoPath())
+	}
+
+	if format != output.Table {
+		return output.FormatOutput(result, format)
+	}
+
+	targetLabel := fmt.Sprintf("%s (%s)", result.Target.ShortCommit, result.Target.Message)
+	if len(result.Resources) == 0 {
+		fmt.Printf("Repository already matches %s; nothing to roll back.\n", targetLabel)
+		return nil
+	}
+
+	if rollbackDryRunFlag {
+		fmt.Printf("Rollback to %s would change %d resource(s):\n", targetLabel, len(result.Resources))
+	} else {
+		fmt.Printf("✓ Rolled back to %s, %d resource(s) changed:\n", targetLabel, len(result.Resources))
+	}
+	for _, ref := range result.Resources {
+		fmt.Printf("  - %s\n", ref)
+	}
+	if !rollbackDryRunFlag {
+		fmt.Println("\n⚠ Resources removed by the rollback may still be installed in projects.")
+		fmt.Println("  Run 'aimgr repair' in affected projects to clean up broken symlinks.")
+	}
+
+	return nil
+}
+
+func init() {
+	repoCmd.AddCommand(repoRollbackCmd)
+	repoRollbackCmd.Flags().BoolVar(&rollbackLastFlag, "last", false, "Undo the most recent aimgr operation that changed resources")
+	repoRollbackCmd.Flags().BoolVar(&rollbackDryRunFlag, "dry-run", false, "Preview which resources would change")
+	repoRollbackCmd.Flags().StringVar(&rollbackFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	_ = repoRollbackCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+}
@@ -0,0 +1,143 @@
+//go:build integration
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+)
+
+// setupHistoryTestRepo creates a git-tracked repository with two import
+// operations (alpha, then beta) followed by a metadata-only commit, as
+// written by 'repo sync' after every sync.
+func setupHistoryTestRepo(t *testing.T) *repo.Manager {
+	t.Helper()
+	t.Setenv("GIT_AUTHOR_NAME", "Test User")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test User")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	repoDir := t.TempDir()
+	t.Setenv("AIMGR_REPO_PATH", repoDir)
+	manager := repo.NewManagerWithPath(repoDir)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+
+	for _, name := range []string{"alpha", "beta"} {
+		commandsDir := filepath.Join(t.TempDir(), "commands")
+		if err := os.MkdirAll(commandsDir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		path := filepath.Join(commandsDir, name+".md")
+		if err := os.WriteFile(path, []byte("---\ndescription: "+name+"\n---\n# "+name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		result, err := manager.AddBulk([]string{path}, repo.BulkImportOptions{ImportMode: "copy", Force: true})
+		if err != nil || len(result.Failed) > 0 {
+			t.Fatalf("AddBulk(%s) = %+v, %v", name, result, err)
+		}
+	}
+
+	timestamps := filepath.Join(repoDir, ".metadata", "sources.json")
+	if err := os.WriteFile(timestamps, []byte(`{"sources":{}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := manager.CommitChangesForPaths("aimgr: update sync timestamps", []string{timestamps}); err != nil {
+		t.Fatalf("failed to commit timestamps: %v", err)
+	}
+	return manager
+}
+
+func TestRepoLog_ListsOperations(t *testing.T) {
+	setupHistoryTestRepo(t)
+
+	oldFormat, oldLimit := repoLogFormatFlag, repoLogLimitFlag
+	defer func() { repoLogFormatFlag, repoLogLimitFlag = oldFormat, oldLimit }()
+	repoLogFormatFlag, repoLogLimitFlag = "json", 0
+
+	var runErr error
+	out := captureStdout(t, func() { runErr = runRepoLog(repoLogCmd, []string{"command/beta"}) })
+	if runErr != nil {
+		t.Fatalf("runRepoLog() error = %v", runErr)
+	}
+	var got repoLogOutput
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if got.Resource != "command/beta" || len(got.Operations) != 1 || !strings.HasPrefix(got.Operations[0].Message, "aimgr: import") {
+		t.Fatalf("repo log command/beta = %+v", got)
+	}
+
+	repoLogFormatFlag = "table"
+	out = captureStdout(t, func() { runErr = runRepoLog(repoLogCmd, nil) })
+	if runErr != nil {
+		t.Fatalf("runRepoLog() table error = %v", runErr)
+	}
+	for _, want := range []string{"update sync timestamps", "command/beta", "aimgr repo rollback <commit>"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestRepoRollback_LastUndoesImportAfterSyncTimestamps(t *testing.T) {
+	manager := setupHistoryTestRepo(t)
+
+	oldLast, oldDryRun, oldFormat := rollbackLastFlag, rollbackDryRunFlag, rollbackFormatFlag
+	defer func() { rollbackLastFlag, rollbackDryRunFlag, rollbackFormatFlag = oldLast, oldDryRun, oldFormat }()
+	rollbackLastFlag, rollbackFormatFlag = true, "table"
+
+	rollbackDryRunFlag = true
+	var runErr error
+	out := captureStdout(t, func() { runErr = runRepoRollback(repoRollbackCmd, nil) })
+	if runErr != nil {
+		t.Fatalf("runRepoRollback(--dry-run) error = %v", runErr)
+	}
+	if !strings.Contains(out, "would change 1 resource(s)") || !strings.Contains(out, "command/beta") {
+		t.Fatalf("dry-run output:\n%s", out)
+	}
+	if _, err := manager.Get("beta", resource.Command); err != nil {
+		t.Fatalf("dry run must not remove command/beta: %v", err)
+	}
+
+	rollbackDryRunFlag = false
+	out = captureStdout(t, func() { runErr = runRepoRollback(repoRollbackCmd, nil) })
+	if runErr != nil {
+		t.Fatalf("runRepoRollback() error = %v", runErr)
+	}
+	if !strings.Contains(out, "Rolled back") {
+		t.Fatalf("rollback output:\n%s", out)
+	}
+	if _, err := manager.Get("beta", resource.Command); err == nil {
+		t.Error("command/beta should be removed by rolling back its import")
+	}
+	if _, err := manager.Get("alpha", resource.Command); err != nil {
+		t.Errorf("command/alpha should be kept: %v", err)
+	}
+
+	entries, err := manager.History("", 1)
+	if err != nil || len(entries) != 1 || !strings.HasPrefix(entries[0].Message, "aimgr: rollback to") {
+		t.Fatalf("newest operation = %+v, %v; want the rollback commit", entries, err)
+	}
+}
+
+func TestRepoRollback_RequiresCommitOrLast(t *testing.T) {
+	oldLast := rollbackLastFlag
+	defer func() { rollbackLastFlag = oldLast }()
+
+	rollbackLastFlag = false
+	if err := repoRollbackCmd.Args(repoRollbackCmd, nil); err == nil {
+		t.Error("expected an error without a commit or --last")
+	}
+	rollbackLastFlag = true
+	if err := repoRollbackCmd.Args(repoRollbackCmd, []string{"abc123"}); err == nil {
+		t.Error("expected an error for a commit combined with --last")
+	}
+}
@@ -0,0 +1,367 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/install"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
+	"github.com/spf13/cobra"
+)
+
+var (
+	snapshotSaveFormatFlag     string
+	snapshotListFormatFlag     string
+	snapshotRestoreDryRunFlag  bool
+	snapshotRestoreProjectFlag []string
+	snapshotRestoreFormatFlag  string
+)
+
+// snapshotProjectImpact lists the installed resources of a project that a
+// restore changes or removes.
+type snapshotProjectImpact struct {
+	Project   string   `json:"project" yaml:"project"`
+	Resources []string `json:"resources" yaml:"resources"`
+}
+
+// snapshotRestoreOutput is the structured output of repo snapshot restore.
+type snapshotRestoreOutput struct {
+	*repo.SnapshotRestoreResult `yaml:",inline"`
+	DryRun                      bool                    `json:"dry_run" yaml:"dry_run"`
+	Projects                    []snapshotProjectImpact `json:"projects,omitempty" yaml:"projects,omitempty"`
+}
+
+// repoSnapshotCmd represents the repo snapshot command group
+var repoSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save and restore named repository snapshots",
+	Long: `Save the repository state under a name and restore it later, for example
+before applying a large team manifest change.
+
+A snapshot captures resources, packages, .metadata, ai.repo.yaml and
+.modifications. In git-tracked repositories a snapshot is a lightweight tag
+(aimgr-snapshot/<name>) on the repository history; other repositories keep a
+copy under .snapshots/<name>/.`,
+}
+
+// repoSnapshotSaveCmd represents the repo snapshot save command
+var repoSnapshotSaveCmd = &cobra.Command{
+	Use:   "save <name>",
+	Short: "Save the current repository state as a snapshot",
+	Long: `Save the current repository state as a named snapshot.
+
+In git-tracked repositories, uncommitted changes to snapshotted paths are
+committed first so the snapshot matches the working tree.
+
+Examples:
+  aimgr repo snapshot save before-platform-manifest`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runRepoSnapshotSave,
+}
+
+// repoSnapshotListCmd represents the repo snapshot list command
+var repoSnapshotListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List repository snapshots",
+	Long: `List saved snapshots, newest first.
+
+Examples:
+  aimgr repo snapshot list
+  aimgr repo snapshot list --format json`,
+	Args: cobra.NoArgs,
+	RunE: runRepoSnapshotList,
+}
+
+// repoSnapshotRestoreCmd represents the repo snapshot restore command
+var repoSnapshotRestoreCmd = &cobra.Command{
+	Use:   "restore <name>",
+	Short: "Restore the repository to a snapshot",
+	Long: `Restore resources, packages, .metadata, ai.repo.yaml and .modifications to
+their state in a snapshot. In git-tracked repositories the restore is recorded
+as a new commit, so it can be undone with 'aimgr repo rollback --last'.
+
+The restore also reports which resources installed in projects it changes or
+removes. There is no registry of projects, so the current directory is checked
+unless --project is given (repeatable).
+
+Examples:
+  aimgr repo snapshot restore before-platform-manifest --dry-run
+  aimgr repo snapshot restore before-platform-manifest --project ~/src/app --project ~/src/api`,
+	Args:              cobra.ExactArgs(1),
+	SilenceUsage:      true,
+	ValidArgsFunction: completeSnapshotNames,
+	RunE:              runRepoSnapshotRestore,
+}
+
+func runRepoSnapshotSave(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(snapshotSaveFormatFlag)
+	if err != nil {
+		return err
+	}
+	if err := repo.ValidateSnapshotName(args[0]); err != nil {
+		return err
+	}
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return err
+	}
+	if err := ensureRepoInitialized(manager); err != nil {
+		return err
+	}
+
+	repoLock, err := manager.AcquireRepoWriteLock(cmd.Context())
+	if err != nil {
+		return wrapLockAcquireError(manager.RepoLockPath(), err)
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	snapshot, err := manager.SaveSnapshot(args[0])
+	if err != nil {
+		return err
+	}
+
+	if format != output.Table {
+		return output.FormatOutput(snapshot, format)
+	}
+	fmt.Printf("✓ Saved snapshot %s%s\n", snapshot.Name, snapshotCommitSuffix(snapshot))
+	fmt.Printf("\nRestore it with: aimgr repo snapshot restore %s\n", snapshot.Name)
+	return nil
+}
+
+func runRepoSnapshotList(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(snapshotListFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return err
+	}
+
+	repoLock, repoExists, err := acquireRepoReadLockIfRepoExists(cmd.Context(), manager)
+	if err != nil {
+		return err
+	}
+	if !repoExists {
+		return missingRepoPathError(manager.GetRepoPath())
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	snapshots, err := manager.ListSnapshots()
+	if err != nil {
+		return err
+	}
+
+	if format != output.Table {
+		if snapshots == nil {
+			snapshots = []repo.Snapshot{}
+		}
+		return output.FormatOutput(snapshots, format)
+	}
+
+	if len(snapshots) == 0 {
+		fmt.Println("No snapshots saved.")
+		fmt.Println("\nSave one with: aimgr repo snapshot save <name>")
+		return nil
+	}
+
+	table := output.NewTable("NAME", "DATE", "COMMIT")
+	for _, snapshot := range snapshots {
+		commit := snapshot.Commit
+		if commit == "" {
+			commit = "-"
+		}
+		table.AddRow(snapshot.Name, snapshot.Time.Local().Format("2006-01-02 15:04"), commit)
+	}
+	return table.Format(format)
+}
+
+func runRepoSnapshotRestore(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(snapshotRestoreFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	projects := snapshotRestoreProjectFlag
+	if len(projects) == 0 {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return fmt.Errorf("failed to get current directory: %w", err)
+		}
+		projects = []string{cwd}
+	}
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return err
+	}
+	if err := ensureRepoInitialized(manager); err != nil {
+		return err
+	}
+
+	repoLock, err := manager.AcquireRepoWriteLock(cmd.Context())
+	if err != nil {
+		return wrapLockAcquireError(manager.RepoLockPath(), err)
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	// Installed resources are read before the restore, while their symlinks
+	// still resolve.
+	preview, err := manager.RestoreSnapshot(args[0], true)
+	if err != nil {
+		return err
+	}
+	impacts, err := snapshotProjectImpacts(projects, preview.Resources)
+	if err != nil {
+		return err
+	}
+
+	result := preview
+	if !snapshotRestoreDryRunFlag {
+		result, err = manager.RestoreSnapshot(args[0], false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if format != output.Table {
+		return output.FormatOutput(snapshotRestoreOutput{
+			SnapshotRestoreResult: result,
+			DryRun:                snapshotRestoreDryRunFlag,
+			Projects:              impacts,
+		}, format)
+	}
+
+	label := result.Snapshot.Name + snapshotCommitSuffix(&result.Snapshot)
+	if len(result.Resources) == 0 && !result.SourcesChanged {
+		fmt.Printf("Repository already matches snapshot %s; nothing to restore.\n", label)
+		return nil
+	}
+
+	if snapshotRestoreDryRunFlag {
+		fmt.Printf("Restoring snapshot %s would change %d resource(s):\n", label, len(result.Resources))
+	} else {
+		fmt.Printf("✓ Restored snapshot %s, %d resource(s) changed:\n", label, len(result.Resources))
+	}
+	for _, ref := range result.Resources {
+		fmt.Printf("  - %s\n", ref)
+	}
+	if result.SourcesChanged {
+		fmt.Println("  - ai.repo.yaml (sources)")
+	}
+
+	if len(impacts) == 0 {
+		fmt.Println("\nNo installed resources in the checked project(s) are affected.")
+		return nil
+	}
+	fmt.Println("\nProject installs affected:")
+	for _, impact := range impacts {
+		fmt.Printf("  %s\n", impact.Project)
+		for _, ref := range impact.Resources {
+			fmt.Printf("    - %s\n", ref)
+		}
+	}
+	fmt.Println("\n  Run 'aimgr repair' in these projects to clean up resources the snapshot removed.")
+	return nil
+}
+
+// snapshotProjectImpacts returns, per project, the installed resources that
+// are in changed. Projects without tool directories are skipped.
+func snapshotProjectImpacts(projects []string, changed []string) ([]snapshotProjectImpact, error) {
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	var impacts []snapshotProjectImpact
+	for _, project := range projects {
+		projectPath, err := filepath.Abs(project)
+		if err != nil {
+			return nil, fmt.Errorf("invalid project path %s: %w", project, err)
+		}
+		detected, err := tools.DetectExistingTools(projectPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to detect tools in %s: %w", projectPath, err)
+		}
+		if len(detected) == 0 {
+			continue
+		}
+		installer, err := install.NewInstallerWithTargets(projectPath, detected)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create installer for %s: %w", projectPath, err)
+		}
+		installed, err := installer.List()
+		if err != nil {
+			return nil, fmt.Errorf("failed to list installed resources in %s: %w", projectPath, err)
+		}
+
+		impact := snapshotProjectImpact{Project: projectPath}
+		for _, res := range installed {
+			ref := fmt.Sprintf("%s/%s", res.Type, res.Name)
+			if slices.Contains(changed, ref) {
+				impact.Resources = append(impact.Resources, ref)
+			}
+		}
+		if len(impact.Resources) > 0 {
+			slices.Sort(impact.Resources)
+			impacts = append(impacts, impact)
+		}
+	}
+	return impacts, nil
+}
+
+func snapshotCommitSuffix(snapshot *repo.Snapshot) string {
+	if snapshot.Commit == "" {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", snapshot.Commit)
+}
+
+func completeSnapshotNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	snapshots, err := manager.ListSnapshots()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		names = append(names, snapshot.Name)
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	repoCmd.AddCommand(repoSnapshotCmd)
+	repoSnapshotCmd.AddCommand(repoSnapshotSaveCmd)
+	repoSnapshotCmd.AddCommand(repoSnapshotListCmd)
+	repoSnapshotCmd.AddCommand(repoSnapshotRestoreCmd)
+
+	repoSnapshotSaveCmd.Flags().StringVar(&snapshotSaveFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	_ = repoSnapshotSaveCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+
+	repoSnapshotListCmd.Flags().StringVar(&snapshotListFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	_ = repoSnapshotListCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+
+	repoSnapshotRestoreCmd.Flags().BoolVar(&snapshotRestoreDryRunFlag, "dry-run", false, "Preview what would change")
+	repoSnapshotRestoreCmd.Flags().StringArrayVar(&snapshotRestoreProjectFlag, "project", nil, "Project directory to check for affected installs (repeatable; default: current directory)")
+	repoSnapshotRestoreCmd.Flags().StringVar(&snapshotRestoreFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	_ = repoSnapshotRestoreCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+}
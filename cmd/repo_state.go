@@ -6,9 +6,11 @@ import (
 	"os"
 	"path/filepath"
 
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repolock"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/workspace"
 	"github.com/spf13/cobra"
 )
 
@@ -16,6 +18,43 @@ func repoManifestPath(repoPath string) string {
 	return filepath.Join(repoPath, repomanifest.ManifestFileName)
 }
 
+// findManifestSource finds the manifest source recorded in resource metadata,
+// preferring the source ID over the name.
+func findManifestSource(manifest *repomanifest.Manifest, sourceID, sourceName string) *repomanifest.Source {
+	for _, src := range manifest.Sources {
+		if sourceID != "" && src.ID == sourceID {
+			return src
+		}
+	}
+	for _, src := range manifest.Sources {
+		if sourceName != "" && src.Name == sourceName {
+			return src
+		}
+	}
+	return nil
+}
+
+// newWorkspaceManager creates a workspace cache manager for repoPath with the
+// credential providers configured in aimgr.yaml applied to git operations.
+func newWorkspaceManager(repoPath string) (*workspace.Manager, error) {
+	wsMgr, err := workspace.NewManager(repoPath)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load credential configuration: %w", err)
+	}
+	resolver, err := cfg.CredentialResolver()
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential configuration: %w", err)
+	}
+	wsMgr.SetCredentialResolver(resolver)
+
+	return wsMgr, nil
+}
+
 func repoPathExists(repoPath string) (bool, error) {
 	info, err := os.Stat(repoPath)
 	if err == nil {
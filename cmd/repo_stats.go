@@ -0,0 +1,349 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tokens"
+	"github.com/spf13/cobra"
+)
+
+var (
+	repoStatsFormatFlag      string
+	repoStatsTokensFlag      bool
+	repoStatsProjectFlag     bool
+	repoStatsProjectPathFlag string
+)
+
+// repoStatsOutput is the result of 'aimgr repo stats'.
+type repoStatsOutput struct {
+	Resources int            `json:"resources" yaml:"resources"`
+	Commands  int            `json:"commands" yaml:"commands"`
+	Skills    int            `json:"skills" yaml:"skills"`
+	Agents    int            `json:"agents" yaml:"agents"`
+	Packages  int            `json:"packages" yaml:"packages"`
+	BySource  map[string]int `json:"by_source" yaml:"by_source"`
+	Tokens    *tokenStats    `json:"tokens,omitempty" yaml:"tokens,omitempty"`
+}
+
+// tokenStats is the estimated token cost of the selected resources.
+type tokenStats struct {
+	Project   string           `json:"project,omitempty" yaml:"project,omitempty"`
+	Resources []resourceTokens `json:"resources" yaml:"resources"`
+	Total     resourceTokens   `json:"total" yaml:"total"`
+	Missing   []string         `json:"missing,omitempty" yaml:"missing,omitempty"`
+	Warnings  []string         `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+}
+
+type resourceTokens struct {
+	Resource         string `json:"resource,omitempty" yaml:"resource,omitempty"`
+	tokens.Breakdown `yaml:",inline"`
+}
+
+var repoStatsCmd = &cobra.Command{
+	Use:   "stats [pattern...]",
+	Short: "Show resource counts and estimated token costs",
+	Long: `Show resource counts by type and source, optionally with the estimated
+number of tokens each resource costs in a model's context.
+
+Token estimates (--tokens) are split by when the content is loaded:
+  DESCRIPTION  name and description, always in context so the tool can pick
+               the resource
+  BODY         the instructions, loaded when the resource is used
+  REFERENCES   other text files of a skill, read on demand (scripts and
+               assets are not counted)
+
+Estimates come from an offline approximation of current tokenizers and are
+typically within 15% of the real count.
+
+--project restricts the statistics to the resources declared in the
+project's ai.package.yaml (packages expanded) and reports what the project
+costs in context. Thresholds from the 'tokens' section of aimgr.yaml are
+reported as warnings.
+
+Examples:
+  aimgr repo stats
+  aimgr repo stats --tokens
+  aimgr repo stats --tokens 'skill/*'
+  aimgr repo stats --project
+  aimgr repo stats --project --project-path ~/src/app --format json`,
+	ValidArgsFunction: completeResourcesWithOptions(completionOptions{
+		multiArg: true,
+	}),
+	SilenceUsage: true,
+	RunE:         runRepoStats,
+}
+
+func init() {
+	repoCmd.AddCommand(repoStatsCmd)
+	repoStatsCmd.Flags().StringVar(&repoStatsFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	repoStatsCmd.Flags().BoolVar(&repoStatsTokensFlag, "tokens", false, "Estimate token costs per resource")
+	repoStatsCmd.Flags().BoolVar(&repoStatsProjectFlag, "project", false, "Only count the resources in the project's ai.package.yaml (implies --tokens)")
+	repoStatsCmd.Flags().StringVar(&repoStatsProjectPathFlag, "project-path", "", "Project directory path for --project (default: current directory)")
+	_ = repoStatsCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+}
+
+func runRepoStats(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(repoStatsFormatFlag)
+	if err != nil {
+		return err
+	}
+	if repoStatsProjectFlag && len(args) > 0 {
+		return fmt.Errorf("patterns cannot be combined with --project")
+	}
+
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return fmt.Errorf("failed to load config: %w", err)
+	}
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return err
+	}
+	if err := ensureRepoInitialized(manager); err != nil {
+		return operationalMissingManifestError(cmd, err)
+	}
+	repoLock, err := manager.AcquireRepoReadLock(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to acquire repository read lock at %s: %w", manager.RepoLockPath(), err)
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	var project string
+	var selected []resource.Resource
+	var missing []string
+	switch {
+	case repoStatsProjectFlag:
+		project = repoStatsProjectPathFlag
+		if project == "" {
+			if project, err = os.Getwd(); err != nil {
+				return fmt.Errorf("failed to get current directory: %w", err)
+			}
+		}
+		selected, missing, err = selectProjectResources(manager, project)
+	default:
+		selected, err = selectRepoResources(manager, args)
+	}
+	if err != nil {
+		return err
+	}
+
+	stats := &repoStatsOutput{BySource: make(map[string]int)}
+	for _, res := range selected {
+		switch res.Type {
+		case resource.Command:
+			stats.Commands++
+		case resource.Skill:
+			stats.Skills++
+		case resource.Agent:
+			stats.Agents++
+		}
+		source := "(unknown)"
+		if meta, err := manager.GetMetadata(res.Name, res.Type); err == nil && meta.SourceName != "" {
+			source = meta.SourceName
+		}
+		stats.BySource[source]++
+	}
+	stats.Resources = len(selected)
+	if len(args) == 0 && !repoStatsProjectFlag {
+		packages, err := manager.ListPackages()
+		if err != nil {
+			return fmt.Errorf("failed to list packages: %w", err)
+		}
+		stats.Packages = len(packages)
+	}
+
+	if repoStatsTokensFlag || repoStatsProjectFlag {
+		stats.Tokens, err = estimateTokenStats(manager, selected, cfg.Tokens)
+		if err != nil {
+			return err
+		}
+		stats.Tokens.Missing = missing
+		if repoStatsProjectFlag {
+			stats.Tokens.Project = project
+			stats.Tokens.Warnings = append(stats.Tokens.Warnings, projectTokenWarnings(stats.Tokens.Total, cfg.Tokens)...)
+		}
+	}
+
+	if format != output.Table {
+		return output.FormatOutput(stats, format)
+	}
+	displayRepoStats(stats)
+	return nil
+}
+
+// selectRepoResources returns the resources matching the patterns, or all
+// resources when there are none.
+func selectRepoResources(manager *repo.Manager, patterns []string) ([]resource.Resource, error) {
+	if len(patterns) == 0 {
+		resources, err := manager.List(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list resources: %w", err)
+		}
+		return resources, nil
+	}
+
+	var selected []resource.Resource
+	seen := make(map[string]bool)
+	for _, pat := range patterns {
+		matches, err := ExpandPattern(manager, pat)
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no resources found matching '%s'", pat)
+		}
+		for _, match := range matches {
+			resType, name, err := ParseResourceArg(match)
+			if err != nil {
+				return nil, err
+			}
+			if seen[match] || resType == resource.PackageType {
+				continue
+			}
+			seen[match] = true
+			res, err := manager.Get(name, resType)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load %s: %w", match, err)
+			}
+			selected = append(selected, *res)
+		}
+	}
+	return selected, nil
+}
+
+// selectProjectResources returns the resources declared in the project's
+// effective manifest, with packages expanded, and the references the
+// repository does not have.
+func selectProjectResources(manager *repo.Manager, projectPath string) ([]resource.Resource, []string, error) {
+	mf, _, err := loadEffectiveProjectManifest(projectPath)
+	if err != nil {
+		return nil, nil, err
+	}
+	if mf == nil {
+		return nil, nil, fmt.Errorf("no ai.package.yaml found in %s", projectPath)
+	}
+
+	refs, errs := expandManifestRefs(mf, manager.GetRepoPath())
+	var missing []string
+	for _, err := range errs {
+		missing = append(missing, err.Error())
+	}
+
+	var selected []resource.Resource
+	for _, ref := range refs {
+		resType, name, err := resource.ParseResourceReference(ref)
+		if err != nil {
+			missing = append(missing, fmt.Sprintf("%s: %v", ref, err))
+			continue
+		}
+		res, err := manager.Get(name, resType)
+		if err != nil {
+			missing = append(missing, fmt.Sprintf("%s: not in repository", ref))
+			continue
+		}
+		selected = append(selected, *res)
+	}
+	return selected, missing, nil
+}
+
+// estimateTokenStats estimates every resource, sorted by total cost, and
+// checks the per-resource budgets.
+func estimateTokenStats(manager *repo.Manager, resources []resource.Resource, budget config.TokensConfig) (*tokenStats, error) {
+	stats := &tokenStats{Resources: []resourceTokens{}}
+	var total tokens.Breakdown
+	for i := range resources {
+		res := &resources[i]
+		ref := FormatResourceArg(res)
+		estimate, err := estimateResourceTokens(res, manager.GetPath(res.Name, res.Type))
+		if err != nil {
+			return nil, err
+		}
+		stats.Resources = append(stats.Resources, resourceTokens{Resource: ref, Breakdown: estimate})
+		total = total.Add(estimate)
+
+		if budget.MaxDescription > 0 && estimate.Description > budget.MaxDescription {
+			stats.Warnings = append(stats.Warnings, fmt.Sprintf("%s: description is %d tokens (maxDescription %d)", ref, estimate.Description, budget.MaxDescription))
+		}
+		if budget.MaxResource > 0 && estimate.Total > budget.MaxResource {
+			stats.Warnings = append(stats.Warnings, fmt.Sprintf("%s: %d tokens in total (maxResource %d)", ref, estimate.Total, budget.MaxResource))
+		}
+	}
+	sort.SliceStable(stats.Resources, func(i, j int) bool {
+		if stats.Resources[i].Total != stats.Resources[j].Total {
+			return stats.Resources[i].Total > stats.Resources[j].Total
+		}
+		return stats.Resources[i].Resource < stats.Resources[j].Resource
+	})
+	stats.Total = resourceTokens{Breakdown: total}
+	return stats, nil
+}
+
+// projectTokenWarnings checks a project's totals against the project budgets.
+func projectTokenWarnings(total resourceTokens, budget config.TokensConfig) []string {
+	var warnings []string
+	if budget.MaxProjectDescriptions > 0 && total.Description > budget.MaxProjectDescriptions {
+		warnings = append(warnings, fmt.Sprintf("project descriptions are %d tokens (maxProjectDescriptions %d)", total.Description, budget.MaxProjectDescriptions))
+	}
+	if budget.MaxProjectTotal > 0 && total.Total > budget.MaxProjectTotal {
+		warnings = append(warnings, fmt.Sprintf("project is %d tokens in total (maxProjectTotal %d)", total.Total, budget.MaxProjectTotal))
+	}
+	return warnings
+}
+
+func displayRepoStats(stats *repoStatsOutput) {
+	if stats.Tokens != nil && stats.Tokens.Project != "" {
+		fmt.Printf("Project: %s\n", stats.Tokens.Project)
+	}
+	fmt.Printf("Resources: %d (%d commands, %d skills, %d agents)\n", stats.Resources, stats.Commands, stats.Skills, stats.Agents)
+	if stats.Packages > 0 {
+		fmt.Printf("Packages: %d\n", stats.Packages)
+	}
+
+	sources := make([]string, 0, len(stats.BySource))
+	for source := range stats.BySource {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	if len(sources) > 0 {
+		fmt.Println("By source:")
+		for _, source := range sources {
+			fmt.Printf("  %-30s %d\n", source, stats.BySource[source])
+		}
+	}
+
+	if stats.Tokens == nil {
+		return
+	}
+	ts := stats.Tokens
+
+	fmt.Println()
+	if len(ts.Resources) > 0 {
+		table := output.NewTable("RESOURCE", "DESCRIPTION", "BODY", "REFERENCES", "TOTAL")
+		table.WithResponsive().WithDynamicColumn(0).WithMinColumnWidths(20, 11, 6, 10, 7)
+		for _, r := range ts.Resources {
+			table.AddRow(r.Resource, strconv.Itoa(r.Description), strconv.Itoa(r.Body), strconv.Itoa(r.References), strconv.Itoa(r.Total))
+		}
+		table.AddRow("TOTAL", strconv.Itoa(ts.Total.Description), strconv.Itoa(ts.Total.Body), strconv.Itoa(ts.Total.References), strconv.Itoa(ts.Total.Total))
+		_ = table.Format(output.Table)
+		fmt.Println()
+	}
+	fmt.Printf("Always in context (descriptions): ~%d tokens\n", ts.Total.Description)
+	fmt.Printf("All resources fully loaded:       ~%d tokens\n", ts.Total.Total)
+
+	for _, m := range ts.Missing {
+		fmt.Printf("⚠ Warning: %s\n", m)
+	}
+	for _, w := range ts.Warnings {
+		fmt.Printf("⚠ Warning: %s\n", w)
+	}
+}
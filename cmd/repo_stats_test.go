@@ -0,0 +1,61 @@
+//go:build integration
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
+)
+
+func TestProjectTokenStats(t *testing.T) {
+	repoDir := t.TempDir()
+	manager := repo.NewManagerWithPath(repoDir)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("Failed to initialize repo: %v", err)
+	}
+
+	writeCommand := func(name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(repoDir, "commands", name+".md"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeCommand("review", "---\ndescription: Review the staged changes and list problems by severity\n---\nRead the diff.\n")
+	writeCommand("short", "---\ndescription: Short\n---\nShort body.\n")
+	writeCommand("unused", "---\ndescription: Not in the project\n---\nUnused.\n")
+
+	projectDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(projectDir, "ai.package.yaml"), []byte("resources:\n  - command/review\n  - command/short\n  - skill/missing\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	selected, missing, err := selectProjectResources(manager, projectDir)
+	if err != nil {
+		t.Fatalf("selectProjectResources() error = %v", err)
+	}
+	if len(selected) != 2 || len(missing) != 1 {
+		t.Fatalf("selected %d resources with %d missing, want 2 and 1 (missing: %v)", len(selected), len(missing), missing)
+	}
+
+	budget := config.TokensConfig{MaxDescription: 8, MaxProjectDescriptions: 5}
+	stats, err := estimateTokenStats(manager, selected, budget)
+	if err != nil {
+		t.Fatalf("estimateTokenStats() error = %v", err)
+	}
+	if stats.Resources[0].Resource != "command/review" {
+		t.Errorf("resources not sorted by total: %+v", stats.Resources)
+	}
+	if stats.Total.Total != stats.Resources[0].Total+stats.Resources[1].Total {
+		t.Errorf("total %d is not the sum of the resources", stats.Total.Total)
+	}
+	if len(stats.Warnings) != 1 {
+		t.Errorf("warnings = %v, want only the review description over maxDescription", stats.Warnings)
+	}
+	if warnings := projectTokenWarnings(stats.Total, budget); len(warnings) != 1 {
+		t.Errorf("projectTokenWarnings() = %v, want maxProjectDescriptions warning", warnings)
+	}
+}
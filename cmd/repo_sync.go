@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
@@ -11,6 +12,7 @@ import (
 	"time"
 
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/marketplace"
 	resmeta "github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/modifications"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
@@ -42,6 +44,12 @@ type sourceSyncResult struct {
 	RemovedCount int                         `json:"removed_count"`
 	Error        string                      `json:"error,omitempty"`
 	Failed       bool                        `json:"failed"`
+	// CredentialProvider names the configured credential provider used for a
+	// failed remote fetch (e.g. "env:GITLAB_TOKEN"). Empty when none matched.
+	CredentialProvider string `json:"credential_provider,omitempty"`
+	// Signer is the verified signer of the synced commit, e.g.
+	// "alice@example.com (ssh)", for sources with a verify setting.
+	Signer string `json:"signer,omitempty"`
 }
 
 // removedResource describes a resource that was removed during sync.
@@ -54,6 +62,22 @@ type removedResource struct {
 type workspaceManager interface {
 	GetOrClone(url string, ref string) (string, error)
 	Update(url string, ref string) error
+	CredentialProvider(url string) string
+}
+
+// remoteFetchError reports a clone/fetch failure for a remote source together
+// with the credential provider that was in effect for its URL.
+type remoteFetchError struct {
+	Provider string
+	Err      error
+}
+
+func (e *remoteFetchError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *remoteFetchError) Unwrap() error {
+	return e.Err
 }
 
 // syncSummary holds aggregate counts for the sync operation.
@@ -65,14 +89,19 @@ type syncSummary struct {
 	ResourcesUpdated int `json:"resources_updated"`
 	ResourcesRemoved int `json:"resources_removed"`
 	ResourcesFailed  int `json:"resources_failed"`
+	// ResourcesLocallyModified counts resources edited inside the repository
+	ResourcesLocallyModified int `json:"resources_locally_modified"`
+	// ResourcesQuarantined counts resources held for review (--review)
+	ResourcesQuarantined int `json:"resources_quarantined,omitempty"`
 }
 
 // syncOutput is the complete sync output, used for JSON/YAML formatting.
 type syncOutput struct {
-	Sources  []sourceSyncResult `json:"sources"`
-	Removed  []removedResource  `json:"removed"`
-	Summary  syncSummary        `json:"summary"`
-	Warnings []string           `json:"warnings,omitempty"`
+	Sources  []sourceSyncResult  `json:"sources"`
+	Removed  []removedResource   `json:"removed"`
+	Summary  syncSummary         `json:"summary"`
+	Warnings []string            `json:"warnings,omitempty"`
+	Cache    *workspace.GCResult `json:"cache,omitempty"`
 }
 
 type syncOutputMode struct {
@@ -84,6 +113,7 @@ type sourceResourceClaim struct {
 	canonicalSourceID string
 	sourceName        string
 	sourceLocation    string
+	priority          int
 }
 
 func (m syncOutputMode) human() bool {
@@ -221,6 +251,8 @@ var (
 	syncFormatFlag       string
 	syncForceFlag        bool
 	syncVerboseFlag      bool
+	syncLocalChangesFlag string
+	syncReviewFlag       bool
 )
 
 // syncCmd represents the sync command
@@ -239,11 +271,24 @@ and re-imports all resources from each source. This is useful for:
 By default, existing resources will be overwritten (force mode). Use --skip-existing
 to skip resources that already exist in the repository.
 
+Resources edited inside the repository since they were imported are detected
+and listed in the output. What happens to them is set with --local-changes
+(or repo.localChanges in aimgr.yaml):
+  keep-local     keep the edited copy and skip the upstream update (default)
+  take-upstream  replace the edited copy with upstream content
+  merge          three-way merge; markdown conflicts get conflict markers
+
 The ai.repo.yaml file is automatically maintained when you use "aimgr repo add".
 
 Include filters (set via "aimgr repo add --filter") are stored in ai.repo.yaml
 and respected during sync: only resources matching the include patterns are imported
 for that source. Sources without include filters import all resources.
+Exclude filters (set via "aimgr repo add --exclude") use the same pattern
+syntax and are applied after include to drop matching resources.
+
+Use --review to put new and changed resources in the quarantine instead of
+importing them; they are installable once approved with "aimgr repo review".
+Sources added with "aimgr repo add --review" are always synced this way.
 
 Use --prune to reconcile stale source-owned resources and packages after include,
 subpath, or discovery changes. Prune cleanup is source-aware and only targets
@@ -265,6 +310,10 @@ Example ai.repo.yaml:
       include:
         - skill/pdf-processing
         - skill/ocr*
+    - name: platform-skills
+      url: https://github.com/org/platform
+      exclude:
+        - skill/experimental-*
 
 Examples:
   # Sync all configured sources (overwrites existing)
@@ -279,6 +328,12 @@ Examples:
   # Reconcile stale source-owned resources/packages
   aimgr repo sync --prune
 
+  # Merge upstream changes into locally edited resources
+  aimgr repo sync --local-changes merge
+
+  # Hold new and changed resources for review instead of importing them
+  aimgr repo sync --review
+
   # Preview prune cleanup without changing the repository
   aimgr repo sync --dry-run --prune`,
 	RunE: runSync,
@@ -294,20 +349,49 @@ func init() {
 	syncCmd.Flags().BoolVar(&syncForceFlag, "force", false, "Overwrite existing resources (default: true)")
 	syncCmd.Flags().StringVar(&syncFormatFlag, "format", "table", "Output format: table, json, yaml")
 	syncCmd.Flags().BoolVarP(&syncVerboseFlag, "verbose", "v", false, "Show full per-resource tables (table format only)")
+	syncCmd.Flags().BoolVar(&syncReviewFlag, "review", false, "Hold new and changed resources in quarantine until approved with 'aimgr repo review'")
+	syncCmd.Flags().StringVar(&syncLocalChangesFlag, "local-changes", "", "Policy for locally edited resources: keep-local, take-upstream, merge (default from aimgr.yaml, else keep-local)")
 	_ = syncCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+	_ = syncCmd.RegisterFlagCompletionFunc("local-changes", cobra.FixedCompletions(
+		[]string{config.LocalChangesKeepLocal, config.LocalChangesTakeUpstream, config.LocalChangesMerge},
+		cobra.ShellCompDirectiveNoFileComp,
+	))
+}
+
+// resolveSyncLocalChangesPolicy returns the --local-changes policy, falling
+// back to repo.localChanges in aimgr.yaml.
+func resolveSyncLocalChangesPolicy() (string, error) {
+	if syncLocalChangesFlag != "" {
+		if err := config.ValidateLocalChangesPolicy(syncLocalChangesFlag); err != nil {
+			return "", fmt.Errorf("--local-changes: %w", err)
+		}
+		return syncLocalChangesFlag, nil
+	}
+
+	cfg, err := config.LoadGlobal()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+	return cfg.Repo.LocalChangesPolicy(), nil
 }
 
 // scanSourceResources scans a source directory and returns the set of
 // resource names it contains, keyed by type.
 // This uses the same discovery functions as importFromLocalPathWithMode
 // to ensure consistent resource detection.
-func scanSourceResources(sourcePath, discoveryMode string) (map[resource.ResourceType]map[string]bool, error) {
+// An error is returned when a remote marketplace plugin could not be fetched,
+// since the scan would otherwise under-report the source's resources.
+func scanSourceResources(sourcePath, discoveryMode string, fetcher marketplace.PluginFetcher) (map[resource.ResourceType]map[string]bool, error) {
 	result := make(map[resource.ResourceType]map[string]bool)
 
-	discovered, err := discoverImportResourcesByMode(sourcePath, discoveryMode)
+	discovered, err := discoverImportResourcesByMode(sourcePath, discoveryMode, fetcher)
 	if err != nil {
 		return nil, err
 	}
+	if discovered.remotePluginsUnresolved() {
+		return nil, fmt.Errorf("remote marketplace plugins could not be fetched: %s",
+			strings.Join(skippedPluginWarnings(discovered.skippedPlugins), "; "))
+	}
 
 	commands := discovered.commands
 	if len(commands) > 0 {
@@ -373,44 +457,87 @@ func scanSourceResources(sourcePath, discoveryMode string) (map[resource.Resourc
 }
 
 func resolveSourcePathForSync(src *repomanifest.Source, manager *repo.Manager) (string, error) {
+	sourcePath, _, err := resolveVerifiedSourcePath(src, manager)
+	return sourcePath, err
+}
+
+// resolveVerifiedSourcePath resolves a source like resolveSourcePathForSync
+// and, for remote sources with a verify setting, checks the signature of the
+// checked-out commit before anything is discovered in it. The signature is
+// nil for sources without a verify setting.
+func resolveVerifiedSourcePath(src *repomanifest.Source, manager *repo.Manager) (string, *workspace.Signature, error) {
 	if src.URL != "" {
 		repoPath := manager.GetRepoPath()
-		wsMgr, err := workspace.NewManager(repoPath)
+		wsMgr, err := newWorkspaceManager(repoPath)
 		if err != nil {
-			return "", fmt.Errorf("failed to create workspace manager: %w", err)
+			return "", nil, fmt.Errorf("failed to create workspace manager: %w", err)
 		}
 
 		parsed, err := parsedRemoteSourceForManifestEntry(src)
 		if err != nil {
-			return "", fmt.Errorf("invalid source URL: %w", err)
+			return "", nil, fmt.Errorf("invalid source URL: %w", err)
 		}
 
 		cloneURL, err := source.GetCloneURL(parsed)
 		if err != nil {
-			return "", fmt.Errorf("failed to get clone URL: %w", err)
+			return "", nil, fmt.Errorf("failed to get clone URL: %w", err)
 		}
 
 		sourcePath, err := prepareRemoteSourcePath(wsMgr, cloneURL, parsed.Ref)
 		if err != nil {
-			return "", err
+			return "", nil, err
+		}
+
+		var signature *workspace.Signature
+		if src.Verify != nil {
+			signature, err = wsMgr.VerifyCommit(cloneURL, src.Verify.SignersPath(repoPath))
+			if err != nil {
+				return "", nil, fmt.Errorf("refusing to sync source %q: %w", src.Name, err)
+			}
 		}
 
 		if parsed.Subpath != "" {
 			sourcePath = filepath.Join(sourcePath, parsed.Subpath)
 		}
 
-		return sourcePath, nil
+		return sourcePath, signature, nil
 	}
 
 	if src.Path != "" {
 		absPath, err := filepath.Abs(src.Path)
 		if err != nil {
-			return "", fmt.Errorf("invalid path %s: %w", src.Path, err)
+			return "", nil, fmt.Errorf("invalid path %s: %w", src.Path, err)
 		}
-		return absPath, nil
+		return absPath, nil, nil
+	}
+
+	return "", nil, fmt.Errorf("source must have either URL or Path")
+}
+
+// newSourcePluginFetcher returns the fetcher for the remote marketplace
+// plugins of src. Plugins of a source with a verify setting must be signed by
+// the same signers as the source itself; unverified plugins are skipped.
+func newSourcePluginFetcher(repoPath string, src *repomanifest.Source) *workspacePluginFetcher {
+	fetcher := newPluginFetcher(repoPath)
+	if src.Verify != nil {
+		fetcher.signers = src.Verify.SignersPath(repoPath)
 	}
+	return fetcher
+}
+
+// sourcePluginFetchers holds one plugin fetcher per source name, so a sync
+// resolves each remote marketplace plugin once for the collision precheck,
+// the import and removal detection.
+type sourcePluginFetchers map[string]*workspacePluginFetcher
 
-	return "", fmt.Errorf("source must have either URL or Path")
+// get returns the fetcher of src, creating it on first use.
+func (f sourcePluginFetchers) get(repoPath string, src *repomanifest.Source) *workspacePluginFetcher {
+	fetcher, ok := f[src.Name]
+	if !ok {
+		fetcher = newSourcePluginFetcher(repoPath, src)
+		f[src.Name] = fetcher
+	}
+	return fetcher
 }
 
 func parsedRemoteSourceForManifestEntry(src *repomanifest.Source) (*source.ParsedSource, error) {
@@ -435,20 +562,38 @@ func parsedRemoteSourceForManifestEntry(src *repomanifest.Source) (*source.Parse
 	return parsed, nil
 }
 
-func applyIncludeFilterToDiscovered(sourceResources map[resource.ResourceType]map[string]bool, include []string) error {
-	if len(include) == 0 {
+// applyIncludeFilterToDiscovered narrows discovered source resources to the
+// ones a source imports: resources must match an include pattern (when any are
+// set) and must not match an exclude pattern.
+func applyIncludeFilterToDiscovered(sourceResources map[resource.ResourceType]map[string]bool, include, exclude []string) error {
+	if len(include) == 0 && len(exclude) == 0 {
 		return nil
 	}
 
-	mm, err := pattern.NewMultiMatcher(include)
-	if err != nil {
-		return fmt.Errorf("invalid include patterns: %w", err)
+	var includeMatcher, excludeMatcher *pattern.MultiMatcher
+	if len(include) > 0 {
+		mm, err := pattern.NewMultiMatcher(include)
+		if err != nil {
+			return fmt.Errorf("invalid include patterns: %w", err)
+		}
+		includeMatcher = mm
+	}
+	if len(exclude) > 0 {
+		mm, err := pattern.NewMultiMatcher(exclude)
+		if err != nil {
+			return fmt.Errorf("invalid exclude patterns: %w", err)
+		}
+		excludeMatcher = mm
 	}
 
 	for resType, typeSet := range sourceResources {
 		for name := range typeSet {
 			res := &resource.Resource{Type: resType, Name: name}
-			if !mm.Match(res) {
+			if includeMatcher != nil && !includeMatcher.Match(res) {
+				delete(typeSet, name)
+				continue
+			}
+			if excludeMatcher != nil && excludeMatcher.Match(res) {
 				delete(typeSet, name)
 			}
 		}
@@ -555,13 +700,20 @@ func sourceLocationSummary(src *repomanifest.Source) string {
 	return "unknown location"
 }
 
-func detectSyncResourceCollisions(manifest *repomanifest.Manifest, manager *repo.Manager) error {
+// sourceShadows maps a source name to the resource references it provides that
+// are shadowed by a higher-priority source (reference -> winning source name).
+type sourceShadows map[string]map[string]string
+
+// detectSyncResourceCollisions checks whether different sources provide the same
+// resource. When the sources have different priorities the higher one wins and
+// the others are shadowed for that resource; equal priorities reject the sync.
+func detectSyncResourceCollisions(manifest *repomanifest.Manifest, manager *repo.Manager, fetchers sourcePluginFetchers) (sourceShadows, error) {
 	if manifest == nil || len(manifest.Sources) == 0 {
-		return nil
+		return nil, nil
 	}
 
-	claims := make(map[string]sourceResourceClaim)
-	conflicts := make([]string, 0)
+	claims := make(map[string][]sourceResourceClaim)
+	refs := make([]string, 0)
 
 	for _, src := range manifest.Sources {
 		sourcePath, err := resolveSourcePathForSync(src, manager)
@@ -570,74 +722,121 @@ func detectSyncResourceCollisions(manifest *repomanifest.Manifest, manager *repo
 			continue
 		}
 
-		sourceResources, err := scanSourceResources(sourcePath, src.Discovery)
+		sourceResources, err := scanSourceResources(sourcePath, src.Discovery, fetchers.get(manager.GetRepoPath(), src))
 		if err != nil {
 			continue
 		}
 
-		if err := applyIncludeFilterToDiscovered(sourceResources, src.Include); err != nil {
-			return fmt.Errorf("source %q has invalid include filters for sync collision precheck: %w", src.Name, err)
+		if err := applyIncludeFilterToDiscovered(sourceResources, src.Include, src.Exclude); err != nil {
+			return nil, fmt.Errorf("source %q has invalid include/exclude filters for sync collision precheck: %w", src.Name, err)
 		}
 
-		currentSourceID := canonicalSourceID(src)
-		currentLocation := sourceLocationSummary(src)
+		claim := sourceResourceClaim{
+			canonicalSourceID: canonicalSourceID(src),
+			sourceName:        src.Name,
+			sourceLocation:    sourceLocationSummary(src),
+			priority:          src.Priority,
+		}
 
 		for resType, typeSet := range sourceResources {
 			for name := range typeSet {
 				resourceRef := fmt.Sprintf("%s/%s", resType, name)
-				if claim, exists := claims[resourceRef]; exists {
-					if claim.canonicalSourceID != currentSourceID {
-						conflicts = append(conflicts, fmt.Sprintf(
-							"%s provided by source %q (%s) and source %q (%s)",
-							resourceRef,
-							claim.sourceName,
-							claim.sourceLocation,
-							src.Name,
-							currentLocation,
-						))
+				existing := claims[resourceRef]
+				duplicate := false
+				for _, c := range existing {
+					if c.canonicalSourceID == claim.canonicalSourceID {
+						duplicate = true
+						break
 					}
+				}
+				if duplicate {
 					continue
 				}
-
-				claims[resourceRef] = sourceResourceClaim{
-					canonicalSourceID: currentSourceID,
-					sourceName:        src.Name,
-					sourceLocation:    currentLocation,
+				if len(existing) == 0 {
+					refs = append(refs, resourceRef)
 				}
+				claims[resourceRef] = append(existing, claim)
+			}
+		}
+	}
+
+	shadows := make(sourceShadows)
+	conflicts := make([]string, 0)
+
+	for _, resourceRef := range refs {
+		resourceClaims := claims[resourceRef]
+		if len(resourceClaims) < 2 {
+			continue
+		}
+
+		// Highest priority wins; equal top priorities are rejected below.
+		sort.SliceStable(resourceClaims, func(i, j int) bool {
+			return resourceClaims[i].priority > resourceClaims[j].priority
+		})
+
+		winner := resourceClaims[0]
+		if resourceClaims[1].priority == winner.priority {
+			conflicts = append(conflicts, fmt.Sprintf(
+				"%s provided by source %q (%s) and source %q (%s)",
+				resourceRef,
+				winner.sourceName,
+				winner.sourceLocation,
+				resourceClaims[1].sourceName,
+				resourceClaims[1].sourceLocation,
+			))
+			continue
+		}
+
+		for _, loser := range resourceClaims[1:] {
+			if shadows[loser.sourceName] == nil {
+				shadows[loser.sourceName] = make(map[string]string)
 			}
+			shadows[loser.sourceName][resourceRef] = winner.sourceName
 		}
 	}
 
 	if len(conflicts) == 0 {
-		return nil
+		return shadows, nil
 	}
 
 	sort.Strings(conflicts)
-	return fmt.Errorf("sync rejected: conflicting resource names across different sources:\n  - %s\nResolve by renaming one resource, narrowing include filters, or removing one of the conflicting sources", strings.Join(conflicts, "\n  - "))
+	return nil, fmt.Errorf("sync rejected: conflicting resource names across different sources:\n  - %s\nResolve by setting a higher 'priority' on the preferred source in ai.repo.yaml, renaming one resource, narrowing include filters, or removing one of the conflicting sources", strings.Join(conflicts, "\n  - "))
 }
 
 func prepareRemoteSourcePath(wsMgr workspaceManager, cloneURL string, ref string) (string, error) {
 	sourcePath, err := wsMgr.GetOrClone(cloneURL, ref)
 	if err != nil {
-		return "", fmt.Errorf("failed to download repository: %w", err)
+		return "", &remoteFetchError{
+			Provider: wsMgr.CredentialProvider(cloneURL),
+			Err:      fmt.Errorf("failed to download repository: %w", err),
+		}
 	}
 
 	// Remote sync must refresh an existing cache before importing resources.
 	// Unlike repo add, sync should not silently proceed from stale cached content.
 	if err := wsMgr.Update(cloneURL, ref); err != nil {
-		return "", fmt.Errorf("failed to update cached repository: %w", err)
+		return "", &remoteFetchError{
+			Provider: wsMgr.CredentialProvider(cloneURL),
+			Err:      fmt.Errorf("failed to update cached repository: %w", err),
+		}
 	}
 
 	return sourcePath, nil
 }
 
 // syncSource syncs resources from a single manifest source.
-// Returns the resolved source path (for use in post-sync scanning), the bulk result, and any error.
+// Returns the resolved source path (for use in post-sync scanning), the bulk
+// result, the verified commit signature (nil unless the source has a verify
+// setting), and any error.
 // When syncSilentMode is true, "Mode: Remote/Local" lines are suppressed.
-func syncSource(src *repomanifest.Source, manager *repo.Manager) (string, *output.BulkOperationResult, error) {
-	sourcePath, err := resolveSourcePathForSync(src, manager)
+func syncSource(src *repomanifest.Source, manager *repo.Manager, opts importOptions, fetcher *workspacePluginFetcher) (string, *output.BulkOperationResult, *workspace.Signature, error) {
+	if err := checkSourcePolicy(sourcePolicyLocation(src.URL, src.Path)); err != nil {
+		return "", nil, nil, err
+	}
+
+	sourcePath, signature, err := resolveVerifiedSourcePath(src, manager)
 	if err != nil {
-		return "", nil, err
+		return "", nil, nil, err
 	}
 
 	var mode string
@@ -655,12 +854,13 @@ func syncSource(src *repomanifest.Source, manager *repo.Manager) (string, *outpu
 		}
 		mode = src.GetMode() // Use mode from source (implicit: path=symlink, url=copy)
 	} else {
-		return "", nil, fmt.Errorf("source must have either URL or Path")
+		return "", nil, nil, fmt.Errorf("source must have either URL or Path")
 	}
 
 	// Import from source path with appropriate mode
 	// Pass src.Include as the filter: only matching resources will be imported.
 	// Empty include (nil/[]) means import everything (backward compatible).
+	// src.Exclude then drops matching resources from that set.
 	var sourceURL string
 	var sourceType string
 	if src.URL != "" {
@@ -670,11 +870,12 @@ func syncSource(src *repomanifest.Source, manager *repo.Manager) (string, *outpu
 		sourceURL = "file://" + sourcePath
 		sourceType = string(source.Local)
 	}
-	bulkResult, err := importFromLocalPathWithMode(sourcePath, manager, src.Include, sourceURL, sourceType, src.Ref, mode, src.Discovery, src.Name, src.ID)
+	opts.exclude = src.Exclude
+	bulkResult, err := importFromLocalPathWithFetcher(sourcePath, manager, src.Include, sourceURL, sourceType, src.Ref, mode, src.Discovery, src.Name, src.ID, fetcher, opts)
 	if err != nil {
-		return "", bulkResult, err
+		return "", bulkResult, nil, err
 	}
-	return sourcePath, bulkResult, nil
+	return sourcePath, bulkResult, signature, nil
 }
 
 // syncResult tracks the outcome of processing all sources.
@@ -693,7 +894,16 @@ type syncRunState struct {
 	repoPath           string
 	sourceDisplayNames map[string]string
 	preSyncResources   map[string][]resourceInfo
+	shadows            sourceShadows
+	fetchers           sourcePluginFetchers
 	warnings           []string
+	// localChanges and review apply to every source of the run; see
+	// importOptions.
+	localChanges string
+	review       bool
+	// fetchFailuresRecorded is set when remote fetch failures were written to
+	// source metadata and must be persisted even if no source synced.
+	fetchFailuresRecorded bool
 }
 
 func applySyncDryRunOverride(overrideDryRun bool) func() {
@@ -762,7 +972,9 @@ func prepareSyncRunState(manager *repo.Manager) (*syncRunState, error) {
 	if err != nil {
 		return nil, newOperationalFailureError(fmt.Errorf("failed to load manifest: %w", err))
 	}
-	if err := detectSyncResourceCollisions(manifest, manager); err != nil {
+	fetchers := make(sourcePluginFetchers)
+	shadows, err := detectSyncResourceCollisions(manifest, manager, fetchers)
+	if err != nil {
 		return nil, newOperationalFailureError(err)
 	}
 	if len(manifest.Sources) == 0 {
@@ -785,6 +997,8 @@ func prepareSyncRunState(manager *repo.Manager) (*syncRunState, error) {
 		repoPath:           repoPath,
 		sourceDisplayNames: buildSourceDisplayNames(manifest.Sources),
 		preSyncResources:   preSyncResources,
+		shadows:            shadows,
+		fetchers:           fetchers,
 		warnings:           warnings,
 	}, nil
 }
@@ -847,6 +1061,7 @@ func updateSourceMetadataAfterSync(metadata *sourcemetadata.SourceMetadata, src
 		if canonicalID != "" {
 			state.SourceID = canonicalID
 		}
+		state.ClearFetchFailure()
 		return
 	}
 
@@ -872,9 +1087,21 @@ func syncManifestSources(state *syncRunState, manager *repo.Manager) ([]sourceSy
 			fmt.Printf("  Syncing %s (%s)...\n", sr.Name, sr.Mode)
 		}
 
-		sourcePath, bulkResult, syncErr := syncSource(src, manager)
+		sourcePath, bulkResult, signature, syncErr := syncSource(src, manager, importOptions{
+			shadowed:     state.shadows[src.Name],
+			localChanges: state.localChanges,
+			review:       state.review || src.Review,
+		}, state.fetchers.get(state.repoPath, src))
 		sr.Result = bulkResult
 		if syncErr != nil {
+			var fetchErr *remoteFetchError
+			if errors.As(syncErr, &fetchErr) {
+				sr.CredentialProvider = fetchErr.Provider
+				if !syncDryRunFlag {
+					state.metadata.SetFetchFailure(src.Name, fetchErr.Err.Error(), fetchErr.Provider)
+					state.fetchFailuresRecorded = true
+				}
+			}
 			sr.Failed = true
 			sr.Error = syncErr.Error()
 			internalResult.sourcesFailed++
@@ -883,12 +1110,18 @@ func syncManifestSources(state *syncRunState, manager *repo.Manager) ([]sourceSy
 			continue
 		}
 
+		if bulkResult != nil {
+			for _, warning := range bulkResult.Warnings {
+				warnings = append(warnings, fmt.Sprintf("source %s: %s", src.Name, warning))
+			}
+		}
+
 		sourceKey := canonicalSourceID(src)
 		if sourceKey == "" {
 			sourceKey = src.Name
 		}
 		if syncPruneFlag {
-			removed, detectWarnings := detectRemovedForSource(src, sourcePath, state.repoPath, state.preSyncResources)
+			removed, detectWarnings := detectRemovedForSource(src, sourcePath, state.repoPath, state.preSyncResources, state.fetchers.get(state.repoPath, src))
 			if len(removed) > 0 {
 				internalResult.removedResources[sourceKey] = removed
 				sr.RemovedCount = len(removed)
@@ -896,8 +1129,18 @@ func syncManifestSources(state *syncRunState, manager *repo.Manager) ([]sourceSy
 			warnings = append(warnings, detectWarnings...)
 		}
 
+		if signature != nil {
+			sr.Signer = signature.String()
+		}
+
 		if !syncDryRunFlag {
 			updateSourceMetadataAfterSync(state.metadata, src)
+			state.metadata.SetShadowed(src.Name, state.shadows[src.Name])
+			if signature != nil {
+				state.metadata.SetVerifiedSignature(src.Name, signature.Signer, signature.Format, signature.Commit)
+			} else {
+				state.metadata.SetVerifiedSignature(src.Name, "", "", "")
+			}
 		}
 
 		internalResult.sourcesProcessed++
@@ -926,6 +1169,8 @@ func buildSyncOutput(sourceResults []sourceSyncResult, removed []removedResource
 		summary.ResourcesAdded += len(sr.Result.Added)
 		summary.ResourcesUpdated += len(sr.Result.Updated)
 		summary.ResourcesFailed += len(sr.Result.Failed)
+		summary.ResourcesLocallyModified += len(sr.Result.LocalChanges)
+		summary.ResourcesQuarantined += len(sr.Result.Quarantined)
 	}
 
 	so := &syncOutput{
@@ -955,7 +1200,7 @@ func syncCompletionError(failedSources, totalSources int) error {
 // detectRemovedForSource compares a source's pre-sync resource inventory with the
 // current source contents to identify resources that were removed from the source.
 func detectRemovedForSource(src *repomanifest.Source, sourcePath, repoPath string,
-	preSyncResources map[string][]resourceInfo) ([]resourceInfo, []string) {
+	preSyncResources map[string][]resourceInfo, fetcher *workspacePluginFetcher) ([]resourceInfo, []string) {
 
 	sourceKey := canonicalSourceID(src)
 	if sourceKey == "" {
@@ -967,36 +1212,19 @@ func detectRemovedForSource(src *repomanifest.Source, sourcePath, repoPath strin
 		return nil, nil
 	}
 
-	sourceResources, scanErr := scanSourceResources(sourcePath, src.Discovery)
+	sourceResources, scanErr := scanSourceResources(sourcePath, src.Discovery, fetcher)
 	if scanErr != nil {
 		return nil, []string{fmt.Sprintf("could not scan source %s for removal detection: %v", src.Name, scanErr)}
 	}
 
-	// Apply include filter to source resources: if include patterns are set, only
-	// resources matching the patterns are considered "present in source". Resources
-	// that exist in the source but are excluded by include are treated as absent —
-	// this ensures that removing an entry from include and re-syncing removes the
-	// previously imported resource (orphan detection for filter changes).
-	if len(src.Include) > 0 {
-		mm, err := pattern.NewMultiMatcher(src.Include)
-		if err != nil {
-			slog.Warn("invalid include patterns in source, skipping include filter for orphan detection",
-				"source", src.Name, "error", err)
-		}
-		if err == nil {
-			for resType, typeSet := range sourceResources {
-				for name := range typeSet {
-					res := &resource.Resource{Type: resType, Name: name}
-					if !mm.Match(res) {
-						delete(typeSet, name)
-					}
-				}
-				// Remove empty type sets to keep the map clean
-				if len(typeSet) == 0 {
-					delete(sourceResources, resType)
-				}
-			}
-		}
+	// Apply include/exclude filters to source resources: only resources the
+	// source would import are considered "present in source". Resources that
+	// exist in the source but are filtered out are treated as absent — this
+	// ensures that narrowing include (or adding an exclude) and re-syncing
+	// removes the previously imported resource (orphan detection for filter changes).
+	if err := applyIncludeFilterToDiscovered(sourceResources, src.Include, src.Exclude); err != nil {
+		slog.Warn("invalid include/exclude patterns in source, skipping filters for orphan detection",
+			"source", src.Name, "error", err)
 	}
 
 	var removed []resourceInfo
@@ -1181,6 +1409,24 @@ func syncRegenerateModifications(manager *repo.Manager, repoPath string) {
 	}
 }
 
+// syncCacheGC applies the repo.cache budget after a sync. It returns nil when
+// no budget is configured; failures are reported as warnings.
+func syncCacheGC(manager *repo.Manager) (*workspace.GCResult, []string) {
+	cfg, err := config.LoadGlobal()
+	if err != nil || !cfg.Repo.Cache.Enabled() {
+		return nil, nil
+	}
+	result, err := runCacheGC(manager, cfg.Repo.Cache, false)
+	if err != nil {
+		return nil, []string{fmt.Sprintf("cache gc: %v", err)}
+	}
+	var warnings []string
+	for _, failure := range result.Failed {
+		warnings = append(warnings, fmt.Sprintf("cache gc: failed to evict %s", failure))
+	}
+	return result, warnings
+}
+
 // syncSaveMetadata saves updated source metadata and commits the changes.
 func syncSaveMetadata(manager *repo.Manager, metadata *sourcemetadata.SourceMetadata) []string {
 	if err := metadata.Save(manager.GetRepoPath()); err != nil {
@@ -1204,6 +1450,9 @@ func printSyncOutputTable(so *syncOutput, verbose bool) {
 		modeLabel := src.Mode
 		if src.Failed {
 			fmt.Printf("  ✗ %-30s — error: %s\n", fmt.Sprintf("%s (%s)", src.Name, modeLabel), src.Error)
+			if src.CredentialProvider != "" {
+				fmt.Printf("    credentials: %s\n", src.CredentialProvider)
+			}
 		} else {
 			var added, updated int
 			if src.Result != nil {
@@ -1218,6 +1467,12 @@ func printSyncOutputTable(so *syncOutput, verbose bool) {
 			if src.RemovedCount > 0 {
 				counts = append(counts, fmt.Sprintf("%d removed", src.RemovedCount))
 			}
+			if src.Result != nil && len(src.Result.LocalChanges) > 0 {
+				counts = append(counts, fmt.Sprintf("%d locally modified", len(src.Result.LocalChanges)))
+			}
+			if src.Result != nil && len(src.Result.Quarantined) > 0 {
+				counts = append(counts, fmt.Sprintf("%d quarantined", len(src.Result.Quarantined)))
+			}
 
 			fmt.Printf("  ✓ %-30s — %s\n",
 				fmt.Sprintf("%s (%s)", src.Name, modeLabel), strings.Join(counts, ", "))
@@ -1247,6 +1502,24 @@ func printSyncOutputTable(so *syncOutput, verbose bool) {
 		}
 		fmt.Printf("  %d source(s) failed: %s\n", so.Summary.SourcesFailed, strings.Join(failedNames, ", "))
 	}
+	if so.Summary.ResourcesLocallyModified > 0 {
+		fmt.Printf("  locally modified (%d):\n", so.Summary.ResourcesLocallyModified)
+		for _, src := range so.Sources {
+			if src.Result == nil {
+				continue
+			}
+			for _, change := range src.Result.LocalChanges {
+				fmt.Printf("    - %s/%s: %s\n", change.Type, change.Name, change.Describe())
+			}
+		}
+	}
+	if so.Summary.ResourcesQuarantined > 0 {
+		fmt.Printf("  quarantined: %d (run 'aimgr repo review' to approve or reject)\n", so.Summary.ResourcesQuarantined)
+	}
+	if so.Cache != nil && so.Cache.EvictCount > 0 {
+		fmt.Printf("  cache gc: evicted %d cache(s), freed %s (%s remaining)\n",
+			so.Cache.EvictCount, formatSize(so.Cache.EvictBytes), formatSize(so.Cache.RemainBytes))
+	}
 	if len(so.Warnings) > 0 {
 		if verbose {
 			fmt.Printf("  warnings (%d):\n", len(so.Warnings))
@@ -1300,6 +1573,11 @@ func runSyncWithManager(cmd *cobra.Command, manager *repo.Manager, lockAlreadyHe
 		return newOperationalFailureError(err)
 	}
 
+	localChangesPolicy, err := resolveSyncLocalChangesPolicy()
+	if err != nil {
+		return err
+	}
+
 	repoLock, err := acquireSyncRunLock(cmd, manager, lockAlreadyHeld)
 	if err != nil {
 		return err
@@ -1314,6 +1592,8 @@ func runSyncWithManager(cmd *cobra.Command, manager *repo.Manager, lockAlreadyHe
 	if err != nil {
 		return err
 	}
+	state.localChanges = localChangesPolicy
+	state.review = syncReviewFlag
 	printSyncStart(state)
 
 	restoreFlags := applySyncOperationFlags()
@@ -1322,7 +1602,7 @@ func runSyncWithManager(cmd *cobra.Command, manager *repo.Manager, lockAlreadyHe
 	sourceResults, internalResult, sourceWarnings := syncManifestSources(state, manager)
 	state.warnings = append(state.warnings, sourceWarnings...)
 
-	if !syncDryRunFlag && internalResult.sourcesProcessed > 0 {
+	if !syncDryRunFlag && (internalResult.sourcesProcessed > 0 || state.fetchFailuresRecorded) {
 		state.warnings = append(state.warnings, syncSaveMetadata(manager, state.metadata)...)
 	}
 
@@ -1333,7 +1613,15 @@ func runSyncWithManager(cmd *cobra.Command, manager *repo.Manager, lockAlreadyHe
 		state.warnings = append(state.warnings, removalWarnings...)
 	}
 
+	var cacheResult *workspace.GCResult
+	if !syncDryRunFlag {
+		var cacheWarnings []string
+		cacheResult, cacheWarnings = syncCacheGC(manager)
+		state.warnings = append(state.warnings, cacheWarnings...)
+	}
+
 	so := buildSyncOutput(sourceResults, removed, state.warnings, len(state.manifest.Sources))
+	so.Cache = cacheResult
 	if err := renderSyncOutput(so, state.format, syncVerboseFlag); err != nil {
 		return newOperationalFailureError(err)
 	}
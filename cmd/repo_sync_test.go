@@ -13,6 +13,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/marketplace"
 	resmeta "github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
@@ -30,6 +31,7 @@ type fakeWorkspaceManager struct {
 	updateErr     error
 	gotCloneURL   string
 	gotRef        string
+	provider      string
 }
 
 func (f *fakeWorkspaceManager) GetOrClone(url string, ref string) (string, error) {
@@ -49,6 +51,10 @@ func (f *fakeWorkspaceManager) Update(url string, ref string) error {
 	return f.updateErr
 }
 
+func (f *fakeWorkspaceManager) CredentialProvider(url string) string {
+	return f.provider
+}
+
 func runGit(t *testing.T, dir string, args ...string) {
 	t.Helper()
 
@@ -513,6 +519,43 @@ func TestRunSync_SingleSource(t *testing.T) {
 	verifySourceSynced(t, repoPath, "test-source-1")
 }
 
+// TestRunSync_SourceReviewQuarantines tests that a source marked for review
+// has its resources quarantined without the --review flag
+func TestRunSync_SourceReviewQuarantines(t *testing.T) {
+	source1 := createTestSource(t)
+
+	sources := []*repomanifest.Source{
+		{
+			Name:   "reviewed-source",
+			Path:   source1,
+			Review: true,
+		},
+	}
+	repoPath, cleanup := setupTestManifest(t, sources)
+	defer cleanup()
+
+	if err := runSync(syncCmd, []string{}); err != nil {
+		t.Fatalf("sync command failed: %v", err)
+	}
+
+	verifyResourcesNotInRepo(t, repoPath, resource.Command, "sync-test-cmd")
+	verifyResourcesNotInRepo(t, repoPath, resource.Skill, "sync-test-skill")
+
+	entries, err := repo.NewManagerWithPath(repoPath).ListQuarantine()
+	if err != nil {
+		t.Fatalf("ListQuarantine() error = %v", err)
+	}
+	held := map[string]bool{}
+	for _, entry := range entries {
+		held[entry.ResourceRef()] = true
+	}
+	for _, ref := range []string{"command/sync-test-cmd", "skill/sync-test-skill"} {
+		if !held[ref] {
+			t.Errorf("expected %s in quarantine, got %v", ref, held)
+		}
+	}
+}
+
 // TestRunSync_MultipleSources tests syncing from multiple sources
 func TestRunSync_MultipleSources(t *testing.T) {
 	source1 := createTestSource(t)
@@ -1020,6 +1063,36 @@ func TestRunSync_WithIncludeFilter(t *testing.T) {
 	verifyResourcesNotInRepo(t, repoPath, resource.Agent, "sync-test-agent", "code-reviewer")
 }
 
+// TestRunSync_WithExcludeFilter verifies that exclude patterns are applied
+// after include and drop matching resources from the import.
+func TestRunSync_WithExcludeFilter(t *testing.T) {
+	sourceDir := createTestSource(t)
+
+	sources := []*repomanifest.Source{
+		{
+			Name:    "excluding-source",
+			Path:    sourceDir,
+			ID:      "src-exclude-test",
+			Include: []string{"skill/*", "command/*"},
+			Exclude: []string{"skill/image-*", "command/test-command"},
+		},
+	}
+	repoPath, cleanup := setupTestManifest(t, sources)
+	defer cleanup()
+
+	err := runSync(syncCmd, []string{})
+	if err != nil {
+		t.Fatalf("sync with exclude filter failed: %v", err)
+	}
+
+	verifyResourcesInRepo(t, repoPath, resource.Command, "sync-test-cmd", "pdf-command")
+	verifyResourcesInRepo(t, repoPath, resource.Skill, "sync-test-skill", "pdf-processing")
+
+	verifyResourcesNotInRepo(t, repoPath, resource.Command, "test-command")
+	verifyResourcesNotInRepo(t, repoPath, resource.Skill, "image-processing")
+	verifyResourcesNotInRepo(t, repoPath, resource.Agent, "sync-test-agent", "code-reviewer")
+}
+
 // TestRunSync_WithIncludeFilter_BackwardCompat verifies that a source without
 // include filters imports all resources (backward compatibility).
 func TestRunSync_WithIncludeFilter_BackwardCompat(t *testing.T) {
@@ -1271,7 +1344,7 @@ func TestScanSourceResources_DiscoversAllTypes(t *testing.T) {
 		t.Fatalf("failed to create package file: %v", err)
 	}
 
-	result, err := scanSourceResources(sourceDir, repomanifest.DiscoveryModeAuto)
+	result, err := scanSourceResources(sourceDir, repomanifest.DiscoveryModeAuto, nil)
 	if err != nil {
 		t.Fatalf("scanSourceResources failed: %v", err)
 	}
@@ -1326,7 +1399,7 @@ func TestScanSourceResources_DiscoversAllTypes(t *testing.T) {
 func TestScanSourceResources_EmptySource(t *testing.T) {
 	emptyDir := t.TempDir()
 
-	result, err := scanSourceResources(emptyDir, repomanifest.DiscoveryModeAuto)
+	result, err := scanSourceResources(emptyDir, repomanifest.DiscoveryModeAuto, nil)
 	if err != nil {
 		t.Fatalf("scanSourceResources failed on empty dir: %v", err)
 	}
@@ -1353,7 +1426,7 @@ func TestScanSourceResources_PartialTypes(t *testing.T) {
 		t.Fatalf("failed to create command: %v", err)
 	}
 
-	result, err := scanSourceResources(sourceDir, repomanifest.DiscoveryModeAuto)
+	result, err := scanSourceResources(sourceDir, repomanifest.DiscoveryModeAuto, nil)
 	if err != nil {
 		t.Fatalf("scanSourceResources failed: %v", err)
 	}
@@ -1547,7 +1620,7 @@ func TestSyncSource_ReturnsSourcePath(t *testing.T) {
 	}
 
 	// syncSource should return the source path
-	returnedPath, _, err := syncSource(sources[0], manager)
+	returnedPath, _, _, err := syncSource(sources[0], manager, importOptions{}, newSourcePluginFetcher(manager.GetRepoPath(), sources[0]))
 	if err != nil {
 		t.Fatalf("syncSource failed: %v", err)
 	}
@@ -1572,7 +1645,7 @@ func TestSyncSource_RespectsDiscoveryMode(t *testing.T) {
 		defer cleanup()
 
 		manager := repo.NewManagerWithPath(repoPath)
-		_, _, err := syncSource(sources[0], manager)
+		_, _, _, err := syncSource(sources[0], manager, importOptions{}, newSourcePluginFetcher(manager.GetRepoPath(), sources[0]))
 		if err != nil {
 			t.Fatalf("syncSource should ignore broken marketplace when discovery=generic: %v", err)
 		}
@@ -1592,7 +1665,7 @@ func TestSyncSource_RespectsDiscoveryMode(t *testing.T) {
 		defer cleanup()
 
 		manager := repo.NewManagerWithPath(repoPath)
-		_, _, err := syncSource(sources[0], manager)
+		_, _, _, err := syncSource(sources[0], manager, importOptions{}, newSourcePluginFetcher(manager.GetRepoPath(), sources[0]))
 		if err == nil {
 			t.Fatal("expected marketplace discovery parsing error, got nil")
 		}
@@ -1615,7 +1688,7 @@ func TestSyncSource_DiscoveryMode_SelectsMarketplaceVsGenericResources(t *testin
 		defer cleanup()
 
 		manager := repo.NewManagerWithPath(repoPath)
-		_, _, err := syncSource(sources[0], manager)
+		_, _, _, err := syncSource(sources[0], manager, importOptions{}, newSourcePluginFetcher(manager.GetRepoPath(), sources[0]))
 		if err != nil {
 			t.Fatalf("syncSource failed: %v", err)
 		}
@@ -1640,7 +1713,7 @@ func TestSyncSource_DiscoveryMode_SelectsMarketplaceVsGenericResources(t *testin
 		defer cleanup()
 
 		manager := repo.NewManagerWithPath(repoPath)
-		_, _, err := syncSource(sources[0], manager)
+		_, _, _, err := syncSource(sources[0], manager, importOptions{}, newSourcePluginFetcher(manager.GetRepoPath(), sources[0]))
 		if err != nil {
 			t.Fatalf("syncSource failed: %v", err)
 		}
@@ -1660,7 +1733,7 @@ func TestSyncSource_DiscoveryMode_SelectsMarketplaceVsGenericResources(t *testin
 		defer cleanup()
 
 		manager := repo.NewManagerWithPath(repoPath)
-		_, _, err := syncSource(sources[0], manager)
+		_, _, _, err := syncSource(sources[0], manager, importOptions{}, newSourcePluginFetcher(manager.GetRepoPath(), sources[0]))
 		if err != nil {
 			t.Fatalf("syncSource failed: %v", err)
 		}
@@ -1683,7 +1756,7 @@ func TestSyncSource_DiscoveryMode_ZeroResolvableMarketplaceErrors(t *testing.T)
 		defer cleanup()
 
 		manager := repo.NewManagerWithPath(repoPath)
-		_, _, err := syncSource(sources[0], manager)
+		_, _, _, err := syncSource(sources[0], manager, importOptions{}, newSourcePluginFetcher(manager.GetRepoPath(), sources[0]))
 		if err == nil {
 			t.Fatal("expected zero-resolvable marketplace error")
 		}
@@ -1702,7 +1775,7 @@ func TestSyncSource_DiscoveryMode_ZeroResolvableMarketplaceErrors(t *testing.T)
 		defer cleanup()
 
 		manager := repo.NewManagerWithPath(repoPath)
-		_, _, err := syncSource(sources[0], manager)
+		_, _, _, err := syncSource(sources[0], manager, importOptions{}, newSourcePluginFetcher(manager.GetRepoPath(), sources[0]))
 		if err == nil {
 			t.Fatal("expected zero-resolvable marketplace error")
 		}
@@ -1737,7 +1810,7 @@ func TestSyncSource_DirectPluginDirectoryMarketplaceFileInputs(t *testing.T) {
 				defer cleanup()
 
 				manager := repo.NewManagerWithPath(repoPath)
-				_, _, err := syncSource(sources[0], manager)
+				_, _, _, err := syncSource(sources[0], manager, importOptions{}, newSourcePluginFetcher(manager.GetRepoPath(), sources[0]))
 				if err != nil {
 					t.Fatalf("syncSource failed for %s: %v", sourcePath, err)
 				}
@@ -1783,7 +1856,7 @@ func TestSyncSource_DirectPluginDirectoryMarketplaceFileInputs(t *testing.T) {
 				runGit(t, cacheRepoPath, "checkout", "main")
 
 				manager := repo.NewManagerWithPath(repoPath)
-				_, _, err := syncSource(sources[0], manager)
+				_, _, _, err := syncSource(sources[0], manager, importOptions{}, newSourcePluginFetcher(manager.GetRepoPath(), sources[0]))
 				if err != nil {
 					t.Fatalf("syncSource failed for remote subpath %q: %v", tt.subpath, err)
 				}
@@ -2029,6 +2102,100 @@ func TestRunSync_PruneRemovesStalePackagesWithLegacySourceNameMetadata(t *testin
 	}
 }
 
+func TestPrepareRemoteSourcePath_FetchFailureReportsCredentialProvider(t *testing.T) {
+	wsMgr := &fakeWorkspaceManager{
+		cachePath: "/tmp/cache",
+		updateErr: fmt.Errorf("authentication failed"),
+		provider:  "env:GITLAB_TOKEN",
+	}
+
+	_, err := prepareRemoteSourcePath(wsMgr, "https://gitlab.example.com/team/skills", "main")
+	if err == nil {
+		t.Fatal("expected fetch failure")
+	}
+
+	var fetchErr *remoteFetchError
+	if !errors.As(err, &fetchErr) {
+		t.Fatalf("expected remoteFetchError, got %T: %v", err, err)
+	}
+	if fetchErr.Provider != "env:GITLAB_TOKEN" {
+		t.Fatalf("Provider = %q, want %q", fetchErr.Provider, "env:GITLAB_TOKEN")
+	}
+	if !strings.Contains(err.Error(), "authentication failed") {
+		t.Fatalf("expected underlying error in message, got %v", err)
+	}
+}
+
+// verifyingWorkspaceManager is a fakeWorkspaceManager that can verify
+// commit signatures.
+type verifyingWorkspaceManager struct {
+	fakeWorkspaceManager
+	verifyErr   error
+	gotSigners  string
+	verifyCalls int
+}
+
+func (f *verifyingWorkspaceManager) VerifyCommit(url string, signers string) (*workspace.Signature, error) {
+	f.verifyCalls++
+	f.gotSigners = signers
+	if f.verifyErr != nil {
+		return nil, f.verifyErr
+	}
+	return &workspace.Signature{}, nil
+}
+
+func TestSourcePluginFetcher_VerifiesPluginsOfVerifiedSources(t *testing.T) {
+	repoPath := t.TempDir()
+	remote := &marketplace.RemoteSource{Kind: marketplace.RemoteKindGitHub, Repo: "example/plugin"}
+
+	unverified := newSourcePluginFetcher(repoPath, &repomanifest.Source{Name: "plain", URL: "https://github.com/example/plain"})
+	unverified.wsMgr = &fakeWorkspaceManager{cachePath: "/tmp/plugin"}
+	if path, err := unverified.FetchPlugin(remote); err != nil || path != "/tmp/plugin" {
+		t.Fatalf("FetchPlugin() without verify = %q, %v", path, err)
+	}
+
+	verified := &repomanifest.Source{Name: "signed", URL: "https://github.com/example/signed", Verify: &repomanifest.VerifyConfig{Signers: "allowed_signers"}}
+	fetcher := newSourcePluginFetcher(repoPath, verified)
+	wsMgr := &verifyingWorkspaceManager{fakeWorkspaceManager: fakeWorkspaceManager{cachePath: "/tmp/plugin"}, verifyErr: fmt.Errorf("commit is not signed")}
+	fetcher.wsMgr = wsMgr
+	if _, err := fetcher.FetchPlugin(remote); err == nil || !strings.Contains(err.Error(), "refusing plugin source https://github.com/example/plugin: commit is not signed") {
+		t.Fatalf("FetchPlugin() of an unsigned plugin error = %v", err)
+	}
+	if want := filepath.Join(repoPath, "allowed_signers"); wsMgr.gotSigners != want {
+		t.Errorf("signers = %q, want %q", wsMgr.gotSigners, want)
+	}
+
+	wsMgr.verifyErr = nil
+	if path, err := fetcher.FetchPlugin(remote); err != nil || path != "/tmp/plugin" {
+		t.Fatalf("FetchPlugin() of a signed plugin = %q, %v", path, err)
+	}
+	if _, err := fetcher.FetchPlugin(remote); err != nil || wsMgr.verifyCalls != 2 {
+		t.Errorf("cached plugin fetch = %v, verify calls %d; want the verified checkout reused", err, wsMgr.verifyCalls)
+	}
+
+	// Workspace managers that cannot verify signatures fail closed.
+	noVerifier := newSourcePluginFetcher(repoPath, verified)
+	noVerifier.wsMgr = &fakeWorkspaceManager{cachePath: "/tmp/plugin"}
+	if _, err := noVerifier.FetchPlugin(remote); err == nil {
+		t.Error("expected FetchPlugin() to refuse a plugin it cannot verify")
+	}
+}
+
+func TestSourcePluginFetchers_OneFetcherPerSource(t *testing.T) {
+	repoPath := t.TempDir()
+	plain := &repomanifest.Source{Name: "plain", URL: "https://github.com/example/plain"}
+	signed := &repomanifest.Source{Name: "signed", URL: "https://github.com/example/signed", Verify: &repomanifest.VerifyConfig{Signers: "allowed_signers"}}
+
+	fetchers := make(sourcePluginFetchers)
+	first := fetchers.get(repoPath, plain)
+	if again := fetchers.get(repoPath, plain); again != first {
+		t.Error("expected the same fetcher for every use of a source within a sync")
+	}
+	if got := fetchers.get(repoPath, signed); got == first || got.signers != filepath.Join(repoPath, "allowed_signers") {
+		t.Errorf("signed source fetcher = %+v, want its own fetcher with the source's signers", got)
+	}
+}
+
 func TestPrepareRemoteSourcePath_RefreshesCachedRepo(t *testing.T) {
 	wsMgr := &fakeWorkspaceManager{cachePath: "/tmp/cache"}
 
@@ -2401,7 +2568,7 @@ func TestRunSync_NestedCommandsRemainTrackedAcrossRepeatedSyncs(t *testing.T) {
 		t.Fatalf("failed to collect pre-sync resources: %v", err)
 	}
 
-	removed, warnings := detectRemovedForSource(manifest.Sources[0], sourceDir, repoPath, preSyncResources)
+	removed, warnings := detectRemovedForSource(manifest.Sources[0], sourceDir, repoPath, preSyncResources, newSourcePluginFetcher(repoPath, manifest.Sources[0]))
 	if len(warnings) != 0 {
 		t.Fatalf("unexpected warnings: %v", warnings)
 	}
@@ -2846,7 +3013,7 @@ func TestDetectRemovedForSource_OverrideKeepsCanonicalSourceKey(t *testing.T) {
 		canonicalID: {{Name: "shared-cmd", Type: resource.Command}},
 	}
 
-	removed, warnings := detectRemovedForSource(src, sourceDir, repoPath, preSync)
+	removed, warnings := detectRemovedForSource(src, sourceDir, repoPath, preSync, newSourcePluginFetcher(repoPath, src))
 	if len(warnings) != 0 {
 		t.Fatalf("expected no warnings when resource is still present in source, got: %v", warnings)
 	}
@@ -2883,7 +3050,7 @@ func TestDetectRemovedForSource_OverrideSubpathUsesStableCanonicalKey(t *testing
 		canonicalID: {{Name: "subpath-cmd", Type: resource.Command}},
 	}
 
-	removed, warnings := detectRemovedForSource(src, sourceDir, repoPath, preSync)
+	removed, warnings := detectRemovedForSource(src, sourceDir, repoPath, preSync, newSourcePluginFetcher(repoPath, src))
 	if len(warnings) != 0 {
 		t.Fatalf("expected no warnings when resource is still present in source, got: %v", warnings)
 	}
@@ -2951,7 +3118,7 @@ func TestDetectRemovedForSource_SyncAfterUpgradeUsesRemappedLegacyRemoteID(t *te
 	manifest := &repomanifest.Manifest{Version: 1, Sources: []*repomanifest.Source{src}}
 	remapLegacyRemoteSourceIDs(preSync, manifest)
 
-	removed, warnings := detectRemovedForSource(src, sourceDir, repoPath, preSync)
+	removed, warnings := detectRemovedForSource(src, sourceDir, repoPath, preSync, newSourcePluginFetcher(repoPath, src))
 	if len(warnings) != 0 {
 		t.Fatalf("unexpected warnings: %v", warnings)
 	}
@@ -2977,7 +3144,7 @@ func TestDetectSyncResourceCollisions_RespectsDiscoveryMode(t *testing.T) {
 	}}
 
 	manager := repo.NewManagerWithPath(t.TempDir())
-	if err := detectSyncResourceCollisions(manifest, manager); err != nil {
+	if _, err := detectSyncResourceCollisions(manifest, manager, make(sourcePluginFetchers)); err != nil {
 		t.Fatalf("expected no collision because marketplace mode excludes loose resources, got: %v", err)
 	}
 }
@@ -3008,7 +3175,7 @@ func TestDetectRemovedForSource_RespectsMarketplaceDiscoveryMode(t *testing.T) {
 		t.Fatalf("failed to seed metadata for loose-command: %v", err)
 	}
 
-	removed, warnings := detectRemovedForSource(src, sourceDir, repoPath, preSync)
+	removed, warnings := detectRemovedForSource(src, sourceDir, repoPath, preSync, newSourcePluginFetcher(repoPath, src))
 	if len(warnings) != 0 {
 		t.Fatalf("unexpected warnings: %v", warnings)
 	}
@@ -3076,3 +3243,55 @@ func TestPreSyncResourcesForSource_IncludesLegacySourceNameEntries(t *testing.T)
 		t.Fatalf("expected deduplicated merged set of size 3, got %d (%#v)", len(got), got)
 	}
 }
+
+func TestRunSync_PriorityShadowsLowerPrioritySource(t *testing.T) {
+	sourceA := t.TempDir()
+	sourceB := t.TempDir()
+
+	for dir, label := range map[string]string{sourceA: "A", sourceB: "B"} {
+		cmdDir := filepath.Join(dir, "commands")
+		if err := os.MkdirAll(cmdDir, 0755); err != nil {
+			t.Fatalf("failed to create commands dir: %v", err)
+		}
+		content := fmt.Sprintf("---\ndescription: shared from %s\n---\n# shared-cmd", label)
+		if err := os.WriteFile(filepath.Join(cmdDir, "shared-cmd.md"), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to create shared command: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(sourceA, "commands", "only-a.md"), []byte("---\ndescription: only A\n---\n# only-a"), 0644); err != nil {
+		t.Fatalf("failed to create only-a: %v", err)
+	}
+
+	sources := []*repomanifest.Source{
+		{Name: "team", Path: sourceA},
+		{Name: "platform", Path: sourceB, Priority: 10},
+	}
+	repoPath, cleanup := setupTestManifest(t, sources)
+	defer cleanup()
+
+	if err := runSync(syncCmd, []string{}); err != nil {
+		t.Fatalf("sync with priorities should succeed, got: %v", err)
+	}
+
+	meta, err := resmeta.Load("shared-cmd", resource.Command, repoPath)
+	if err != nil {
+		t.Fatalf("failed to load shared-cmd metadata: %v", err)
+	}
+	if meta.SourceName != "platform" {
+		t.Fatalf("shared-cmd source = %q, want higher-priority source platform", meta.SourceName)
+	}
+	if _, err := resmeta.Load("only-a", resource.Command, repoPath); err != nil {
+		t.Fatalf("non-conflicting resource from lower-priority source should still import: %v", err)
+	}
+
+	srcMeta, err := sourcemetadata.Load(repoPath)
+	if err != nil {
+		t.Fatalf("failed to load source metadata: %v", err)
+	}
+	if winner, ok := srcMeta.ShadowedBy("team", "command/shared-cmd"); !ok || winner != "platform" {
+		t.Fatalf("ShadowedBy(team, command/shared-cmd) = (%q, %v), want (platform, true)", winner, ok)
+	}
+	if _, ok := srcMeta.ShadowedBy("platform", "command/shared-cmd"); ok {
+		t.Fatal("winning source must not be recorded as shadowed")
+	}
+}
@@ -28,6 +28,9 @@ type VerifyResult struct {
 	PackagesWithMissingRefs  []PackageIssue  `json:"packages_with_missing_refs,omitempty" yaml:"packages_with_missing_refs,omitempty"`
 	HasErrors                bool            `json:"has_errors" yaml:"has_errors"`
 	HasWarnings              bool            `json:"has_warnings" yaml:"has_warnings"`
+
+	// IntegrityIssues is only populated by --integrity
+	IntegrityIssues []repo.IntegrityReport `json:"integrity_issues,omitempty" yaml:"integrity_issues,omitempty"`
 }
 
 // ResourceIssue represents a resource with an issue
@@ -75,6 +78,7 @@ const (
 
 var (
 	verifyFix        bool
+	verifyIntegrity  bool
 	verifyJSON       bool // Deprecated: use --format=json
 	verifyFormatFlag string
 )
@@ -94,6 +98,11 @@ This command performs the following checks:
   - Type mismatches between resource and metadata (error)
   - Packages with missing resource references (error)
 
+With --integrity, the files of every resource are also hashed and compared
+with the per-file manifest recorded in .metadata when it was imported:
+  - Resources with modified, added or removed files (error)
+  - Resources imported before manifests were recorded (warning; re-sync to record one)
+
 Use 'aimgr repo repair' to automatically resolve issues (--fix is deprecated):
   - Create missing metadata for resources
   - Remove orphaned metadata files
@@ -124,6 +133,7 @@ Examples:
   aimgr repo verify                  # Check all resources
   aimgr repo verify skill/*          # Check only skills
   aimgr repo verify command/test*    # Check commands starting with "test"
+  aimgr repo verify --integrity      # Also detect tampered resource files
   aimgr repo repair                  # Fix issues automatically
   aimgr repo verify --format=json    # Machine-readable output
   aimgr repo verify --format=yaml    # YAML output`,
@@ -246,6 +256,22 @@ Examples:
 			return newOperationalFailureError(fmt.Errorf("verification failed: %w", err))
 		}
 
+		if verifyIntegrity {
+			result.IntegrityIssues, err = checkRepositoryIntegrity(manager, matcher)
+			if err != nil {
+				if outErr := outputVerifyOperationalFailure(parsedFormat, err); outErr != nil {
+					return outErr
+				}
+				if parsedFormat == output.JSON || parsedFormat == output.YAML {
+					return newSuppressedOperationalFailureError(fmt.Errorf("integrity check failed: %w", err))
+				}
+				return newOperationalFailureError(fmt.Errorf("integrity check failed: %w", err))
+			}
+			modified, unverified := integrityFindings(result.IntegrityIssues)
+			result.HasErrors = result.HasErrors || modified
+			result.HasWarnings = result.HasWarnings || unverified
+		}
+
 		setVerifyStatusForCompletedResult(result, verifyFix)
 
 		// Output results in requested format
@@ -272,8 +298,9 @@ func setVerifyStatusForCompletedResult(result *VerifyResult, fixed bool) {
 		// but they should not count as remaining findings for status/exit-code
 		// purposes. Only unresolved issues still present after auto-fix should
 		// drive completed_with_findings.
-		result.HasErrors = len(result.TypeMismatches) > 0 || len(result.PackagesWithMissingRefs) > 0
-		result.HasWarnings = len(result.MissingSourcePaths) > 0
+		modified, unverified := integrityFindings(result.IntegrityIssues)
+		result.HasErrors = len(result.TypeMismatches) > 0 || len(result.PackagesWithMissingRefs) > 0 || modified
+		result.HasWarnings = len(result.MissingSourcePaths) > 0 || unverified
 	}
 
 	if result.HasErrors || result.HasWarnings {
@@ -457,6 +484,92 @@ func verifyRepository(manager *repo.Manager, fix bool, matcher *pattern.Matcher)
 	return result, nil
 }
 
+// checkRepositoryIntegrity compares the resources matching the pattern with
+// their recorded file manifests and returns the ones that do not verify.
+// Resources are found through their metadata rather than by parsing them, so
+// a file edited beyond recognition is still checked. Metadata without a
+// resource is skipped; the orphaned metadata check reports it.
+func checkRepositoryIntegrity(manager *repo.Manager, matcher *pattern.Matcher) ([]repo.IntegrityReport, error) {
+	metadataDir := filepath.Join(manager.GetRepoPath(), ".metadata")
+
+	var issues []repo.IntegrityReport
+	for _, resType := range []resource.ResourceType{resource.Command, resource.Skill, resource.Agent} {
+		entries, err := os.ReadDir(filepath.Join(metadataDir, string(resType)+"s"))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, fmt.Errorf("failed to read %s metadata: %w", resType, err)
+		}
+
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasSuffix(entry.Name(), "-metadata.json") {
+				continue
+			}
+			metaPath := filepath.Join(metadataDir, string(resType)+"s", entry.Name())
+			data, err := os.ReadFile(metaPath)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read metadata file %s: %w", metaPath, err)
+			}
+			var meta metadata.ResourceMetadata
+			if err := json.Unmarshal(data, &meta); err != nil {
+				return nil, fmt.Errorf("failed to parse metadata file %s: %w", metaPath, err)
+			}
+
+			res := resource.Resource{Name: meta.Name, Type: resType}
+			if matcher != nil && !matcher.Match(&res) {
+				continue
+			}
+			if _, err := os.Stat(manager.GetPath(meta.Name, resType)); err != nil {
+				continue
+			}
+			report, err := manager.VerifyIntegrity(meta.Name, resType)
+			if err != nil {
+				return nil, err
+			}
+			if report.Status != repo.IntegrityOK {
+				issues = append(issues, *report)
+			}
+		}
+	}
+	return issues, nil
+}
+
+// integrityFindings reports whether any resource failed verification and
+// whether any could not be verified for lack of a manifest.
+func integrityFindings(reports []repo.IntegrityReport) (modified, unverified bool) {
+	for _, report := range reports {
+		switch report.Status {
+		case repo.IntegrityModified:
+			modified = true
+		case repo.IntegrityNoManifest:
+			unverified = true
+		}
+	}
+	return modified, unverified
+}
+
+// describeIntegrityChanges summarizes changed files as "modified: a, b; added: c".
+func describeIntegrityChanges(report repo.IntegrityReport) string {
+	if report.Status == repo.IntegrityNoManifest {
+		return "No manifest recorded (re-sync to record one)"
+	}
+	var parts []string
+	for _, group := range []struct {
+		label string
+		files []string
+	}{
+		{"modified", report.Modified},
+		{"added", report.Added},
+		{"removed", report.Removed},
+	} {
+		if len(group.files) > 0 {
+			parts = append(parts, group.label+": "+strings.Join(group.files, ", "))
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
 // findVerifyOrphanedMetadata finds metadata files without corresponding resources
 func findVerifyOrphanedMetadata(resourceMap map[string]resource.Resource, metadataDir string, fix bool, matcher *pattern.Matcher) ([]MetadataIssue, error) {
 	var orphaned []MetadataIssue
@@ -693,6 +806,22 @@ func displayVerifyResults(result *VerifyResult, fixed bool) {
 		fmt.Println()
 	}
 
+	// Display integrity failures (error) and unverifiable resources (warning)
+	if len(result.IntegrityIssues) > 0 {
+		hasIssues = true
+		fmt.Printf("✗ Resources failing integrity check: %d\n\n", len(result.IntegrityIssues))
+
+		table := output.NewTable("Name", "Status", "Files")
+		table.WithResponsive().
+			WithDynamicColumn(2).
+			WithMinColumnWidths(40, 11, 20)
+		for _, report := range result.IntegrityIssues {
+			table.AddRow(formatResourceReference(report.Type, report.Name), report.Status, describeIntegrityChanges(report))
+		}
+		_ = table.Format(output.Table)
+		fmt.Println()
+	}
+
 	// Summary
 	if !hasIssues {
 		fmt.Println("✓ No issues found. Repository is healthy!")
@@ -705,6 +834,10 @@ func displayVerifyResults(result *VerifyResult, fixed bool) {
 			fmt.Println()
 			fmt.Println("Run 'aimgr repo repair' to automatically resolve these issues.")
 		}
+		if modified, _ := integrityFindings(result.IntegrityIssues); modified {
+			fmt.Println()
+			fmt.Println("Run 'aimgr repo sync --local-changes take-upstream' to restore modified resources.")
+		}
 	}
 }
 
@@ -728,6 +861,7 @@ func init() {
 	repoCmd.AddCommand(repoVerifyCmd)
 	repoVerifyCmd.Flags().BoolVar(&verifyFix, "fix", false, "Automatically fix issues (create missing metadata, remove orphaned)")
 	_ = repoVerifyCmd.Flags().MarkDeprecated("fix", "use 'aimgr repo repair' instead")
+	repoVerifyCmd.Flags().BoolVar(&verifyIntegrity, "integrity", false, "Also compare resource files with the SHA-256 manifest recorded at import")
 
 	// Add new --format flag
 	repoVerifyCmd.Flags().StringVar(&verifyFormatFlag, "format", "table", "Output format (table|json|yaml)")
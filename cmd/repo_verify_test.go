@@ -568,3 +568,63 @@ func TestRepoVerifyWarningsReturnCompletedWithFindingsExit(t *testing.T) {
 		t.Fatalf("exit code=%d want %d", cmdErr.ExitCode, commandExitCodeCompletedWithFindings)
 	}
 }
+
+func TestCheckRepositoryIntegrity(t *testing.T) {
+	t.Setenv("GIT_AUTHOR_NAME", "test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+
+	repoDir := t.TempDir()
+	manager := repo.NewManagerWithPath(repoDir)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("Failed to initialize repo: %v", err)
+	}
+
+	sourceDir := filepath.Join(t.TempDir(), "commands")
+	if err := os.MkdirAll(sourceDir, 0755); err != nil {
+		t.Fatalf("Failed to create source directory: %v", err)
+	}
+	for _, name := range []string{"build", "deploy"} {
+		content := "---\ndescription: " + name + "\n---\n# " + name + "\n"
+		path := filepath.Join(sourceDir, name+".md")
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write command: %v", err)
+		}
+		if _, err := manager.AddBulk([]string{path}, repo.BulkImportOptions{ImportMode: "copy", Force: true}); err != nil {
+			t.Fatalf("AddBulk() error = %v", err)
+		}
+	}
+
+	issues, err := checkRepositoryIntegrity(manager, nil)
+	if err != nil {
+		t.Fatalf("checkRepositoryIntegrity() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Fatalf("checkRepositoryIntegrity() = %+v before edits, want none", issues)
+	}
+
+	// Content without frontmatter no longer parses as a command, but is
+	// still found through its metadata.
+	if err := os.WriteFile(manager.GetPath("deploy", resource.Command), []byte("# tampered\n"), 0644); err != nil {
+		t.Fatalf("Failed to modify command: %v", err)
+	}
+
+	issues, err = checkRepositoryIntegrity(manager, nil)
+	if err != nil {
+		t.Fatalf("checkRepositoryIntegrity() error = %v", err)
+	}
+	if len(issues) != 1 || issues[0].Name != "deploy" || issues[0].Status != repo.IntegrityModified {
+		t.Fatalf("checkRepositoryIntegrity() = %+v, want deploy modified", issues)
+	}
+	if got := describeIntegrityChanges(issues[0]); got != "modified: deploy.md" {
+		t.Errorf("describeIntegrityChanges() = %q, want %q", got, "modified: deploy.md")
+	}
+
+	result := &VerifyResult{IntegrityIssues: issues}
+	result.HasErrors, result.HasWarnings = integrityFindings(issues)
+	setVerifyStatusForCompletedResult(result, false)
+	if result.Status != verifyStatusCompletedWithFindings || !result.HasErrors {
+		t.Errorf("status = %q (has_errors=%v), want completed_with_findings with errors", result.Status, result.HasErrors)
+	}
+}
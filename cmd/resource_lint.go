@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/lint"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/version"
+	"github.com/spf13/cobra"
+)
+
+// formatSARIF is the extra output format supported by resource lint.
+const formatSARIF = "sarif"
+
+var (
+	resourceLintFormatFlag    string
+	resourceLintConfigFlag    string
+	resourceLintListRulesFlag bool
+)
+
+var resourceLintCmd = &cobra.Command{
+	Use:   "lint [path]",
+	Short: "Check resources in a source tree against lint rules",
+	Long: `Check the skills, commands and agents below a source tree (default: the
+current directory) against content quality rules: vague or short descriptions,
+missing "when to use" guidance, missing licenses, overly long files, duplicate
+descriptions and leftover TODOs.
+
+Rules are configured in .aimgr-lint.yaml, looked up in the linted directory
+and its parents up to the git work tree root (or set with --config):
+
+  ignore:
+    - skills/experimental-*
+  rules:
+    description-too-short:
+      severity: error
+      min-length: 60
+    missing-license: off
+
+Run with --list-rules to see every rule, its default severity and options.
+
+Exit status:
+  0 - No error-level findings
+  1 - At least one error-level finding
+  2 - Invalid configuration or usage error
+
+Examples:
+  aimgr resource lint
+  aimgr resource lint ./my-skills --format json
+  aimgr resource lint --format sarif > aimgr-lint.sarif`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runResourceLint,
+}
+
+func init() {
+	resourceCmd.AddCommand(resourceLintCmd)
+
+	resourceLintCmd.Flags().StringVar(&resourceLintFormatFlag, "format", "table", "Output format (table|json|yaml|sarif)")
+	resourceLintCmd.Flags().StringVar(&resourceLintConfigFlag, "config", "", "Lint configuration file (default: nearest "+lint.ConfigFileName+")")
+	resourceLintCmd.Flags().BoolVar(&resourceLintListRulesFlag, "list-rules", false, "List the available rules and exit")
+	_ = resourceLintCmd.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return []string{"table", "json", "yaml", formatSARIF}, cobra.ShellCompDirectiveNoFileComp
+	})
+}
+
+func runResourceLint(cmd *cobra.Command, args []string) error {
+	sarif := strings.EqualFold(resourceLintFormatFlag, formatSARIF)
+	format := output.JSON
+	if !sarif {
+		parsed, err := output.ParseFormat(resourceLintFormatFlag)
+		if err != nil {
+			return newOperationalFailureError(fmt.Errorf("%w (or sarif)", err))
+		}
+		format = parsed
+	}
+
+	if resourceLintListRulesFlag {
+		return listLintRules(format)
+	}
+
+	root := "."
+	if len(args) == 1 {
+		root = args[0]
+	}
+	if info, err := os.Stat(root); err != nil {
+		return newOperationalFailureError(fmt.Errorf("failed to read %s: %w", root, err))
+	} else if !info.IsDir() {
+		return newOperationalFailureError(fmt.Errorf("%s is not a directory", root))
+	}
+
+	configPath := resourceLintConfigFlag
+	if configPath == "" {
+		found, err := lint.FindConfig(root)
+		if err != nil {
+			return newOperationalFailureError(err)
+		}
+		configPath = found
+	}
+	var cfg *lint.Config
+	if configPath != "" {
+		loaded, err := lint.LoadConfig(configPath)
+		if err != nil {
+			return newOperationalFailureError(err)
+		}
+		cfg = loaded
+	}
+
+	report, err := lint.Lint(root, cfg)
+	if err != nil {
+		return newOperationalFailureError(err)
+	}
+	report.Config = configPath
+
+	switch {
+	case sarif:
+		if err := output.EncodeJSON(os.Stdout, report.SARIF(version.Version)); err != nil {
+			return err
+		}
+	case format == output.Table:
+		displayLintReport(report)
+	default:
+		if err := output.FormatOutput(report, format); err != nil {
+			return err
+		}
+	}
+
+	if report.Summary.Errors > 0 {
+		return newCompletedWithFindingsError(fmt.Sprintf("lint found %d error(s)", report.Summary.Errors))
+	}
+	return nil
+}
+
+func displayLintReport(report *lint.Report) {
+	if len(report.Findings) > 0 {
+		table := output.NewTable("SEVERITY", "RULE", "LOCATION", "MESSAGE")
+		table.WithResponsive().WithDynamicColumn(3).WithMinColumnWidths(8, 20, 24, 30)
+		for _, f := range report.Findings {
+			location := f.File
+			if f.Line > 0 {
+				location = fmt.Sprintf("%s:%d", f.File, f.Line)
+			}
+			table.AddRow(string(f.Severity), f.Rule, location, f.Message)
+		}
+		_ = table.Format(output.Table)
+		fmt.Println()
+	}
+
+	status := statusIconOK
+	if report.Summary.Errors > 0 {
+		status = statusIconFail
+	}
+	fmt.Printf("%s Linted %d resource(s): %d error(s), %d warning(s), %d info\n",
+		status, report.Summary.Resources, report.Summary.Errors, report.Summary.Warnings, report.Summary.Infos)
+	if report.Config != "" {
+		fmt.Printf("  config: %s\n", report.Config)
+	}
+}
+
+// lintRuleInfo describes a rule for --list-rules.
+type lintRuleInfo struct {
+	ID       string   `json:"id" yaml:"id"`
+	Severity string   `json:"default_severity" yaml:"default_severity"`
+	Types    []string `json:"default_types,omitempty" yaml:"default_types,omitempty"`
+	Options  []string `json:"options,omitempty" yaml:"options,omitempty"`
+	Summary  string   `json:"description" yaml:"description"`
+}
+
+func listLintRules(format output.Format) error {
+	var infos []lintRuleInfo
+	for _, rule := range lint.Rules() {
+		info := lintRuleInfo{
+			ID:       rule.ID,
+			Severity: string(rule.DefaultSeverity),
+			Types:    rule.DefaultTypes,
+			Summary:  rule.Description,
+		}
+		for option := range rule.Options {
+			info.Options = append(info.Options, option)
+		}
+		sort.Strings(info.Options)
+		infos = append(infos, info)
+	}
+
+	if format != output.Table {
+		return output.FormatOutput(infos, format)
+	}
+	table := output.NewTable("RULE", "SEVERITY", "TYPES", "OPTIONS", "DESCRIPTION").WithResponsive().WithDynamicColumn(4)
+	for _, info := range infos {
+		types := strings.Join(info.Types, ",")
+		if types == "" {
+			types = "all"
+		}
+		table.AddRow(info.ID, info.Severity, types, strings.Join(info.Options, ","), info.Summary)
+	}
+	return table.Format(output.Table)
+}
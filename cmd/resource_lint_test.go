@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/lint"
+)
+
+// writeLintSourceTree creates a git work tree with one skill whose
+// description is short, and a lint config at the tree root.
+func writeLintSourceTree(t *testing.T, config string) string {
+	t.Helper()
+	root := t.TempDir()
+	skillDir := filepath.Join(root, "skills", "pdf")
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0755); err != nil {
+		t.Fatalf("mkdir .git: %v", err)
+	}
+	if err := os.MkdirAll(skillDir, 0755); err != nil {
+		t.Fatalf("mkdir skill: %v", err)
+	}
+	skill := "---\nname: pdf\ndescription: PDF tools\nlicense: MIT\n---\n# PDF\n\n## When to use\n\nWorking with PDF files.\n"
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(skill), 0644); err != nil {
+		t.Fatalf("write skill: %v", err)
+	}
+	if config != "" {
+		if err := os.WriteFile(filepath.Join(root, lint.ConfigFileName), []byte(config), 0644); err != nil {
+			t.Fatalf("write config: %v", err)
+		}
+	}
+	return root
+}
+
+func setResourceLintFlags(t *testing.T, format, config string) {
+	t.Helper()
+	oldFormat, oldConfig, oldList := resourceLintFormatFlag, resourceLintConfigFlag, resourceLintListRulesFlag
+	t.Cleanup(func() {
+		resourceLintFormatFlag, resourceLintConfigFlag, resourceLintListRulesFlag = oldFormat, oldConfig, oldList
+	})
+	resourceLintFormatFlag, resourceLintConfigFlag, resourceLintListRulesFlag = format, config, false
+}
+
+func TestRunResourceLint_DiscoversConfigAndExitsWithFindings(t *testing.T) {
+	root := writeLintSourceTree(t, "rules:\n  description-too-short:\n    severity: error\n")
+	setResourceLintFlags(t, "json", "")
+
+	// The config at the work tree root applies to a linted subdirectory.
+	var runErr error
+	out := captureValidateStdout(t, func() {
+		runErr = runResourceLint(resourceLintCmd, []string{filepath.Join(root, "skills")})
+	})
+
+	var exitErr *commandExitError
+	if !errors.As(runErr, &exitErr) || exitErr.ExitCode != commandExitCodeCompletedWithFindings {
+		t.Fatalf("runResourceLint() error = %v, want a completed-with-findings exit", runErr)
+	}
+	var report lint.Report
+	if err := json.Unmarshal([]byte(out), &report); err != nil {
+		t.Fatalf("invalid JSON output: %v\n%s", err, out)
+	}
+	if report.Config != filepath.Join(root, lint.ConfigFileName) {
+		t.Errorf("config = %q, want the work tree root config", report.Config)
+	}
+	if report.Summary.Errors != 1 || len(report.Findings) != 1 || report.Findings[0].Rule != "description-too-short" {
+		t.Errorf("report = %+v, want one description-too-short error", report)
+	}
+}
+
+func TestRunResourceLint_WarningsDoNotFail(t *testing.T) {
+	root := writeLintSourceTree(t, "")
+	// A config outside the work tree is not picked up.
+	if err := os.WriteFile(filepath.Join(filepath.Dir(root), lint.ConfigFileName), []byte("rules:\n  description-too-short:\n    severity: error\n"), 0644); err != nil {
+		t.Fatalf("write outer config: %v", err)
+	}
+	setResourceLintFlags(t, "table", "")
+
+	var runErr error
+	out := captureValidateStdout(t, func() { runErr = runResourceLint(resourceLintCmd, []string{root}) })
+	if runErr != nil {
+		t.Fatalf("runResourceLint() error = %v, want success for warnings only", runErr)
+	}
+	if !strings.Contains(out, "description-too-short") || !strings.Contains(out, "warning") {
+		t.Errorf("table output should list the warning:\n%s", out)
+	}
+}
+
+func TestRunResourceLint_SARIF(t *testing.T) {
+	root := writeLintSourceTree(t, "")
+	config := filepath.Join(t.TempDir(), "lint.yaml")
+	if err := os.WriteFile(config, []byte("rules:\n  description-too-short:\n    severity: error\n"), 0644); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	setResourceLintFlags(t, "sarif", config)
+
+	var runErr error
+	out := captureValidateStdout(t, func() { runErr = runResourceLint(resourceLintCmd, []string{root}) })
+	if runErr == nil {
+		t.Fatal("expected a completed-with-findings error for an error-level finding")
+	}
+
+	var sarif struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Tool struct {
+				Driver struct {
+					Name string `json:"name"`
+				} `json:"driver"`
+			} `json:"tool"`
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Level     string `json:"level"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal([]byte(out), &sarif); err != nil {
+		t.Fatalf("invalid SARIF output: %v\n%s", err, out)
+	}
+	if sarif.Version != "2.1.0" || len(sarif.Runs) != 1 || sarif.Runs[0].Tool.Driver.Name != "aimgr" {
+		t.Fatalf("unexpected SARIF log: %s", out)
+	}
+	results := sarif.Runs[0].Results
+	if len(results) != 1 || results[0].RuleID != "description-too-short" || results[0].Level != "error" {
+		t.Fatalf("results = %+v", results)
+	}
+	if len(results[0].Locations) != 1 || results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI != "skills/pdf/SKILL.md" {
+		t.Errorf("locations = %+v", results[0].Locations)
+	}
+}
+
+func TestRunResourceLint_InvalidConfigIsOperationalFailure(t *testing.T) {
+	root := writeLintSourceTree(t, "rules:\n  no-such-rule: error\n")
+	setResourceLintFlags(t, "table", "")
+
+	err := runResourceLint(resourceLintCmd, []string{root})
+	var exitErr *commandExitError
+	if !errors.As(err, &exitErr) || exitErr.ExitCode != commandExitCodeOperationalFailure {
+		t.Fatalf("runResourceLint() error = %v, want an operational failure", err)
+	}
+}
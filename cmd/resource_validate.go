@@ -8,10 +8,13 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repo"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/schema"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 )
@@ -26,6 +29,7 @@ type validateDiagnostic struct {
 	Code             string `json:"code" yaml:"code"`
 	Message          string `json:"message" yaml:"message"`
 	Field            string `json:"field,omitempty" yaml:"field,omitempty"`
+	Tool             string `json:"tool,omitempty" yaml:"tool,omitempty"`
 	FilePath         string `json:"file_path,omitempty" yaml:"file_path,omitempty"`
 	ResourceName     string `json:"resource_name,omitempty" yaml:"resource_name,omitempty"`
 	ResourceType     string `json:"resource_type,omitempty" yaml:"resource_type,omitempty"`
@@ -78,6 +82,7 @@ var (
 	resourceValidateFormatFlag       string
 	resourceValidateSourceRootFlag   string
 	resourceValidateRepoManifestFlag string
+	resourceValidateTargetFlag       []string
 )
 
 var resourceValidateCmd = &cobra.Command{
@@ -92,6 +97,14 @@ Resolution rules:
 For canonical IDs, resolution context precedence is:
   --source-root, local repo (if available), then --repo-manifest.
 
+--target also checks the frontmatter against the schema of each listed tool
+(claude, opencode, copilot, windsurf), after the tool's field mappings from
+aimgr.yaml are applied, and reports which tool would reject which field.
+
+Examples:
+  aimgr resource validate ./agents/reviewer.md
+  aimgr resource validate agent/reviewer --target claude,opencode
+
 Exit status:
   0 - Validation passed
   1 - Validation failed
@@ -105,10 +118,27 @@ Exit status:
 			os.Exit(resourceValidateExitUsageError)
 		}
 
+		targets, err := parseValidateTargets(resourceValidateTargetFlag)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(resourceValidateExitUsageError)
+		}
+		var mappings config.TypeMappings
+		if len(targets) > 0 {
+			cfg, err := config.LoadGlobal()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+				os.Exit(resourceValidateExitUsageError)
+			}
+			mappings = cfg.Mappings
+		}
+
 		result := runResourceValidate(args[0], resourceValidateOptions{
 			format:       resourceValidateFormatFlag,
 			sourceRoot:   resourceValidateSourceRootFlag,
 			repoManifest: resourceValidateRepoManifestFlag,
+			targets:      targets,
+			mappings:     mappings,
 		})
 
 		if err := outputResourceValidateResult(&result.Output, result.Output.Valid, result.ExitCode, resourceValidateFormatFlag); err != nil {
@@ -127,6 +157,32 @@ type resourceValidateOptions struct {
 	format       string
 	sourceRoot   string
 	repoManifest string
+	targets      []tools.Tool
+	mappings     config.TypeMappings
+}
+
+// parseValidateTargets parses --target values, which may be repeated or
+// comma-separated.
+func parseValidateTargets(values []string) ([]tools.Tool, error) {
+	var targets []tools.Tool
+	seen := make(map[tools.Tool]bool)
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			tool, err := tools.ParseTool(name)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --target: %w", err)
+			}
+			if !seen[tool] {
+				seen[tool] = true
+				targets = append(targets, tool)
+			}
+		}
+	}
+	return targets, nil
 }
 
 func runResourceValidate(target string, opts resourceValidateOptions) resourceValidateRunResult {
@@ -146,7 +202,7 @@ func runResourceValidate(target string, opts resourceValidateOptions) resourceVa
 	}
 
 	if pathExists {
-		res := validatePathTarget(target, resolvedPath, resourceValidateOptions{sourceRoot: resolvedSourceRoot, repoManifest: opts.repoManifest})
+		res := validatePathTarget(target, resolvedPath, resourceValidateOptions{sourceRoot: resolvedSourceRoot, repoManifest: opts.repoManifest, targets: opts.targets, mappings: opts.mappings})
 		if res.Valid {
 			return resourceValidateRunResult{Output: res, ExitCode: 0}
 		}
@@ -168,7 +224,7 @@ func runResourceValidate(target string, opts resourceValidateOptions) resourceVa
 		return resourceValidateRunResult{Output: res, ExitCode: resourceValidateExitUsageError}
 	}
 
-	res := validatePathTarget(target, resolvedCanonicalPath, resourceValidateOptions{sourceRoot: resolvedSourceRoot, repoManifest: opts.repoManifest})
+	res := validatePathTarget(target, resolvedCanonicalPath, resourceValidateOptions{sourceRoot: resolvedSourceRoot, repoManifest: opts.repoManifest, targets: opts.targets, mappings: opts.mappings})
 	res.ResolvedID = canonical.Raw
 	res.Context = ctxInfo
 	if res.ResourceType == "" {
@@ -356,6 +412,8 @@ func validatePathTarget(originalTarget, resolvedPath string, opts resourceValida
 		return validatePackagePathTarget(originalTarget, resolvedPath, opts)
 	}
 
+	var linkIssues []resource.LinkIssue
+	var scriptIssues []resource.ScriptIssue
 	res, loadErr := resource.Load(resolvedPath)
 	if loadErr == nil {
 		result.ResourceType = string(res.Type)
@@ -385,12 +443,32 @@ func validatePathTarget(originalTarget, resolvedPath string, opts resourceValida
 				Message:  fmt.Sprintf("resource type %q is not supported by static validation", res.Type),
 			}}
 		}
+		if result.Valid {
+			if issues, err := resource.CheckResourceLinks(res); err == nil {
+				linkIssues = issues
+			}
+			if issues, err := resource.CheckResourceScripts(res); err == nil {
+				scriptIssues = issues
+			}
+			result.Diagnostics = append(result.Diagnostics, permissionDiagnostics(res)...)
+			result.Diagnostics = append(result.Diagnostics, toolSchemaDiagnostics(res, opts)...)
+		}
 	} else {
 		// Fallback for standalone command files outside commands/ directories.
 		if strings.EqualFold(filepath.Ext(resolvedPath), ".md") && strings.Contains(loadErr.Error(), "command file must be in a 'commands/' directory") {
 			if cmdErr := validateCommandStaticStandalone(resolvedPath); cmdErr == nil {
 				result.ResourceType = string(resource.Command)
 				result.Valid = true
+				if issues, err := resource.CheckFileLinks(resolvedPath); err == nil {
+					linkIssues = issues
+				}
+				standalone := &resource.Resource{
+					Name: strings.TrimSuffix(filepath.Base(resolvedPath), filepath.Ext(resolvedPath)),
+					Type: resource.Command,
+					Path: resolvedPath,
+				}
+				result.Diagnostics = append(result.Diagnostics, permissionDiagnostics(standalone)...)
+				result.Diagnostics = append(result.Diagnostics, toolSchemaDiagnostics(standalone, opts)...)
 			} else {
 				result.Diagnostics = []validateDiagnostic{diagnosticFromError("validation_error", cmdErr)}
 				if result.ResourceType == "" {
@@ -402,11 +480,169 @@ func validatePathTarget(originalTarget, resolvedPath string, opts resourceValida
 		}
 	}
 
-	result.Summary.ErrorCount = len(result.Diagnostics)
-	result.Summary.WarningCount = 0
+	if len(opts.targets) > 0 {
+		result.Mode = "static+tool-schema"
+	}
+	result.Diagnostics = append(result.Diagnostics, diagnosticsFromLinkIssues(linkIssues, result.ResourceType)...)
+	result.Diagnostics = append(result.Diagnostics, diagnosticsFromScriptIssues(scriptIssues, result.ResourceType)...)
+	result.Summary = summarizeDiagnostics(result.Diagnostics)
+	result.Valid = result.Valid && result.Summary.ErrorCount == 0
 	return result
 }
 
+// diagnosticsFromLinkIssues converts link check results into diagnostics.
+// Broken links are errors; links leaving the resource and unreferenced
+// bundled files are warnings.
+func diagnosticsFromLinkIssues(issues []resource.LinkIssue, resourceType string) []validateDiagnostic {
+	diagnostics := make([]validateDiagnostic, 0, len(issues))
+	for _, issue := range issues {
+		d := validateDiagnostic{
+			Severity:     "warning",
+			Code:         issue.Kind,
+			Message:      issue.String(),
+			FilePath:     issue.File,
+			ResourceType: resourceType,
+		}
+		switch issue.Kind {
+		case resource.LinkIssueBroken:
+			d.Severity = "error"
+			d.MissingReference = issue.Target
+			d.Suggestion = "Fix the path or restore the file"
+		case resource.LinkIssueOutside:
+			d.Suggestion = "Move the file into the skill directory; only the skill directory is imported"
+		case resource.LinkIssueUnreferenced:
+			d.Suggestion = "Reference the file from SKILL.md or remove it"
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
+
+// diagnosticsFromScriptIssues converts script check results into
+// diagnostics. CRLF line endings are errors because the script cannot run at
+// all; a missing exec bit or interpreter is a warning, since agents often run
+// scripts through an explicit interpreter and interpreters vary by machine.
+func diagnosticsFromScriptIssues(issues []resource.ScriptIssue, resourceType string) []validateDiagnostic {
+	diagnostics := make([]validateDiagnostic, 0, len(issues))
+	for _, issue := range issues {
+		d := validateDiagnostic{
+			Severity:     "warning",
+			Code:         issue.Kind,
+			Message:      issue.String(),
+			FilePath:     issue.File,
+			ResourceType: resourceType,
+		}
+		switch issue.Kind {
+		case resource.ScriptIssueNotExecutable:
+			d.Suggestion = fmt.Sprintf("Run 'chmod +x %s' in the source, or 'aimgr repo repair' for imported skills", issue.File)
+		case resource.ScriptIssueCRLF:
+			d.Severity = "error"
+			d.Suggestion = "Convert the file to LF line endings (e.g. with dos2unix) and add '*.sh text eol=lf' to .gitattributes"
+		case resource.ScriptIssueMissingInterpreter:
+			d.MissingReference = issue.Interpreter
+			d.Suggestion = fmt.Sprintf("Install %s or document it as a prerequisite of the skill", issue.Interpreter)
+		}
+		diagnostics = append(diagnostics, d)
+	}
+	return diagnostics
+}
+
+// permissionDiagnostics checks the allowed-tools and tools entries of a
+// resource. Entries that are not valid permission syntax are errors; tools
+// that are neither built in nor MCP tools are warnings, since tools and
+// plugins can add their own.
+func permissionDiagnostics(res *resource.Resource) []validateDiagnostic {
+	grants, issues, err := resourcePermissions(res)
+	if err != nil {
+		return nil // frontmatter errors are reported by static validation
+	}
+	var diagnostics []validateDiagnostic
+	for _, issue := range issues {
+		diagnostics = append(diagnostics, validateDiagnostic{
+			Severity:     "error",
+			Code:         "invalid_permission",
+			Message:      fmt.Sprintf("%s: %s", issue.Field, issue.Message),
+			Field:        issue.Field,
+			ResourceType: string(res.Type),
+			Suggestion:   "Use Tool, Tool(argument) or mcp__server__tool, e.g. Read, Bash(git diff:*), mcp__github__get_issue",
+		})
+	}
+	for _, g := range grants {
+		if g.Known {
+			continue
+		}
+		diagnostics = append(diagnostics, validateDiagnostic{
+			Severity:     "warning",
+			Code:         "unknown_tool",
+			Message:      fmt.Sprintf("%s: %q is not a built-in tool", g.Field, g.Tool),
+			Field:        g.Field,
+			ResourceType: string(res.Type),
+			Suggestion:   "Check the spelling; MCP tools are written mcp__<server>__<tool>",
+		})
+	}
+	return diagnostics
+}
+
+// toolSchemaDiagnostics checks a resource against the schema of each --target
+// tool. Schema violations are errors; targets that do not install the
+// resource type get a warning.
+func toolSchemaDiagnostics(res *resource.Resource, opts resourceValidateOptions) []validateDiagnostic {
+	var diagnostics []validateDiagnostic
+	for _, tool := range opts.targets {
+		checked, err := schema.CheckResource(res, tool, opts.mappings)
+		if err != nil {
+			diagnostics = append(diagnostics, validateDiagnostic{
+				Severity:     "error",
+				Code:         "tool_schema_error",
+				Message:      fmt.Sprintf("%s: %v", tool, err),
+				Tool:         tool.String(),
+				ResourceType: string(res.Type),
+			})
+			continue
+		}
+		if !checked.Supported {
+			diagnostics = append(diagnostics, validateDiagnostic{
+				Severity:     "warning",
+				Code:         "unsupported_by_tool",
+				Message:      fmt.Sprintf("%s does not support %ss; the resource is skipped when installing for %s", tools.GetToolInfo(tool).Name, res.Type, tool),
+				Tool:         tool.String(),
+				ResourceType: string(res.Type),
+			})
+			continue
+		}
+		for _, issue := range checked.Issues {
+			message := fmt.Sprintf("%s would reject %s: %s", tool, issue.Field, issue.Message)
+			if field, _, _ := strings.Cut(issue.Field, "."); checked.IsMapped(field) {
+				message += " (after field mappings)"
+			}
+			diagnostics = append(diagnostics, validateDiagnostic{
+				Severity:     "error",
+				Code:         "tool_schema_violation",
+				Message:      message,
+				Field:        issue.Field,
+				Tool:         tool.String(),
+				FilePath:     res.Path,
+				ResourceName: res.Name,
+				ResourceType: string(res.Type),
+			})
+		}
+	}
+	return diagnostics
+}
+
+// summarizeDiagnostics counts diagnostics by severity.
+func summarizeDiagnostics(diagnostics []validateDiagnostic) validateSummary {
+	var summary validateSummary
+	for _, d := range diagnostics {
+		if d.Severity == "warning" {
+			summary.WarningCount++
+		} else {
+			summary.ErrorCount++
+		}
+	}
+	return summary
+}
+
 func validatePackagePathTarget(originalTarget, resolvedPath string, opts resourceValidateOptions) resourceValidationResult {
 	result := resourceValidationResult{
 		Target:       originalTarget,
@@ -657,7 +893,7 @@ func outputResourceValidateResult(result *resourceValidationResult, valid bool,
 
 	result.Valid = valid
 	if result.Summary.ErrorCount == 0 {
-		result.Summary.ErrorCount = len(result.Diagnostics)
+		result.Summary = summarizeDiagnostics(result.Diagnostics)
 	}
 
 	switch parsedFormat {
@@ -744,4 +980,6 @@ func init() {
 
 	resourceValidateCmd.Flags().StringVar(&resourceValidateSourceRootFlag, "source-root", "", "Source root for canonical resource ID resolution")
 	resourceValidateCmd.Flags().StringVar(&resourceValidateRepoManifestFlag, "repo-manifest", "", "Manifest path/url for canonical resource ID resolution")
+	resourceValidateCmd.Flags().StringSliceVar(&resourceValidateTargetFlag, "target", nil, "Check against the frontmatter schema of these tools (claude,opencode,copilot,windsurf)")
+	_ = resourceValidateCmd.RegisterFlagCompletionFunc("target", completeToolNames)
 }
@@ -8,6 +8,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
 	"gopkg.in/yaml.v3"
 )
 
@@ -90,6 +92,70 @@ description: test command
 	})
 }
 
+func TestRunResourceValidate_ToolTargets(t *testing.T) {
+	agentsDir := filepath.Join(t.TempDir(), "agents")
+	if err := os.MkdirAll(agentsDir, 0755); err != nil {
+		t.Fatalf("mkdir agents dir: %v", err)
+	}
+	agentPath := filepath.Join(agentsDir, "reviewer.md")
+	agentContent := `---
+name: reviewer
+description: Reviews code
+tools: Read, Grep
+model: sonnet-4.5
+---
+# Agent
+`
+	if err := os.WriteFile(agentPath, []byte(agentContent), 0644); err != nil {
+		t.Fatalf("write agent: %v", err)
+	}
+
+	claudeOnly := runResourceValidate(agentPath, resourceValidateOptions{format: "table", targets: []tools.Tool{tools.Claude}})
+	if claudeOnly.ExitCode != 0 {
+		t.Fatalf("expected agent to be valid for claude, got diagnostics %+v", claudeOnly.Output.Diagnostics)
+	}
+
+	mappings := config.TypeMappings{Agent: config.FieldMappings{"model": {"sonnet-4.5": {"opencode": "anthropic/claude-sonnet-4-5"}}}}
+	result := runResourceValidate(agentPath, resourceValidateOptions{
+		format:   "table",
+		targets:  []tools.Tool{tools.Claude, tools.OpenCode, tools.Windsurf},
+		mappings: mappings,
+	})
+	if result.ExitCode != resourceValidateExitValidationError {
+		t.Fatalf("expected exit code %d, got %d", resourceValidateExitValidationError, result.ExitCode)
+	}
+
+	var violations, warnings []validateDiagnostic
+	for _, d := range result.Output.Diagnostics {
+		switch d.Code {
+		case "tool_schema_violation":
+			violations = append(violations, d)
+		case "unsupported_by_tool":
+			warnings = append(warnings, d)
+		}
+	}
+	// The mapped model is valid for opencode; the tools string is not.
+	if len(violations) != 1 || violations[0].Tool != "opencode" || violations[0].Field != "tools" {
+		t.Fatalf("expected one opencode violation for tools, got %+v", violations)
+	}
+	if len(warnings) != 1 || warnings[0].Tool != "windsurf" {
+		t.Fatalf("expected windsurf unsupported warning, got %+v", warnings)
+	}
+}
+
+func TestParseValidateTargets(t *testing.T) {
+	targets, err := parseValidateTargets([]string{"claude,opencode", "claude"})
+	if err != nil {
+		t.Fatalf("parseValidateTargets() error = %v", err)
+	}
+	if len(targets) != 2 || targets[0] != tools.Claude || targets[1] != tools.OpenCode {
+		t.Fatalf("parseValidateTargets() = %v, want [claude opencode]", targets)
+	}
+	if _, err := parseValidateTargets([]string{"emacs"}); err == nil {
+		t.Fatal("expected error for unknown target")
+	}
+}
+
 func TestRunResourceValidate_CanonicalIDWithSourceRoot(t *testing.T) {
 	root := t.TempDir()
 	commandsDir := filepath.Join(root, "commands", "team")
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/discovery"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/logging"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/manifest"
@@ -19,6 +20,7 @@ var (
 	cfgFile     string
 	versionFlag bool
 	logLevel    string
+	profileFlag string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -30,6 +32,7 @@ AI resources like commands and skills across different LLM tools
 (Claude Code, OpenCode, etc.).
 
 It helps you organize and share reusable AI configurations.`,
+	PersistentPreRunE: selectActiveProfile,
 	Run: func(cmd *cobra.Command, args []string) {
 		if versionFlag {
 			fmt.Println(version.GetVersion())
@@ -94,6 +97,8 @@ func init() {
 
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.config/aimgr/aimgr.yaml)")
 	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "log level (debug, info, warn, error)")
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "repository profile from aimgr.yaml (overrides "+config.ProfileEnvVar+" and project pins)")
+	_ = rootCmd.RegisterFlagCompletionFunc("profile", completeProfileNames)
 
 	// Version flag
 	rootCmd.Flags().BoolVarP(&versionFlag, "version", "v", false, "Show version information")
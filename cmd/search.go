@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/output"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/search"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
+	"github.com/spf13/cobra"
+)
+
+var (
+	searchTypeFlag   []string
+	searchSourceFlag []string
+	searchToolFlag   []string
+	searchLimitFlag  int
+	searchFormatFlag string
+)
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "Search repository resources by content",
+	Long: `Search skills, commands, agents and packages in the repository by name,
+description, tags and body text. Results are ranked by relevance: matches in
+names count most, then tags, descriptions and body text. A query word also
+matches longer words it starts with ("form" finds "forms").
+
+The search index is kept in .metadata/search-index.json and updated
+incrementally by repo add, sync and remove; resources edited by hand are
+re-indexed the next time you search.
+
+Filters:
+  --type     Only these resource types (command, skill, agent, package)
+  --source   Only resources from these sources (names from ai.repo.yaml)
+  --tool     Only resources every listed tool can install (claude, opencode,
+             copilot, windsurf); packages match if the tool supports a member
+
+Examples:
+  aimgr search "pdf forms"
+  aimgr search review --type skill,agent
+  aimgr search deploy --source platform --tool copilot
+  aimgr search "database migration" --format json`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSearch,
+}
+
+func init() {
+	rootCmd.AddCommand(searchCmd)
+	searchCmd.Flags().StringSliceVar(&searchTypeFlag, "type", nil, "Filter by resource type (command, skill, agent, package); repeatable or comma-separated")
+	searchCmd.Flags().StringSliceVar(&searchSourceFlag, "source", nil, "Filter by source name; repeatable or comma-separated")
+	searchCmd.Flags().StringSliceVar(&searchToolFlag, "tool", nil, "Only resources installable by these tools; repeatable or comma-separated")
+	searchCmd.Flags().IntVar(&searchLimitFlag, "limit", 20, "Maximum number of results (0 for all)")
+	searchCmd.Flags().StringVar(&searchFormatFlag, "format", "table", "Output format (table|json|yaml)")
+	_ = searchCmd.RegisterFlagCompletionFunc("type", completeResourceTypes)
+	_ = searchCmd.RegisterFlagCompletionFunc("source", completeSourceNames)
+	_ = searchCmd.RegisterFlagCompletionFunc("tool", completeToolNames)
+	_ = searchCmd.RegisterFlagCompletionFunc("format", completeFormatFlag)
+}
+
+// buildSearchQuery turns the command arguments and flags into a query.
+func buildSearchQuery(args []string) (search.Query, error) {
+	query := search.Query{
+		Text:    strings.Join(args, " "),
+		Sources: searchSourceFlag,
+		Limit:   searchLimitFlag,
+	}
+	if query.Limit < 0 {
+		return query, fmt.Errorf("--limit must not be negative")
+	}
+	for _, value := range searchTypeFlag {
+		resType := resource.ResourceType(strings.ToLower(strings.TrimSpace(value)))
+		switch resType {
+		case resource.Command, resource.Skill, resource.Agent, resource.PackageType:
+			query.Types = append(query.Types, resType)
+		default:
+			return query, fmt.Errorf("invalid --type %q (must be command, skill, agent or package)", value)
+		}
+	}
+	for _, value := range searchToolFlag {
+		tool, err := tools.ParseTool(strings.TrimSpace(value))
+		if err != nil {
+			return query, err
+		}
+		query.Tools = append(query.Tools, tool)
+	}
+	return query, nil
+}
+
+func runSearch(cmd *cobra.Command, args []string) error {
+	format, err := output.ParseFormat(searchFormatFlag)
+	if err != nil {
+		return err
+	}
+	query, err := buildSearchQuery(args)
+	if err != nil {
+		return err
+	}
+
+	manager, err := NewManagerWithLogLevel()
+	if err != nil {
+		return err
+	}
+	repoLock, repoExists, err := acquireRepoReadLockIfRepoExists(cmd.Context(), manager)
+	if err != nil {
+		return err
+	}
+	if !repoExists {
+		return missingRepoPathError(manager.GetRepoPath())
+	}
+	defer func() {
+		_ = repoLock.Unlock()
+	}()
+
+	repoPath := manager.GetRepoPath()
+	idx := search.Load(repoPath)
+	changed, err := idx.Refresh(repoPath)
+	if err != nil {
+		return fmt.Errorf("failed to update search index: %w", err)
+	}
+	if changed > 0 {
+		// Writers are excluded by the read lock and the write is atomic, so
+		// saving here only spares the next search the same refresh.
+		if err := idx.Save(repoPath); err != nil && manager.GetLogger() != nil {
+			manager.GetLogger().Warn("failed to save search index", "error", err.Error())
+		}
+	}
+
+	results := idx.Search(query)
+	if format != output.Table {
+		return output.FormatOutput(results, format)
+	}
+
+	if len(results) == 0 {
+		fmt.Printf("No resources match %q.\n", query.Text)
+		return nil
+	}
+	table := output.NewTable("NAME", "SOURCE", "SCORE", "MATCHED", "DESCRIPTION").WithResponsive().WithDynamicColumn(4)
+	for _, result := range results {
+		table.AddRow(result.Ref, result.Source, strconv.FormatFloat(result.Score, 'f', 2, 64),
+			strings.Join(result.Matched, ","), result.Description)
+	}
+	return table.Format(format)
+}
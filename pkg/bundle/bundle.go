@@ -0,0 +1,383 @@
+// Package bundle reads and writes offline repository bundles.
+//
+// A bundle is a gzip-compressed tar archive that mirrors the repository
+// layout for a selection of resources, so it can be restored on a machine
+// without network access:
+//
+//	bundle.json                  bundle manifest (resources, caches)
+//	ai.repo.yaml                 sources that provided the bundled resources
+//	commands/, skills/, agents/  resource content
+//	packages/                    package definitions
+//	.metadata/                   resource and package metadata
+//	caches/<hash>/               workspace caches of the bundled remote sources
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// ManifestFileName is the bundle manifest at the archive root.
+	ManifestFileName = "bundle.json"
+
+	// FormatVersion is the bundle format written by this version of aimgr.
+	FormatVersion = 1
+
+	// CachesDir is the archive directory holding workspace caches.
+	CachesDir = "caches"
+)
+
+// Manifest describes the content of a bundle.
+type Manifest struct {
+	Version   int        `json:"version"`
+	CreatedAt time.Time  `json:"created_at"`
+	Resources []Resource `json:"resources"`
+	Caches    []Cache    `json:"caches,omitempty"`
+}
+
+// Resource is one bundled resource.
+type Resource struct {
+	Ref    string `json:"ref"`              // "type/name" reference
+	Path   string `json:"path"`             // Archive path of the resource content
+	Source string `json:"source,omitempty"` // Source name from ai.repo.yaml, if any
+}
+
+// Cache is one bundled workspace cache.
+type Cache struct {
+	URL  string `json:"url"`           // Clone URL the cache belongs to
+	Ref  string `json:"ref,omitempty"` // Ref checked out in the cache
+	Path string `json:"path"`          // Archive directory of the cache
+}
+
+// Writer writes a bundle archive.
+type Writer struct {
+	file    *os.File
+	gz      *gzip.Writer
+	tw      *tar.Writer
+	written map[string]bool
+}
+
+// Create creates (or truncates) the bundle archive at path.
+func Create(path string) (*Writer, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bundle: %w", err)
+	}
+
+	gz := gzip.NewWriter(file)
+	return &Writer{
+		file:    file,
+		gz:      gz,
+		tw:      tar.NewWriter(gz),
+		written: make(map[string]bool),
+	}, nil
+}
+
+// AddPath adds a file or directory tree under archivePath. A symlink at
+// srcPath itself is followed (symlink-mode resources are bundled by content);
+// symlinks inside a directory tree are stored as symlinks.
+func (w *Writer) AddPath(archivePath, srcPath string) error {
+	info, err := os.Stat(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", srcPath, err)
+	}
+
+	if !info.IsDir() {
+		return w.addFile(archivePath, srcPath, info)
+	}
+
+	root, err := filepath.EvalSymlinks(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", srcPath, err)
+	}
+
+	return filepath.Walk(root, func(p string, fi os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		name := path.Join(archivePath, filepath.ToSlash(rel))
+
+		switch {
+		case fi.Mode()&os.ModeSymlink != 0:
+			target, err := os.Readlink(p)
+			if err != nil {
+				return fmt.Errorf("failed to read symlink %s: %w", p, err)
+			}
+			return w.writeHeader(&tar.Header{Typeflag: tar.TypeSymlink, Name: name, Linkname: target, Mode: 0777, ModTime: fi.ModTime()})
+		case fi.IsDir():
+			return w.writeHeader(&tar.Header{Typeflag: tar.TypeDir, Name: name + "/", Mode: int64(fi.Mode().Perm()), ModTime: fi.ModTime()})
+		case fi.Mode().IsRegular():
+			return w.addFile(name, p, fi)
+		default:
+			// Sockets, devices and pipes have no place in a bundle.
+			return nil
+		}
+	})
+}
+
+// AddBytes adds a regular file with the given content.
+func (w *Writer) AddBytes(archivePath string, data []byte) error {
+	if err := w.writeHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     archivePath,
+		Mode:     0644,
+		Size:     int64(len(data)),
+		ModTime:  time.Now(),
+	}); err != nil {
+		return err
+	}
+	if _, err := w.tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", archivePath, err)
+	}
+	return nil
+}
+
+// WriteManifest adds the bundle manifest.
+func (w *Writer) WriteManifest(m *Manifest) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal bundle manifest: %w", err)
+	}
+	return w.AddBytes(ManifestFileName, data)
+}
+
+// Close finishes the archive. The archive is incomplete until Close succeeds.
+func (w *Writer) Close() error {
+	errs := []error{w.tw.Close(), w.gz.Close(), w.file.Close()}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("failed to finish bundle: %w", err)
+	}
+	return nil
+}
+
+func (w *Writer) addFile(archivePath, srcPath string, info os.FileInfo) error {
+	file, err := os.Open(srcPath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", srcPath, err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	if err := w.writeHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     archivePath,
+		Mode:     int64(info.Mode().Perm()),
+		Size:     info.Size(),
+		ModTime:  info.ModTime(),
+	}); err != nil {
+		return err
+	}
+	if _, err := io.Copy(w.tw, file); err != nil {
+		return fmt.Errorf("failed to write %s: %w", archivePath, err)
+	}
+	return nil
+}
+
+// writeHeader writes a tar header once per archive path. Directories may be
+// announced more than once (e.g. nested command folders); files may not.
+func (w *Writer) writeHeader(hdr *tar.Header) error {
+	key := strings.TrimSuffix(hdr.Name, "/")
+	if w.written[key] {
+		if hdr.Typeflag == tar.TypeDir {
+			return nil
+		}
+		return fmt.Errorf("duplicate bundle entry: %s", key)
+	}
+	w.written[key] = true
+
+	if err := w.tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("failed to write %s: %w", hdr.Name, err)
+	}
+	return nil
+}
+
+// Extract unpacks the bundle at archivePath into destDir and returns its
+// manifest. Bundle content is untrusted: entries that would escape destDir,
+// directly or through a symlink extracted earlier, are rejected, as are
+// symlinks that resolve outside destDir.
+func Extract(archivePath, destDir string) (*Manifest, error) {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bundle: %w", err)
+	}
+	defer func() {
+		_ = file.Close()
+	}()
+
+	gz, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("not a bundle archive %s: %w", archivePath, err)
+	}
+	defer func() {
+		_ = gz.Close()
+	}()
+
+	if err := os.MkdirAll(destDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", destDir, err)
+	}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bundle: %w", err)
+		}
+		if err := extractEntry(tr, hdr, destDir); err != nil {
+			return nil, err
+		}
+	}
+	if err := checkSymlinks(destDir); err != nil {
+		return nil, err
+	}
+
+	return LoadManifest(destDir)
+}
+
+// LoadManifest reads the bundle manifest from an extracted bundle.
+func LoadManifest(dir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("invalid bundle: %s not found", ManifestFileName)
+		}
+		return nil, fmt.Errorf("failed to read bundle manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse bundle manifest: %w", err)
+	}
+	if m.Version < 1 || m.Version > FormatVersion {
+		return nil, fmt.Errorf("unsupported bundle version %d (supported: 1-%d)", m.Version, FormatVersion)
+	}
+
+	return &m, nil
+}
+
+func extractEntry(r io.Reader, hdr *tar.Header, destDir string) error {
+	target, err := safeJoin(destDir, hdr.Name)
+	if err != nil {
+		return err
+	}
+	if err := checkNoSymlinkInPath(destDir, hdr.Name); err != nil {
+		return err
+	}
+
+	switch hdr.Typeflag {
+	case tar.TypeDir:
+		return os.MkdirAll(target, 0755)
+	case tar.TypeReg:
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", hdr.Name, err)
+		}
+		return writeFile(target, r, os.FileMode(hdr.Mode).Perm()|0200)
+	case tar.TypeSymlink:
+		if filepath.IsAbs(hdr.Linkname) {
+			return fmt.Errorf("invalid bundle entry %s: absolute symlink target", hdr.Name)
+		}
+		if _, err := safeJoin(destDir, path.Join(path.Dir(hdr.Name), hdr.Linkname)); err != nil {
+			return fmt.Errorf("invalid bundle entry %s: symlink escapes bundle", hdr.Name)
+		}
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", hdr.Name, err)
+		}
+		return os.Symlink(hdr.Linkname, target)
+	default:
+		return nil
+	}
+}
+
+func writeFile(target string, r io.Reader, mode os.FileMode) error {
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", target, err)
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write %s: %w", target, err)
+	}
+	return file.Close()
+}
+
+// checkNoSymlinkInPath rejects an archive path that goes through a symlink
+// below destDir, so a chain of links like "a -> ." and "b -> a/.." cannot
+// redirect a later write outside destDir. Missing components are fine: they
+// are created as real directories.
+func checkNoSymlinkInPath(destDir, name string) error {
+	current := destDir
+	for _, segment := range strings.Split(strings.TrimSuffix(name, "/"), "/") {
+		if segment == "" || segment == "." {
+			continue
+		}
+		current = filepath.Join(current, segment)
+		info, err := os.Lstat(current)
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to check %s: %w", name, err)
+		}
+		if info.Mode()&os.ModeSymlink != 0 {
+			return fmt.Errorf("invalid bundle entry %s: path goes through a symlink", name)
+		}
+	}
+	return nil
+}
+
+// checkSymlinks rejects extracted symlinks that resolve outside destDir, so
+// reading the extracted content never reaches other files on the machine.
+// Dangling links are left alone: they resolve to nothing.
+func checkSymlinks(destDir string) error {
+	root, err := filepath.EvalSymlinks(destDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve %s: %w", destDir, err)
+	}
+	return filepath.WalkDir(destDir, func(p string, d os.DirEntry, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		}
+		if d.Type()&os.ModeSymlink == 0 {
+			return nil
+		}
+		resolved, err := filepath.EvalSymlinks(p)
+		if err != nil {
+			return nil
+		}
+		if rel, err := filepath.Rel(root, resolved); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			name, _ := filepath.Rel(destDir, p)
+			return fmt.Errorf("invalid bundle entry %s: symlink escapes bundle", filepath.ToSlash(name))
+		}
+		return nil
+	})
+}
+
+// safeJoin resolves an archive path below destDir, rejecting absolute paths
+// and ".." segments that would leave it.
+func safeJoin(destDir, name string) (string, error) {
+	if strings.HasPrefix(name, "/") || filepath.IsAbs(name) {
+		return "", fmt.Errorf("invalid bundle entry %s: absolute path", name)
+	}
+	for _, segment := range strings.Split(name, "/") {
+		if segment == ".." {
+			return "", fmt.Errorf("invalid bundle entry %s: path escapes bundle", name)
+		}
+	}
+	return filepath.Join(destDir, filepath.FromSlash(name)), nil
+}
@@ -0,0 +1,140 @@
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriterExtract_RoundTrip(t *testing.T) {
+	src := t.TempDir()
+	skillDir := filepath.Join(src, "pdf")
+	if err := os.MkdirAll(filepath.Join(skillDir, "scripts"), 0755); err != nil {
+		t.Fatalf("failed to create skill: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("---\nname: pdf\n---\n"), 0644); err != nil {
+		t.Fatalf("failed to write SKILL.md: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "scripts", "run.sh"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+	if err := os.Symlink("scripts/run.sh", filepath.Join(skillDir, "run")); err != nil {
+		t.Fatalf("failed to create symlink: %v", err)
+	}
+
+	// Symlink-mode resources are bundled by content.
+	linkedSkill := filepath.Join(src, "linked")
+	if err := os.Symlink(skillDir, linkedSkill); err != nil {
+		t.Fatalf("failed to create skill symlink: %v", err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "resources.tar.gz")
+	w, err := Create(archive)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if err := w.AddPath("skills/pdf", linkedSkill); err != nil {
+		t.Fatalf("AddPath() error = %v", err)
+	}
+	if err := w.AddBytes("ai.repo.yaml", []byte("version: 1\n")); err != nil {
+		t.Fatalf("AddBytes() error = %v", err)
+	}
+	manifest := &Manifest{
+		Version:   FormatVersion,
+		CreatedAt: time.Now().UTC(),
+		Resources: []Resource{{Ref: "skill/pdf", Path: "skills/pdf", Source: "team"}},
+	}
+	if err := w.WriteManifest(manifest); err != nil {
+		t.Fatalf("WriteManifest() error = %v", err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	dest := t.TempDir()
+	got, err := Extract(archive, dest)
+	if err != nil {
+		t.Fatalf("Extract() error = %v", err)
+	}
+	if len(got.Resources) != 1 || got.Resources[0].Source != "team" {
+		t.Fatalf("unexpected manifest: %+v", got)
+	}
+
+	info, err := os.Lstat(filepath.Join(dest, "skills", "pdf"))
+	if err != nil || !info.IsDir() {
+		t.Fatalf("expected skills/pdf to be extracted as a directory, got %v (%v)", info, err)
+	}
+	scriptInfo, err := os.Stat(filepath.Join(dest, "skills", "pdf", "scripts", "run.sh"))
+	if err != nil {
+		t.Fatalf("script missing: %v", err)
+	}
+	if scriptInfo.Mode().Perm()&0100 == 0 {
+		t.Errorf("script mode = %v, want executable", scriptInfo.Mode().Perm())
+	}
+	if target, err := os.Readlink(filepath.Join(dest, "skills", "pdf", "run")); err != nil || target != "scripts/run.sh" {
+		t.Errorf("inner symlink = %q (%v), want scripts/run.sh", target, err)
+	}
+}
+
+func TestExtract_RejectsEscapingEntries(t *testing.T) {
+	tests := []struct {
+		name string
+		hdrs []tar.Header
+	}{
+		{name: "parent path", hdrs: []tar.Header{{Typeflag: tar.TypeReg, Name: "../evil", Mode: 0644}}},
+		{name: "absolute path", hdrs: []tar.Header{{Typeflag: tar.TypeReg, Name: "/etc/evil", Mode: 0644}}},
+		{name: "escaping symlink", hdrs: []tar.Header{{Typeflag: tar.TypeSymlink, Name: "skills/x", Linkname: "../../outside"}}},
+		{name: "write through chained symlinks", hdrs: []tar.Header{
+			{Typeflag: tar.TypeSymlink, Name: "a", Linkname: "."},
+			{Typeflag: tar.TypeSymlink, Name: "b", Linkname: "a/.."},
+			{Typeflag: tar.TypeReg, Name: "b/evil", Mode: 0644},
+		}},
+		{name: "chained symlinks resolving outside", hdrs: []tar.Header{
+			{Typeflag: tar.TypeSymlink, Name: "a", Linkname: "."},
+			{Typeflag: tar.TypeSymlink, Name: "b", Linkname: "a/.."},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			archive := filepath.Join(t.TempDir(), "evil.tar.gz")
+			file, err := os.Create(archive)
+			if err != nil {
+				t.Fatalf("failed to create archive: %v", err)
+			}
+			gz := gzip.NewWriter(file)
+			tw := tar.NewWriter(gz)
+			for i := range tt.hdrs {
+				if err := tw.WriteHeader(&tt.hdrs[i]); err != nil {
+					t.Fatalf("failed to write header: %v", err)
+				}
+			}
+			_ = tw.Close()
+			_ = gz.Close()
+			_ = file.Close()
+
+			parent := t.TempDir()
+			if _, err := Extract(archive, filepath.Join(parent, "dest")); err == nil || !strings.Contains(err.Error(), "invalid bundle entry") {
+				t.Fatalf("Extract() error = %v, want invalid bundle entry", err)
+			}
+			if _, err := os.Stat(filepath.Join(parent, "evil")); !os.IsNotExist(err) {
+				t.Fatalf("entry was written outside the destination, stat error = %v", err)
+			}
+		})
+	}
+}
+
+func TestLoadManifest_RejectsUnknownVersion(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ManifestFileName), []byte(`{"version": 99}`), 0644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	if _, err := LoadManifest(dir); err == nil || !strings.Contains(err.Error(), "unsupported bundle version") {
+		t.Fatalf("LoadManifest() error = %v, want unsupported version", err)
+	}
+}
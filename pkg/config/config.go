@@ -1,14 +1,18 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/adrg/xdg"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/gitauth"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
 	"github.com/spf13/viper"
@@ -90,6 +94,163 @@ type Config struct {
 
 	// Mappings holds tool-specific field transformations for each resource type
 	Mappings TypeMappings `yaml:"mappings,omitempty"`
+
+	// Credentials maps HTTPS host patterns to git credential providers
+	Credentials []gitauth.Rule `yaml:"credentials,omitempty"`
+
+	// Policy is the path or HTTP(S) URL of an organization policy enforced
+	// by repo add, repo sync, repo apply-manifest and install
+	Policy string `yaml:"policy,omitempty"`
+
+	// Tokens sets the warning thresholds of 'aimgr repo stats --tokens'
+	Tokens TokensConfig `yaml:"tokens,omitempty"`
+
+	// Profile is the default profile, set by 'aimgr profile use'
+	Profile string `yaml:"profile,omitempty"`
+
+	// Profiles are named repositories with their own default install targets
+	Profiles map[string]Profile `yaml:"profiles,omitempty"`
+
+	// ActiveProfile is the profile in effect after loading: the selected
+	// profile (see SelectProfile) or Profile. Empty when no profile is used.
+	ActiveProfile string `yaml:"-"`
+}
+
+// PolicyLocation returns the configured policy with ~ expanded for local
+// paths, or "" when no policy is configured.
+func (c *Config) PolicyLocation() (string, error) {
+	if c.Policy == "" || strings.HasPrefix(c.Policy, "http://") || strings.HasPrefix(c.Policy, "https://") {
+		return c.Policy, nil
+	}
+	return normalizeRepoPath(c.Policy)
+}
+
+// TokensConfig holds token budget warning thresholds. Zero disables a
+// threshold.
+type TokensConfig struct {
+	// MaxDescription is the budget for one resource's description, which is
+	// always in context
+	MaxDescription int `yaml:"maxDescription,omitempty"`
+
+	// MaxResource is the budget for one resource's description, body and
+	// references together
+	MaxResource int `yaml:"maxResource,omitempty"`
+
+	// MaxProjectDescriptions is the budget for the descriptions of all
+	// resources in a project's ai.package.yaml
+	MaxProjectDescriptions int `yaml:"maxProjectDescriptions,omitempty"`
+
+	// MaxProjectTotal is the budget for everything in a project's
+	// ai.package.yaml
+	MaxProjectTotal int `yaml:"maxProjectTotal,omitempty"`
+}
+
+// Validate checks that no threshold is negative.
+func (c TokensConfig) Validate() error {
+	thresholds := []struct {
+		name  string
+		value int
+	}{
+		{"maxDescription", c.MaxDescription},
+		{"maxResource", c.MaxResource},
+		{"maxProjectDescriptions", c.MaxProjectDescriptions},
+		{"maxProjectTotal", c.MaxProjectTotal},
+	}
+	for _, t := range thresholds {
+		if t.value < 0 {
+			return fmt.Errorf("tokens.%s: must not be negative, got %d", t.name, t.value)
+		}
+	}
+	return nil
+}
+
+// Profile is a named repository with its own default install targets.
+type Profile struct {
+	// Path is the repository path used while the profile is active
+	Path string `yaml:"path"`
+
+	// Targets replaces install.targets while the profile is active
+	Targets []string `yaml:"targets,omitempty"`
+}
+
+// ProfileEnvVar selects a profile for a single invocation.
+const ProfileEnvVar = "AIMGR_PROFILE"
+
+// ErrUnknownProfile is returned by LoadGlobal when the selected or default
+// profile is not defined.
+var ErrUnknownProfile = errors.New("unknown profile")
+
+// selectedProfile overrides Config.Profile for this process (--profile,
+// AIMGR_PROFILE or a project pin).
+var selectedProfile string
+
+// SelectProfile makes LoadGlobal activate the named profile instead of the
+// configured default. An empty name restores the default.
+func SelectProfile(name string) {
+	selectedProfile = name
+}
+
+// SelectedProfile returns the profile passed to SelectProfile.
+func SelectedProfile() string {
+	return selectedProfile
+}
+
+var profileNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateProfileName checks that name can be used as a profile name.
+func ValidateProfileName(name string) error {
+	if !profileNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '.', '_' and '-', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+// ProfileNames returns the defined profile names, sorted.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// EffectiveRepoPath returns the repository path of the active profile, or
+// repo.path when no profile is active.
+func (c *Config) EffectiveRepoPath() string {
+	if profile, ok := c.Profiles[c.ActiveProfile]; ok && c.ActiveProfile != "" {
+		return profile.Path
+	}
+	return c.Repo.Path
+}
+
+// ProfileForRepoPath returns the profile whose repository is repoPath.
+func (c *Config) ProfileForRepoPath(repoPath string) (string, bool) {
+	for _, name := range c.ProfileNames() {
+		if filepath.Clean(c.Profiles[name].Path) == filepath.Clean(repoPath) {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// activateProfile sets ActiveProfile from the selected or default profile.
+func (c *Config) activateProfile() error {
+	name := selectedProfile
+	if name == "" {
+		name = c.Profile
+	}
+	if name == "" {
+		return nil
+	}
+	if _, ok := c.Profiles[name]; !ok {
+		if len(c.Profiles) == 0 {
+			return fmt.Errorf("%w %q: no profiles are defined in aimgr.yaml", ErrUnknownProfile, name)
+		}
+		return fmt.Errorf("%w %q (defined: %s)", ErrUnknownProfile, name, strings.Join(c.ProfileNames(), ", "))
+	}
+	c.ActiveProfile = name
+	return nil
 }
 
 // InstallConfig holds installation-related configuration
@@ -103,6 +264,280 @@ type InstallConfig struct {
 type RepoConfig struct {
 	// Path is an optional custom repository path
 	Path string `yaml:"path,omitempty"`
+
+	// AutoSync refreshes stale sources before install and list
+	AutoSync AutoSyncConfig `yaml:"autoSync,omitempty"`
+
+	// LocalChanges is what sync does with resources edited inside the
+	// repository: keep-local (default), take-upstream or merge
+	LocalChanges string `yaml:"localChanges,omitempty"`
+
+	// Cache limits the size and idle age of the workspace caches
+	Cache CacheConfig `yaml:"cache,omitempty"`
+
+	// Secrets configures secret scanning of imported files
+	Secrets SecretsConfig `yaml:"secrets,omitempty"`
+}
+
+// Secret scanning policies for repo add and sync.
+const (
+	SecretsWarn  = "warn"  // import and report findings as warnings (default)
+	SecretsBlock = "block" // refuse to import resources with findings
+	SecretsOff   = "off"   // do not scan
+)
+
+// SecretsConfig configures the secret scan run on every imported resource.
+type SecretsConfig struct {
+	// Policy is warn (default), block or off
+	Policy string `yaml:"policy,omitempty"`
+
+	// Patterns are extra secret regexes; the first capture group, if any,
+	// is the secret
+	Patterns []SecretPattern `yaml:"patterns,omitempty"`
+
+	// EntropyThreshold is the bits-per-character entropy above which quoted
+	// strings are reported (default 4.5); a negative value disables it
+	EntropyThreshold float64 `yaml:"entropyThreshold,omitempty"`
+
+	// Allowlist is an allowlist file applied to every source, in addition to
+	// the .aimgr-secrets-allowlist file at each source root
+	Allowlist string `yaml:"allowlist,omitempty"`
+}
+
+// SecretPattern is a named custom secret regex.
+type SecretPattern struct {
+	ID    string `yaml:"id"`
+	Regex string `yaml:"regex"`
+}
+
+// PolicyOrDefault returns the configured policy, defaulting to warn.
+func (c SecretsConfig) PolicyOrDefault() string {
+	if c.Policy == "" {
+		return SecretsWarn
+	}
+	return c.Policy
+}
+
+// AllowlistPath returns the allowlist path with ~ expanded, or "" when
+// none is configured.
+func (c SecretsConfig) AllowlistPath() (string, error) {
+	if c.Allowlist == "" {
+		return "", nil
+	}
+	return normalizeRepoPath(c.Allowlist)
+}
+
+// ValidateSecretsPolicy checks a secret scanning policy value.
+func ValidateSecretsPolicy(policy string) error {
+	switch policy {
+	case SecretsWarn, SecretsBlock, SecretsOff:
+		return nil
+	default:
+		return fmt.Errorf("invalid secrets policy %q (must be %s, %s or %s)", policy, SecretsWarn, SecretsBlock, SecretsOff)
+	}
+}
+
+// Validate checks the policy and compiles the custom patterns.
+func (c SecretsConfig) Validate() error {
+	if c.Policy != "" {
+		if err := ValidateSecretsPolicy(c.Policy); err != nil {
+			return fmt.Errorf("repo.secrets.policy: %w", err)
+		}
+	}
+	for i, p := range c.Patterns {
+		if strings.TrimSpace(p.ID) == "" {
+			return fmt.Errorf("repo.secrets.patterns[%d]: id is required", i)
+		}
+		if _, err := regexp.Compile(p.Regex); err != nil || p.Regex == "" {
+			return fmt.Errorf("repo.secrets.patterns[%d] (%s): invalid regex %q", i, p.ID, p.Regex)
+		}
+	}
+	return nil
+}
+
+// CacheConfig is the workspace cache budget enforced after sync and by
+// 'aimgr repo cache gc'. Empty values disable a limit.
+type CacheConfig struct {
+	// MaxSize is the maximum total size of all caches, e.g. "5GB" or "500MiB"
+	MaxSize string `yaml:"maxSize,omitempty"`
+
+	// MaxAge is how long a cache may go unused, as a Go duration ("72h") or a
+	// number of days ("30d")
+	MaxAge string `yaml:"maxAge,omitempty"`
+}
+
+// Enabled reports whether any cache limit is configured.
+func (c CacheConfig) Enabled() bool {
+	return c.MaxSize != "" || c.MaxAge != ""
+}
+
+// MaxSizeBytes returns MaxSize in bytes, or 0 when unset or invalid (invalid
+// values are rejected by Validate).
+func (c CacheConfig) MaxSizeBytes() int64 {
+	if c.MaxSize == "" {
+		return 0
+	}
+	size, err := ParseByteSize(c.MaxSize)
+	if err != nil {
+		return 0
+	}
+	return size
+}
+
+// MaxIdleAge returns MaxAge as a duration, or 0 when unset or invalid.
+func (c CacheConfig) MaxIdleAge() time.Duration {
+	if c.MaxAge == "" {
+		return 0
+	}
+	age, err := parseMaxAge(c.MaxAge)
+	if err != nil {
+		return 0
+	}
+	return age
+}
+
+// Validate checks the size and age values.
+func (c CacheConfig) Validate() error {
+	if c.MaxSize != "" {
+		if _, err := ParseByteSize(c.MaxSize); err != nil {
+			return fmt.Errorf("repo.cache.maxSize: %w", err)
+		}
+	}
+	if c.MaxAge != "" {
+		if _, err := parseMaxAge(c.MaxAge); err != nil {
+			return fmt.Errorf("repo.cache.maxAge: %w", err)
+		}
+	}
+	return nil
+}
+
+var byteSizeUnits = map[string]float64{
+	"": 1, "B": 1,
+	"K": 1e3, "KB": 1e3, "KIB": 1 << 10,
+	"M": 1e6, "MB": 1e6, "MIB": 1 << 20,
+	"G": 1e9, "GB": 1e9, "GIB": 1 << 30,
+	"T": 1e12, "TB": 1e12, "TIB": 1 << 40,
+}
+
+// ParseByteSize parses a positive size such as "500MB", "1.5GiB" or "1024".
+// Decimal units (KB, MB, GB, TB) are powers of 1000, binary units (KiB, MiB,
+// GiB, TiB) powers of 1024.
+func ParseByteSize(value string) (int64, error) {
+	trimmed := strings.TrimSpace(value)
+	split := strings.IndexFunc(trimmed, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	number, unit := trimmed, ""
+	if split >= 0 {
+		number, unit = trimmed[:split], strings.TrimSpace(trimmed[split:])
+	}
+	multiplier, ok := byteSizeUnits[strings.ToUpper(unit)]
+	n, err := strconv.ParseFloat(number, 64)
+	if !ok || err != nil {
+		return 0, fmt.Errorf("invalid size %q: expected a size like 500MB or 5GB", value)
+	}
+	size := int64(n * multiplier)
+	if size <= 0 {
+		return 0, fmt.Errorf("invalid size %q: must be positive", value)
+	}
+	return size, nil
+}
+
+// Policies for resources that were edited inside the repository since they
+// were imported.
+const (
+	LocalChangesKeepLocal    = "keep-local"
+	LocalChangesTakeUpstream = "take-upstream"
+	LocalChangesMerge        = "merge"
+)
+
+// ValidateLocalChangesPolicy checks a local-changes policy value.
+func ValidateLocalChangesPolicy(policy string) error {
+	switch policy {
+	case LocalChangesKeepLocal, LocalChangesTakeUpstream, LocalChangesMerge:
+		return nil
+	default:
+		return fmt.Errorf("invalid local changes policy %q (must be %s, %s or %s)",
+			policy, LocalChangesKeepLocal, LocalChangesTakeUpstream, LocalChangesMerge)
+	}
+}
+
+// LocalChangesPolicy returns the configured local-changes policy, defaulting
+// to keep-local so sync never silently discards edits.
+func (r RepoConfig) LocalChangesPolicy() string {
+	if r.LocalChanges == "" {
+		return LocalChangesKeepLocal
+	}
+	return r.LocalChanges
+}
+
+// DefaultAutoSyncMaxAge is used when auto-sync is enabled without a maxAge.
+const DefaultAutoSyncMaxAge = 24 * time.Hour
+
+// AutoSyncConfig controls opt-in syncing of sources whose last sync is too old.
+type AutoSyncConfig struct {
+	// Enabled turns auto-sync on (disabled by default)
+	Enabled bool `yaml:"enabled"`
+
+	// MaxAge is how long a source may go unsynced before it is stale,
+	// as a Go duration ("12h") or a number of days ("7d")
+	MaxAge string `yaml:"maxAge,omitempty"`
+
+	// Sources overrides MaxAge per source name
+	Sources map[string]string `yaml:"sources,omitempty"`
+}
+
+// MaxAgeFor returns the staleness threshold for a source. Invalid values are
+// rejected by Validate, so they fall back to the default here.
+func (a AutoSyncConfig) MaxAgeFor(sourceName string) time.Duration {
+	if value, ok := a.Sources[sourceName]; ok {
+		if age, err := parseMaxAge(value); err == nil {
+			return age
+		}
+	}
+	if a.MaxAge != "" {
+		if age, err := parseMaxAge(a.MaxAge); err == nil {
+			return age
+		}
+	}
+	return DefaultAutoSyncMaxAge
+}
+
+func (a AutoSyncConfig) validate() error {
+	if a.MaxAge != "" {
+		if _, err := parseMaxAge(a.MaxAge); err != nil {
+			return fmt.Errorf("repo.autoSync.maxAge: %w", err)
+		}
+	}
+	for name, value := range a.Sources {
+		if _, err := parseMaxAge(value); err != nil {
+			return fmt.Errorf("repo.autoSync.sources.%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// parseMaxAge parses a positive Go duration or a whole number of days ("7d").
+func parseMaxAge(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	var age time.Duration
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid max age %q: expected a duration like 12h or 7d", value)
+		}
+		age = time.Duration(n) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid max age %q: expected a duration like 12h or 7d", value)
+		}
+		age = parsed
+	}
+	if age <= 0 {
+		return 0, fmt.Errorf("invalid max age %q: must be positive", value)
+	}
+	return age, nil
 }
 
 // GetConfigPath returns the path to the config file in XDG config directory
@@ -112,6 +547,22 @@ func GetConfigPath() (string, error) {
 	return filepath.Join(configDir, DefaultConfigFileName), nil
 }
 
+// GlobalConfigPath returns the config file used by LoadGlobal: the file set
+// via --config, or the default XDG location.
+func GlobalConfigPath() (string, error) {
+	// Priority 1: Check if Viper has a config file set (via --config flag)
+	if viperConfigFile := viper.ConfigFileUsed(); viperConfigFile != "" {
+		return viperConfigFile, nil
+	}
+
+	// Priority 2: Use default config path
+	configPath, err := GetConfigPath()
+	if err != nil {
+		return "", fmt.Errorf("getting config path: %w", err)
+	}
+	return configPath, nil
+}
+
 // getOldConfigPath returns the path to the legacy config file in home directory
 // Returns ~/.ai-repo.yaml
 func getOldConfigPath() (string, error) {
@@ -178,19 +629,20 @@ func Load(projectPath string) (*Config, error) {
 // Automatically migrates from old location if found
 // If no config exists, returns a default config with "claude" as the default target
 func LoadGlobal() (*Config, error) {
-	var configPath string
-	var err error
+	return loadGlobal(true)
+}
 
-	// Priority 1: Check if Viper has a config file set (via --config flag)
-	viperConfigFile := viper.ConfigFileUsed()
-	if viperConfigFile != "" {
-		configPath = viperConfigFile
-	} else {
-		// Priority 2: Use default config path
-		configPath, err = GetConfigPath()
-		if err != nil {
-			return nil, fmt.Errorf("getting config path: %w", err)
-		}
+// LoadGlobalForEdit loads the global configuration like LoadGlobal but does
+// not activate a profile, so a config with an unknown default profile can
+// still be loaded and fixed.
+func LoadGlobalForEdit() (*Config, error) {
+	return loadGlobal(false)
+}
+
+func loadGlobal(activate bool) (*Config, error) {
+	configPath, err := GlobalConfigPath()
+	if err != nil {
+		return nil, err
 	}
 
 	// Check if new config exists
@@ -218,6 +670,11 @@ func LoadGlobal() (*Config, error) {
 					Path: "",
 				},
 			}
+			if activate {
+				if err := defaultConfig.activateProfile(); err != nil {
+					return nil, err
+				}
+			}
 			return defaultConfig, nil
 		}
 	}
@@ -247,6 +704,12 @@ func LoadGlobal() (*Config, error) {
 		return nil, fmt.Errorf("invalid config: %w", err)
 	}
 
+	if activate {
+		if err := config.activateProfile(); err != nil {
+			return nil, err
+		}
+	}
+
 	return &config, nil
 }
 
@@ -284,26 +747,60 @@ func (c *Config) Validate() error {
 
 	// Validate repo path if provided
 	if c.Repo.Path != "" {
-		// Expand ~ to home directory
-		if strings.HasPrefix(c.Repo.Path, "~/") {
-			home, err := os.UserHomeDir()
-			if err != nil {
-				return fmt.Errorf("repo.path: cannot expand ~: %w", err)
-			}
-			c.Repo.Path = filepath.Join(home, c.Repo.Path[2:])
+		path, err := normalizeRepoPath(c.Repo.Path)
+		if err != nil {
+			return fmt.Errorf("repo.path: %w", err)
 		}
+		c.Repo.Path = path
+	}
 
-		// Convert to absolute path if relative
-		if !filepath.IsAbs(c.Repo.Path) {
-			absPath, err := filepath.Abs(c.Repo.Path)
-			if err != nil {
-				return fmt.Errorf("repo.path: cannot convert to absolute path: %w", err)
+	// Validate profiles
+	for _, name := range c.ProfileNames() {
+		profile := c.Profiles[name]
+		if err := ValidateProfileName(name); err != nil {
+			return fmt.Errorf("profiles: %w", err)
+		}
+		if profile.Path == "" {
+			return fmt.Errorf("profiles.%s.path is required", name)
+		}
+		path, err := normalizeRepoPath(profile.Path)
+		if err != nil {
+			return fmt.Errorf("profiles.%s.path: %w", name, err)
+		}
+		profile.Path = path
+		for _, target := range profile.Targets {
+			if _, err := tools.ParseTool(target); err != nil {
+				return fmt.Errorf("profiles.%s.targets: invalid tool '%s': %w", name, target, err)
 			}
-			c.Repo.Path = absPath
 		}
+		c.Profiles[name] = profile
+	}
 
-		// Clean the path
-		c.Repo.Path = filepath.Clean(c.Repo.Path)
+	// Validate auto-sync thresholds
+	if err := c.Repo.AutoSync.validate(); err != nil {
+		return err
+	}
+
+	// Validate workspace cache budget
+	if err := c.Repo.Cache.Validate(); err != nil {
+		return err
+	}
+
+	// Validate secret scanning
+	if err := c.Repo.Secrets.Validate(); err != nil {
+		return err
+	}
+
+	// Validate token budgets
+	if err := c.Tokens.Validate(); err != nil {
+		return err
+	}
+
+	// Validate local-changes policy
+	if c.Repo.LocalChanges != "" {
+		if err := ValidateLocalChangesPolicy(c.Repo.LocalChanges); err != nil {
+			return fmt.Errorf("repo.localChanges: %w", err)
+		}
 	}
 
 	// Validate mappings - warn about unknown tool names but don't error
@@ -311,9 +808,46 @@ func (c *Config) Validate() error {
 		c.validateMappingsToolNames()
 	}
 
+	// Validate credential providers
+	if _, err := gitauth.NewResolver(c.Credentials); err != nil {
+		return err
+	}
+
 	return nil
 }
 
+// normalizeRepoPath expands ~ and makes a repository path absolute and clean.
+func normalizeRepoPath(path string) (string, error) {
+	// Expand ~ to home directory
+	if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot expand ~: %w", err)
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	// Convert to absolute path if relative
+	if !filepath.IsAbs(path) {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return "", fmt.Errorf("cannot convert to absolute path: %w", err)
+		}
+		path = absPath
+	}
+
+	return filepath.Clean(path), nil
+}
+
+// CredentialResolver returns a resolver for the configured credential providers.
+// Returns nil when no credentials are configured.
+func (c *Config) CredentialResolver() (*gitauth.Resolver, error) {
+	if len(c.Credentials) == 0 {
+		return nil, nil
+	}
+	return gitauth.NewResolver(c.Credentials)
+}
+
 // validateMappingsToolNames checks if tool names in mappings are known
 // and logs warnings for unknown tools (allows future tools)
 func (c *Config) validateMappingsToolNames() {
@@ -342,9 +876,13 @@ func (c *Config) validateMappingsToolNames() {
 	}
 }
 
-// GetDefaultTargets returns the configured default installation targets
+// GetDefaultTargets returns the configured default installation targets,
+// taking them from the active profile when it defines any.
 func (c *Config) GetDefaultTargets() ([]tools.Tool, error) {
 	targetStrs := c.Install.Targets
+	if profile, ok := c.Profiles[c.ActiveProfile]; ok && len(profile.Targets) > 0 {
+		targetStrs = profile.Targets
+	}
 	if len(targetStrs) == 0 {
 		return nil, fmt.Errorf("install.targets is not configured")
 	}
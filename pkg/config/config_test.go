@@ -1,12 +1,15 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/adrg/xdg"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/gitauth"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
 )
@@ -1379,3 +1382,308 @@ func TestValidate_MappingsWarnsUnknownTools(t *testing.T) {
 		t.Errorf("Validate() should not error for unknown tools, got: %v", err)
 	}
 }
+
+func TestValidate_Credentials(t *testing.T) {
+	valid := &Config{
+		Install: InstallConfig{Targets: []string{"claude"}},
+		Credentials: []gitauth.Rule{
+			{Host: "gitlab.example.com", Provider: gitauth.ProviderEnv, Env: "GITLAB_TOKEN"},
+		},
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	resolver, err := valid.CredentialResolver()
+	if err != nil || resolver == nil {
+		t.Fatalf("CredentialResolver() = (%v, %v), want resolver", resolver, err)
+	}
+
+	invalid := &Config{
+		Install:     InstallConfig{Targets: []string{"claude"}},
+		Credentials: []gitauth.Rule{{Host: "gitlab.example.com", Provider: "vault"}},
+	}
+	err = invalid.Validate()
+	if err == nil || !strings.Contains(err.Error(), "credentials[0]") {
+		t.Fatalf("Validate() error = %v, want credentials[0] error", err)
+	}
+
+	empty := &Config{Install: InstallConfig{Targets: []string{"claude"}}}
+	resolver, err = empty.CredentialResolver()
+	if err != nil || resolver != nil {
+		t.Fatalf("CredentialResolver() without rules = (%v, %v), want (nil, nil)", resolver, err)
+	}
+}
+
+func TestLoad_WithCredentials(t *testing.T) {
+	tmpDir := t.TempDir()
+	configYAML := `install:
+  targets: [claude]
+credentials:
+  - host: "*.example.com"
+    provider: command
+    command: gh auth token
+  - host: git.internal
+    provider: netrc
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, DefaultConfigFileName), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if len(cfg.Credentials) != 2 {
+		t.Fatalf("expected 2 credential rules, got %d", len(cfg.Credentials))
+	}
+	if cfg.Credentials[0].Provider != gitauth.ProviderCommand || cfg.Credentials[0].Command != "gh auth token" {
+		t.Errorf("unexpected first rule: %+v", cfg.Credentials[0])
+	}
+}
+
+func TestLoad_WithAutoSync(t *testing.T) {
+	tmpDir := t.TempDir()
+	configYAML := `install:
+  targets: [claude]
+repo:
+  autoSync:
+    enabled: true
+    maxAge: 12h
+    sources:
+      team-tools: 7d
+`
+	if err := os.WriteFile(filepath.Join(tmpDir, DefaultConfigFileName), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+
+	cfg, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load() error: %v", err)
+	}
+	if !cfg.Repo.AutoSync.Enabled {
+		t.Fatal("expected auto-sync to be enabled")
+	}
+	if got := cfg.Repo.AutoSync.MaxAgeFor("other"); got != 12*time.Hour {
+		t.Errorf("MaxAgeFor(other) = %v, want 12h", got)
+	}
+	if got := cfg.Repo.AutoSync.MaxAgeFor("team-tools"); got != 7*24*time.Hour {
+		t.Errorf("MaxAgeFor(team-tools) = %v, want 168h", got)
+	}
+	if got := (AutoSyncConfig{Enabled: true}).MaxAgeFor("any"); got != DefaultAutoSyncMaxAge {
+		t.Errorf("MaxAgeFor without maxAge = %v, want default", got)
+	}
+}
+
+func TestValidate_AutoSyncMaxAge(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     AutoSyncConfig
+		wantErr string
+	}{
+		{name: "valid duration", cfg: AutoSyncConfig{MaxAge: "90m"}},
+		{name: "invalid duration", cfg: AutoSyncConfig{MaxAge: "soon"}, wantErr: "repo.autoSync.maxAge"},
+		{name: "negative duration", cfg: AutoSyncConfig{MaxAge: "-1h"}, wantErr: "must be positive"},
+		{name: "invalid source override", cfg: AutoSyncConfig{Sources: map[string]string{"team": "xd"}}, wantErr: "repo.autoSync.sources.team"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Install: InstallConfig{Targets: []string{"claude"}}, Repo: RepoConfig{AutoSync: tt.cfg}}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidate_LocalChangesPolicy(t *testing.T) {
+	for _, policy := range []string{"", LocalChangesKeepLocal, LocalChangesTakeUpstream, LocalChangesMerge} {
+		cfg := &Config{Install: InstallConfig{Targets: []string{"claude"}}, Repo: RepoConfig{LocalChanges: policy}}
+		if err := cfg.Validate(); err != nil {
+			t.Errorf("Validate(%q) unexpected error: %v", policy, err)
+		}
+	}
+
+	cfg := &Config{Install: InstallConfig{Targets: []string{"claude"}}, Repo: RepoConfig{LocalChanges: "overwrite"}}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "repo.localChanges") {
+		t.Fatalf("Validate() error = %v, want repo.localChanges error", err)
+	}
+
+	if got := (RepoConfig{}).LocalChangesPolicy(); got != LocalChangesKeepLocal {
+		t.Errorf("default policy = %q, want %q", got, LocalChangesKeepLocal)
+	}
+}
+
+func TestValidate_Profiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		profiles map[string]Profile
+		wantErr  string
+	}{
+		{name: "valid", profiles: map[string]Profile{"client-a": {Path: "/repos/a", Targets: []string{"opencode"}}}},
+		{name: "invalid name", profiles: map[string]Profile{"bad/name": {Path: "/repos/a"}}, wantErr: "invalid profile name"},
+		{name: "missing path", profiles: map[string]Profile{"work": {}}, wantErr: "profiles.work.path is required"},
+		{name: "invalid target", profiles: map[string]Profile{"work": {Path: "/repos/a", Targets: []string{"vim"}}}, wantErr: "profiles.work.targets"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Install: InstallConfig{Targets: []string{"claude"}}, Profiles: tt.profiles}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadGlobal_Profiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	xdgConfigDir := filepath.Join(tmpDir, "config")
+	t.Setenv("XDG_CONFIG_HOME", xdgConfigDir)
+	t.Setenv("AIMGR_REPO_PATH", "")
+	t.Cleanup(func() {
+		SelectProfile("")
+		xdg.Reload()
+	})
+
+	configDir := filepath.Join(xdgConfigDir, "aimgr")
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		t.Fatalf("failed to create config dir: %v", err)
+	}
+	configYAML := `install:
+  targets: [claude]
+repo:
+  path: /repos/default
+profile: client-a
+profiles:
+  client-a:
+    path: /repos/client-a
+    targets: [opencode]
+  internal:
+    path: /repos/internal
+`
+	if err := os.WriteFile(filepath.Join(configDir, DefaultConfigFileName), []byte(configYAML), 0644); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	xdg.Reload()
+
+	cfg, err := LoadGlobal()
+	if err != nil {
+		t.Fatalf("LoadGlobal() error = %v", err)
+	}
+	if cfg.ActiveProfile != "client-a" || cfg.EffectiveRepoPath() != "/repos/client-a" {
+		t.Errorf("default profile = %q (%s), want client-a", cfg.ActiveProfile, cfg.EffectiveRepoPath())
+	}
+	targets, err := cfg.GetDefaultTargets()
+	if err != nil || len(targets) != 1 || targets[0] != tools.OpenCode {
+		t.Errorf("GetDefaultTargets() = %v, %v; want [opencode]", targets, err)
+	}
+	if cfg.Repo.Path != "/repos/default" {
+		t.Errorf("Repo.Path = %q, profiles must not change it", cfg.Repo.Path)
+	}
+
+	// A selected profile without targets falls back to install.targets.
+	SelectProfile("internal")
+	cfg, err = LoadGlobal()
+	if err != nil {
+		t.Fatalf("LoadGlobal(internal) error = %v", err)
+	}
+	if cfg.EffectiveRepoPath() != "/repos/internal" {
+		t.Errorf("EffectiveRepoPath() = %q, want /repos/internal", cfg.EffectiveRepoPath())
+	}
+	targets, err = cfg.GetDefaultTargets()
+	if err != nil || len(targets) != 1 || targets[0] != tools.Claude {
+		t.Errorf("GetDefaultTargets() = %v, %v; want [claude]", targets, err)
+	}
+	if name, ok := cfg.ProfileForRepoPath("/repos/client-a/"); !ok || name != "client-a" {
+		t.Errorf("ProfileForRepoPath() = %q, %v; want client-a", name, ok)
+	}
+
+	SelectProfile("missing")
+	if _, err := LoadGlobal(); !errors.Is(err, ErrUnknownProfile) {
+		t.Fatalf("LoadGlobal(missing) error = %v, want ErrUnknownProfile", err)
+	}
+	cfg, err = LoadGlobalForEdit()
+	if err != nil || cfg.ActiveProfile != "" {
+		t.Fatalf("LoadGlobalForEdit() = %q, %v; want no active profile", cfg.ActiveProfile, err)
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    int64
+		wantErr bool
+	}{
+		{value: "1024", want: 1024},
+		{value: "500MB", want: 500 * 1000 * 1000},
+		{value: "5 GB", want: 5 * 1000 * 1000 * 1000},
+		{value: "1.5GiB", want: 3 << 29},
+		{value: "10kib", want: 10 << 10},
+		{value: "0", wantErr: true},
+		{value: "5XB", wantErr: true},
+		{value: "GB", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseByteSize(tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("ParseByteSize(%q) = %d, want error", tt.value, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("ParseByteSize(%q) = %d, %v; want %d", tt.value, got, err, tt.want)
+		}
+	}
+}
+
+func TestValidate_CacheBudget(t *testing.T) {
+	cfg := &Config{Install: InstallConfig{Targets: []string{"claude"}}, Repo: RepoConfig{Cache: CacheConfig{MaxSize: "2GB", MaxAge: "30d"}}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+	if !cfg.Repo.Cache.Enabled() || cfg.Repo.Cache.MaxSizeBytes() != 2e9 || cfg.Repo.Cache.MaxIdleAge() != 30*24*time.Hour {
+		t.Errorf("unexpected budget: %+v", cfg.Repo.Cache)
+	}
+	if (CacheConfig{}).Enabled() {
+		t.Error("empty cache config should be disabled")
+	}
+
+	for field, cache := range map[string]CacheConfig{
+		"repo.cache.maxSize": {MaxSize: "lots"},
+		"repo.cache.maxAge":  {MaxAge: "forever"},
+	} {
+		cfg := &Config{Install: InstallConfig{Targets: []string{"claude"}}, Repo: RepoConfig{Cache: cache}}
+		if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), field) {
+			t.Errorf("Validate() error = %v, want %s error", err, field)
+		}
+	}
+}
+
+func TestValidate_TokenBudgets(t *testing.T) {
+	cfg := &Config{Install: InstallConfig{Targets: []string{"claude"}}, Tokens: TokensConfig{MaxDescription: 100, MaxProjectTotal: 50000}}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	cfg = &Config{Install: InstallConfig{Targets: []string{"claude"}}, Tokens: TokensConfig{MaxProjectDescriptions: -1}}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "tokens.maxProjectDescriptions") {
+		t.Errorf("Validate() error = %v, want tokens.maxProjectDescriptions error", err)
+	}
+}
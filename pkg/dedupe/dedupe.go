@@ -0,0 +1,328 @@
+// Package dedupe finds duplicate and near-duplicate resources.
+//
+// Exact duplicates have the same content digest once the resource's own name
+// is left out: the frontmatter name field and, for commands and agents, the
+// file name. Near duplicates are compared on normalized text (lowercased
+// words, frontmatter name dropped) by the Jaccard similarity of their
+// three-word shingles, which tolerates small edits and reordered paragraphs.
+package dedupe
+
+import (
+	"bytes"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resourcediff"
+)
+
+// DefaultThreshold is the default minimum similarity of near duplicates.
+const DefaultThreshold = 0.85
+
+// shingleSize is the number of words per shingle.
+const shingleSize = 3
+
+// Match kinds of a duplicate group.
+const (
+	MatchExact   = "exact"
+	MatchSimilar = "similar"
+)
+
+// Candidate is a resource considered for deduplication, with the facts used
+// to suggest which copy to keep.
+type Candidate struct {
+	Type resource.ResourceType
+	Name string
+	// Files is the resource content; MainFile names its main file
+	// (SKILL.md for skills).
+	Files    resourcediff.FileSet
+	MainFile string
+	// Source is the name of the source the resource was imported from.
+	Source string
+	// SourcePriority is the priority of that source in ai.repo.yaml.
+	SourcePriority int
+	// PackageRefs is the number of packages referencing the resource.
+	PackageRefs int
+	// Updated is when the resource was last imported.
+	Updated time.Time
+}
+
+// Ref returns the candidate as a "type/name" reference.
+func (c *Candidate) Ref() string {
+	return string(c.Type) + "/" + c.Name
+}
+
+// Member is a resource of a duplicate group.
+type Member struct {
+	Resource string `json:"resource" yaml:"resource"`
+	Source   string `json:"source,omitempty" yaml:"source,omitempty"`
+	Digest   string `json:"digest" yaml:"digest"`
+	// Similarity is the similarity to the kept copy, 1 for exact copies.
+	Similarity float64 `json:"similarity" yaml:"similarity"`
+	Keep       bool    `json:"keep" yaml:"keep"`
+}
+
+// Group is a set of resources of one type that duplicate each other.
+type Group struct {
+	Type    resource.ResourceType `json:"type" yaml:"type"`
+	Match   string                `json:"match" yaml:"match"` // MatchExact or MatchSimilar
+	Keep    string                `json:"keep" yaml:"keep"`
+	Reason  string                `json:"reason" yaml:"reason"`
+	Members []Member              `json:"members" yaml:"members"`
+}
+
+// Redundant returns the references of the members that are not kept.
+func (g *Group) Redundant() []string {
+	var refs []string
+	for _, m := range g.Members {
+		if !m.Keep {
+			refs = append(refs, m.Resource)
+		}
+	}
+	return refs
+}
+
+// analyzed is a candidate with its digest and shingles.
+type analyzed struct {
+	*Candidate
+	digest   string
+	shingles map[uint64]struct{}
+}
+
+// Find groups candidates of the same type that are exact duplicates or whose
+// similarity is at least threshold. Groups are transitive: a resource
+// similar to any member joins the group. Groups are sorted by type and kept
+// reference.
+func Find(candidates []Candidate, threshold float64) []Group {
+	byType := make(map[resource.ResourceType][]*analyzed)
+	for i := range candidates {
+		c := &candidates[i]
+		byType[c.Type] = append(byType[c.Type], &analyzed{
+			Candidate: c,
+			digest:    ContentDigest(c.Files, c.MainFile),
+			shingles:  shingles(Normalize(joinText(c.Files, c.MainFile))),
+		})
+	}
+
+	var groups []Group
+	for _, items := range byType {
+		sort.Slice(items, func(i, j int) bool { return items[i].Name < items[j].Name })
+
+		parent := make([]int, len(items))
+		for i := range parent {
+			parent[i] = i
+		}
+		var find func(int) int
+		find = func(i int) int {
+			if parent[i] != i {
+				parent[i] = find(parent[i])
+			}
+			return parent[i]
+		}
+
+		for i := range items {
+			for j := i + 1; j < len(items); j++ {
+				if find(i) == find(j) {
+					continue
+				}
+				if items[i].digest == items[j].digest || similarEnough(items[i].shingles, items[j].shingles, threshold) {
+					parent[find(j)] = find(i)
+				}
+			}
+		}
+
+		clusters := make(map[int][]*analyzed)
+		for i, item := range items {
+			root := find(i)
+			clusters[root] = append(clusters[root], item)
+		}
+		for _, cluster := range clusters {
+			if len(cluster) > 1 {
+				groups = append(groups, newGroup(cluster))
+			}
+		}
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Type != groups[j].Type {
+			return groups[i].Type < groups[j].Type
+		}
+		return groups[i].Keep < groups[j].Keep
+	})
+	return groups
+}
+
+// newGroup picks the copy to keep and builds the group.
+func newGroup(cluster []*analyzed) Group {
+	keep, reason := chooseKeep(cluster)
+	group := Group{Type: keep.Type, Match: MatchExact, Keep: keep.Ref(), Reason: reason}
+	for _, item := range cluster {
+		member := Member{Resource: item.Ref(), Source: item.Source, Digest: item.digest, Similarity: 1, Keep: item == keep}
+		if item.digest != keep.digest {
+			member.Similarity = math.Round(similarity(keep.shingles, item.shingles)*100) / 100
+			group.Match = MatchSimilar
+		}
+		group.Members = append(group.Members, member)
+	}
+	sort.SliceStable(group.Members, func(i, j int) bool {
+		if group.Members[i].Keep != group.Members[j].Keep {
+			return group.Members[i].Keep
+		}
+		return group.Members[i].Resource < group.Members[j].Resource
+	})
+	return group
+}
+
+// chooseKeep suggests the copy to keep: the one from the highest-priority
+// source (the copy sync would keep for a name clash), then the one most
+// packages reference, then the most recently updated, then the first by
+// name.
+func chooseKeep(cluster []*analyzed) (*analyzed, string) {
+	ranked := append([]*analyzed(nil), cluster...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		a, b := ranked[i], ranked[j]
+		switch {
+		case a.SourcePriority != b.SourcePriority:
+			return a.SourcePriority > b.SourcePriority
+		case a.PackageRefs != b.PackageRefs:
+			return a.PackageRefs > b.PackageRefs
+		case !a.Updated.Equal(b.Updated):
+			return a.Updated.After(b.Updated)
+		default:
+			return a.Name < b.Name
+		}
+	})
+
+	best, next := ranked[0], ranked[1]
+	switch {
+	case best.SourcePriority != next.SourcePriority:
+		return best, "highest source priority"
+	case best.PackageRefs != next.PackageRefs:
+		return best, "referenced by the most packages"
+	case !best.Updated.Equal(next.Updated):
+		return best, "most recently updated"
+	default:
+		return best, "first by name"
+	}
+}
+
+// ContentDigest returns the digest of a resource's content without its own
+// name, so copies imported under different names compare equal.
+func ContentDigest(files resourcediff.FileSet, mainFile string) string {
+	normalized := make(resourcediff.FileSet, len(files))
+	for p, data := range files {
+		if p == mainFile || len(files) == 1 {
+			normalized["main"] = stripName(data)
+			continue
+		}
+		normalized[p] = data
+	}
+	return resourcediff.Digest(normalized)
+}
+
+// Normalize returns the words of text, lowercased, with the frontmatter
+// name field dropped and punctuation and markup removed.
+func Normalize(text []byte) []string {
+	return strings.FieldsFunc(strings.ToLower(string(stripName(text))), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// stripName removes the name field from the frontmatter of a markdown file.
+func stripName(data []byte) []byte {
+	if !bytes.HasPrefix(data, []byte("---\n")) && !bytes.HasPrefix(data, []byte("---\r\n")) {
+		return data
+	}
+	lines := bytes.SplitAfter(data, []byte("\n"))
+	out := make([]byte, 0, len(data))
+	out = append(out, lines[0]...)
+	inFrontmatter := true
+	for _, line := range lines[1:] {
+		trimmed := bytes.TrimRight(line, "\r\n")
+		if inFrontmatter && bytes.Equal(trimmed, []byte("---")) {
+			inFrontmatter = false
+		} else if inFrontmatter && bytes.HasPrefix(trimmed, []byte("name:")) {
+			continue
+		}
+		out = append(out, line...)
+	}
+	return out
+}
+
+// joinText concatenates the text files of a resource, main file first.
+func joinText(files resourcediff.FileSet, mainFile string) []byte {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		if (paths[i] == mainFile) != (paths[j] == mainFile) {
+			return paths[i] == mainFile
+		}
+		return paths[i] < paths[j]
+	})
+
+	var buf bytes.Buffer
+	for _, p := range paths {
+		if bytes.IndexByte(files[p], 0) >= 0 {
+			continue // binary
+		}
+		buf.Write(files[p])
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// shingles hashes the overlapping word n-grams of words. Texts shorter than
+// one shingle are hashed whole.
+func shingles(words []string) map[uint64]struct{} {
+	set := make(map[uint64]struct{})
+	if len(words) == 0 {
+		return set
+	}
+	n := shingleSize
+	if len(words) < n {
+		n = len(words)
+	}
+	for i := 0; i+n <= len(words); i++ {
+		h := fnv.New64a()
+		h.Write([]byte(strings.Join(words[i:i+n], " ")))
+		set[h.Sum64()] = struct{}{}
+	}
+	return set
+}
+
+// similarEnough reports whether two shingle sets reach threshold. The index
+// is at most the ratio of the set sizes, which rules out most pairs cheaply.
+func similarEnough(a, b map[uint64]struct{}, threshold float64) bool {
+	small, large := len(a), len(b)
+	if small > large {
+		small, large = large, small
+	}
+	if large > 0 && float64(small)/float64(large) < threshold {
+		return false
+	}
+	return similarity(a, b) >= threshold
+}
+
+// similarity is the Jaccard index of two shingle sets.
+func similarity(a, b map[uint64]struct{}) float64 {
+	if len(a) == 0 && len(b) == 0 {
+		return 1
+	}
+	small, large := a, b
+	if len(small) > len(large) {
+		small, large = large, small
+	}
+	shared := 0
+	for h := range small {
+		if _, ok := large[h]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
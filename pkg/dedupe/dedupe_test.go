@@ -0,0 +1,91 @@
+package dedupe
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resourcediff"
+)
+
+const reviewBody = `Review the staged changes for correctness, naming and missing tests.
+Point out risky patterns, unclear error handling and places where the change
+breaks existing callers. Suggest concrete fixes with short code snippets and
+keep the feedback focused on what the author can act on today.
+`
+
+func command(name, body string) Candidate {
+	content := "---\nname: " + name + "\ndescription: Review code\n---\n" + body
+	return Candidate{
+		Type:     resource.Command,
+		Name:     name,
+		Files:    resourcediff.FileSet{name + ".md": []byte(content)},
+		MainFile: name + ".md",
+	}
+}
+
+func TestFind(t *testing.T) {
+	edited := strings.Replace(reviewBody, "short code snippets", "brief code snippets", 1)
+	candidates := []Candidate{
+		command("review", reviewBody),
+		command("code-review", reviewBody),
+		command("review-lite", edited),
+		command("deploy", "Deploy the service to staging and run the smoke tests.\n"),
+		{Type: resource.Skill, Name: "review", Files: resourcediff.FileSet{"SKILL.md": []byte("---\nname: review\n---\n" + reviewBody)}, MainFile: "SKILL.md"},
+	}
+	candidates[2].SourcePriority = 10
+
+	groups := Find(candidates, DefaultThreshold)
+	if len(groups) != 1 {
+		t.Fatalf("Find() = %+v, want one group", groups)
+	}
+	g := groups[0]
+	if g.Match != MatchSimilar || g.Keep != "command/review-lite" || g.Reason != "highest source priority" {
+		t.Errorf("group = %+v", g)
+	}
+	if want := []string{"command/code-review", "command/review"}; !reflect.DeepEqual(g.Redundant(), want) {
+		t.Errorf("Redundant() = %v, want %v", g.Redundant(), want)
+	}
+	if s := g.Members[1].Similarity; s < DefaultThreshold || s >= 1 {
+		t.Errorf("similarity of an edited copy = %v", s)
+	}
+
+	// Without the edited copy the remaining pair is exact.
+	groups = Find([]Candidate{candidates[0], candidates[1], candidates[3]}, DefaultThreshold)
+	if len(groups) != 1 || groups[0].Match != MatchExact || groups[0].Keep != "command/code-review" || groups[0].Reason != "first by name" {
+		t.Errorf("exact groups = %+v", groups)
+	}
+}
+
+func TestChooseKeep(t *testing.T) {
+	now := time.Now()
+	a, b := command("a", reviewBody), command("b", reviewBody)
+	b.PackageRefs = 2
+	if g := Find([]Candidate{a, b}, DefaultThreshold); g[0].Keep != "command/b" || g[0].Reason != "referenced by the most packages" {
+		t.Errorf("group = %+v", g[0])
+	}
+	b.PackageRefs = 0
+	a.Updated, b.Updated = now.Add(-time.Hour), now
+	if g := Find([]Candidate{a, b}, DefaultThreshold); g[0].Keep != "command/b" || g[0].Reason != "most recently updated" {
+		t.Errorf("group = %+v", g[0])
+	}
+}
+
+func TestContentDigest_IgnoresName(t *testing.T) {
+	a := ContentDigest(resourcediff.FileSet{"a.md": []byte("---\nname: a\ndescription: x\n---\nbody\n")}, "a.md")
+	b := ContentDigest(resourcediff.FileSet{"b.md": []byte("---\nname: b\ndescription: x\n---\nbody\n")}, "b.md")
+	c := ContentDigest(resourcediff.FileSet{"c.md": []byte("---\nname: c\ndescription: x\n---\nbody changed\n")}, "c.md")
+	if a != b || a == c {
+		t.Errorf("digests = %s, %s, %s", a, b, c)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	got := Normalize([]byte("---\nname: x\ndescription: Check **PRs**\n---\n# Title\nRun `go test`.\n"))
+	want := []string{"description", "check", "prs", "title", "run", "go", "test"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Normalize() = %q, want %q", got, want)
+	}
+}
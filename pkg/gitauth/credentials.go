@@ -0,0 +1,355 @@
+// Package gitauth resolves HTTPS credentials for Git operations from
+// user-configured credential providers.
+//
+// Credentials are matched by host pattern and handed to git through
+// environment-scoped configuration (GIT_CONFIG_COUNT/KEY/VALUE), so tokens
+// never appear on the command line, in log output, or in any file written by
+// aimgr.
+package gitauth
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
+// Supported credential provider kinds.
+const (
+	ProviderEnv     = "env"
+	ProviderCommand = "command"
+	ProviderNetrc   = "netrc"
+)
+
+// DefaultUsername is used for token-based providers when no username is configured.
+// GitLab, GitHub Enterprise and Bitbucket all accept a token with an arbitrary
+// non-empty username over HTTPS basic auth.
+const DefaultUsername = "oauth2"
+
+// Rule maps a host pattern to a credential provider.
+//
+// Example (aimgr.yaml):
+//
+//	credentials:
+//	  - host: gitlab.example.com
+//	    provider: env
+//	    env: GITLAB_TOKEN
+//	  - host: "*.ghe.example.com"
+//	    provider: command
+//	    command: gh auth token --hostname ghe.example.com
+//	  - host: git.internal
+//	    provider: netrc
+//	    netrc: ~/.config/aimgr/netrc
+type Rule struct {
+	// Host is a glob pattern matched against the URL host (case-insensitive).
+	Host string `yaml:"host"`
+	// Provider selects how the token is obtained: env, command or netrc.
+	Provider string `yaml:"provider"`
+	// Env is the environment variable holding the token (provider: env).
+	Env string `yaml:"env,omitempty"`
+	// Command prints the token on stdout (provider: command).
+	Command string `yaml:"command,omitempty"`
+	// Netrc is the netrc file to read (provider: netrc). Defaults to ~/.netrc.
+	Netrc string `yaml:"netrc,omitempty"`
+	// Username overrides the basic-auth username for env/command providers.
+	Username string `yaml:"username,omitempty"`
+}
+
+// Validate checks that the rule is complete for its provider.
+func (r Rule) Validate() error {
+	if strings.TrimSpace(r.Host) == "" {
+		return fmt.Errorf("host is required")
+	}
+	if _, err := glob.Compile(strings.ToLower(r.Host)); err != nil {
+		return fmt.Errorf("invalid host pattern %q: %w", r.Host, err)
+	}
+
+	switch r.Provider {
+	case ProviderEnv:
+		if strings.TrimSpace(r.Env) == "" {
+			return fmt.Errorf("provider %q requires 'env'", r.Provider)
+		}
+	case ProviderCommand:
+		if strings.TrimSpace(r.Command) == "" {
+			return fmt.Errorf("provider %q requires 'command'", r.Provider)
+		}
+	case ProviderNetrc:
+		// netrc path is optional (defaults to ~/.netrc)
+	case "":
+		return fmt.Errorf("provider is required (env, command, netrc)")
+	default:
+		return fmt.Errorf("unknown provider %q (valid: env, command, netrc)", r.Provider)
+	}
+
+	return nil
+}
+
+// Describe returns a short, secret-free description of the rule suitable for
+// user-facing diagnostics, e.g. "env:GITLAB_TOKEN" or "netrc:~/.netrc".
+func (r Rule) Describe() string {
+	switch r.Provider {
+	case ProviderEnv:
+		return ProviderEnv + ":" + r.Env
+	case ProviderCommand:
+		fields := strings.Fields(r.Command)
+		if len(fields) == 0 {
+			return ProviderCommand
+		}
+		return ProviderCommand + ":" + filepath.Base(fields[0])
+	case ProviderNetrc:
+		path := r.Netrc
+		if path == "" {
+			path = "~/.netrc"
+		}
+		return ProviderNetrc + ":" + path
+	default:
+		return r.Provider
+	}
+}
+
+// Credential is a resolved username/token pair for a single host.
+type Credential struct {
+	Username string
+	Token    string
+	// Provider is the secret-free description of the rule that produced it.
+	Provider string
+}
+
+// Resolver matches URLs against configured rules. The first matching rule wins.
+type Resolver struct {
+	rules    []Rule
+	matchers []glob.Glob
+}
+
+// NewResolver builds a resolver from configured rules.
+// Rules that fail validation are rejected so misconfiguration surfaces early.
+func NewResolver(rules []Rule) (*Resolver, error) {
+	r := &Resolver{}
+	for i, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("credentials[%d]: %w", i, err)
+		}
+		g, _ := glob.Compile(strings.ToLower(rule.Host))
+		r.rules = append(r.rules, rule)
+		r.matchers = append(r.matchers, g)
+	}
+	return r, nil
+}
+
+// Match returns the first rule whose host pattern matches the URL.
+// Only http(s) URLs are considered; SSH and local URLs never match.
+func (r *Resolver) Match(rawURL string) (Rule, bool) {
+	if r == nil {
+		return Rule{}, false
+	}
+	host := httpsHost(rawURL)
+	if host == "" {
+		return Rule{}, false
+	}
+	for i, g := range r.matchers {
+		if g.Match(host) {
+			return r.rules[i], true
+		}
+	}
+	return Rule{}, false
+}
+
+// Resolve returns the credential for a URL, or (nil, nil) when no rule matches.
+func (r *Resolver) Resolve(rawURL string) (*Credential, error) {
+	rule, ok := r.Match(rawURL)
+	if !ok {
+		return nil, nil
+	}
+
+	cred := &Credential{Username: rule.Username, Provider: rule.Describe()}
+	if cred.Username == "" {
+		cred.Username = DefaultUsername
+	}
+
+	switch rule.Provider {
+	case ProviderEnv:
+		cred.Token = strings.TrimSpace(os.Getenv(rule.Env))
+		if cred.Token == "" {
+			return nil, fmt.Errorf("credential provider %s: environment variable %s is not set", cred.Provider, rule.Env)
+		}
+	case ProviderCommand:
+		token, err := runTokenCommand(rule.Command)
+		if err != nil {
+			return nil, fmt.Errorf("credential provider %s: %w", cred.Provider, err)
+		}
+		cred.Token = token
+	case ProviderNetrc:
+		login, password, err := lookupNetrc(rule.Netrc, httpsHost(rawURL))
+		if err != nil {
+			return nil, fmt.Errorf("credential provider %s: %w", cred.Provider, err)
+		}
+		if login != "" && rule.Username == "" {
+			cred.Username = login
+		}
+		cred.Token = password
+	}
+
+	return cred, nil
+}
+
+// GitEnv returns environment entries that make git send the credential as an
+// HTTP Authorization header for the URL's scheme and host only.
+// The header is injected via GIT_CONFIG_* variables (git >= 2.31) so it is
+// never written to .git/config or visible in the process argument list.
+// Entries already in the environment (e.g. set by CI) are kept: the header
+// is appended after them.
+func (c *Credential) GitEnv(rawURL string) []string {
+	if c == nil || c.Token == "" {
+		return nil
+	}
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || u.Host == "" {
+		return nil
+	}
+
+	index := 0
+	if n, err := strconv.Atoi(strings.TrimSpace(os.Getenv("GIT_CONFIG_COUNT"))); err == nil && n > 0 {
+		index = n
+	}
+
+	basic := base64.StdEncoding.EncodeToString([]byte(c.Username + ":" + c.Token))
+	return []string{
+		fmt.Sprintf("GIT_CONFIG_COUNT=%d", index+1),
+		fmt.Sprintf("GIT_CONFIG_KEY_%d=http.%s://%s/.extraHeader", index, strings.ToLower(u.Scheme), u.Host),
+		fmt.Sprintf("GIT_CONFIG_VALUE_%d=Authorization: Basic %s", index, basic),
+		"GIT_TERMINAL_PROMPT=0",
+	}
+}
+
+// httpsHost returns the lowercase host (without port) of an http(s) URL.
+func httpsHost(rawURL string) string {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return ""
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return ""
+	}
+	return strings.ToLower(u.Hostname())
+}
+
+// runTokenCommand executes a token command without a shell and returns the
+// first line of its stdout. Stderr is discarded so helpers cannot leak
+// secrets into aimgr output.
+func runTokenCommand(command string) (string, error) {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty command")
+	}
+
+	// #nosec G204 -- the command is explicitly configured by the user in aimgr.yaml.
+	cmd := exec.Command(fields[0], fields[1:]...)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("command %q failed: %w", filepath.Base(fields[0]), err)
+	}
+
+	token := strings.TrimSpace(strings.SplitN(string(out), "\n", 2)[0])
+	if token == "" {
+		return "", fmt.Errorf("command %q printed no token", filepath.Base(fields[0]))
+	}
+	return token, nil
+}
+
+// lookupNetrc finds login/password for host in a netrc file.
+// Falls back to a "default" entry when no machine entry matches.
+func lookupNetrc(path, host string) (string, string, error) {
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", "", fmt.Errorf("cannot locate home directory: %w", err)
+		}
+		path = filepath.Join(home, ".netrc")
+	} else if strings.HasPrefix(path, "~/") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", "", fmt.Errorf("cannot expand ~: %w", err)
+		}
+		path = filepath.Join(home, path[2:])
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to open netrc: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var tokens []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		tokens = append(tokens, strings.Fields(line)...)
+	}
+	if err := scanner.Err(); err != nil {
+		return "", "", fmt.Errorf("failed to read netrc: %w", err)
+	}
+
+	type entry struct{ login, password string }
+	var matched, fallback *entry
+	var current *entry
+	var currentMatches, currentDefault bool
+
+	flush := func() {
+		if current == nil {
+			return
+		}
+		if currentMatches && matched == nil {
+			matched = current
+		}
+		if currentDefault && fallback == nil {
+			fallback = current
+		}
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		switch tokens[i] {
+		case "machine":
+			flush()
+			current = &entry{}
+			currentDefault = false
+			currentMatches = i+1 < len(tokens) && strings.EqualFold(tokens[i+1], host)
+			i++
+		case "default":
+			flush()
+			current = &entry{}
+			currentDefault = true
+			currentMatches = false
+		case "login":
+			if current != nil && i+1 < len(tokens) {
+				current.login = tokens[i+1]
+			}
+			i++
+		case "password":
+			if current != nil && i+1 < len(tokens) {
+				current.password = tokens[i+1]
+			}
+			i++
+		case "account", "macdef":
+			i++
+		}
+	}
+	flush()
+
+	if matched == nil {
+		matched = fallback
+	}
+	if matched == nil || matched.password == "" {
+		return "", "", fmt.Errorf("no entry for host %s in %s", host, path)
+	}
+	return matched.login, matched.password, nil
+}
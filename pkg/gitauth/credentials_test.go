@@ -0,0 +1,212 @@
+package gitauth
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRuleValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    Rule
+		wantErr string
+	}{
+		{name: "env ok", rule: Rule{Host: "gitlab.example.com", Provider: ProviderEnv, Env: "TOKEN"}},
+		{name: "command ok", rule: Rule{Host: "*.example.com", Provider: ProviderCommand, Command: "gh auth token"}},
+		{name: "netrc ok without path", rule: Rule{Host: "git.internal", Provider: ProviderNetrc}},
+		{name: "missing host", rule: Rule{Provider: ProviderEnv, Env: "TOKEN"}, wantErr: "host is required"},
+		{name: "missing provider", rule: Rule{Host: "x"}, wantErr: "provider is required"},
+		{name: "unknown provider", rule: Rule{Host: "x", Provider: "vault"}, wantErr: "unknown provider"},
+		{name: "env without var", rule: Rule{Host: "x", Provider: ProviderEnv}, wantErr: "requires 'env'"},
+		{name: "command without command", rule: Rule{Host: "x", Provider: ProviderCommand}, wantErr: "requires 'command'"},
+		{name: "bad glob", rule: Rule{Host: "[", Provider: ProviderNetrc}, wantErr: "invalid host pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("Validate() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("Validate() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRuleDescribe_NeverIncludesSecrets(t *testing.T) {
+	tests := []struct {
+		rule Rule
+		want string
+	}{
+		{Rule{Provider: ProviderEnv, Env: "GITLAB_TOKEN"}, "env:GITLAB_TOKEN"},
+		{Rule{Provider: ProviderCommand, Command: "/usr/bin/gh auth token --secret xyz"}, "command:gh"},
+		{Rule{Provider: ProviderNetrc}, "netrc:~/.netrc"},
+		{Rule{Provider: ProviderNetrc, Netrc: "/etc/aimgr.netrc"}, "netrc:/etc/aimgr.netrc"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.rule.Describe(); got != tt.want {
+			t.Errorf("Describe() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestResolverMatch(t *testing.T) {
+	r, err := NewResolver([]Rule{
+		{Host: "gitlab.example.com", Provider: ProviderEnv, Env: "A"},
+		{Host: "*.ghe.example.com", Provider: ProviderEnv, Env: "B"},
+	})
+	if err != nil {
+		t.Fatalf("NewResolver() error: %v", err)
+	}
+
+	tests := []struct {
+		url     string
+		wantEnv string
+	}{
+		{"https://gitlab.example.com/team/skills.git", "A"},
+		{"https://GitLab.Example.com:8443/team/skills", "A"},
+		{"https://code.ghe.example.com/org/repo", "B"},
+		{"https://github.com/org/repo", ""},
+		{"git@gitlab.example.com:team/skills.git", ""},
+		{"file:///tmp/repo", ""},
+	}
+
+	for _, tt := range tests {
+		rule, ok := r.Match(tt.url)
+		if tt.wantEnv == "" {
+			if ok {
+				t.Errorf("Match(%q) matched %q, want no match", tt.url, rule.Env)
+			}
+			continue
+		}
+		if !ok || rule.Env != tt.wantEnv {
+			t.Errorf("Match(%q) = (%q, %v), want %q", tt.url, rule.Env, ok, tt.wantEnv)
+		}
+	}
+}
+
+func TestResolverMatch_NilResolver(t *testing.T) {
+	var r *Resolver
+	if _, ok := r.Match("https://gitlab.example.com/x"); ok {
+		t.Fatal("nil resolver should never match")
+	}
+	cred, err := r.Resolve("https://gitlab.example.com/x")
+	if err != nil || cred != nil {
+		t.Fatalf("nil resolver Resolve() = (%v, %v), want (nil, nil)", cred, err)
+	}
+}
+
+func TestResolve_Env(t *testing.T) {
+	t.Setenv("AIMGR_TEST_TOKEN", "s3cret")
+	r, err := NewResolver([]Rule{{Host: "gitlab.example.com", Provider: ProviderEnv, Env: "AIMGR_TEST_TOKEN"}})
+	if err != nil {
+		t.Fatalf("NewResolver() error: %v", err)
+	}
+
+	cred, err := r.Resolve("https://gitlab.example.com/team/repo")
+	if err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+	if cred.Token != "s3cret" || cred.Username != DefaultUsername || cred.Provider != "env:AIMGR_TEST_TOKEN" {
+		t.Fatalf("Resolve() = %+v", cred)
+	}
+}
+
+func TestResolve_EnvUnset(t *testing.T) {
+	t.Setenv("AIMGR_TEST_TOKEN", "")
+	r, _ := NewResolver([]Rule{{Host: "gitlab.example.com", Provider: ProviderEnv, Env: "AIMGR_TEST_TOKEN"}})
+
+	_, err := r.Resolve("https://gitlab.example.com/team/repo")
+	if err == nil || !strings.Contains(err.Error(), "env:AIMGR_TEST_TOKEN") {
+		t.Fatalf("Resolve() error = %v, want provider in message", err)
+	}
+}
+
+func TestResolve_Netrc(t *testing.T) {
+	netrcPath := filepath.Join(t.TempDir(), "netrc")
+	content := `# comment
+machine other.example.com login other password nope
+machine git.internal
+  login deploy
+  password tok123
+default login anon password fallback
+`
+	if err := os.WriteFile(netrcPath, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write netrc: %v", err)
+	}
+
+	r, err := NewResolver([]Rule{{Host: "*", Provider: ProviderNetrc, Netrc: netrcPath}})
+	if err != nil {
+		t.Fatalf("NewResolver() error: %v", err)
+	}
+
+	cred, err := r.Resolve("https://git.internal/team/repo.git")
+	if err != nil {
+		t.Fatalf("Resolve() error: %v", err)
+	}
+	if cred.Username != "deploy" || cred.Token != "tok123" {
+		t.Fatalf("Resolve() = %+v, want deploy/tok123", cred)
+	}
+
+	cred, err = r.Resolve("https://unknown.example.com/repo")
+	if err != nil {
+		t.Fatalf("Resolve() default entry error: %v", err)
+	}
+	if cred.Username != "anon" || cred.Token != "fallback" {
+		t.Fatalf("Resolve() default = %+v, want anon/fallback", cred)
+	}
+}
+
+func TestGitEnv(t *testing.T) {
+	cred := &Credential{Username: "oauth2", Token: "abc"}
+	env := cred.GitEnv("https://gitlab.example.com/team/repo.git")
+
+	joined := strings.Join(env, "\n")
+	wantHeader := "Authorization: Basic " + base64.StdEncoding.EncodeToString([]byte("oauth2:abc"))
+	for _, want := range []string{
+		"GIT_CONFIG_COUNT=1",
+		"GIT_CONFIG_KEY_0=http.https://gitlab.example.com/.extraHeader",
+		"GIT_CONFIG_VALUE_0=" + wantHeader,
+		"GIT_TERMINAL_PROMPT=0",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("GitEnv() missing %q in:\n%s", want, joined)
+		}
+	}
+
+	if env := (&Credential{}).GitEnv("https://x"); env != nil {
+		t.Errorf("GitEnv() without token = %v, want nil", env)
+	}
+}
+
+func TestGitEnv_AppendsToExistingConfigEntries(t *testing.T) {
+	t.Setenv("GIT_CONFIG_COUNT", "2")
+	t.Setenv("GIT_CONFIG_KEY_0", "safe.directory")
+	t.Setenv("GIT_CONFIG_VALUE_0", "*")
+	t.Setenv("GIT_CONFIG_KEY_1", "http.sslCAInfo")
+	t.Setenv("GIT_CONFIG_VALUE_1", "/etc/ci/ca.pem")
+
+	env := (&Credential{Username: "oauth2", Token: "abc"}).GitEnv("https://gitlab.example.com/team/repo.git")
+	joined := strings.Join(env, "\n")
+	for _, want := range []string{
+		"GIT_CONFIG_COUNT=3",
+		"GIT_CONFIG_KEY_2=http.https://gitlab.example.com/.extraHeader",
+		"GIT_CONFIG_VALUE_2=Authorization: Basic ",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("GitEnv() missing %q in:\n%s", want, joined)
+		}
+	}
+	if strings.Contains(joined, "GIT_CONFIG_KEY_0=") || strings.Contains(joined, "GIT_CONFIG_KEY_1=") {
+		t.Errorf("GitEnv() overwrote existing entries:\n%s", joined)
+	}
+}
@@ -0,0 +1,200 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFileName is the lint configuration file looked up in a source tree.
+const ConfigFileName = ".aimgr-lint.yaml"
+
+// Config selects and tunes the lint rules for a source tree.
+//
+// Example .aimgr-lint.yaml:
+//
+//	ignore:
+//	  - skills/experimental-*
+//	rules:
+//	  description-too-short:
+//	    severity: error
+//	    min-length: 60
+//	  missing-license: off
+type Config struct {
+	// Ignore lists path patterns (relative to the linted root, "/"-separated)
+	// of resources to skip. A pattern also matches everything below a
+	// matching directory.
+	Ignore []string `yaml:"ignore,omitempty"`
+
+	// Rules overrides rule settings by rule ID. Rules not listed run with
+	// their defaults.
+	Rules map[string]RuleConfig `yaml:"rules,omitempty"`
+}
+
+// RuleConfig overrides the settings of one rule. Besides severity and types,
+// each rule reads its own options (e.g. min-length, max-lines).
+type RuleConfig struct {
+	Severity Severity               `yaml:"severity,omitempty"`
+	Types    []string               `yaml:"types,omitempty"`
+	Options  map[string]interface{} `yaml:",inline"`
+}
+
+// UnmarshalYAML accepts a bare severity ("missing-license: off") as
+// shorthand for a rule config that only sets the severity.
+func (c *RuleConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		c.Severity = Severity(node.Value)
+		return nil
+	}
+	type plain RuleConfig
+	var decoded plain
+	if err := node.Decode(&decoded); err != nil {
+		return err
+	}
+	*c = RuleConfig(decoded)
+	return nil
+}
+
+// FindConfig returns the configuration file that applies to root: the first
+// .aimgr-lint.yaml found in root or its parents, stopping at the enclosing
+// git work tree. It returns "" when there is none.
+func FindConfig(root string) (string, error) {
+	dir, err := filepath.Abs(root)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s: %w", root, err)
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+	for {
+		candidate := filepath.Join(dir, ConfigFileName)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate, nil
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return "", nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// LoadConfig reads and validates a lint configuration file.
+func LoadConfig(configPath string) (*Config, error) {
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lint config: %w", err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", configPath, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", configPath, err)
+	}
+	return &cfg, nil
+}
+
+// Validate checks that every configured rule exists and that severities,
+// types and ignore patterns are well-formed.
+func (c *Config) Validate() error {
+	var errs []error
+	for _, pattern := range c.Ignore {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("ignore pattern %q: %w", pattern, err))
+		}
+	}
+
+	ids := make([]string, 0, len(c.Rules))
+	for id := range c.Rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		rule := ruleByID(id)
+		if rule == nil {
+			errs = append(errs, fmt.Errorf("unknown rule %q (known rules: %s)", id, strings.Join(RuleIDs(), ", ")))
+			continue
+		}
+		rc := c.Rules[id]
+		if rc.Severity != "" && !rc.Severity.valid() {
+			errs = append(errs, fmt.Errorf("rule %s: invalid severity %q (must be error, warning, info or off)", id, rc.Severity))
+		}
+		for _, t := range rc.Types {
+			switch resource.ResourceType(t) {
+			case resource.Skill, resource.Command, resource.Agent:
+			default:
+				errs = append(errs, fmt.Errorf("rule %s: invalid type %q (must be skill, command or agent)", id, t))
+			}
+		}
+		for key, value := range rc.Options {
+			kind, ok := rule.Options[key]
+			if !ok {
+				errs = append(errs, fmt.Errorf("rule %s: unknown option %q", id, key))
+				continue
+			}
+			if !kind.accepts(value) {
+				errs = append(errs, fmt.Errorf("rule %s: option %s must be %s", id, key, kind))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ignored reports whether a resource at relPath is excluded by an ignore
+// pattern.
+func (c *Config) ignored(relPath string) bool {
+	for _, pattern := range c.Ignore {
+		for p := relPath; p != "." && p != "/" && p != ""; p = path.Dir(p) {
+			if ok, _ := path.Match(pattern, p); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rule returns the effective settings of a rule.
+func (c *Config) rule(id string) RuleConfig {
+	if c == nil {
+		return RuleConfig{}
+	}
+	return c.Rules[id]
+}
+
+// intOption returns an integer option or def when unset.
+func (rc RuleConfig) intOption(key string, def int) int {
+	switch v := rc.Options[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	default:
+		return def
+	}
+}
+
+// stringsOption returns a list option or def when unset.
+func (rc RuleConfig) stringsOption(key string, def []string) []string {
+	values, ok := rc.Options[key].([]interface{})
+	if !ok {
+		return def
+	}
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
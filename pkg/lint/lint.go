@@ -0,0 +1,297 @@
+// Package lint checks resources in a source tree against named, configurable
+// quality rules (vague descriptions, missing licenses, overly long files...).
+// Unlike resource validation, lint findings concern content quality rather
+// than whether aimgr can import a resource.
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/discovery"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+)
+
+// Severity is the level a rule reports findings at.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+	SeverityOff     Severity = "off" // disables the rule
+)
+
+func (s Severity) valid() bool {
+	switch s {
+	case SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+		return true
+	}
+	return false
+}
+
+// Finding is one rule violation.
+type Finding struct {
+	Rule     string   `json:"rule" yaml:"rule"`
+	Severity Severity `json:"severity" yaml:"severity"`
+	Resource string   `json:"resource,omitempty" yaml:"resource,omitempty"` // type/name
+	File     string   `json:"file" yaml:"file"`                             // relative to the linted root
+	Line     int      `json:"line,omitempty" yaml:"line,omitempty"`
+	Message  string   `json:"message" yaml:"message"`
+}
+
+// Summary counts findings by severity.
+type Summary struct {
+	Resources int `json:"resources" yaml:"resources"`
+	Errors    int `json:"errors" yaml:"errors"`
+	Warnings  int `json:"warnings" yaml:"warnings"`
+	Infos     int `json:"infos" yaml:"infos"`
+}
+
+// Report is the result of linting a source tree.
+type Report struct {
+	Root     string    `json:"root" yaml:"root"`
+	Config   string    `json:"config,omitempty" yaml:"config,omitempty"`
+	Findings []Finding `json:"findings" yaml:"findings"`
+	Summary  Summary   `json:"summary" yaml:"summary"`
+}
+
+// Target is a resource as seen by the rules.
+type Target struct {
+	Ref         string // type/name
+	Type        resource.ResourceType
+	Name        string
+	File        string // main file (SKILL.md or the .md file), relative to the root
+	Description string
+	Frontmatter resource.Frontmatter
+	Lines       []string // main file content, one entry per line
+	BodyLine    int      // 1-based line number where the body starts
+}
+
+// fieldLine returns the line number of a top-level frontmatter key, or 1
+// when it is not present.
+func (t *Target) fieldLine(key string) int {
+	prefix := key + ":"
+	for i := 0; i < t.BodyLine-1 && i < len(t.Lines); i++ {
+		if strings.HasPrefix(t.Lines[i], prefix) {
+			return i + 1
+		}
+	}
+	return 1
+}
+
+// Lint discovers the skills, commands and agents below root and checks them
+// against the rules. A nil cfg runs every rule with its defaults.
+func Lint(root string, cfg *Config) (*Report, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", root, err)
+	}
+	if cfg == nil {
+		cfg = &Config{}
+	}
+
+	targets, findings, err := collectTargets(absRoot, cfg)
+	if err != nil {
+		return nil, err
+	}
+	invalid := effective(invalidResourceRule, cfg)
+	if invalid.Severity == SeverityOff {
+		findings = nil
+	}
+	for i := range findings {
+		findings[i].Severity = invalid.Severity
+	}
+
+	for _, rule := range rules {
+		if rule.check == nil && rule.checkAll == nil {
+			continue
+		}
+		rc := effective(rule, cfg)
+		if rc.Severity == SeverityOff {
+			continue
+		}
+		applicable := make([]*Target, 0, len(targets))
+		for _, t := range targets {
+			if appliesTo(rc.Types, t.Type) {
+				applicable = append(applicable, t)
+			}
+		}
+		var found []Finding
+		if rule.checkAll != nil {
+			found = rule.checkAll(applicable, rc)
+		} else {
+			for _, t := range applicable {
+				found = append(found, rule.check(t, rc)...)
+			}
+		}
+		for _, f := range found {
+			f.Rule = rule.ID
+			f.Severity = rc.Severity
+			findings = append(findings, f)
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Rule < b.Rule
+	})
+
+	report := &Report{Root: absRoot, Findings: findings}
+	if report.Findings == nil {
+		report.Findings = []Finding{}
+	}
+	report.Summary.Resources = len(targets)
+	for _, f := range findings {
+		switch f.Severity {
+		case SeverityError:
+			report.Summary.Errors++
+		case SeverityWarning:
+			report.Summary.Warnings++
+		case SeverityInfo:
+			report.Summary.Infos++
+		}
+	}
+	return report, nil
+}
+
+// effective merges a rule's defaults with its configuration.
+func effective(rule *Rule, cfg *Config) RuleConfig {
+	rc := cfg.rule(rule.ID)
+	if rc.Severity == "" {
+		rc.Severity = rule.DefaultSeverity
+	}
+	if len(rc.Types) == 0 {
+		rc.Types = rule.DefaultTypes
+	}
+	return rc
+}
+
+func appliesTo(types []string, resType resource.ResourceType) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, t := range types {
+		if resource.ResourceType(t) == resType {
+			return true
+		}
+	}
+	return false
+}
+
+// collectTargets discovers resources below root and loads their main files.
+// Resources that fail to load are returned as invalid-resource findings.
+func collectTargets(root string, cfg *Config) ([]*Target, []Finding, error) {
+	var targets []*Target
+	var findings []Finding
+
+	addErrors := func(errs []discovery.DiscoveryError) {
+		for _, e := range errs {
+			rel := relPath(root, e.Path)
+			if cfg.ignored(rel) {
+				continue
+			}
+			findings = append(findings, Finding{
+				Rule:    invalidResourceRule.ID,
+				File:    rel,
+				Message: e.Error.Error(),
+			})
+		}
+	}
+
+	skills, skillErrs, err := discovery.DiscoverSkillsWithErrors(root, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover skills: %w", err)
+	}
+	addErrors(skillErrs)
+	commands, commandErrs, err := discovery.DiscoverCommandsWithErrors(root, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover commands: %w", err)
+	}
+	addErrors(commandErrs)
+	agents, agentErrs, err := discovery.DiscoverAgentsWithErrors(root, "")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to discover agents: %w", err)
+	}
+	addErrors(agentErrs)
+
+	seen := make(map[string]bool)
+	for _, group := range [][]*resource.Resource{skills, commands, agents} {
+		for _, res := range group {
+			mainFile := res.Path
+			if res.Type == resource.Skill {
+				mainFile = filepath.Join(res.Path, "SKILL.md")
+			}
+			rel := relPath(root, mainFile)
+			if seen[rel] || cfg.ignored(relPath(root, res.Path)) {
+				continue
+			}
+			seen[rel] = true
+
+			target, err := loadTarget(res, mainFile, rel)
+			if err != nil {
+				findings = append(findings, Finding{
+					Rule:     invalidResourceRule.ID,
+					Resource: fmt.Sprintf("%s/%s", res.Type, res.Name),
+					File:     rel,
+					Message:  err.Error(),
+				})
+				continue
+			}
+			targets = append(targets, target)
+		}
+	}
+
+	sort.Slice(targets, func(i, j int) bool { return targets[i].File < targets[j].File })
+	return targets, findings, nil
+}
+
+func loadTarget(res *resource.Resource, mainFile, rel string) (*Target, error) {
+	data, err := os.ReadFile(mainFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", rel, err)
+	}
+	fm, body, err := resource.ParseFrontmatter(mainFile)
+	if err != nil {
+		return nil, err
+	}
+
+	content := strings.ReplaceAll(string(data), "\r\n", "\n")
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	bodyLine := 1
+	if trimmed := strings.TrimLeft(body, "\n"); trimmed != "" {
+		if idx := strings.Index(content, trimmed); idx >= 0 {
+			bodyLine = strings.Count(content[:idx], "\n") + 1
+		}
+	} else {
+		bodyLine = len(lines) + 1
+	}
+
+	return &Target{
+		Ref:         fmt.Sprintf("%s/%s", res.Type, res.Name),
+		Type:        res.Type,
+		Name:        res.Name,
+		File:        rel,
+		Description: res.Description,
+		Frontmatter: fm,
+		Lines:       lines,
+		BodyLine:    bodyLine,
+	}, nil
+}
+
+func relPath(root, p string) string {
+	rel, err := filepath.Rel(root, p)
+	if err != nil {
+		return filepath.ToSlash(p)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,175 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/test/testutil"
+)
+
+func findingsByRule(report *Report) map[string][]Finding {
+	out := make(map[string][]Finding)
+	for _, f := range report.Findings {
+		out[f.Rule] = append(out[f.Rule], f)
+	}
+	return out
+}
+
+func TestLint_DefaultRules(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFile(t, filepath.Join(root, "skills/alpha/SKILL.md"), "---\nname: alpha\ndescription: Various stuff\n---\n# Alpha\n\nTODO: write the steps\n")
+	testutil.WriteFile(t, filepath.Join(root, "skills/beta/SKILL.md"), "---\nname: beta\ndescription: Various stuff\nlicense: MIT\n---\n# Beta\n\n## When to use\n\nAlways.\n")
+	testutil.WriteFile(t, filepath.Join(root, "skills/gamma/SKILL.md"), "---\nname: gamma\ndescription: Fill PDF forms from structured data. Use when the user provides a PDF form to complete.\nlicense: MIT\n---\n# Gamma\n")
+
+	report, err := Lint(root, nil)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if report.Summary.Resources != 3 {
+		t.Fatalf("Resources = %d, want 3", report.Summary.Resources)
+	}
+
+	byRule := findingsByRule(report)
+	for _, f := range report.Findings {
+		if f.File == "skills/gamma/SKILL.md" {
+			t.Errorf("unexpected finding for a well-described skill: %+v", f)
+		}
+	}
+	if got := len(byRule["duplicate-description"]); got != 2 {
+		t.Errorf("duplicate-description findings = %d, want 2", got)
+	}
+	if got := len(byRule["missing-when-to-use"]); got != 1 || byRule["missing-when-to-use"][0].Resource != "skill/alpha" {
+		t.Errorf("missing-when-to-use = %+v, want only skill/alpha", byRule["missing-when-to-use"])
+	}
+	if got := byRule["trailing-todo"]; len(got) != 1 || got[0].Line != 7 {
+		t.Errorf("trailing-todo = %+v, want one finding on line 7", got)
+	}
+	if got := byRule["description-too-short"]; len(got) != 2 || got[0].Line != 3 || got[0].Severity != SeverityWarning {
+		t.Errorf("description-too-short = %+v, want two warnings on line 3", got)
+	}
+	if got := byRule["missing-license"]; len(got) != 1 || got[0].Severity != SeverityInfo {
+		t.Errorf("missing-license = %+v, want one info finding", got)
+	}
+	if report.Summary.Errors != 0 {
+		t.Errorf("Errors = %d, want 0", report.Summary.Errors)
+	}
+}
+
+func TestLint_Config(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFile(t, filepath.Join(root, "skills/alpha/SKILL.md"), "---\nname: alpha\ndescription: Short one\n---\n# Alpha\n\nFIXME later\n")
+	testutil.WriteFile(t, filepath.Join(root, "skills/experimental-x/SKILL.md"), "---\nname: experimental-x\ndescription: x\n---\n")
+	testutil.WriteFile(t, filepath.Join(root, "commands/deploy.md"), "---\ndescription: Deploy\n---\nline\nline\nline\n")
+	testutil.WriteFile(t, filepath.Join(root, ConfigFileName), `ignore:
+  - skills/experimental-*
+rules:
+  description-too-short:
+    severity: error
+    min-length: 5
+  missing-license: off
+  missing-when-to-use: off
+  file-too-long:
+    max-lines: 4
+    types: [command]
+  trailing-todo:
+    markers: [FIXME]
+`)
+
+	configPath, err := FindConfig(filepath.Join(root, "skills"))
+	if err != nil || configPath != filepath.Join(root, ConfigFileName) {
+		t.Fatalf("FindConfig() = %q, %v", configPath, err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	report, err := Lint(root, cfg)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+
+	if report.Summary.Resources != 2 {
+		t.Errorf("Resources = %d, want 2 (experimental skill ignored)", report.Summary.Resources)
+	}
+	byRule := findingsByRule(report)
+	if _, ok := byRule["missing-license"]; ok {
+		t.Error("missing-license ran although it is off")
+	}
+	if got := byRule["description-too-short"]; len(got) != 0 {
+		t.Errorf("description-too-short = %+v, want none with min-length 5", got)
+	}
+	if got := byRule["file-too-long"]; len(got) != 1 || got[0].File != "commands/deploy.md" {
+		t.Errorf("file-too-long = %+v, want only commands/deploy.md", got)
+	}
+	if got := byRule["trailing-todo"]; len(got) != 1 || !strings.Contains(got[0].Message, "FIXME") {
+		t.Errorf("trailing-todo = %+v, want the FIXME marker", got)
+	}
+
+	// Raising the minimum turns both descriptions into errors.
+	cfg.Rules["description-too-short"] = RuleConfig{Severity: SeverityError, Options: map[string]interface{}{"min-length": 20}}
+	report, err = Lint(root, cfg)
+	if err != nil {
+		t.Fatalf("Lint() error = %v", err)
+	}
+	if report.Summary.Errors != 2 {
+		t.Errorf("Errors = %d, want 2", report.Summary.Errors)
+	}
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown rule", "rules:\n  no-such-rule: error\n", `unknown rule "no-such-rule"`},
+		{"bad severity", "rules:\n  missing-license: fatal\n", `invalid severity "fatal"`},
+		{"unknown option", "rules:\n  missing-license:\n    max-lines: 3\n", `unknown option "max-lines"`},
+		{"wrong option type", "rules:\n  file-too-long:\n    max-lines: many\n", "must be an integer"},
+		{"bad type", "rules:\n  file-too-long:\n    types: [package]\n", `invalid type "package"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ConfigFileName)
+			if err := os.WriteFile(path, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := LoadConfig(path)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LoadConfig() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestReportSARIF(t *testing.T) {
+	report := &Report{Findings: []Finding{
+		{Rule: "trailing-todo", Severity: SeverityWarning, File: "skills/a/SKILL.md", Line: 7, Message: "unresolved TODO"},
+		{Rule: "missing-license", Severity: SeverityInfo, File: "skills/a/SKILL.md", Message: "no license"},
+	}}
+
+	log := report.SARIF("1.2.3")
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("unexpected SARIF log: %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != len(Rules()) {
+		t.Errorf("driver rules = %d, want %d", len(run.Tool.Driver.Rules), len(Rules()))
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("results = %d, want 2", len(run.Results))
+	}
+	first := run.Results[0]
+	if first.Level != "warning" || first.Locations[0].PhysicalLocation.Region.StartLine != 7 {
+		t.Errorf("first result = %+v", first)
+	}
+	if run.Tool.Driver.Rules[first.RuleIndex].ID != "trailing-todo" {
+		t.Errorf("ruleIndex %d does not point at trailing-todo", first.RuleIndex)
+	}
+	second := run.Results[1]
+	if second.Level != "note" || second.Locations[0].PhysicalLocation.Region != nil {
+		t.Errorf("second result = %+v", second)
+	}
+}
@@ -0,0 +1,308 @@
+package lint
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// optionKind is the value type a rule option accepts.
+type optionKind string
+
+const (
+	intOption     optionKind = "an integer"
+	stringsOption optionKind = "a list of strings"
+)
+
+func (k optionKind) String() string { return string(k) }
+
+func (k optionKind) accepts(value interface{}) bool {
+	switch k {
+	case intOption:
+		_, ok := value.(int)
+		return ok
+	case stringsOption:
+		values, ok := value.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, v := range values {
+			if _, ok := v.(string); !ok {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// Rule is a named lint check. Per-resource rules set check; rules that
+// compare resources with each other set checkAll.
+type Rule struct {
+	ID              string
+	Description     string
+	DefaultSeverity Severity
+	DefaultTypes    []string              // empty: all resource types
+	Options         map[string]optionKind // rule-specific options
+
+	check    func(t *Target, rc RuleConfig) []Finding
+	checkAll func(targets []*Target, rc RuleConfig) []Finding
+}
+
+// Defaults for rule options.
+const (
+	defaultMinDescriptionLength = 40
+	defaultMaxLines             = 500
+)
+
+var (
+	defaultVaguePhrases = []string{
+		"various", "stuff", "things", "misc", "miscellaneous", "etc",
+		"and more", "general purpose", "helps with", "useful",
+	}
+	defaultWhenToUsePhrases = []string{
+		"use when", "use this", "use for", "use it", "when to use",
+		"when the user", "when you", "invoke when", "trigger",
+	}
+	defaultTodoMarkers = []string{"TODO", "FIXME", "XXX"}
+)
+
+// invalidResourceRule reports resources discovery or parsing rejected. It
+// has no check of its own; Lint fills it from discovery errors.
+var invalidResourceRule = &Rule{
+	ID:              "invalid-resource",
+	Description:     "Resource could not be loaded",
+	DefaultSeverity: SeverityError,
+}
+
+// rules is the rule set, in documentation order.
+var rules = []*Rule{
+	invalidResourceRule,
+	{
+		ID:              "description-too-short",
+		Description:     "Description is too short to tell when the resource applies",
+		DefaultSeverity: SeverityWarning,
+		Options:         map[string]optionKind{"min-length": intOption},
+		check:           checkDescriptionTooShort,
+	},
+	{
+		ID:              "description-vague",
+		Description:     "Description relies on vague filler words",
+		DefaultSeverity: SeverityWarning,
+		Options:         map[string]optionKind{"phrases": stringsOption},
+		check:           checkDescriptionVague,
+	},
+	{
+		ID:              "missing-when-to-use",
+		Description:     "Neither the description nor the body says when to use the resource",
+		DefaultSeverity: SeverityWarning,
+		DefaultTypes:    []string{"skill", "agent"},
+		Options:         map[string]optionKind{"phrases": stringsOption},
+		check:           checkMissingWhenToUse,
+	},
+	{
+		ID:              "missing-license",
+		Description:     "Frontmatter has no license field",
+		DefaultSeverity: SeverityInfo,
+		DefaultTypes:    []string{"skill"},
+		check:           checkMissingLicense,
+	},
+	{
+		ID:              "file-too-long",
+		Description:     "Main file (e.g. SKILL.md) has too many lines",
+		DefaultSeverity: SeverityWarning,
+		Options:         map[string]optionKind{"max-lines": intOption},
+		check:           checkFileTooLong,
+	},
+	{
+		ID:              "duplicate-description",
+		Description:     "Several resources share the same description",
+		DefaultSeverity: SeverityWarning,
+		checkAll:        checkDuplicateDescriptions,
+	},
+	{
+		ID:              "trailing-todo",
+		Description:     "Body still contains TODO markers",
+		DefaultSeverity: SeverityWarning,
+		Options:         map[string]optionKind{"markers": stringsOption},
+		check:           checkTrailingTodo,
+	},
+}
+
+// Rules returns the available rules in documentation order.
+func Rules() []*Rule {
+	return rules
+}
+
+// RuleIDs returns the IDs of all rules.
+func RuleIDs() []string {
+	ids := make([]string, len(rules))
+	for i, rule := range rules {
+		ids[i] = rule.ID
+	}
+	return ids
+}
+
+func ruleByID(id string) *Rule {
+	for _, rule := range rules {
+		if rule.ID == id {
+			return rule
+		}
+	}
+	return nil
+}
+
+func checkDescriptionTooShort(t *Target, rc RuleConfig) []Finding {
+	minLength := rc.intOption("min-length", defaultMinDescriptionLength)
+	length := len([]rune(strings.TrimSpace(t.Description)))
+	if length >= minLength {
+		return nil
+	}
+	return []Finding{{
+		Resource: t.Ref,
+		File:     t.File,
+		Line:     t.fieldLine("description"),
+		Message:  fmt.Sprintf("description has %d characters (minimum %d)", length, minLength),
+	}}
+}
+
+func checkDescriptionVague(t *Target, rc RuleConfig) []Finding {
+	description := " " + normalizeWords(t.Description) + " "
+	var matched []string
+	for _, phrase := range rc.stringsOption("phrases", defaultVaguePhrases) {
+		if strings.Contains(description, " "+normalizeWords(phrase)+" ") {
+			matched = append(matched, phrase)
+		}
+	}
+	if len(matched) == 0 {
+		return nil
+	}
+	return []Finding{{
+		Resource: t.Ref,
+		File:     t.File,
+		Line:     t.fieldLine("description"),
+		Message:  fmt.Sprintf("description uses vague wording (%s); say what the resource does and when", strings.Join(matched, ", ")),
+	}}
+}
+
+// whenToUseHeading matches a markdown heading like "## When to use".
+var whenToUseHeading = regexp.MustCompile(`(?im)^#+\s*when\s+(to|should\s+\w+)\s+use`)
+
+func checkMissingWhenToUse(t *Target, rc RuleConfig) []Finding {
+	description := strings.ToLower(t.Description)
+	for _, phrase := range rc.stringsOption("phrases", defaultWhenToUsePhrases) {
+		if strings.Contains(description, strings.ToLower(phrase)) {
+			return nil
+		}
+	}
+	if t.BodyLine <= len(t.Lines) && whenToUseHeading.MatchString(strings.Join(t.Lines[t.BodyLine-1:], "\n")) {
+		return nil
+	}
+	return []Finding{{
+		Resource: t.Ref,
+		File:     t.File,
+		Line:     t.fieldLine("description"),
+		Message:  `no "when to use" guidance: mention it in the description (e.g. "Use when ...") or add a "When to use" section`,
+	}}
+}
+
+func checkMissingLicense(t *Target, _ RuleConfig) []Finding {
+	if strings.TrimSpace(t.Frontmatter.GetString("license")) != "" {
+		return nil
+	}
+	return []Finding{{
+		Resource: t.Ref,
+		File:     t.File,
+		Line:     1,
+		Message:  "frontmatter has no license",
+	}}
+}
+
+func checkFileTooLong(t *Target, rc RuleConfig) []Finding {
+	maxLines := rc.intOption("max-lines", defaultMaxLines)
+	if len(t.Lines) <= maxLines {
+		return nil
+	}
+	return []Finding{{
+		Resource: t.Ref,
+		File:     t.File,
+		Line:     maxLines + 1,
+		Message:  fmt.Sprintf("%s has %d lines (maximum %d); move details into separate files", path.Base(t.File), len(t.Lines), maxLines),
+	}}
+}
+
+func checkDuplicateDescriptions(targets []*Target, _ RuleConfig) []Finding {
+	byDescription := make(map[string][]*Target)
+	for _, t := range targets {
+		key := normalizeWords(t.Description)
+		if key == "" {
+			continue
+		}
+		byDescription[key] = append(byDescription[key], t)
+	}
+
+	var findings []Finding
+	for _, group := range byDescription {
+		if len(group) < 2 {
+			continue
+		}
+		refs := make([]string, len(group))
+		for i, t := range group {
+			refs[i] = t.Ref
+		}
+		sort.Strings(refs)
+		for _, t := range group {
+			var others []string
+			for _, ref := range refs {
+				if ref != t.Ref {
+					others = append(others, ref)
+				}
+			}
+			findings = append(findings, Finding{
+				Resource: t.Ref,
+				File:     t.File,
+				Line:     t.fieldLine("description"),
+				Message:  fmt.Sprintf("description is identical to %s", strings.Join(others, ", ")),
+			})
+		}
+	}
+	return findings
+}
+
+func checkTrailingTodo(t *Target, rc RuleConfig) []Finding {
+	markers := rc.stringsOption("markers", defaultTodoMarkers)
+	if len(markers) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(markers))
+	for i, marker := range markers {
+		quoted[i] = regexp.QuoteMeta(marker)
+	}
+	pattern := regexp.MustCompile(`\b(` + strings.Join(quoted, "|") + `)\b`)
+
+	var findings []Finding
+	for i := t.BodyLine - 1; i < len(t.Lines); i++ {
+		if i < 0 {
+			continue
+		}
+		if marker := pattern.FindString(t.Lines[i]); marker != "" {
+			findings = append(findings, Finding{
+				Resource: t.Ref,
+				File:     t.File,
+				Line:     i + 1,
+				Message:  fmt.Sprintf("unresolved %s: %s", marker, strings.TrimSpace(t.Lines[i])),
+			})
+		}
+	}
+	return findings
+}
+
+// normalizeWords lowercases text and collapses everything but letters and
+// digits into single spaces.
+func normalizeWords(text string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r > 127)
+	}), " ")
+}
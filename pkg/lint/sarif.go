@@ -0,0 +1,130 @@
+package lint
+
+// SARIF 2.1.0 types, limited to what code scanning UIs need to show
+// findings inline on a pull request.
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolURI = "https://github.com/dynatrace-oss/ai-config-manager"
+)
+
+// SARIFLog is the root object of a SARIF file.
+type SARIFLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "note"
+	default:
+		return "none"
+	}
+}
+
+// SARIF converts the report to a SARIF log. File locations are relative to
+// the linted root (uriBaseId %SRCROOT%), so upload the file from that
+// directory.
+func (r *Report) SARIF(toolVersion string) *SARIFLog {
+	driver := sarifDriver{
+		Name:           "aimgr",
+		Version:        toolVersion,
+		InformationURI: sarifToolURI,
+	}
+	ruleIndex := make(map[string]int, len(rules))
+	for i, rule := range rules {
+		ruleIndex[rule.ID] = i
+		driver.Rules = append(driver.Rules, sarifRule{
+			ID:                   rule.ID,
+			ShortDescription:     sarifMessage{Text: rule.Description},
+			DefaultConfiguration: sarifConfiguration{Level: sarifLevel(rule.DefaultSeverity)},
+		})
+	}
+
+	results := make([]sarifResult, 0, len(r.Findings))
+	for _, f := range r.Findings {
+		location := sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: f.File, URIBaseID: "%SRCROOT%"},
+		}
+		if f.Line > 0 {
+			location.Region = &sarifRegion{StartLine: f.Line}
+		}
+		results = append(results, sarifResult{
+			RuleID:    f.Rule,
+			RuleIndex: ruleIndex[f.Rule],
+			Level:     sarifLevel(f.Severity),
+			Message:   sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{{PhysicalLocation: location}},
+		})
+	}
+
+	return &SARIFLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs:    []sarifRun{{Tool: sarifTool{Driver: driver}, Results: results}},
+	}
+}
@@ -84,6 +84,7 @@ func LoadProjectManifests(projectPath string) (*ProjectManifests, error) {
 }
 
 // Merge builds an effective manifest using additive overlay semantics:
+//   - profile: a local pin replaces the base pin
 //   - resources: base order preserved, local-only entries appended, exact duplicates removed
 //   - install.targets: base order preserved, local-only entries appended, exact duplicates removed
 //   - sources: base order preserved, local-only entries appended, canonical duplicates
@@ -98,12 +99,16 @@ func Merge(base, local *Manifest) *Manifest {
 	}
 
 	if base != nil {
+		merged.Profile = base.Profile
 		merged.Resources = append(merged.Resources, base.Resources...)
 		merged.Install.Targets = append(merged.Install.Targets, base.Install.Targets...)
 		merged.Sources = append(merged.Sources, base.Sources...)
 	}
 
 	if local != nil {
+		if local.Profile != "" {
+			merged.Profile = local.Profile
+		}
 		merged.Resources = appendUniqueStrings(merged.Resources, local.Resources...)
 		merged.Install.Targets = appendUniqueStrings(merged.Install.Targets, local.Install.Targets...)
 		merged.Sources = appendUniqueSources(merged.Sources, local.Sources...)
@@ -239,6 +244,10 @@ type Manifest struct {
 	// Install configuration for installation targets
 	Install InstallConfig `yaml:"install,omitempty"`
 
+	// Profile pins the aimgr profile (from aimgr.yaml) whose repository
+	// this project installs from
+	Profile string `yaml:"profile,omitempty"`
+
 	// Sources declares optional remote catalogs used by this project.
 	// Canonical identity for merge/dedup is normalized url+subpath.
 	Sources []ManifestSource `yaml:"sources,omitempty"`
@@ -371,7 +380,8 @@ func (m *Manifest) Validate() error {
 
 // Add adds a resource to the manifest
 // Resource should be in "type/name" format (e.g., "skill/pdf-processing")
-// If the resource already exists, it's not added again
+// If the resource already exists, it's not added again; a source-qualified
+// reference replaces the existing entry in place so the source is kept.
 func (m *Manifest) Add(resource string) error {
 	if m == nil {
 		return fmt.Errorf("cannot add to nil manifest")
@@ -383,8 +393,13 @@ func (m *Manifest) Add(resource string) error {
 	}
 
 	// Check if already exists
-	if m.Has(resource) {
-		return nil // Already exists, nothing to do
+	for i, r := range m.Resources {
+		if sameResourceReference(r, resource) {
+			if resource != unqualifiedReference(resource) {
+				m.Resources[i] = resource
+			}
+			return nil
+		}
 	}
 
 	// Add to resources
@@ -393,7 +408,9 @@ func (m *Manifest) Add(resource string) error {
 }
 
 // Remove removes a resource from the manifest
-// Returns nil even if the resource doesn't exist
+// Returns nil even if the resource doesn't exist.
+// Source-qualified and unqualified references to the same resource
+// (e.g. "skill/platform:pdf" and "skill/pdf") are treated as equal.
 func (m *Manifest) Remove(resource string) error {
 	if m == nil {
 		return fmt.Errorf("cannot remove from nil manifest")
@@ -402,7 +419,7 @@ func (m *Manifest) Remove(resource string) error {
 	// Find and remove the resource
 	newResources := make([]string, 0, len(m.Resources))
 	for _, r := range m.Resources {
-		if r != resource {
+		if !sameResourceReference(r, resource) {
 			newResources = append(newResources, r)
 		}
 	}
@@ -411,20 +428,39 @@ func (m *Manifest) Remove(resource string) error {
 	return nil
 }
 
-// Has checks if a resource exists in the manifest
+// Has checks if a resource exists in the manifest.
+// Source qualifiers are ignored when comparing references.
 func (m *Manifest) Has(resource string) bool {
 	if m == nil {
 		return false
 	}
 
 	for _, r := range m.Resources {
-		if r == resource {
+		if sameResourceReference(r, resource) {
 			return true
 		}
 	}
 	return false
 }
 
+// sameResourceReference compares two references ignoring an optional source
+// qualifier on the name ("type/source:name").
+func sameResourceReference(a, b string) bool {
+	return unqualifiedReference(a) == unqualifiedReference(b)
+}
+
+// unqualifiedReference strips the source qualifier from "type/source:name".
+func unqualifiedReference(ref string) string {
+	resourceType, name, found := strings.Cut(ref, "/")
+	if !found {
+		return ref
+	}
+	if _, bare, qualified := strings.Cut(name, ":"); qualified {
+		return resourceType + "/" + bare
+	}
+	return ref
+}
+
 // Exists checks if a manifest file exists at the given path
 func Exists(path string) bool {
 	_, err := os.Stat(path)
@@ -514,6 +514,40 @@ func TestHas(t *testing.T) {
 	}
 }
 
+func TestHasAndRemove_SourceQualifiedReferences(t *testing.T) {
+	m := &Manifest{
+		Resources: []string{"skill/platform:code-review", "command/build"},
+	}
+
+	if !m.Has("skill/code-review") {
+		t.Error("Has() should match a source-qualified entry by its unqualified reference")
+	}
+	if !m.Has("skill/team:code-review") {
+		t.Error("Has() should ignore the source qualifier when comparing")
+	}
+
+	if err := m.Add("skill/code-review"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if len(m.Resources) != 2 || m.Resources[0] != "skill/platform:code-review" {
+		t.Errorf("Add() should not duplicate or unqualify a qualified entry, got %v", m.Resources)
+	}
+
+	if err := m.Add("skill/team:code-review"); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	if len(m.Resources) != 2 || m.Resources[0] != "skill/team:code-review" {
+		t.Errorf("Add() should replace the entry with the new source qualifier, got %v", m.Resources)
+	}
+
+	if err := m.Remove("skill/code-review"); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if len(m.Resources) != 1 || m.Resources[0] != "command/build" {
+		t.Errorf("Remove() should drop the qualified entry, got %v", m.Resources)
+	}
+}
+
 func TestExists(t *testing.T) {
 	tmpDir := t.TempDir()
 	path := filepath.Join(tmpDir, "ai.package.yaml")
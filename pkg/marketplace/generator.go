@@ -17,6 +17,29 @@ type PackageInfo struct {
 	SourcePath string // Absolute path to plugin source directory
 }
 
+// SkippedPlugin records a marketplace plugin that did not produce a package
+type SkippedPlugin struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Reason string `json:"reason"`
+	// FetchFailed is set when a remote plugin source could not be fetched,
+	// meaning its resources are unknown rather than absent.
+	FetchFailed bool `json:"fetch_failed,omitempty"`
+}
+
+// PluginFetcher fetches remote plugin sources.
+// FetchPlugin returns the local directory containing the plugin
+// (already resolved to RemoteSource.Path when set).
+type PluginFetcher interface {
+	FetchPlugin(remote *RemoteSource) (string, error)
+}
+
+// GenerateResult holds generated packages and the plugins that were skipped
+type GenerateResult struct {
+	Packages []*PackageInfo
+	Skipped  []SkippedPlugin
+}
+
 // GeneratePackages generates aimgr packages from marketplace plugin entries.
 // It discovers resources in each plugin's source directory and creates a package
 // for each plugin with resource references in type/name format.
@@ -24,8 +47,22 @@ type PackageInfo struct {
 // basePath is the directory containing the marketplace.json file (used to resolve
 // relative plugin source paths).
 //
-// Returns an array of generated package info. Plugins with no resources are skipped.
+// Returns an array of generated package info. Plugins with no resources, missing
+// source directories or remote sources are skipped; use
+// GeneratePackagesWithFetcher to fetch remote plugins and learn which were skipped.
 func GeneratePackages(marketplace *MarketplaceConfig, basePath string) ([]*PackageInfo, error) {
+	result, err := GeneratePackagesWithFetcher(marketplace, basePath, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result.Packages, nil
+}
+
+// GeneratePackagesWithFetcher generates packages like GeneratePackages and
+// resolves remote plugin sources through fetcher. A nil fetcher skips remote
+// plugins. Every plugin that does not produce a package is reported in
+// GenerateResult.Skipped with the reason.
+func GeneratePackagesWithFetcher(marketplace *MarketplaceConfig, basePath string, fetcher PluginFetcher) (*GenerateResult, error) {
 	if marketplace == nil {
 		return nil, fmt.Errorf("marketplace config cannot be nil")
 	}
@@ -41,18 +78,42 @@ func GeneratePackages(marketplace *MarketplaceConfig, basePath string) ([]*Packa
 		return nil, fmt.Errorf("basePath is not a directory: %s", basePath)
 	}
 
-	var packages []*PackageInfo
+	result := &GenerateResult{}
 
 	for i, plugin := range marketplace.Plugins {
-		// Resolve plugin source path (relative to basePath)
-		sourcePath := plugin.Source
-		if !filepath.IsAbs(sourcePath) {
-			sourcePath = filepath.Join(basePath, plugin.Source)
+		skip := func(reason string, fetchFailed bool) {
+			result.Skipped = append(result.Skipped, SkippedPlugin{
+				Name:        plugin.Name,
+				Source:      plugin.SourceDescription(),
+				Reason:      reason,
+				FetchFailed: fetchFailed,
+			})
+		}
+
+		var sourcePath string
+		if plugin.Remote != nil {
+			if fetcher == nil {
+				skip("remote source not fetched", false)
+				continue
+			}
+			fetched, err := fetcher.FetchPlugin(plugin.Remote)
+			if err != nil {
+				skip(fmt.Sprintf("failed to fetch remote source: %v", err), true)
+				continue
+			}
+			sourcePath = fetched
+		} else {
+			// Resolve plugin source path (relative to basePath)
+			sourcePath = plugin.Source
+			if !filepath.IsAbs(sourcePath) {
+				sourcePath = filepath.Join(basePath, plugin.Source)
+			}
 		}
 
 		// Check if source directory exists
 		if info, err := os.Stat(sourcePath); err != nil {
-			// Skip plugins with missing source directories (could be placeholders)
+			// Missing source directories could be placeholders; report, don't fail
+			skip("source directory not found", plugin.Remote != nil)
 			continue
 		} else if !info.IsDir() {
 			return nil, fmt.Errorf("plugin %d (%s): source is not a directory: %s", i, plugin.Name, sourcePath)
@@ -74,6 +135,7 @@ func GeneratePackages(marketplace *MarketplaceConfig, basePath string) ([]*Packa
 
 		// Skip plugins with no resources
 		if len(resources) == 0 {
+			skip("no resources found", false)
 			continue
 		}
 
@@ -85,15 +147,13 @@ func GeneratePackages(marketplace *MarketplaceConfig, basePath string) ([]*Packa
 		}
 
 		// Store package info with source path
-		pkgInfo := &PackageInfo{
+		result.Packages = append(result.Packages, &PackageInfo{
 			Package:    pkg,
 			SourcePath: sourcePath,
-		}
-
-		packages = append(packages, pkgInfo)
+		})
 	}
 
-	return packages, nil
+	return result, nil
 }
 
 // sanitizeName converts a plugin name to a valid aimgr package name.
@@ -1,6 +1,7 @@
 package marketplace
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -891,3 +892,97 @@ func TestGeneratePackages_Integration(t *testing.T) {
 		}
 	}
 }
+
+type fakePluginFetcher struct {
+	dirs  map[string]string
+	calls []string
+}
+
+func (f *fakePluginFetcher) FetchPlugin(remote *RemoteSource) (string, error) {
+	f.calls = append(f.calls, remote.CloneURL())
+	dir, ok := f.dirs[remote.CloneURL()]
+	if !ok {
+		return "", errors.New("authentication required")
+	}
+	if remote.Path != "" {
+		dir = filepath.Join(dir, remote.Path)
+	}
+	return dir, nil
+}
+
+func TestGeneratePackagesWithFetcher_RemoteAndSkipped(t *testing.T) {
+	tmpDir := t.TempDir()
+	remoteDir := filepath.Join(t.TempDir(), "checkout")
+	commandsDir := filepath.Join(remoteDir, "plugins", "review", "commands")
+	if err := os.MkdirAll(commandsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(commandsDir, "review.md"), []byte("---\ndescription: Review\n---\n# Review"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, "plugins", "empty"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	mp := &MarketplaceConfig{
+		Name: "test-marketplace",
+		Plugins: []Plugin{
+			{Name: "review", Description: "Remote review", Remote: &RemoteSource{Kind: RemoteKindGitHub, Repo: "acme/tools", Path: "plugins/review"}},
+			{Name: "private", Description: "Unreachable", Remote: &RemoteSource{Kind: RemoteKindURL, URL: "https://git.example.com/private.git"}},
+			{Name: "missing", Description: "Missing dir", Source: "./plugins/missing"},
+			{Name: "empty", Description: "No resources", Source: "./plugins/empty"},
+		},
+	}
+
+	fetcher := &fakePluginFetcher{dirs: map[string]string{"https://github.com/acme/tools": remoteDir}}
+	result, err := GeneratePackagesWithFetcher(mp, tmpDir, fetcher)
+	if err != nil {
+		t.Fatalf("GeneratePackagesWithFetcher() error = %v", err)
+	}
+
+	if len(result.Packages) != 1 || result.Packages[0].Package.Name != "review" {
+		t.Fatalf("Packages = %+v, want only review", result.Packages)
+	}
+	if got := result.Packages[0].Package.Resources; len(got) != 1 || got[0] != "command/review" {
+		t.Errorf("review resources = %v, want [command/review]", got)
+	}
+
+	want := map[string]struct {
+		reason      string
+		fetchFailed bool
+	}{
+		"private": {"failed to fetch remote source: authentication required", true},
+		"missing": {"source directory not found", false},
+		"empty":   {"no resources found", false},
+	}
+	if len(result.Skipped) != len(want) {
+		t.Fatalf("Skipped = %+v, want %d entries", result.Skipped, len(want))
+	}
+	for _, skipped := range result.Skipped {
+		w, ok := want[skipped.Name]
+		if !ok {
+			t.Errorf("unexpected skipped plugin %q", skipped.Name)
+			continue
+		}
+		if skipped.Reason != w.reason || skipped.FetchFailed != w.fetchFailed {
+			t.Errorf("skipped %q = %+v, want reason %q fetchFailed %v", skipped.Name, skipped, w.reason, w.fetchFailed)
+		}
+	}
+}
+
+func TestGeneratePackagesWithFetcher_NilFetcherSkipsRemote(t *testing.T) {
+	mp := &MarketplaceConfig{
+		Name: "test-marketplace",
+		Plugins: []Plugin{
+			{Name: "remote", Description: "Remote", Remote: &RemoteSource{Kind: RemoteKindGitHub, Repo: "acme/tools"}},
+		},
+	}
+
+	result, err := GeneratePackagesWithFetcher(mp, t.TempDir(), nil)
+	if err != nil {
+		t.Fatalf("GeneratePackagesWithFetcher() error = %v", err)
+	}
+	if len(result.Packages) != 0 || len(result.Skipped) != 1 || result.Skipped[0].Source != "acme/tools" {
+		t.Fatalf("result = %+v, want remote plugin skipped", result)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"strings"
 )
 
 // MarketplaceConfig represents a Claude marketplace.json configuration file
@@ -30,6 +31,134 @@ type Plugin struct {
 	Category    string  `json:"category,omitempty"`
 	Version     string  `json:"version,omitempty"`
 	Author      *Author `json:"author,omitempty"`
+
+	// Remote is set when the plugin source points at another repository,
+	// either as an object ({"source": "github", "repo": "owner/repo"}) or as a
+	// git URL string. Source is empty in the object form.
+	Remote *RemoteSource `json:"-"`
+}
+
+// Remote plugin source kinds
+const (
+	RemoteKindGitHub = "github"
+	RemoteKindURL    = "url"
+)
+
+// RemoteSource describes a plugin hosted outside the marketplace repository
+type RemoteSource struct {
+	Kind string `json:"source"`
+	Repo string `json:"repo,omitempty"` // owner/repo (github)
+	URL  string `json:"url,omitempty"`  // git clone URL (url)
+	Ref  string `json:"ref,omitempty"`  // branch, tag or commit
+	Path string `json:"path,omitempty"` // subdirectory within the repository
+}
+
+// CloneURL returns the git URL to fetch the remote source from
+func (r *RemoteSource) CloneURL() string {
+	if r.Kind == RemoteKindGitHub {
+		return "https://github.com/" + strings.TrimSuffix(r.Repo, ".git")
+	}
+	return r.URL
+}
+
+// String returns a short human-readable description of the remote source
+func (r *RemoteSource) String() string {
+	s := r.Repo
+	if r.Kind != RemoteKindGitHub {
+		s = r.URL
+	}
+	if r.Path != "" {
+		s += "//" + r.Path
+	}
+	if r.Ref != "" {
+		s += "@" + r.Ref
+	}
+	return s
+}
+
+// SourceDescription returns the plugin source as written in the marketplace,
+// for use in user-facing messages
+func (p *Plugin) SourceDescription() string {
+	if p.Remote != nil {
+		return p.Remote.String()
+	}
+	return p.Source
+}
+
+// UnmarshalJSON accepts both the string and the object form of "source"
+func (p *Plugin) UnmarshalJSON(data []byte) error {
+	type plain Plugin
+	var raw struct {
+		plain
+		Source json.RawMessage `json:"source"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*p = Plugin(raw.plain)
+	p.Source = ""
+	p.Remote = nil
+
+	src := strings.TrimSpace(string(raw.Source))
+	if src == "" || src == "null" {
+		return nil
+	}
+
+	if strings.HasPrefix(src, "{") {
+		var remote struct {
+			RemoteSource
+			SHA string `json:"sha,omitempty"`
+		}
+		if err := json.Unmarshal(raw.Source, &remote); err != nil {
+			return fmt.Errorf("invalid plugin source object: %w", err)
+		}
+		if remote.Ref == "" {
+			remote.Ref = remote.SHA
+		}
+		if remote.Kind == "git" {
+			remote.Kind = RemoteKindURL
+		}
+		p.Remote = &remote.RemoteSource
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(raw.Source, &s); err != nil {
+		return fmt.Errorf("plugin source must be a string or an object: %w", err)
+	}
+	if isGitURL(s) {
+		p.Remote = &RemoteSource{Kind: RemoteKindURL, URL: s}
+		return nil
+	}
+	p.Source = s
+	return nil
+}
+
+// MarshalJSON writes remote sources back in object form
+func (p Plugin) MarshalJSON() ([]byte, error) {
+	type plain Plugin
+	out := struct {
+		plain
+		Source any `json:"source"`
+	}{plain: plain(p), Source: p.Source}
+	if p.Remote != nil {
+		out.Source = p.Remote
+	}
+	return json.Marshal(out)
+}
+
+// isGitURL reports whether a string source refers to a git repository rather
+// than a path inside the marketplace repository
+func isGitURL(s string) bool {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "git@") || strings.HasPrefix(s, "git://") || strings.HasPrefix(s, "ssh://") {
+		return true
+	}
+	if strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://") {
+		return true
+	}
+	return false
 }
 
 // Author represents author information for a marketplace or plugin
@@ -111,9 +240,38 @@ func validatePlugin(plugin *Plugin, index int) error {
 		return fmt.Errorf("plugin at index %d: description is required", index)
 	}
 
+	if plugin.Remote != nil {
+		return validateRemoteSource(plugin.Remote, index)
+	}
+
 	if plugin.Source == "" {
 		return fmt.Errorf("plugin at index %d: source is required", index)
 	}
 
 	return nil
 }
+
+// validateRemoteSource validates the object form of a plugin source
+func validateRemoteSource(remote *RemoteSource, index int) error {
+	switch remote.Kind {
+	case RemoteKindGitHub:
+		parts := strings.Split(strings.TrimSuffix(remote.Repo, ".git"), "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("plugin at index %d: github source requires repo in owner/repo form, got %q", index, remote.Repo)
+		}
+	case RemoteKindURL:
+		if remote.URL == "" {
+			return fmt.Errorf("plugin at index %d: url source requires url", index)
+		}
+	case "":
+		return fmt.Errorf("plugin at index %d: source object requires a \"source\" kind (github, url)", index)
+	default:
+		return fmt.Errorf("plugin at index %d: unsupported source kind %q (supported: github, url)", index, remote.Kind)
+	}
+
+	if strings.Contains(remote.Path, "..") {
+		return fmt.Errorf("plugin at index %d: source path must not contain '..'", index)
+	}
+
+	return nil
+}
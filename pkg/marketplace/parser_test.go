@@ -667,3 +667,107 @@ func TestMarketplaceRoundTrip(t *testing.T) {
 		}
 	}
 }
+
+func TestParseMarketplace_RemotePluginSources(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := `{
+  "name": "remote-marketplace",
+  "plugins": [
+    {"name": "local", "description": "Local plugin", "source": "./plugins/local"},
+    {"name": "gh", "description": "GitHub plugin", "source": {"source": "github", "repo": "acme/review-plugin", "ref": "v1.2.0"}},
+    {"name": "url", "description": "URL plugin", "source": {"source": "url", "url": "https://git.example.com/team/tools.git", "path": "plugins/lint"}},
+    {"name": "pinned", "description": "Pinned plugin", "source": {"source": "github", "repo": "acme/pinned", "sha": "abc123"}},
+    {"name": "giturl", "description": "Git URL string", "source": "https://github.com/acme/other.git"}
+  ]
+}`
+	filePath := filepath.Join(tmpDir, "marketplace.json")
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := ParseMarketplace(filePath)
+	if err != nil {
+		t.Fatalf("ParseMarketplace() error = %v", err)
+	}
+
+	if got := config.Plugins[0]; got.Remote != nil || got.Source != "./plugins/local" {
+		t.Errorf("local plugin = %+v, want local source", got)
+	}
+
+	gh := config.Plugins[1].Remote
+	if gh == nil || gh.Kind != RemoteKindGitHub || gh.Repo != "acme/review-plugin" || gh.Ref != "v1.2.0" {
+		t.Fatalf("github plugin remote = %+v", gh)
+	}
+	if gh.CloneURL() != "https://github.com/acme/review-plugin" {
+		t.Errorf("CloneURL() = %q", gh.CloneURL())
+	}
+
+	u := config.Plugins[2].Remote
+	if u == nil || u.Kind != RemoteKindURL || u.URL != "https://git.example.com/team/tools.git" || u.Path != "plugins/lint" {
+		t.Fatalf("url plugin remote = %+v", u)
+	}
+	if got := config.Plugins[2].SourceDescription(); got != "https://git.example.com/team/tools.git//plugins/lint" {
+		t.Errorf("SourceDescription() = %q", got)
+	}
+
+	if pinned := config.Plugins[3].Remote; pinned == nil || pinned.Ref != "abc123" {
+		t.Errorf("sha should populate ref, got %+v", pinned)
+	}
+
+	if gitURL := config.Plugins[4].Remote; gitURL == nil || gitURL.Kind != RemoteKindURL || gitURL.URL != "https://github.com/acme/other.git" {
+		t.Errorf("git URL string should parse as remote, got %+v", gitURL)
+	}
+}
+
+func TestParseMarketplace_InvalidRemotePluginSources(t *testing.T) {
+	tests := []struct {
+		name    string
+		source  string
+		wantErr string
+	}{
+		{"missing kind", `{"repo": "acme/x"}`, `requires a "source" kind`},
+		{"unsupported kind", `{"source": "npm", "package": "x"}`, `unsupported source kind "npm"`},
+		{"github without repo", `{"source": "github"}`, "owner/repo"},
+		{"url without url", `{"source": "url"}`, "requires url"},
+		{"path traversal", `{"source": "github", "repo": "acme/x", "path": "../etc"}`, "must not contain '..'"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filePath := filepath.Join(t.TempDir(), "marketplace.json")
+			content := `{"name": "m", "plugins": [{"name": "p", "description": "d", "source": ` + tt.source + `}]}`
+			if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := ParseMarketplace(filePath)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("ParseMarketplace() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPluginJSONRoundTrip_RemoteSource(t *testing.T) {
+	original := Plugin{
+		Name:        "gh",
+		Description: "GitHub plugin",
+		Remote:      &RemoteSource{Kind: RemoteKindGitHub, Repo: "acme/plugin", Ref: "main"},
+	}
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+	if !strings.Contains(string(data), `"source":{"source":"github","repo":"acme/plugin","ref":"main"}`) {
+		t.Errorf("Marshal() = %s, want object source", data)
+	}
+
+	var parsed Plugin
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+	if parsed.Remote == nil || *parsed.Remote != *original.Remote {
+		t.Errorf("round trip remote = %+v, want %+v", parsed.Remote, original.Remote)
+	}
+}
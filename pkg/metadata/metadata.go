@@ -20,15 +20,27 @@ import (
 //   - Skills:   ~/.local/share/ai-config/repo/.metadata/skills/myskill-metadata.json
 //   - Agents:   ~/.local/share/ai-config/repo/.metadata/agents/myagent-metadata.json
 type ResourceMetadata struct {
-	Name           string                `json:"name"`                  // Resource name
-	Type           resource.ResourceType `json:"type"`                  // Resource type (command, skill, agent)
-	SourceType     string                `json:"source_type"`           // Source type: "github", "local", "file"
-	SourceURL      string                `json:"source_url"`            // Source URL or file path
-	SourceName     string                `json:"source_name,omitempty"` // Source name from ai.repo.yaml or derived from URL/path
-	SourceID       string                `json:"source_id,omitempty"`   // Source ID from ai.repo.yaml (hash-based)
-	Ref            string                `json:"ref,omitempty"`         // Git ref (branch/tag/commit), defaults to "main" if empty
-	FirstInstalled time.Time             `json:"first_installed"`       // When resource was first added
-	LastUpdated    time.Time             `json:"last_updated"`          // When resource was last updated
+	Name           string                `json:"name"`                     // Resource name
+	Type           resource.ResourceType `json:"type"`                     // Resource type (command, skill, agent)
+	SourceType     string                `json:"source_type"`              // Source type: "github", "local", "file"
+	SourceURL      string                `json:"source_url"`               // Source URL or file path
+	SourceName     string                `json:"source_name,omitempty"`    // Source name from ai.repo.yaml or derived from URL/path
+	SourceID       string                `json:"source_id,omitempty"`      // Source ID from ai.repo.yaml (hash-based)
+	Ref            string                `json:"ref,omitempty"`            // Git ref (branch/tag/commit), defaults to "main" if empty
+	FirstInstalled time.Time             `json:"first_installed"`          // When resource was first added
+	LastUpdated    time.Time             `json:"last_updated"`             // When resource was last updated
+	ContentDigest  string                `json:"content_digest,omitempty"` // Digest of the imported content (copy mode only), used to detect local edits
+	Files          map[string]string     `json:"files,omitempty"`          // Per-file SHA-256 of the imported content, checked by verify --integrity
+	Review         *Review               `json:"review,omitempty"`         // Approval of the imported content, for resources imported through repo review
+}
+
+// Review records who approved a quarantined resource for import, and which
+// content they approved. A later import without review drops it.
+type Review struct {
+	Reviewer   string    `json:"reviewer" yaml:"reviewer"`
+	ApprovedAt time.Time `json:"approved_at" yaml:"approved_at"`
+	Digest     string    `json:"digest" yaml:"digest"` // Content digest of the approved version
+	Note       string    `json:"note,omitempty" yaml:"note,omitempty"`
 }
 
 // Save writes metadata to a JSON file in the .metadata/ directory.
@@ -269,4 +281,5 @@ type PackageMetadata struct {
 	LastUpdated    time.Time `json:"last_updated"`
 	ResourceCount  int       `json:"resource_count"`
 	OriginalFormat string    `json:"original_format,omitempty"`
+	Review         *Review   `json:"review,omitempty"` // Approval of the imported package, for packages imported through repo review
 }
@@ -11,6 +11,7 @@
 //	    skills/
 //	      my-skill/
 //	        SKILL.md
+//	        scripts/...   (copied with their file modes)
 //	    agents/
 //	      reviewer.md
 //	    commands/
@@ -23,9 +24,11 @@ package modifications
 
 import (
 	"fmt"
+	"io/fs"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/frontmatter"
@@ -73,7 +76,7 @@ func (g *Generator) GenerateForResource(res *resource.Resource) ([]string, error
 	var generatedTools []string
 
 	for _, toolName := range toolsWithMappings {
-		generated, err := g.generateForTool(res, toolName)
+		generated, err := g.generateForTool(res, toolName, g.ModificationsDir())
 		if err != nil {
 			return generatedTools, fmt.Errorf("generating modification for tool %s: %w", toolName, err)
 		}
@@ -85,9 +88,28 @@ func (g *Generator) GenerateForResource(res *resource.Resource) ([]string, error
 	return generatedTools, nil
 }
 
-// generateForTool creates a modification for a specific tool if needed.
-// Returns true if a modification was generated.
-func (g *Generator) generateForTool(res *resource.Resource, toolName string) (bool, error) {
+// Render writes the modification of a resource for a tool below dir, laid out
+// like the .modifications directory, and returns the path of the generated
+// file (or skill directory). It returns "" when the tool needs no variant.
+// Verification uses it to compare an installed variant with a fresh one.
+func (g *Generator) Render(res *resource.Resource, toolName, dir string) (string, error) {
+	if res == nil {
+		return "", fmt.Errorf("resource is nil")
+	}
+	generated, err := g.generateForTool(res, toolName, dir)
+	if err != nil || !generated {
+		return "", err
+	}
+	outputPath := g.getModificationFilePath(dir, res, toolName)
+	if res.Type == resource.Skill {
+		return filepath.Dir(outputPath), nil
+	}
+	return outputPath, nil
+}
+
+// generateForTool creates a modification for a specific tool below root if
+// needed. Returns true if a modification was generated.
+func (g *Generator) generateForTool(res *resource.Resource, toolName, root string) (bool, error) {
 	// Determine which file to process based on resource type
 	filePath := g.getSourceFilePath(res)
 	if filePath == "" {
@@ -99,6 +121,10 @@ func (g *Generator) generateForTool(res *resource.Resource, toolName string) (bo
 	if err != nil {
 		return false, fmt.Errorf("reading source file: %w", err)
 	}
+	sourceInfo, err := os.Stat(filePath)
+	if err != nil {
+		return false, fmt.Errorf("reading source file: %w", err)
+	}
 
 	// Parse frontmatter
 	fm, err := frontmatter.Parse(content)
@@ -111,53 +137,44 @@ func (g *Generator) generateForTool(res *resource.Resource, toolName string) (bo
 		return false, nil
 	}
 
-	// Get fields that have mappings for this resource type
-	fieldMappings := g.getFieldMappingsForType(res.Type)
-	if fieldMappings == nil {
-		return false, nil
-	}
-
-	// Apply mappings to frontmatter fields
-	modified := false
-	for fieldName := range fieldMappings {
-		currentValue := fm.GetString(fieldName)
-		mappedValue, found := g.mappings.GetMappingWithNull(res.Type, fieldName, currentValue, toolName)
-		if found {
-			fm.SetField(fieldName, mappedValue)
-			modified = true
-			if g.logger != nil {
-				g.logger.Debug("applied field mapping",
-					"resource", res.Name,
-					"tool", toolName,
-					"field", fieldName,
-					"from", currentValue,
-					"to", mappedValue,
-				)
-			}
-		}
-	}
-
 	// If no fields were modified, don't create a modification file
-	if !modified {
+	if len(g.ApplyMappings(res, fm, toolName)) == 0 {
 		return false, nil
 	}
 
 	// Create output path
-	outputPath := g.getModificationFilePath(res, toolName)
+	outputPath := g.getModificationFilePath(root, res, toolName)
 
 	// Ensure output directory exists
 	outputDir := filepath.Dir(outputPath)
+	if res.Type == resource.Skill {
+		// Start from a clean variant so files removed from the skill go too.
+		if err := os.RemoveAll(outputDir); err != nil {
+			return false, fmt.Errorf("removing stale modification directory: %w", err)
+		}
+	}
 	if err := os.MkdirAll(outputDir, 0755); err != nil {
 		return false, fmt.Errorf("creating output directory: %w", err)
 	}
 
 	// Write transformed file
 	renderedContent := fm.Render()
-	// outputPath is generated by getModificationFilePath from repo root + controlled segments.
-	// #nosec G703 -- write target is confined to the repository's .modifications tree
-	if err := os.WriteFile(outputPath, renderedContent, 0644); err != nil {
+	// outputPath is generated by getModificationFilePath from root + controlled segments.
+	// #nosec G703 -- write target is confined to the modifications tree below root
+	if err := os.WriteFile(outputPath, renderedContent, sourceInfo.Mode().Perm()); err != nil {
 		return false, fmt.Errorf("writing modification file: %w", err)
 	}
+	if err := os.Chmod(outputPath, sourceInfo.Mode().Perm()); err != nil {
+		return false, fmt.Errorf("writing modification file: %w", err)
+	}
+
+	// The variant directory replaces the skill when installed for this tool,
+	// so it needs the scripts, references and assets as well.
+	if res.Type == resource.Skill {
+		if err := copySkillFiles(res.Path, outputDir); err != nil {
+			return false, fmt.Errorf("copying skill files: %w", err)
+		}
+	}
 
 	if g.logger != nil {
 		g.logger.Info("generated modification",
@@ -170,6 +187,77 @@ func (g *Generator) generateForTool(res *resource.Resource, toolName string) (bo
 	return true, nil
 }
 
+// ApplyMappings applies the field mappings for a tool to a resource's parsed
+// frontmatter, as they appear in the generated modification, and returns the
+// names of the fields it changed.
+func (g *Generator) ApplyMappings(res *resource.Resource, fm *frontmatter.Frontmatter, toolName string) []string {
+	// Get fields that have mappings for this resource type
+	fieldMappings := g.getFieldMappingsForType(res.Type)
+	if fieldMappings == nil || fm == nil {
+		return nil
+	}
+
+	var changed []string
+	for fieldName := range fieldMappings {
+		currentValue := fm.GetString(fieldName)
+		mappedValue, found := g.mappings.GetMappingWithNull(res.Type, fieldName, currentValue, toolName)
+		if found {
+			fm.SetField(fieldName, mappedValue)
+			changed = append(changed, fieldName)
+			if g.logger != nil {
+				g.logger.Debug("applied field mapping",
+					"resource", res.Name,
+					"tool", toolName,
+					"field", fieldName,
+					"from", currentValue,
+					"to", mappedValue,
+				)
+			}
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// copySkillFiles copies every file of a skill except SKILL.md into dst,
+// keeping file modes so scripts stay executable. Skills from local sources
+// are symlinks, so the walk starts at the resolved directory.
+func copySkillFiles(src, dst string) error {
+	root, err := filepath.EvalSymlinks(src)
+	if err != nil {
+		return err
+	}
+	return filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil || rel == "." || rel == "SKILL.md" {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		info, err := os.Stat(p)
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		}
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		// #nosec G703 -- target is below the skill's .modifications directory
+		if err := os.WriteFile(target, data, info.Mode().Perm()); err != nil {
+			return err
+		}
+		return os.Chmod(target, info.Mode().Perm())
+	})
+}
+
 // getSourceFilePath returns the path to the file that should be transformed.
 func (g *Generator) getSourceFilePath(res *resource.Resource) string {
 	switch res.Type {
@@ -198,8 +286,9 @@ func (g *Generator) getFieldMappingsForType(resType resource.ResourceType) confi
 	}
 }
 
-// getModificationFilePath returns the path where the modification file should be written.
-func (g *Generator) getModificationFilePath(res *resource.Resource, toolName string) string {
+// getModificationFilePath returns the path below root (normally
+// ModificationsDir) where the modification file should be written.
+func (g *Generator) getModificationFilePath(root string, res *resource.Resource, toolName string) string {
 	typePlural := g.getTypePluralDir(res.Type)
 	if typePlural == "" {
 		return ""
@@ -208,14 +297,14 @@ func (g *Generator) getModificationFilePath(res *resource.Resource, toolName str
 	switch res.Type {
 	case resource.Skill:
 		// Skills: .modifications/<tool>/skills/<name>/SKILL.md
-		return filepath.Join(g.ModificationsDir(), toolName, typePlural, res.Name, "SKILL.md")
+		return filepath.Join(root, toolName, typePlural, res.Name, "SKILL.md")
 	case resource.Agent, resource.Command:
 		// Agents/Commands: .modifications/<tool>/<type>s/<name>.md
 		fileName := res.Name + ".md"
 		if res.Type == resource.Agent {
 			fileName = tools.AgentArtifactNameForToolName(toolName, res.Name)
 		}
-		return filepath.Join(g.ModificationsDir(), toolName, typePlural, fileName)
+		return filepath.Join(root, toolName, typePlural, fileName)
 	default:
 		return ""
 	}
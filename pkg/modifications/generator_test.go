@@ -110,6 +110,56 @@ This is the skill content.
 	}
 }
 
+func TestGenerateForResource_SkillCopiesScriptsWithModes(t *testing.T) {
+	tmpDir := t.TempDir()
+	repoPath := filepath.Join(tmpDir, "repo")
+	skillDir := filepath.Join(repoPath, "skills", "my-skill")
+	if err := os.MkdirAll(filepath.Join(skillDir, "scripts"), 0755); err != nil {
+		t.Fatalf("failed to create skill directory: %v", err)
+	}
+	skillContent := "---\nname: my-skill\ndescription: A test skill\nmodel: sonnet-4.5\n---\nRun scripts/run.sh\n"
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(skillContent), 0644); err != nil {
+		t.Fatalf("failed to write SKILL.md: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "scripts", "run.sh"), []byte("#!/bin/sh\necho hi\n"), 0755); err != nil {
+		t.Fatalf("failed to write script: %v", err)
+	}
+
+	mappings := config.TypeMappings{
+		Skill: config.FieldMappings{
+			"model": {"sonnet-4.5": {"opencode": "langdock/claude-sonnet-4-5"}},
+		},
+	}
+	gen := NewGenerator(repoPath, mappings, nil)
+	res, err := resource.LoadSkill(skillDir)
+	if err != nil {
+		t.Fatalf("failed to load skill: %v", err)
+	}
+	if _, err := gen.GenerateForResource(res); err != nil {
+		t.Fatalf("GenerateForResource() error = %v", err)
+	}
+
+	variant := filepath.Join(repoPath, ".modifications", "opencode", "skills", "my-skill")
+	info, err := os.Stat(filepath.Join(variant, "scripts", "run.sh"))
+	if err != nil {
+		t.Fatalf("script not copied into variant: %v", err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("script mode = %v, want 0755", info.Mode().Perm())
+	}
+
+	// A script removed from the skill disappears from the regenerated variant.
+	if err := os.Remove(filepath.Join(skillDir, "scripts", "run.sh")); err != nil {
+		t.Fatalf("failed to remove script: %v", err)
+	}
+	if _, err := gen.GenerateForResource(res); err != nil {
+		t.Fatalf("GenerateForResource() error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(variant, "scripts", "run.sh")); !os.IsNotExist(err) {
+		t.Errorf("stale script kept in variant: %v", err)
+	}
+}
+
 func TestGenerateForResource_SkillNoClaudeMapping(t *testing.T) {
 	// Create temp directory
 	tmpDir := t.TempDir()
@@ -938,6 +988,44 @@ Deploy the application to production.
 	}
 }
 
+func TestRender_WritesBelowDir(t *testing.T) {
+	repoPath := filepath.Join(t.TempDir(), "repo")
+	commandsDir := filepath.Join(repoPath, "commands")
+	if err := os.MkdirAll(commandsDir, 0755); err != nil {
+		t.Fatalf("failed to create commands directory: %v", err)
+	}
+	commandPath := filepath.Join(commandsDir, "deploy.md")
+	if err := os.WriteFile(commandPath, []byte("---\ndescription: Deploy\nmodel: opus-4\n---\n# Deploy\n"), 0644); err != nil {
+		t.Fatalf("failed to write command file: %v", err)
+	}
+	gen := NewGenerator(repoPath, config.TypeMappings{
+		Command: config.FieldMappings{"model": {"opus-4": {"opencode": "langdock/claude-opus-4"}}},
+	}, nil)
+	res, err := resource.LoadCommand(commandPath)
+	if err != nil {
+		t.Fatalf("failed to load command: %v", err)
+	}
+
+	dir := t.TempDir()
+	got, err := gen.Render(res, "opencode", dir)
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if want := filepath.Join(dir, "opencode", "commands", "deploy.md"); got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+	if content, err := os.ReadFile(got); err != nil || !strings.Contains(string(content), "langdock/claude-opus-4") {
+		t.Errorf("rendered variant = %q (%v), want the mapped model", content, err)
+	}
+	if _, err := os.Stat(gen.ModificationsDir()); !os.IsNotExist(err) {
+		t.Errorf("Render() must not write to the repository, stat error = %v", err)
+	}
+
+	if got, err := gen.Render(res, "claude", dir); err != nil || got != "" {
+		t.Errorf("Render() for a tool without mappings = %q, %v; want no variant", got, err)
+	}
+}
+
 func TestGenerateForResource_MultipleTools(t *testing.T) {
 	// Create temp directory
 	tmpDir := t.TempDir()
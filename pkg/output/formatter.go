@@ -44,6 +44,48 @@ type BulkOperationResult struct {
 	SkillCount   int              `json:"skill_count" yaml:"skill_count"`
 	AgentCount   int              `json:"agent_count" yaml:"agent_count"`
 	PackageCount int              `json:"package_count" yaml:"package_count"`
+	Warnings     []string         `json:"warnings,omitempty" yaml:"warnings,omitempty"`
+	// LocalChanges lists resources edited inside the repository since import
+	LocalChanges []LocalChangeResult `json:"local_changes,omitempty" yaml:"local_changes,omitempty"`
+	// Quarantined lists new or changed resources held for 'repo review'
+	Quarantined []QuarantinedResult `json:"quarantined,omitempty" yaml:"quarantined,omitempty"`
+}
+
+// QuarantinedResult describes a resource held in quarantine for review
+type QuarantinedResult struct {
+	Name   string `json:"name" yaml:"name"`
+	Type   string `json:"type" yaml:"type"`
+	Change string `json:"change" yaml:"change"`
+}
+
+// LocalChangeResult describes how a re-import handled a locally edited resource
+type LocalChangeResult struct {
+	Name      string   `json:"name" yaml:"name"`
+	Type      string   `json:"type" yaml:"type"`
+	Action    string   `json:"action" yaml:"action"`
+	Conflicts []string `json:"conflicts,omitempty" yaml:"conflicts,omitempty"`
+	Message   string   `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// Describe returns a human-readable summary of the action.
+func (c LocalChangeResult) Describe() string {
+	var text string
+	switch c.Action {
+	case repo.LocalChangeKept:
+		text = "Local edits kept; upstream changes not applied"
+	case repo.LocalChangeReplaced:
+		text = "Local edits replaced by upstream"
+	case repo.LocalChangeMerged:
+		text = "Local edits merged with upstream"
+	case repo.LocalChangeConflicts:
+		text = "Merged with conflicts in " + strings.Join(c.Conflicts, ", ")
+	default:
+		text = c.Action
+	}
+	if c.Message != "" {
+		text += " (" + c.Message + ")"
+	}
+	return text
 }
 
 // ResourceResult represents the result of a single resource operation
@@ -95,6 +137,29 @@ func FromBulkImportResult(result *repo.BulkImportResult) *BulkOperationResult {
 		})
 	}
 
+	for _, change := range result.LocalChanges {
+		bor.LocalChanges = append(bor.LocalChanges, LocalChangeResult{
+			Name:      change.Name,
+			Type:      string(change.Type),
+			Action:    change.Action,
+			Conflicts: change.Conflicts,
+			Message:   change.Message,
+		})
+	}
+
+	for _, entry := range result.Quarantined {
+		bor.Quarantined = append(bor.Quarantined, QuarantinedResult{
+			Name:   entry.Name,
+			Type:   string(entry.Type),
+			Change: entry.Change,
+		})
+	}
+
+	for _, warning := range result.Warnings {
+		bor.Warnings = append(bor.Warnings, fmt.Sprintf("%s/%s: %s",
+			extractResourceType(warning.Path), extractResourceName(warning.Path), warning.Message))
+	}
+
 	// Convert failed resources
 	for _, fail := range result.Failed {
 		bor.Failed = append(bor.Failed, ResourceResult{
@@ -235,6 +300,22 @@ func formatAsTable(result *BulkOperationResult) error {
 		hasContent = true
 	}
 
+	// Add locally modified resources
+	for _, change := range result.LocalChanges {
+		if err := table.Append(fmt.Sprintf("%s/%s", change.Type, change.Name), "MODIFIED", change.Describe()); err != nil {
+			return fmt.Errorf("failed to append row: %w", err)
+		}
+		hasContent = true
+	}
+
+	// Add quarantined resources
+	for _, q := range result.Quarantined {
+		if err := table.Append(fmt.Sprintf("%s/%s", q.Type, q.Name), "QUARANTINED", fmt.Sprintf("Awaiting review (%s)", q.Change)); err != nil {
+			return fmt.Errorf("failed to append row: %w", err)
+		}
+		hasContent = true
+	}
+
 	// Add failed resources
 	for _, res := range result.Failed {
 		status := "FAILED"
@@ -261,10 +342,15 @@ func formatAsTable(result *BulkOperationResult) error {
 	totalUpdated := len(result.Updated)
 	totalSkipped := len(result.Skipped)
 	totalFailed := len(result.Failed)
-	totalResources := totalAdded + totalUpdated + totalSkipped + totalFailed
+	totalQuarantined := len(result.Quarantined)
+	totalResources := totalAdded + totalUpdated + totalSkipped + totalFailed + totalQuarantined
 
 	if totalResources == 0 {
 		fmt.Println("No resources to process")
+	} else if totalQuarantined > 0 {
+		fmt.Printf("Summary: %d quarantined, %d added, %d updated, %d failed, %d skipped (%d total)\n",
+			totalQuarantined, totalAdded, totalUpdated, totalFailed, totalSkipped, totalResources)
+		fmt.Println("Run 'aimgr repo review' to approve or reject quarantined resources.")
 	} else {
 		fmt.Printf("Summary: %d added, %d updated, %d failed, %d skipped (%d total)\n",
 			totalAdded, totalUpdated, totalFailed, totalSkipped, totalResources)
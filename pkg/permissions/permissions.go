@@ -0,0 +1,397 @@
+// Package permissions parses the tool permissions resources grant: the
+// allowed-tools of commands and skills and the tools of agents.
+//
+// Entries use the Claude Code permission syntax:
+//
+//	Read                     the Read tool, unrestricted
+//	Bash(git diff:*)         shell commands starting with "git diff"
+//	Edit(src/**)             edits to paths matching src/**
+//	WebFetch(domain:go.dev)  fetches from go.dev
+//	mcp__github              every tool of the "github" MCP server
+//	mcp__github__get_issue   one MCP tool
+package permissions
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Capability groups tools by what they let a model do.
+type Capability string
+
+// Capabilities, from the most to the least sensitive.
+const (
+	CapabilityAll       Capability = "all"
+	CapabilityShell     Capability = "shell"
+	CapabilityFileWrite Capability = "file-write"
+	CapabilityNetwork   Capability = "network"
+	CapabilityMCP       Capability = "mcp"
+	CapabilitySubagents Capability = "subagents"
+	CapabilityFileRead  Capability = "file-read"
+	CapabilityOther     Capability = "other"
+)
+
+var capabilityOrder = map[Capability]int{
+	CapabilityAll:       0,
+	CapabilityShell:     1,
+	CapabilityFileWrite: 2,
+	CapabilityNetwork:   3,
+	CapabilityMCP:       4,
+	CapabilitySubagents: 5,
+	CapabilityFileRead:  6,
+	CapabilityOther:     7,
+}
+
+// knownTools maps the lower-cased names of built-in tools, in Claude Code and
+// OpenCode spelling, to their capability.
+var knownTools = map[string]Capability{
+	"bash":         CapabilityShell,
+	"bashoutput":   CapabilityShell,
+	"killshell":    CapabilityShell,
+	"write":        CapabilityFileWrite,
+	"edit":         CapabilityFileWrite,
+	"multiedit":    CapabilityFileWrite,
+	"notebookedit": CapabilityFileWrite,
+	"patch":        CapabilityFileWrite,
+	"webfetch":     CapabilityNetwork,
+	"websearch":    CapabilityNetwork,
+	"task":         CapabilitySubagents,
+	"read":         CapabilityFileRead,
+	"glob":         CapabilityFileRead,
+	"grep":         CapabilityFileRead,
+	"ls":           CapabilityFileRead,
+	"list":         CapabilityFileRead,
+	"notebookread": CapabilityFileRead,
+	"todowrite":    CapabilityOther,
+	"todoread":     CapabilityOther,
+	"exitplanmode": CapabilityOther,
+	"slashcommand": CapabilityOther,
+	"skill":        CapabilityOther,
+}
+
+var (
+	toolNamePattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+	mcpPartPattern  = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+)
+
+// Spec is one parsed permission entry.
+type Spec struct {
+	Raw        string     `json:"raw" yaml:"raw"`
+	Tool       string     `json:"tool" yaml:"tool"`
+	Specifier  string     `json:"specifier,omitempty" yaml:"specifier,omitempty"` // argument inside the parentheses
+	MCPServer  string     `json:"mcp_server,omitempty" yaml:"mcp_server,omitempty"`
+	MCPTool    string     `json:"mcp_tool,omitempty" yaml:"mcp_tool,omitempty"` // empty for every tool of the server
+	Capability Capability `json:"capability" yaml:"capability"`
+	Known      bool       `json:"known" yaml:"known"` // a built-in tool or an MCP tool
+	// Unrestricted is true when the entry allows every use of the tool, e.g.
+	// "Bash", "Bash(*)" or "mcp__github".
+	Unrestricted bool `json:"unrestricted" yaml:"unrestricted"`
+}
+
+// String returns the canonical form of the entry.
+func (s Spec) String() string {
+	if s.Specifier == "" {
+		return s.Tool
+	}
+	return fmt.Sprintf("%s(%s)", s.Tool, s.Specifier)
+}
+
+// Scope describes in words what the entry allows.
+func (s Spec) Scope() string {
+	switch {
+	case s.Capability == CapabilityAll:
+		return "every tool"
+	case s.MCPServer != "" && s.MCPTool == "":
+		return fmt.Sprintf("every tool of MCP server %s", s.MCPServer)
+	case s.MCPServer != "":
+		return fmt.Sprintf("tool %s of MCP server %s", s.MCPTool, s.MCPServer)
+	case s.Unrestricted:
+		return "any use"
+	case strings.EqualFold(s.Tool, "Bash"):
+		if prefix, ok := strings.CutSuffix(s.Specifier, ":*"); ok {
+			return fmt.Sprintf("commands starting with %q", prefix)
+		}
+		if strings.Contains(s.Specifier, "*") {
+			return fmt.Sprintf("commands matching %q", s.Specifier)
+		}
+		return fmt.Sprintf("command %q", s.Specifier)
+	case strings.EqualFold(s.Tool, "WebFetch"):
+		return "domain " + strings.TrimPrefix(s.Specifier, "domain:")
+	case s.Capability == CapabilityFileRead || s.Capability == CapabilityFileWrite:
+		return "paths matching " + s.Specifier
+	default:
+		return s.Specifier
+	}
+}
+
+// AllTools is the grant of an agent without a tools field, which inherits
+// every tool available to the session.
+func AllTools() Spec {
+	return Spec{Tool: "*", Capability: CapabilityAll, Known: true, Unrestricted: true}
+}
+
+// Parse parses and validates one permission entry.
+func Parse(raw string) (Spec, error) {
+	entry := strings.TrimSpace(raw)
+	if entry == "" {
+		return Spec{}, fmt.Errorf("empty entry")
+	}
+	spec := Spec{Raw: raw, Tool: entry}
+
+	if open := strings.Index(entry, "("); open >= 0 {
+		if !strings.HasSuffix(entry, ")") {
+			return Spec{}, fmt.Errorf("%q: missing closing parenthesis or text after it", entry)
+		}
+		spec.Tool = strings.TrimSpace(entry[:open])
+		spec.Specifier = strings.TrimSpace(entry[open+1 : len(entry)-1])
+		if depth := parenDepth(spec.Specifier); depth != 0 {
+			return Spec{}, fmt.Errorf("%q: unbalanced parentheses", entry)
+		}
+		if spec.Specifier == "" {
+			return Spec{}, fmt.Errorf("%q: empty argument; write %s to allow every use", entry, spec.Tool)
+		}
+	} else if strings.Contains(entry, ")") {
+		return Spec{}, fmt.Errorf("%q: unbalanced parentheses", entry)
+	}
+
+	if strings.HasPrefix(spec.Tool, "mcp__") {
+		return parseMCP(spec, entry)
+	}
+	if !toolNamePattern.MatchString(spec.Tool) {
+		return Spec{}, fmt.Errorf("%q: invalid tool name %q", entry, spec.Tool)
+	}
+
+	capability, known := knownTools[strings.ToLower(spec.Tool)]
+	if !known {
+		capability = CapabilityOther
+	}
+	spec.Capability = capability
+	spec.Known = known
+	spec.Unrestricted = strings.Trim(spec.Specifier, "*: ") == ""
+
+	switch strings.ToLower(spec.Tool) {
+	case "bash":
+		// The legacy prefix syntax only allows ":*" at the end.
+		if i := strings.Index(spec.Specifier, ":*"); i >= 0 && i != len(spec.Specifier)-2 {
+			return Spec{}, fmt.Errorf("%q: \":*\" is only allowed at the end of a command prefix", entry)
+		}
+	case "webfetch":
+		if !spec.Unrestricted {
+			domain, ok := strings.CutPrefix(spec.Specifier, "domain:")
+			if !ok || strings.TrimSpace(domain) == "" {
+				return Spec{}, fmt.Errorf("%q: WebFetch takes domain:<host>", entry)
+			}
+		}
+	}
+	if spec.Unrestricted {
+		spec.Specifier = ""
+	}
+	return spec, nil
+}
+
+func parseMCP(spec Spec, entry string) (Spec, error) {
+	if spec.Specifier != "" {
+		return Spec{}, fmt.Errorf("%q: MCP tools take no argument", entry)
+	}
+	parts := strings.SplitN(strings.TrimPrefix(spec.Tool, "mcp__"), "__", 2)
+	if !mcpPartPattern.MatchString(parts[0]) {
+		return Spec{}, fmt.Errorf("%q: invalid MCP server name", entry)
+	}
+	spec.MCPServer = parts[0]
+	if len(parts) == 2 && parts[1] != "*" {
+		if !mcpPartPattern.MatchString(parts[1]) {
+			return Spec{}, fmt.Errorf("%q: invalid MCP tool name", entry)
+		}
+		spec.MCPTool = parts[1]
+	}
+	spec.Capability = CapabilityMCP
+	spec.Known = true
+	spec.Unrestricted = spec.MCPTool == ""
+	return spec, nil
+}
+
+func parenDepth(s string) int {
+	depth := 0
+	for _, r := range s {
+		switch r {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return depth
+			}
+		}
+	}
+	return depth
+}
+
+// Issue is an entry that failed to parse.
+type Issue struct {
+	Field   string `json:"field" yaml:"field"`
+	Entry   string `json:"entry" yaml:"entry"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// ParseField splits and parses the value of an allowed-tools or tools
+// field, collecting the entries that fail to parse.
+func ParseField(field string, value interface{}) ([]Spec, []Issue) {
+	var specs []Spec
+	var issues []Issue
+	for _, entry := range Split(value) {
+		spec, err := Parse(entry)
+		if err != nil {
+			issues = append(issues, Issue{Field: field, Entry: entry, Message: err.Error()})
+			continue
+		}
+		specs = append(specs, spec)
+	}
+	return specs, issues
+}
+
+// Split returns the entries of an allowed-tools or tools value: a YAML list,
+// a comma- or space-separated string such as "Read, Grep, Bash(git diff:*)",
+// or an OpenCode map of tool names to booleans, of which the enabled tools
+// are returned.
+func Split(value interface{}) []string {
+	var raw []string
+	switch v := value.(type) {
+	case string:
+		raw = splitList(v)
+	case []interface{}:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				raw = append(raw, splitList(s)...)
+			}
+		}
+	case []string:
+		for _, s := range v {
+			raw = append(raw, splitList(s)...)
+		}
+	case map[string]interface{}:
+		for name, enabled := range v {
+			if b, ok := enabled.(bool); ok && b {
+				raw = append(raw, name)
+			}
+		}
+		sort.Strings(raw)
+	}
+	return raw
+}
+
+// splitList splits on commas and, outside parentheses, on whitespace when
+// the list has no commas.
+func splitList(s string) []string {
+	sep := func(r rune) bool { return r == ',' }
+	if !strings.Contains(s, ",") {
+		sep = func(r rune) bool { return r == ' ' || r == '\t' }
+	}
+
+	var out []string
+	var current strings.Builder
+	depth := 0
+	flush := func() {
+		if entry := strings.TrimSpace(current.String()); entry != "" {
+			out = append(out, entry)
+		}
+		current.Reset()
+	}
+	for _, r := range s {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0 && sep(r):
+			flush()
+			continue
+		}
+		current.WriteRune(r)
+	}
+	flush()
+	return out
+}
+
+// Normalize reduces wildcard-only arguments to the bare tool name, so
+// "Bash(*)" and "Bash(:*)" compare equal to unrestricted "Bash".
+func Normalize(entry string) string {
+	open := strings.Index(entry, "(")
+	if open < 0 || !strings.HasSuffix(entry, ")") {
+		return entry
+	}
+	arg := strings.TrimSpace(entry[open+1 : len(entry)-1])
+	if strings.Trim(arg, "*: ") == "" {
+		return strings.TrimSpace(entry[:open])
+	}
+	return entry
+}
+
+// Grant is a permission entry and the resource field it came from.
+type Grant struct {
+	Spec     `yaml:",inline"`
+	Resource string `json:"resource" yaml:"resource"` // type/name
+	Field    string `json:"field" yaml:"field"`       // allowed-tools or tools
+}
+
+// ToolSummary is the aggregate of the grants for one tool.
+type ToolSummary struct {
+	Capability   Capability `json:"capability" yaml:"capability"`
+	Tool         string     `json:"tool" yaml:"tool"`
+	Unrestricted bool       `json:"unrestricted" yaml:"unrestricted"`
+	// Scopes lists the restricted forms granted; it is empty when any grant
+	// is unrestricted, which subsumes them.
+	Scopes    []string `json:"scopes,omitempty" yaml:"scopes,omitempty"`
+	GrantedBy []string `json:"granted_by" yaml:"granted_by"`
+}
+
+// Summarize aggregates grants per tool, most sensitive capabilities first.
+// Tool names are compared case-insensitively, so OpenCode's "bash" and
+// Claude's "Bash" are one tool.
+func Summarize(grants []Grant) []ToolSummary {
+	byTool := make(map[string]*ToolSummary)
+	scopes := make(map[string]map[string]bool)
+	grantedBy := make(map[string]map[string]bool)
+	for _, g := range grants {
+		key := strings.ToLower(g.Tool)
+		summary, ok := byTool[key]
+		if !ok {
+			summary = &ToolSummary{Capability: g.Capability, Tool: g.Tool}
+			byTool[key] = summary
+			scopes[key] = make(map[string]bool)
+			grantedBy[key] = make(map[string]bool)
+		}
+		if g.Unrestricted {
+			summary.Unrestricted = true
+		} else {
+			scopes[key][g.Scope()] = true
+		}
+		grantedBy[key][g.Resource] = true
+	}
+
+	out := make([]ToolSummary, 0, len(byTool))
+	for key, summary := range byTool {
+		if !summary.Unrestricted {
+			summary.Scopes = sortedKeys(scopes[key])
+		}
+		summary.GrantedBy = sortedKeys(grantedBy[key])
+		out = append(out, *summary)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Capability != out[j].Capability {
+			return capabilityOrder[out[i].Capability] < capabilityOrder[out[j].Capability]
+		}
+		return strings.ToLower(out[i].Tool) < strings.ToLower(out[j].Tool)
+	})
+	return out
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
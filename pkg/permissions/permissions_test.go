@@ -0,0 +1,152 @@
+package permissions
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		raw          string
+		tool         string
+		specifier    string
+		capability   Capability
+		known        bool
+		unrestricted bool
+		scope        string
+	}{
+		{"Read", "Read", "", CapabilityFileRead, true, true, "any use"},
+		{" Bash(git diff:*) ", "Bash", "git diff:*", CapabilityShell, true, false, `commands starting with "git diff"`},
+		{"Bash(npm run test)", "Bash", "npm run test", CapabilityShell, true, false, `command "npm run test"`},
+		{"Bash(*)", "Bash", "", CapabilityShell, true, true, "any use"},
+		{"Bash(:*)", "Bash", "", CapabilityShell, true, true, "any use"},
+		{"Edit(src/**)", "Edit", "src/**", CapabilityFileWrite, true, false, "paths matching src/**"},
+		{"WebFetch(domain:go.dev)", "WebFetch", "domain:go.dev", CapabilityNetwork, true, false, "domain go.dev"},
+		{"write", "write", "", CapabilityFileWrite, true, true, "any use"},
+		{"CustomTool", "CustomTool", "", CapabilityOther, false, true, "any use"},
+		{"mcp__github", "mcp__github", "", CapabilityMCP, true, true, "every tool of MCP server github"},
+		{"mcp__github__get_issue", "mcp__github__get_issue", "", CapabilityMCP, true, false, "tool get_issue of MCP server github"},
+	}
+	for _, tt := range tests {
+		spec, err := Parse(tt.raw)
+		if err != nil {
+			t.Errorf("Parse(%q) error = %v", tt.raw, err)
+			continue
+		}
+		if spec.Tool != tt.tool || spec.Specifier != tt.specifier || spec.Capability != tt.capability ||
+			spec.Known != tt.known || spec.Unrestricted != tt.unrestricted {
+			t.Errorf("Parse(%q) = %+v", tt.raw, spec)
+		}
+		if spec.Scope() != tt.scope {
+			t.Errorf("Parse(%q).Scope() = %q, want %q", tt.raw, spec.Scope(), tt.scope)
+		}
+	}
+
+	spec, _ := Parse("mcp__github__get_issue")
+	if spec.MCPServer != "github" || spec.MCPTool != "get_issue" {
+		t.Errorf("MCP parts = %q, %q", spec.MCPServer, spec.MCPTool)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"":                     "empty entry",
+		"Bash(git diff:*":      "missing closing parenthesis",
+		"Bash(git) --force":    "missing closing parenthesis",
+		"Bash()":               "empty argument",
+		"Read)":                "unbalanced",
+		"Bash(a:*b)":           `":*" is only allowed at the end`,
+		"WebFetch(go.dev)":     "WebFetch takes domain:<host>",
+		"mcp__":                "invalid MCP server name",
+		"mcp__github__bad.one": "invalid MCP tool name",
+		"mcp__github(x)":       "MCP tools take no argument",
+		"1Password":            "invalid tool name",
+	}
+	for raw, want := range tests {
+		_, err := Parse(raw)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q) error = %v, want %q", raw, err, want)
+		}
+	}
+}
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		want  []string
+	}{
+		{"Read, Grep, Bash(git diff:*)", []string{"Read", "Grep", "Bash(git diff:*)"}},
+		{"Read Grep Bash(git diff:*)", []string{"Read", "Grep", "Bash(git diff:*)"}},
+		{[]interface{}{"Read", "Bash(git add:*), Bash(git commit:*)"}, []string{"Read", "Bash(git add:*)", "Bash(git commit:*)"}},
+		{map[string]interface{}{"write": false, "bash": true, "read": true}, []string{"bash", "read"}},
+		{nil, nil},
+	}
+	for _, tt := range tests {
+		if got := Split(tt.value); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Split(%v) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestParseField(t *testing.T) {
+	specs, issues := ParseField("allowed-tools", "Read, Bash(), mcp__db__query")
+	if len(specs) != 2 || specs[0].Tool != "Read" || specs[1].MCPServer != "db" {
+		t.Errorf("specs = %+v", specs)
+	}
+	if len(issues) != 1 || issues[0].Entry != "Bash()" || issues[0].Field != "allowed-tools" {
+		t.Errorf("issues = %+v", issues)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	grant := func(resource, raw string) Grant {
+		spec, err := Parse(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return Grant{Spec: spec, Resource: resource, Field: "allowed-tools"}
+	}
+	summary := Summarize([]Grant{
+		grant("command/review", "Read"),
+		grant("command/review", "Bash(git diff:*)"),
+		grant("command/commit", "Bash(git commit:*)"),
+		grant("agent/writer", "Edit(docs/**)"),
+		grant("agent/builder", "bash"),
+		grant("command/search", "Read"),
+		{Spec: AllTools(), Resource: "agent/general", Field: "tools"},
+	})
+
+	var tools []string
+	for _, s := range summary {
+		tools = append(tools, s.Tool)
+	}
+	if want := []string{"*", "Bash", "Edit", "Read"}; !reflect.DeepEqual(tools, want) {
+		t.Fatalf("tools = %v, want %v", tools, want)
+	}
+	bash := summary[1]
+	if !bash.Unrestricted || len(bash.Scopes) != 0 {
+		t.Errorf("Bash summary = %+v, want unrestricted without scopes", bash)
+	}
+	if want := []string{"agent/builder", "command/commit", "command/review"}; !reflect.DeepEqual(bash.GrantedBy, want) {
+		t.Errorf("Bash granted by = %v, want %v", bash.GrantedBy, want)
+	}
+	edit := summary[2]
+	if edit.Unrestricted || !reflect.DeepEqual(edit.Scopes, []string{"paths matching docs/**"}) {
+		t.Errorf("Edit summary = %+v", edit)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"Bash(*)":          "Bash",
+		"Bash(:*)":         "Bash",
+		"Bash(git diff:*)": "Bash(git diff:*)",
+		"Read":             "Read",
+	}
+	for entry, want := range tests {
+		if got := Normalize(entry); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", entry, got, want)
+		}
+	}
+}
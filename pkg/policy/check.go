@@ -0,0 +1,183 @@
+package policy
+
+import (
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/giturl"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/permissions"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+)
+
+// Rules reported in violations.
+const (
+	RuleSourceNotAllowed = "source-not-allowed"
+	RuleForbiddenTool    = "forbidden-tool"
+	RuleForbiddenModel   = "forbidden-model"
+	RuleLicenseRequired  = "license-required"
+	RuleBlocklisted      = "blocklisted"
+)
+
+// Violation is a source or resource that breaks the policy.
+type Violation struct {
+	Rule     string `json:"rule" yaml:"rule"`
+	Resource string `json:"resource,omitempty" yaml:"resource,omitempty"` // type/name
+	Source   string `json:"source,omitempty" yaml:"source,omitempty"`     // source identity
+	Message  string `json:"message" yaml:"message"`
+}
+
+// String formats the violation as "subject: message (rule)".
+func (v Violation) String() string {
+	subject := v.Resource
+	if subject == "" {
+		subject = v.Source
+	}
+	return fmt.Sprintf("%s: %s (%s)", subject, v.Message, v.Rule)
+}
+
+// Summarize joins violations into one line for import and install errors.
+func Summarize(violations []Violation) string {
+	parts := make([]string, 0, len(violations))
+	for _, v := range violations {
+		parts = append(parts, fmt.Sprintf("%s (%s)", v.Message, v.Rule))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// SourceIdentity returns the form of a source location matched against
+// allowedSources: "host/path" for remote URLs (https, ssh and scp-style) and
+// "local:<absolute path>" for local paths and file:// URLs.
+func SourceIdentity(location string) string {
+	location = strings.TrimSpace(location)
+	switch {
+	case strings.HasPrefix(location, "file://"):
+		return "local:" + filepath.ToSlash(filepath.Clean(strings.TrimPrefix(location, "file://")))
+	case strings.HasPrefix(location, "local:"):
+		location = strings.TrimPrefix(location, "local:")
+		if abs, err := filepath.Abs(location); err == nil {
+			location = abs
+		}
+		return "local:" + filepath.ToSlash(location)
+	case strings.HasPrefix(location, "gh:"):
+		location = "github.com/" + strings.TrimPrefix(location, "gh:")
+	case strings.Contains(location, "://"):
+		if u, err := url.Parse(location); err == nil {
+			location = u.Host + u.Path
+		}
+	case strings.HasPrefix(location, "git@") || (strings.Contains(location, "@") && strings.Contains(location, ":")):
+		// scp-style: git@host:owner/repo.git
+		location = location[strings.Index(location, "@")+1:]
+		location = strings.Replace(location, ":", "/", 1)
+	default:
+		if filepath.IsAbs(location) {
+			return "local:" + filepath.ToSlash(filepath.Clean(location))
+		}
+	}
+	return giturl.NormalizeURL(location)
+}
+
+// CheckSource reports whether resources may come from a source location
+// (URL, local path or file:// URL). It returns nil when the source is allowed.
+func (p *Policy) CheckSource(location string) *Violation {
+	if p == nil || len(p.sources) == 0 || location == "" {
+		return nil
+	}
+	id := SourceIdentity(location)
+	if matchAny(p.sources, strings.ToLower(id)) {
+		return nil
+	}
+	return &Violation{
+		Rule:    RuleSourceNotAllowed,
+		Source:  id,
+		Message: fmt.Sprintf("source %s is not in allowedSources (%s)", id, strings.Join(p.AllowedSources, ", ")),
+	}
+}
+
+// CheckRef reports whether a resource reference is blocklisted. It is used
+// for packages, which have no frontmatter to check.
+func (p *Policy) CheckRef(resType resource.ResourceType, name string) *Violation {
+	if p == nil {
+		return nil
+	}
+	res := &resource.Resource{Type: resType, Name: name}
+	for i, m := range p.blocklist {
+		if m.Match(res) {
+			return &Violation{
+				Rule:     RuleBlocklisted,
+				Resource: fmt.Sprintf("%s/%s", resType, name),
+				Message:  fmt.Sprintf("%s/%s matches blocklist entry %q", resType, name, p.Blocklist[i]),
+			}
+		}
+	}
+	return nil
+}
+
+// CheckResource evaluates the blocklist, tool, model and license rules for a
+// command, skill or agent, reading its frontmatter from res.Path.
+func (p *Policy) CheckResource(res *resource.Resource) ([]Violation, error) {
+	if p == nil {
+		return nil, nil
+	}
+	ref := fmt.Sprintf("%s/%s", res.Type, res.Name)
+
+	var violations []Violation
+	if v := p.CheckRef(res.Type, res.Name); v != nil {
+		violations = append(violations, *v)
+	}
+	if len(p.tools) == 0 && len(p.models) == 0 && len(p.licenses) == 0 {
+		return violations, nil
+	}
+
+	frontmatterPath := res.Path
+	if res.Type == resource.Skill {
+		frontmatterPath = filepath.Join(res.Path, "SKILL.md")
+	}
+	frontmatter, _, err := resource.ParseFrontmatter(frontmatterPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ref, err)
+	}
+
+	if len(p.tools) > 0 {
+		for _, key := range []string{"allowed-tools", "tools"} {
+			for _, tool := range permissions.Split(frontmatter[key]) {
+				if matchAny(p.tools, permissions.Normalize(tool)) || matchAny(p.tools, tool) {
+					violations = append(violations, Violation{
+						Rule:     RuleForbiddenTool,
+						Resource: ref,
+						Message:  fmt.Sprintf("%s entry %q is forbidden", key, tool),
+					})
+				}
+			}
+		}
+	}
+
+	if model := frontmatter.GetString("model"); model != "" && matchAny(p.models, strings.ToLower(model)) {
+		violations = append(violations, Violation{
+			Rule:     RuleForbiddenModel,
+			Resource: ref,
+			Message:  fmt.Sprintf("model %q is forbidden", model),
+		})
+	}
+
+	if len(p.licenses) > 0 {
+		license := strings.TrimSpace(res.License)
+		switch {
+		case license == "":
+			violations = append(violations, Violation{
+				Rule:     RuleLicenseRequired,
+				Resource: ref,
+				Message:  fmt.Sprintf("no license declared; one of %s is required", strings.Join(p.RequiredLicenses, ", ")),
+			})
+		case !matchAny(p.licenses, strings.ToLower(license)):
+			violations = append(violations, Violation{
+				Rule:     RuleLicenseRequired,
+				Resource: ref,
+				Message:  fmt.Sprintf("license %q is not one of %s", license, strings.Join(p.RequiredLicenses, ", ")),
+			})
+		}
+	}
+
+	return violations, nil
+}
@@ -0,0 +1,175 @@
+// Package policy enforces an organization policy on repository sources and
+// resources: which sources may be used, which tools and models resources may
+// request, which licenses they must carry and which resources are banned.
+package policy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/pattern"
+	"github.com/gobwas/glob"
+	"gopkg.in/yaml.v3"
+)
+
+// fetchTimeout bounds the download of a remote policy file.
+const fetchTimeout = 30 * time.Second
+
+// Policy is an organization policy file.
+//
+// Every list is optional; an empty list does not restrict anything.
+type Policy struct {
+	// AllowedSources are patterns for the sources resources may come from,
+	// e.g. "github.com/my-org/*" or "local:/home/*/ai/**". Remote URLs are
+	// compared without scheme, user and .git suffix; local sources as
+	// "local:<absolute path>".
+	AllowedSources []string `yaml:"allowedSources,omitempty" json:"allowedSources,omitempty"`
+
+	// ForbiddenTools are allowed-tools (or agent tools) entries resources may
+	// not request, e.g. "Bash" for unrestricted shell access or "mcp__*".
+	ForbiddenTools []string `yaml:"forbiddenTools,omitempty" json:"forbiddenTools,omitempty"`
+
+	// ForbiddenModels are model values resources may not set.
+	ForbiddenModels []string `yaml:"forbiddenModels,omitempty" json:"forbiddenModels,omitempty"`
+
+	// RequiredLicenses are the licenses of which every command, skill and
+	// agent must declare one.
+	RequiredLicenses []string `yaml:"requiredLicenses,omitempty" json:"requiredLicenses,omitempty"`
+
+	// Blocklist are resource patterns ("skill/pdf*", "command/*", "evil")
+	// that may not be imported or installed.
+	Blocklist []string `yaml:"blocklist,omitempty" json:"blocklist,omitempty"`
+
+	// Location is the path or URL the policy was loaded from.
+	Location string `yaml:"-" json:"location"`
+
+	sources   []glob.Glob
+	tools     []glob.Glob
+	models    []glob.Glob
+	licenses  []glob.Glob
+	blocklist []*pattern.Matcher
+}
+
+// Load reads a policy from a local path or an HTTP(S) URL.
+func Load(location string) (*Policy, error) {
+	return loadWithClient(location, &http.Client{Timeout: fetchTimeout})
+}
+
+func loadWithClient(location string, client *http.Client) (*Policy, error) {
+	if strings.TrimSpace(location) == "" {
+		return nil, fmt.Errorf("policy location cannot be empty")
+	}
+
+	var data []byte
+	if u, err := url.Parse(location); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		data, err = fetch(u, client)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		data, err = os.ReadFile(location)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read policy: %w", err)
+		}
+	}
+
+	p, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid policy %s: %w", location, err)
+	}
+	p.Location = location
+	return p, nil
+}
+
+func fetch(u *url.URL, client *http.Client) ([]byte, error) {
+	resp, err := client.Get(u.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch policy: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch policy %s: unexpected status %d", u.Redacted(), resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy response: %w", err)
+	}
+	return data, nil
+}
+
+// Parse parses and compiles a policy. Unknown fields are rejected so that a
+// misspelled rule does not silently allow everything.
+func Parse(data []byte) (*Policy, error) {
+	p := &Policy{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(p); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	if err := p.compile(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Policy) compile() error {
+	var err error
+	if p.sources, err = compileGlobs("allowedSources", p.AllowedSources, true, '/'); err != nil {
+		return err
+	}
+	if p.tools, err = compileGlobs("forbiddenTools", p.ForbiddenTools, false); err != nil {
+		return err
+	}
+	if p.models, err = compileGlobs("forbiddenModels", p.ForbiddenModels, true); err != nil {
+		return err
+	}
+	if p.licenses, err = compileGlobs("requiredLicenses", p.RequiredLicenses, true); err != nil {
+		return err
+	}
+	for i, entry := range p.Blocklist {
+		m, err := pattern.NewMatcher(strings.TrimSpace(entry))
+		if err != nil {
+			return fmt.Errorf("blocklist[%d]: %w", i, err)
+		}
+		p.blocklist = append(p.blocklist, m)
+	}
+	return nil
+}
+
+// compileGlobs compiles patterns, lower-casing them when matching is
+// case-insensitive.
+func compileGlobs(field string, patterns []string, foldCase bool, separators ...rune) ([]glob.Glob, error) {
+	globs := make([]glob.Glob, 0, len(patterns))
+	for i, raw := range patterns {
+		entry := strings.TrimSpace(raw)
+		if entry == "" {
+			return nil, fmt.Errorf("%s[%d]: pattern cannot be empty", field, i)
+		}
+		if foldCase {
+			entry = strings.ToLower(entry)
+		}
+		g, err := glob.Compile(entry, separators...)
+		if err != nil {
+			return nil, fmt.Errorf("%s[%d]: invalid pattern %q: %w", field, i, raw, err)
+		}
+		globs = append(globs, g)
+	}
+	return globs, nil
+}
+
+func matchAny(globs []glob.Glob, value string) bool {
+	for _, g := range globs {
+		if g.Match(value) {
+			return true
+		}
+	}
+	return false
+}
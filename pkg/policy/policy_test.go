@@ -0,0 +1,169 @@
+package policy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/test/testutil"
+)
+
+const testPolicy = `allowedSources:
+  - github.com/my-org/*
+  - local:/opt/ai/**
+forbiddenTools:
+  - Bash
+  - mcp__prod__*
+forbiddenModels:
+  - "*opus*"
+requiredLicenses:
+  - MIT
+  - Apache-2.0
+blocklist:
+  - skill/legacy-*
+  - evil
+`
+
+func TestSourceIdentity(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"https://github.com/My-Org/Tools.git", "github.com/my-org/tools"},
+		{"https://user@bitbucket.example.com/scm/team/repo.git/", "bitbucket.example.com/scm/team/repo"},
+		{"git@github.com:my-org/tools.git", "github.com/my-org/tools"},
+		{"ssh://git@gitlab.com/group/sub/repo.git", "gitlab.com/group/sub/repo"},
+		{"gh:my-org/tools", "github.com/my-org/tools"},
+		{"file:///opt/ai/team", "local:/opt/ai/team"},
+		{"/opt/ai/team/", "local:/opt/ai/team"},
+	}
+	for _, tt := range tests {
+		if got := SourceIdentity(tt.in); got != tt.want {
+			t.Errorf("SourceIdentity(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCheckSource(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	for _, allowed := range []string{"https://github.com/my-org/tools", "git@github.com:My-Org/x.git", "file:///opt/ai/team/skills"} {
+		if v := p.CheckSource(allowed); v != nil {
+			t.Errorf("CheckSource(%q) = %v, want allowed", allowed, v)
+		}
+	}
+	for _, denied := range []string{"https://github.com/other/tools", "https://github.com/my-org/tools/nested", "file:///home/me/ai"} {
+		if v := p.CheckSource(denied); v == nil || v.Rule != RuleSourceNotAllowed {
+			t.Errorf("CheckSource(%q) = %v, want %s", denied, v, RuleSourceNotAllowed)
+		}
+	}
+
+	var open *Policy
+	if v := open.CheckSource("https://anything.example.com/x"); v != nil {
+		t.Errorf("nil policy CheckSource() = %v", v)
+	}
+}
+
+func TestCheckResource(t *testing.T) {
+	p, err := Parse([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	root := t.TempDir()
+	testutil.WriteFile(t, filepath.Join(root, "skills/legacy-pdf/SKILL.md"), "---\nname: legacy-pdf\ndescription: Old\nlicense: MIT\nallowed-tools: Read Grep Bash(*)\n---\n")
+	testutil.WriteFile(t, filepath.Join(root, "commands/deploy.md"), "---\ndescription: Deploy\nmodel: claude-opus-4\nallowed-tools: Read, Bash(git diff:*), mcp__prod__db\nlicense: GPL-3.0\n---\n")
+	testutil.WriteFile(t, filepath.Join(root, "agents/reviewer.md"), "---\ndescription: Reviews\ntools:\n  - Read\n  - Bash(git log:*)\nlicense: apache-2.0\n---\n")
+
+	check := func(res *resource.Resource, err error) []string {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		violations, err := p.CheckResource(res)
+		if err != nil {
+			t.Fatalf("CheckResource() error = %v", err)
+		}
+		var got []string
+		for _, v := range violations {
+			got = append(got, v.String())
+		}
+		sort.Strings(got)
+		return got
+	}
+
+	got := check(resource.LoadSkill(filepath.Join(root, "skills", "legacy-pdf")))
+	want := []string{
+		`skill/legacy-pdf: allowed-tools entry "Bash(*)" is forbidden (forbidden-tool)`,
+		`skill/legacy-pdf: skill/legacy-pdf matches blocklist entry "skill/legacy-*" (blocklisted)`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("skill violations:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	got = check(resource.LoadCommand(filepath.Join(root, "commands", "deploy.md")))
+	want = []string{
+		`command/deploy: allowed-tools entry "mcp__prod__db" is forbidden (forbidden-tool)`,
+		`command/deploy: license "GPL-3.0" is not one of MIT, Apache-2.0 (license-required)`,
+		`command/deploy: model "claude-opus-4" is forbidden (forbidden-model)`,
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("command violations:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if got := check(resource.LoadAgent(filepath.Join(root, "agents", "reviewer.md"))); len(got) != 0 {
+		t.Errorf("agent violations = %v, want none", got)
+	}
+
+	if v := p.CheckRef(resource.PackageType, "evil"); v == nil || v.Rule != RuleBlocklisted {
+		t.Errorf("CheckRef(package/evil) = %v, want blocklisted", v)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name, content, wantErr string
+	}{
+		{"unknown field", "forbiddenTool:\n  - Bash\n", "field forbiddenTool not found"},
+		{"bad glob", "allowedSources:\n  - github.com/[org\n", "allowedSources[0]"},
+		{"empty pattern", "forbiddenModels:\n  - ''\n", "forbiddenModels[0]: pattern cannot be empty"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	testutil.WriteFile(t, path, testPolicy)
+	p, err := Load(path)
+	if err != nil || p.Location != path || len(p.Blocklist) != 2 {
+		t.Fatalf("Load() = %+v, %v", p, err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/policy.yaml" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(testPolicy))
+	}))
+	defer server.Close()
+
+	p, err = loadWithClient(server.URL+"/policy.yaml", server.Client())
+	if err != nil || len(p.AllowedSources) != 2 {
+		t.Fatalf("loadWithClient() = %+v, %v", p, err)
+	}
+	if _, err := loadWithClient(server.URL+"/missing.yaml", server.Client()); err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Errorf("loadWithClient(missing) error = %v", err)
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/modifications"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resourcediff"
 )
 
 // resourceLoader is a strategy function type for loading different resource types
@@ -185,6 +186,19 @@ func (m *Manager) addResource(sourcePath, sourceURL, sourceType, ref string, opt
 		Ref:            ref,
 		FirstInstalled: now,
 		LastUpdated:    now,
+		Review:         opts.Approval,
+	}
+
+	// Record what was imported: the per-file manifest backs verify
+	// --integrity, the digest lets later syncs detect local edits. Symlinked
+	// resources are their source, so there are no local edits to detect.
+	if files, err := resourcediff.LoadPath(destPath); err == nil {
+		meta.Files = resourcediff.NewManifest(files)
+		if opts.ImportMode != "symlink" {
+			meta.ContentDigest = resourcediff.Digest(files)
+		}
+	} else if m.logger != nil {
+		m.logger.Warn("failed to compute content digest", "resource", res.Name, "error", err)
 	}
 
 	// Use explicit sourceName from opts if provided, otherwise derive
@@ -479,6 +493,7 @@ func (m *Manager) addPackageWithOptions(sourcePath, sourceURL, sourceType, ref s
 		FirstAdded:    now,
 		LastUpdated:   now,
 		ResourceCount: len(pkg.Resources),
+		Review:        opts.Approval,
 	}
 	if err := metadata.SavePackageMetadata(pkgMeta, m.repoPath); err != nil {
 		if m.logger != nil {
@@ -8,7 +8,9 @@ import (
 
 	pkgerrors "github.com/dynatrace-oss/ai-config-manager/v3/pkg/errors"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/policy"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/secrets"
 )
 
 // BulkImportOptions contains options for bulk import operations
@@ -22,14 +24,32 @@ type BulkImportOptions struct {
 	SourceURL    string // Original source URL (for Git sources)
 	SourceType   string // Source type (github, git-url, file, local)
 	Ref          string // Git ref (branch/tag/commit), defaults to "main" if empty
+	// LocalChanges is the config.LocalChanges* policy applied when Force
+	// would overwrite a resource edited inside the repository. Empty disables
+	// detection and always overwrites.
+	LocalChanges string
+	// Secrets scans every resource before import; nil disables scanning.
+	Secrets *secrets.Scanner
+	// SecretsPolicy is config.SecretsBlock to refuse resources with
+	// findings; otherwise findings are reported in BulkImportResult.Warnings.
+	SecretsPolicy string
+	// Policy refuses resources that violate the organization policy; nil
+	// disables the check.
+	Policy *policy.Policy
+	// Quarantine stores new and changed resources in the quarantine area for
+	// 'repo review' instead of importing them.
+	Quarantine bool
+	// Approval is recorded in the metadata of every imported resource.
+	Approval *metadata.Review
 }
 
 // ImportOptions contains options for single resource import operations
 type ImportOptions struct {
-	SourceName string // Explicit source name from manifest (overrides derived name)
-	SourceID   string // Source ID from manifest (hash-based)
-	ImportMode string // "copy" or "symlink"
-	Force      bool   // Overwrite existing resources
+	SourceName string           // Explicit source name from manifest (overrides derived name)
+	SourceID   string           // Source ID from manifest (hash-based)
+	ImportMode string           // "copy" or "symlink"
+	Force      bool             // Overwrite existing resources
+	Approval   *metadata.Review // Review approval to record in metadata (optional)
 }
 
 // ImportError represents an error during resource import
@@ -40,14 +60,19 @@ type ImportError struct {
 
 // BulkImportResult contains the results of a bulk import operation
 type BulkImportResult struct {
-	Added        []string      // Successfully added resources (new)
-	Updated      []string      // Successfully updated resources (already existed, re-added with Force)
-	Skipped      []string      // Skipped due to conflicts
-	Failed       []ImportError // Failed imports with reasons
-	CommandCount int           // Number of commands imported
-	SkillCount   int           // Number of skills imported
-	AgentCount   int           // Number of agents imported
-	PackageCount int           // Number of packages imported
+	Added        []string          // Successfully added resources (new)
+	Updated      []string          // Successfully updated resources (already existed, re-added with Force)
+	Skipped      []string          // Skipped due to conflicts
+	Failed       []ImportError     // Failed imports with reasons
+	CommandCount int               // Number of commands imported
+	SkillCount   int               // Number of skills imported
+	AgentCount   int               // Number of agents imported
+	PackageCount int               // Number of packages imported
+	LocalChanges []LocalChange     // Resources with local edits and how they were handled
+	Warnings     []ImportError     // Imported resources with findings, e.g. possible secrets
+	Quarantined  []QuarantineEntry // New or changed resources held for review (Quarantine mode)
+
+	pendingMerges []pendingMerge
 }
 
 func (m *Manager) AddBulk(sources []string, opts BulkImportOptions) (*BulkImportResult, error) {
@@ -113,6 +138,17 @@ func (m *Manager) AddBulk(sources []string, opts BulkImportOptions) (*BulkImport
 		}
 	}
 
+	if !opts.DryRun && len(result.pendingMerges) > 0 {
+		if err := m.applyPendingMerges(result.pendingMerges); err != nil {
+			return result, err
+		}
+		result.pendingMerges = nil
+	}
+
+	if !opts.DryRun && (len(result.Added) > 0 || len(result.Updated) > 0 || len(result.LocalChanges) > 0) {
+		m.updateSearchIndex()
+	}
+
 	return result, nil
 }
 
@@ -212,13 +248,47 @@ func (m *Manager) importResource(sourcePath string, opts BulkImportOptions, resu
 		return typedErr
 	}
 
+	if err := checkPolicy(sourcePath, res, opts, result); err != nil {
+		return err
+	}
+	if err := checkSecrets(sourcePath, opts, result); err != nil {
+		return err
+	}
+
 	// Check if resource already exists
 	destPath := m.GetPath(res.Name, resourceType)
 	_, statErr := os.Stat(destPath)
 	exists := statErr == nil
 
+	// Under review, new resources and forced updates wait in quarantine;
+	// conflicts without --force are handled as usual below.
+	if opts.Quarantine && (!exists || opts.Force) {
+		return m.quarantineResource(sourcePath, res, destPath, exists, opts, result)
+	}
+
 	if exists {
 		if opts.Force {
+			if opts.LocalChanges != "" {
+				change, merge, err := m.checkLocalChanges(res, resourceType, sourcePath, opts.LocalChanges)
+				if err != nil {
+					typedErr := pkgerrors.Resource(err, "failed to check for local changes")
+					result.Failed = append(result.Failed, ImportError{
+						Path:    sourcePath,
+						Message: typedErr.Error(),
+					})
+					return typedErr
+				}
+				if change != nil {
+					result.LocalChanges = append(result.LocalChanges, *change)
+					if change.Action == LocalChangeKept {
+						return nil
+					}
+					if merge != nil {
+						result.pendingMerges = append(result.pendingMerges, *merge)
+					}
+				}
+			}
+
 			// Force mode: remove existing and continue
 			if !opts.DryRun {
 				if err := m.Remove(res.Name, resourceType); err != nil {
@@ -275,6 +345,7 @@ func (m *Manager) importResource(sourcePath string, opts BulkImportOptions, resu
 			SourceID:   opts.SourceID,
 			ImportMode: opts.ImportMode,
 			Force:      opts.Force,
+			Approval:   opts.Approval,
 		}
 		// Default to "copy" if not specified
 		if importOpts.ImportMode == "" {
@@ -334,11 +405,20 @@ func (m *Manager) importPackage(sourcePath string, opts BulkImportOptions, resul
 		return typedErr
 	}
 
+	if err := checkPackagePolicy(sourcePath, pkg, opts, result); err != nil {
+		return err
+	}
+
 	// Check if package already exists
 	destPath := resource.GetPackagePath(pkg.Name, m.repoPath)
 	_, statErr := os.Stat(destPath)
 	exists := statErr == nil
 
+	// Under review, packages wait in quarantine like other resources.
+	if opts.Quarantine && (!exists || opts.Force) {
+		return m.quarantineResource(sourcePath, &resource.Resource{Name: pkg.Name, Type: resource.PackageType}, destPath, exists, opts, result)
+	}
+
 	if exists {
 		if opts.Force {
 			// Force mode: remove existing and continue
@@ -410,6 +490,7 @@ func (m *Manager) importPackage(sourcePath string, opts BulkImportOptions, resul
 			SourceID:   opts.SourceID,
 			ImportMode: opts.ImportMode,
 			Force:      opts.Force,
+			Approval:   opts.Approval,
 		}
 		// Default to "copy" if not specified
 		if importOpts.ImportMode == "" {
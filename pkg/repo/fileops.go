@@ -75,6 +75,19 @@ func (m *Manager) copyFile(src, dst string) error {
 		return err
 	}
 
+	// os.Create uses 0666 minus umask; keep the source permissions so skill
+	// scripts stay executable.
+	if err := destFile.Chmod(srcInfo.Mode().Perm()); err != nil {
+		if m.logger != nil {
+			m.logger.Error("failed to set destination file permissions",
+				"dest", dst,
+				"permissions", srcInfo.Mode().Perm(),
+				"error", err.Error(),
+			)
+		}
+		return err
+	}
+
 	if m.logger != nil {
 		m.logger.Debug("file copied successfully",
 			"source", src,
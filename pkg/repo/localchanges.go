@@ -0,0 +1,215 @@
+package repo
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resourcediff"
+)
+
+// Actions taken for a locally modified resource during a forced re-import.
+const (
+	LocalChangeKept      = "kept-local"
+	LocalChangeReplaced  = "took-upstream"
+	LocalChangeMerged    = "merged"
+	LocalChangeConflicts = "merged-with-conflicts"
+)
+
+// LocalChange records a resource that was edited inside the repository since
+// it was imported, and what a re-import did about it.
+type LocalChange struct {
+	Path   string // Source path that was being imported
+	Name   string
+	Type   resource.ResourceType
+	Action string // One of the LocalChange* actions
+	// Conflicts lists files that could not be merged cleanly: markdown files
+	// now containing conflict markers, and other files that kept the local
+	// version.
+	Conflicts []string
+	Message   string
+}
+
+// pendingMerge is a merge result written after the upstream version has been
+// imported and committed, so the import commit keeps the pristine upstream
+// content as the base for later merges.
+type pendingMerge struct {
+	destPath string
+	isDir    bool
+	files    resourcediff.FileSet
+}
+
+// IsLocallyModified reports whether a copied resource differs from the
+// content recorded when it was imported. Symlinked resources and resources
+// imported before digests were recorded are never reported as modified.
+func (m *Manager) IsLocallyModified(name string, resType resource.ResourceType) (bool, error) {
+	_, _, modified, err := m.localEdits(name, resType)
+	return modified, err
+}
+
+// localEdits loads the repository copy of a resource and its metadata and
+// reports whether the copy was edited since import.
+func (m *Manager) localEdits(name string, resType resource.ResourceType) (resourcediff.FileSet, *metadata.ResourceMetadata, bool, error) {
+	destPath := m.GetPath(name, resType)
+	info, err := os.Lstat(destPath)
+	if err != nil || info.Mode()&os.ModeSymlink != 0 {
+		return nil, nil, false, nil
+	}
+
+	meta, err := metadata.Load(name, resType, m.repoPath)
+	if err != nil || meta.ContentDigest == "" {
+		return nil, nil, false, nil
+	}
+
+	local, err := resourcediff.LoadPath(destPath)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("failed to read %s/%s: %w", resType, name, err)
+	}
+
+	return local, meta, resourcediff.Digest(local) != meta.ContentDigest, nil
+}
+
+// checkLocalChanges applies the local-changes policy to a resource that is
+// about to be overwritten by a forced import. It returns nil when the
+// resource has no local edits. A kept-local change means the import must be
+// skipped; a pending merge must be written once the import has committed.
+func (m *Manager) checkLocalChanges(res *resource.Resource, resType resource.ResourceType, sourcePath, policy string) (*LocalChange, *pendingMerge, error) {
+	local, meta, modified, err := m.localEdits(res.Name, resType)
+	if err != nil || !modified {
+		return nil, nil, err
+	}
+
+	change := &LocalChange{Path: sourcePath, Name: res.Name, Type: resType}
+	switch policy {
+	case config.LocalChangesTakeUpstream:
+		change.Action = LocalChangeReplaced
+		return change, nil, nil
+	case config.LocalChangesMerge:
+		// handled below
+	default:
+		change.Action = LocalChangeKept
+		return change, nil, nil
+	}
+
+	destPath := m.GetPath(res.Name, resType)
+	isDir := resType == resource.Skill
+	base, ok := m.importedFiles(meta, destPath)
+	if !ok {
+		change.Action = LocalChangeKept
+		change.Message = "no base version in repository history to merge with"
+		return change, nil, nil
+	}
+
+	upstream, err := resourcediff.LoadPath(sourcePath)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", sourcePath, err)
+	}
+	if !isDir {
+		// Single-file resources are keyed by the repository file name.
+		upstream = resourcediff.FileSet{filepath.Base(destPath): upstream[filepath.Base(sourcePath)]}
+	}
+
+	merged, conflicts := resourcediff.MergeFileSets(base, local, upstream)
+	change.Action = LocalChangeMerged
+	if len(conflicts) > 0 {
+		change.Action = LocalChangeConflicts
+		change.Conflicts = conflicts
+	}
+	return change, &pendingMerge{destPath: destPath, isDir: isDir, files: merged}, nil
+}
+
+// importedFiles returns the resource content from the commit that last wrote
+// its metadata, i.e. the import, provided it matches the recorded digest.
+func (m *Manager) importedFiles(meta *metadata.ResourceMetadata, destPath string) (resourcediff.FileSet, bool) {
+	if !m.isGitRepo() {
+		return nil, false
+	}
+
+	metaRel, err := filepath.Rel(m.repoPath, metadata.GetMetadataPath(meta.Name, meta.Type, m.repoPath))
+	if err != nil {
+		return nil, false
+	}
+	resRel, err := filepath.Rel(m.repoPath, destPath)
+	if err != nil {
+		return nil, false
+	}
+
+	commit, err := m.runGit("log", "-1", "--format=%H", "--", filepath.ToSlash(metaRel))
+	commit = strings.TrimSpace(commit)
+	if err != nil || commit == "" {
+		return nil, false
+	}
+
+	files, err := m.ResourceFilesAt(commit, resRel)
+	if err != nil || resourcediff.Digest(files) != meta.ContentDigest {
+		return nil, false
+	}
+	return files, true
+}
+
+// applyPendingMerges writes merged content over freshly imported resources
+// and commits it separately from the import.
+func (m *Manager) applyPendingMerges(merges []pendingMerge) error {
+	paths := make([]string, 0, len(merges))
+	for _, merge := range merges {
+		if err := writeMergedFiles(merge); err != nil {
+			return err
+		}
+		paths = append(paths, merge.destPath)
+	}
+
+	if err := m.CommitChangesForPaths(fmt.Sprintf("aimgr: merge local changes into %d resource(s)", len(merges)), paths); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to commit changes: %v\n", err)
+	}
+	return nil
+}
+
+func writeMergedFiles(merge pendingMerge) error {
+	if !merge.isDir {
+		for _, data := range merge.files {
+			if err := writeKeepingMode(merge.destPath, data); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	current, err := resourcediff.LoadPath(merge.destPath)
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", merge.destPath, err)
+	}
+	for rel := range current {
+		if _, keep := merge.files[rel]; !keep {
+			if err := os.Remove(filepath.Join(merge.destPath, filepath.FromSlash(rel))); err != nil {
+				return fmt.Errorf("failed to remove %s: %w", rel, err)
+			}
+		}
+	}
+	for rel, data := range merge.files {
+		path := filepath.Join(merge.destPath, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", rel, err)
+		}
+		if err := writeKeepingMode(path, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeKeepingMode writes data to path, keeping the mode of an existing file
+// (e.g. executable scripts from the upstream copy).
+func writeKeepingMode(path string, data []byte) error {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := os.WriteFile(path, data, mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+//go:build unit
+
+package repo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/test/testutil"
+)
+
+const localChangesBaseSkill = "---\nname: notes\ndescription: Notes skill\n---\n\n# Notes\n\nintro\n\nstep one\nstep two\n\nfooter\n"
+
+// setupLocalChangesRepo imports a skill from an upstream directory and returns
+// the manager, the upstream skill directory and the repository SKILL.md path.
+func setupLocalChangesRepo(t *testing.T) (*Manager, string, string) {
+	t.Helper()
+
+	repoDir := t.TempDir()
+	setupGitRepo(t, repoDir)
+	manager := NewManagerWithPath(repoDir)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	upstream := filepath.Join(t.TempDir(), "skills", "notes")
+	if err := os.MkdirAll(upstream, 0755); err != nil {
+		t.Fatalf("Failed to create upstream skill: %v", err)
+	}
+	testutil.WriteFile(t, filepath.Join(upstream, "SKILL.md"), localChangesBaseSkill)
+
+	result, err := manager.AddBulk([]string{upstream}, BulkImportOptions{ImportMode: "copy"})
+	if err != nil || len(result.Failed) > 0 {
+		t.Fatalf("AddBulk() error = %v, failed = %v", err, result.Failed)
+	}
+
+	return manager, upstream, filepath.Join(repoDir, "skills", "notes", "SKILL.md")
+}
+
+func readLocalChangesFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return string(data)
+}
+
+func TestImport_RecordsContentDigest(t *testing.T) {
+	manager, _, repoSkill := setupLocalChangesRepo(t)
+
+	meta, err := manager.GetMetadata("notes", resource.Skill)
+	if err != nil {
+		t.Fatalf("GetMetadata() error = %v", err)
+	}
+	if !strings.HasPrefix(meta.ContentDigest, "sha256:") {
+		t.Fatalf("ContentDigest = %q, want sha256 digest", meta.ContentDigest)
+	}
+
+	modified, err := manager.IsLocallyModified("notes", resource.Skill)
+	if err != nil || modified {
+		t.Fatalf("IsLocallyModified() = %v, %v; want false", modified, err)
+	}
+
+	testutil.WriteFile(t, repoSkill, strings.Replace(localChangesBaseSkill, "intro", "local intro", 1))
+	modified, err = manager.IsLocallyModified("notes", resource.Skill)
+	if err != nil || !modified {
+		t.Fatalf("IsLocallyModified() after edit = %v, %v; want true", modified, err)
+	}
+}
+
+func TestAddBulk_LocalChangesPolicies(t *testing.T) {
+	localEdit := strings.Replace(localChangesBaseSkill, "intro", "local intro", 1)
+	upstreamEdit := strings.Replace(localChangesBaseSkill, "footer", "upstream footer", 1)
+
+	tests := []struct {
+		policy     string
+		wantAction string
+		want       string
+	}{
+		{policy: config.LocalChangesKeepLocal, wantAction: LocalChangeKept, want: localEdit},
+		{policy: config.LocalChangesTakeUpstream, wantAction: LocalChangeReplaced, want: upstreamEdit},
+		{
+			policy:     config.LocalChangesMerge,
+			wantAction: LocalChangeMerged,
+			want:       strings.Replace(localEdit, "footer", "upstream footer", 1),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			manager, upstream, repoSkill := setupLocalChangesRepo(t)
+			testutil.WriteFile(t, repoSkill, localEdit)
+			testutil.WriteFile(t, filepath.Join(upstream, "SKILL.md"), upstreamEdit)
+
+			result, err := manager.AddBulk([]string{upstream}, BulkImportOptions{ImportMode: "copy", Force: true, LocalChanges: tt.policy})
+			if err != nil || len(result.Failed) > 0 {
+				t.Fatalf("AddBulk() error = %v, failed = %v", err, result.Failed)
+			}
+			if len(result.LocalChanges) != 1 || result.LocalChanges[0].Action != tt.wantAction {
+				t.Fatalf("LocalChanges = %+v, want action %s", result.LocalChanges, tt.wantAction)
+			}
+			if got := readLocalChangesFile(t, repoSkill); got != tt.want {
+				t.Errorf("SKILL.md =\n%s\nwant\n%s", got, tt.want)
+			}
+
+			// Local edits stay detectable until upstream and repository agree.
+			modified, err := manager.IsLocallyModified("notes", resource.Skill)
+			if err != nil {
+				t.Fatalf("IsLocallyModified() error = %v", err)
+			}
+			if wantModified := tt.policy != config.LocalChangesTakeUpstream; modified != wantModified {
+				t.Errorf("IsLocallyModified() = %v, want %v", modified, wantModified)
+			}
+		})
+	}
+}
+
+func TestAddBulk_MergeConflictsAndRepeatedSync(t *testing.T) {
+	manager, upstream, repoSkill := setupLocalChangesRepo(t)
+	testutil.WriteFile(t, repoSkill, strings.Replace(localChangesBaseSkill, "step two", "step 2 (local)", 1))
+	testutil.WriteFile(t, filepath.Join(upstream, "SKILL.md"), strings.Replace(localChangesBaseSkill, "step two", "step 2 (upstream)", 1))
+
+	opts := BulkImportOptions{ImportMode: "copy", Force: true, LocalChanges: config.LocalChangesMerge}
+	result, err := manager.AddBulk([]string{upstream}, opts)
+	if err != nil {
+		t.Fatalf("AddBulk() error = %v", err)
+	}
+	if len(result.LocalChanges) != 1 || result.LocalChanges[0].Action != LocalChangeConflicts ||
+		strings.Join(result.LocalChanges[0].Conflicts, ",") != "SKILL.md" {
+		t.Fatalf("LocalChanges = %+v, want a SKILL.md conflict", result.LocalChanges)
+	}
+	merged := readLocalChangesFile(t, repoSkill)
+	if !strings.Contains(merged, "<<<<<<< local\nstep 2 (local)\n=======\nstep 2 (upstream)\n>>>>>>> upstream\n") {
+		t.Fatalf("expected conflict markers, got:\n%s", merged)
+	}
+	if msg := getLastCommitMessage(t, manager.GetRepoPath()); !strings.HasPrefix(msg, "aimgr: merge local changes") {
+		t.Errorf("last commit = %q, want the merge commit", msg)
+	}
+
+	// After resolving, the next sync merges against the new upstream base.
+	resolved := strings.Replace(localChangesBaseSkill, "step two", "step 2 (resolved)", 1)
+	testutil.WriteFile(t, repoSkill, resolved)
+	result, err = manager.AddBulk([]string{upstream}, opts)
+	if err != nil {
+		t.Fatalf("second AddBulk() error = %v", err)
+	}
+	if len(result.LocalChanges) != 1 || result.LocalChanges[0].Action != LocalChangeMerged {
+		t.Fatalf("second sync LocalChanges = %+v, want a clean merge", result.LocalChanges)
+	}
+	if got := readLocalChangesFile(t, repoSkill); got != resolved {
+		t.Errorf("resolved content should be kept, got:\n%s", got)
+	}
+}
+
+func TestAddBulk_WithoutPolicyOverwritesLocalChanges(t *testing.T) {
+	manager, upstream, repoSkill := setupLocalChangesRepo(t)
+	testutil.WriteFile(t, repoSkill, "local only\n")
+
+	result, err := manager.AddBulk([]string{upstream}, BulkImportOptions{ImportMode: "copy", Force: true})
+	if err != nil {
+		t.Fatalf("AddBulk() error = %v", err)
+	}
+	if len(result.LocalChanges) != 0 || len(result.Updated) != 1 {
+		t.Fatalf("expected a plain update, got %+v", result)
+	}
+	if got := readLocalChangesFile(t, repoSkill); got != localChangesBaseSkill {
+		t.Errorf("SKILL.md = %q, want upstream content", got)
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/logging"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repolock"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repomanifest"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/search"
 )
 
 // Manager manages the AI resources repository
@@ -28,7 +29,7 @@ type Manager struct {
 // NewManager creates a new repository manager
 // Repository path determined by 3-level precedence:
 // 1. AIMGR_REPO_PATH environment variable (highest priority)
-// 2. repo.path from config file (~/.config/aimgr/aimgr.yaml)
+// 2. active profile path or repo.path from config file (~/.config/aimgr/aimgr.yaml)
 // 3. XDG default (~/.local/share/ai-config/repo/)
 func NewManager() (*Manager, error) {
 	repoPath := ResolveRepoPath()
@@ -46,7 +47,7 @@ func NewManager() (*Manager, error) {
 //
 // Precedence:
 // 1. AIMGR_REPO_PATH environment variable
-// 2. repo.path from global config
+// 2. active profile path or repo.path from global config
 // 3. XDG default (~/.local/share/ai-config/repo)
 func ResolveRepoPath() string {
 	if repoPath := os.Getenv("AIMGR_REPO_PATH"); repoPath != "" {
@@ -54,8 +55,8 @@ func ResolveRepoPath() string {
 	}
 
 	// Ignore config loading errors to match NewManager behavior.
-	if cfg, err := config.LoadGlobal(); err == nil && cfg.Repo.Path != "" {
-		return cfg.Repo.Path
+	if cfg, err := config.LoadGlobal(); err == nil && cfg.EffectiveRepoPath() != "" {
+		return cfg.EffectiveRepoPath()
 	}
 
 	return filepath.Join(xdg.DataHome, "ai-config", "repo")
@@ -306,6 +307,8 @@ func (m *Manager) Init() error {
 	gitignoreContent := `# aimgr workspace cache (Git clones for remote sources)
 .workspace/
 
+` + searchIndexIgnore + `
+` + quarantineIgnore + `
 # Log files
 logs/
 *.log
@@ -358,6 +361,31 @@ logs/
 				}
 				return fmt.Errorf("failed to append to .gitignore: %w", err)
 			}
+		} else if !strings.Contains(string(content), search.IndexFileName) {
+			// Repositories created before search: the index is rebuilt locally
+			// and must not be committed.
+			f, err := os.OpenFile(gitignorePath, os.O_APPEND|os.O_WRONLY, 0644)
+			if err != nil {
+				return fmt.Errorf("failed to open .gitignore for append: %w", err)
+			}
+			defer f.Close()
+
+			if _, err := f.WriteString("\n" + searchIndexIgnore); err != nil {
+				return fmt.Errorf("failed to append to .gitignore: %w", err)
+			}
+		}
+		if strings.Contains(string(content), ".workspace") && !strings.Contains(string(content), quarantineDir+"/") {
+			// Repositories created before reviewed imports: quarantined
+			// resources are local until approved.
+			f, err := os.OpenFile(gitignorePath, os.O_APPEND|os.O_WRONLY, 0644)
+			if err != nil {
+				return fmt.Errorf("failed to open .gitignore for append: %w", err)
+			}
+			defer f.Close()
+
+			if _, err := f.WriteString("\n" + quarantineIgnore); err != nil {
+				return fmt.Errorf("failed to append to .gitignore: %w", err)
+			}
 		}
 	} else {
 		// Create new .gitignore
@@ -412,8 +412,10 @@ description: A test skill with subdirectories
 	// Verify subdirectories were copied
 	destPath := manager.GetPath("test-skill", resource.Skill)
 	destScriptPath := filepath.Join(destPath, "scripts", "test.sh")
-	if _, err := os.Stat(destScriptPath); err != nil {
+	if info, err := os.Stat(destScriptPath); err != nil {
 		t.Errorf("Script was not copied: %v", err)
+	} else if info.Mode().Perm() != 0755 {
+		t.Errorf("Script mode = %v, want 0755", info.Mode().Perm())
 	}
 
 	destRefPath := filepath.Join(destPath, "references", "README.md")
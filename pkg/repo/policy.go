@@ -0,0 +1,49 @@
+package repo
+
+import (
+	"errors"
+
+	pkgerrors "github.com/dynatrace-oss/ai-config-manager/v3/pkg/errors"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/policy"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+)
+
+// checkPolicy evaluates the organization policy for a resource before import.
+// A resource that violates it is recorded as failed and an error is returned.
+func checkPolicy(sourcePath string, res *resource.Resource, opts BulkImportOptions, result *BulkImportResult) error {
+	if opts.Policy == nil {
+		return nil
+	}
+
+	violations, err := opts.Policy.CheckResource(res)
+	if err != nil {
+		typedErr := pkgerrors.Resource(err, "failed to evaluate policy")
+		result.Failed = append(result.Failed, ImportError{Path: sourcePath, Message: typedErr.Error()})
+		return typedErr
+	}
+	return recordPolicyViolations(sourcePath, violations, result)
+}
+
+// CheckPackagePolicy applies the policy blocklist to a package that is saved
+// without AddBulk, such as one generated from a marketplace. A violation is
+// recorded in result.Failed under path and returned.
+func CheckPackagePolicy(path string, pkg *resource.Package, opts BulkImportOptions, result *BulkImportResult) error {
+	return checkPackagePolicy(path, pkg, opts, result)
+}
+
+// checkPackagePolicy applies the policy blocklist to a package before import.
+func checkPackagePolicy(sourcePath string, pkg *resource.Package, opts BulkImportOptions, result *BulkImportResult) error {
+	if v := opts.Policy.CheckRef(resource.PackageType, pkg.Name); v != nil {
+		return recordPolicyViolations(sourcePath, []policy.Violation{*v}, result)
+	}
+	return nil
+}
+
+func recordPolicyViolations(sourcePath string, violations []policy.Violation, result *BulkImportResult) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	typedErr := pkgerrors.Validation(errors.New(policy.Summarize(violations)), "blocked by policy")
+	result.Failed = append(result.Failed, ImportError{Path: sourcePath, Message: typedErr.Error()})
+	return typedErr
+}
@@ -0,0 +1,55 @@
+//go:build unit
+
+package repo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/policy"
+)
+
+func TestAddBulk_Policy(t *testing.T) {
+	source := t.TempDir()
+	for name, content := range map[string]string{
+		"ok.md":     "---\ndescription: Fine\nallowed-tools: Read\n---\nok\n",
+		"danger.md": "---\ndescription: Danger\nallowed-tools: Read, Bash\n---\nrun\n",
+	} {
+		path := filepath.Join(source, "commands", name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := policy.Parse([]byte("forbiddenTools: [Bash]\n"))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	repoDir := t.TempDir()
+	setupGitRepo(t, repoDir)
+	manager := NewManagerWithPath(repoDir)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	result, _ := manager.AddBulk([]string{
+		filepath.Join(source, "commands", "ok.md"),
+		filepath.Join(source, "commands", "danger.md"),
+	}, BulkImportOptions{ImportMode: "copy", Policy: p})
+
+	if len(result.Added) != 1 || len(result.Failed) != 1 {
+		t.Fatalf("AddBulk() added=%v failed=%v, want one of each", result.Added, result.Failed)
+	}
+	if !strings.Contains(result.Failed[0].Message, `allowed-tools entry "Bash" is forbidden`) {
+		t.Errorf("Failed message = %q", result.Failed[0].Message)
+	}
+	if _, err := os.Stat(filepath.Join(repoDir, "commands", "danger.md")); !os.IsNotExist(err) {
+		t.Errorf("blocked command was imported (stat err = %v)", err)
+	}
+}
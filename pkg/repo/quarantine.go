@@ -0,0 +1,341 @@
+package repo
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	pkgerrors "github.com/dynatrace-oss/ai-config-manager/v3/pkg/errors"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/fileutil"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resourcediff"
+)
+
+const (
+	// quarantineDir holds resources awaiting review. It mirrors the resource
+	// directories of the repository, so install and list never see it.
+	quarantineDir = ".quarantine"
+	// quarantineIndexFile lists pending and rejected versions.
+	quarantineIndexFile = "quarantine.json"
+	// quarantineIgnore is the .gitignore entry for the quarantine.
+	quarantineIgnore = "# aimgr quarantine (resources awaiting review)\n" + quarantineDir + "/\n"
+)
+
+// Kinds of quarantined changes.
+const (
+	QuarantineNew     = "new"
+	QuarantineChanged = "changed"
+)
+
+// QuarantineEntry is a resource version waiting in quarantine for review,
+// with the source it would be imported from once approved.
+type QuarantineEntry struct {
+	Name          string                `json:"name" yaml:"name"`
+	Type          resource.ResourceType `json:"type" yaml:"type"`
+	Change        string                `json:"change" yaml:"change"` // QuarantineNew or QuarantineChanged
+	Digest        string                `json:"digest" yaml:"digest"`
+	SourceName    string                `json:"source_name,omitempty" yaml:"source_name,omitempty"`
+	SourceID      string                `json:"source_id,omitempty" yaml:"source_id,omitempty"`
+	SourceURL     string                `json:"source_url,omitempty" yaml:"source_url,omitempty"`
+	SourceType    string                `json:"source_type,omitempty" yaml:"source_type,omitempty"`
+	Ref           string                `json:"ref,omitempty" yaml:"ref,omitempty"`
+	ImportMode    string                `json:"import_mode,omitempty" yaml:"import_mode,omitempty"` // "copy" (default) or "symlink", reused on approval
+	SourcePath    string                `json:"source_path,omitempty" yaml:"source_path,omitempty"` // Absolute source path a symlink-mode resource links to once approved
+	QuarantinedAt time.Time             `json:"quarantined_at" yaml:"quarantined_at"`
+}
+
+// ResourceRef returns the entry as a "type/name" reference.
+func (e QuarantineEntry) ResourceRef() string {
+	return fmt.Sprintf("%s/%s", e.Type, e.Name)
+}
+
+// RejectedVersion is a resource version a reviewer rejected. Later reviewed
+// imports skip the same content instead of quarantining it again.
+type RejectedVersion struct {
+	Name       string                `json:"name" yaml:"name"`
+	Type       resource.ResourceType `json:"type" yaml:"type"`
+	Digest     string                `json:"digest" yaml:"digest"`
+	Reviewer   string                `json:"reviewer" yaml:"reviewer"`
+	RejectedAt time.Time             `json:"rejected_at" yaml:"rejected_at"`
+	Reason     string                `json:"reason,omitempty" yaml:"reason,omitempty"`
+}
+
+// quarantineIndex is the content of .quarantine/quarantine.json.
+type quarantineIndex struct {
+	Pending  []QuarantineEntry `json:"pending"`
+	Rejected []RejectedVersion `json:"rejected,omitempty"`
+}
+
+// QuarantinePath returns the path of a quarantined resource, laid out like
+// the resource in the repository (e.g. .quarantine/skills/<name>).
+func (m *Manager) QuarantinePath(name string, resType resource.ResourceType) string {
+	rel, err := filepath.Rel(m.repoPath, m.GetPath(name, resType))
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(m.repoPath, quarantineDir, rel)
+}
+
+// QuarantineRoot returns the quarantine directory of the repository.
+func (m *Manager) QuarantineRoot() string {
+	return filepath.Join(m.repoPath, quarantineDir)
+}
+
+// ListQuarantine returns the resources waiting for review, sorted by
+// reference.
+func (m *Manager) ListQuarantine() ([]QuarantineEntry, error) {
+	index, err := m.loadQuarantineIndex()
+	if err != nil {
+		return nil, err
+	}
+	entries := append([]QuarantineEntry(nil), index.Pending...)
+	sort.Slice(entries, func(i, j int) bool { return entries[i].ResourceRef() < entries[j].ResourceRef() })
+	return entries, nil
+}
+
+func (m *Manager) loadQuarantineIndex() (*quarantineIndex, error) {
+	index := &quarantineIndex{}
+	data, err := os.ReadFile(filepath.Join(m.QuarantineRoot(), quarantineIndexFile))
+	if os.IsNotExist(err) {
+		return index, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read quarantine index: %w", err)
+	}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("failed to parse quarantine index: %w", err)
+	}
+	return index, nil
+}
+
+func (m *Manager) saveQuarantineIndex(index *quarantineIndex) error {
+	if err := os.MkdirAll(m.QuarantineRoot(), 0755); err != nil {
+		return fmt.Errorf("failed to create quarantine directory: %w", err)
+	}
+	data, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode quarantine index: %w", err)
+	}
+	if err := fileutil.AtomicWrite(filepath.Join(m.QuarantineRoot(), quarantineIndexFile), data, 0644); err != nil {
+		return fmt.Errorf("failed to write quarantine index: %w", err)
+	}
+	return nil
+}
+
+// quarantineResource copies a new or changed resource into quarantine
+// instead of importing it (helper for importResource). Resources identical to
+// the repository copy, and versions that were rejected before, are skipped.
+func (m *Manager) quarantineResource(sourcePath string, res *resource.Resource, destPath string, exists bool, opts BulkImportOptions, result *BulkImportResult) error {
+	fail := func(err error, msg string) error {
+		typedErr := pkgerrors.Resource(err, msg)
+		result.Failed = append(result.Failed, ImportError{Path: sourcePath, Message: typedErr.Error()})
+		return typedErr
+	}
+
+	files, err := resourcediff.LoadPath(sourcePath)
+	if err != nil {
+		return fail(err, "failed to read resource")
+	}
+	digest := resourcediff.Digest(files)
+
+	entry := QuarantineEntry{
+		Name:          res.Name,
+		Type:          res.Type,
+		Change:        QuarantineNew,
+		Digest:        digest,
+		SourceName:    opts.SourceName,
+		SourceID:      opts.SourceID,
+		SourceURL:     opts.SourceURL,
+		SourceType:    opts.SourceType,
+		Ref:           opts.Ref,
+		ImportMode:    opts.ImportMode,
+		QuarantinedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	if opts.ImportMode == "symlink" {
+		absPath, err := filepath.Abs(sourcePath)
+		if err != nil {
+			return fail(err, "failed to resolve source path")
+		}
+		entry.SourcePath = absPath
+	}
+	if exists {
+		if current, err := resourcediff.LoadPath(destPath); err == nil && resourcediff.Digest(current) == digest {
+			result.Skipped = append(result.Skipped, sourcePath)
+			return nil
+		}
+		entry.Change = QuarantineChanged
+	}
+
+	index, err := m.loadQuarantineIndex()
+	if err != nil {
+		return fail(err, "failed to load quarantine")
+	}
+	for _, rejected := range index.Rejected {
+		if rejected.Type == entry.Type && rejected.Name == entry.Name && rejected.Digest == digest {
+			result.Skipped = append(result.Skipped, sourcePath)
+			return nil
+		}
+	}
+
+	if !opts.DryRun {
+		quarantinePath := m.QuarantinePath(res.Name, res.Type)
+		if err := os.RemoveAll(quarantinePath); err != nil {
+			return fail(err, "failed to clear quarantined version")
+		}
+		if err := os.MkdirAll(filepath.Dir(quarantinePath), 0755); err != nil {
+			return fail(err, "failed to create quarantine directory")
+		}
+		if res.Type == resource.Skill {
+			err = m.copyDir(sourcePath, quarantinePath)
+		} else {
+			err = m.copyFile(sourcePath, quarantinePath)
+		}
+		if err != nil {
+			return fail(err, "failed to quarantine resource")
+		}
+
+		index.Pending = replaceQuarantineEntry(index.Pending, entry)
+		if err := m.saveQuarantineIndex(index); err != nil {
+			return fail(err, "failed to update quarantine")
+		}
+	}
+
+	result.Quarantined = append(result.Quarantined, entry)
+	return nil
+}
+
+// replaceQuarantineEntry adds entry, replacing a pending entry for the same
+// resource.
+func replaceQuarantineEntry(entries []QuarantineEntry, entry QuarantineEntry) []QuarantineEntry {
+	for i := range entries {
+		if entries[i].Type == entry.Type && entries[i].Name == entry.Name {
+			entries[i] = entry
+			return entries
+		}
+	}
+	return append(entries, entry)
+}
+
+// ApproveQuarantined imports the given quarantined resources into the
+// repository and records the approval in their metadata. Resources are
+// imported with the import mode of their source: copies come from the
+// quarantine, symlinks point at the source path, which must still hold the
+// reviewed content. Resources whose import failed stay in quarantine. opts supplies the import
+// policy (Policy, LocalChanges); source fields come from each entry. Callers
+// must hold the repository write lock.
+func (m *Manager) ApproveQuarantined(entries []QuarantineEntry, approval metadata.Review, opts BulkImportOptions) (*BulkImportResult, error) {
+	combined := &BulkImportResult{Added: []string{}, Updated: []string{}, Skipped: []string{}, Failed: []ImportError{}}
+	approved := make(map[string]bool, len(entries))
+
+	for _, entry := range entries {
+		review := approval
+		review.Digest = entry.Digest
+
+		entryOpts := opts
+		entryOpts.SourceName = entry.SourceName
+		entryOpts.SourceID = entry.SourceID
+		entryOpts.SourceURL = entry.SourceURL
+		entryOpts.SourceType = entry.SourceType
+		entryOpts.Ref = entry.Ref
+		entryOpts.ImportMode = "copy"
+		entryOpts.Force = true
+		entryOpts.SkipExisting = false
+		entryOpts.Quarantine = false
+		entryOpts.Secrets = nil
+		entryOpts.Approval = &review
+
+		importPath := m.QuarantinePath(entry.Name, entry.Type)
+		if entry.ImportMode == "symlink" {
+			if files, err := resourcediff.LoadPath(entry.SourcePath); err != nil || resourcediff.Digest(files) != entry.Digest {
+				combined.Failed = append(combined.Failed, ImportError{
+					Path:    entry.SourcePath,
+					Message: fmt.Sprintf("%s changed at its source since it was quarantined; import it again to review the current version", entry.ResourceRef()),
+				})
+				continue
+			}
+			entryOpts.ImportMode = "symlink"
+			importPath = entry.SourcePath
+		}
+		result, err := m.AddBulk([]string{importPath}, entryOpts)
+		if result != nil {
+			MergeBulkResults(combined, result)
+			if len(result.Failed) == 0 && len(result.Added)+len(result.Updated) > 0 {
+				approved[entry.ResourceRef()] = true
+			}
+		}
+		if err != nil {
+			if rmErr := m.removeFromQuarantine(approved, nil, opts.DryRun); rmErr != nil {
+				return combined, rmErr
+			}
+			return combined, err
+		}
+	}
+
+	return combined, m.removeFromQuarantine(approved, nil, opts.DryRun)
+}
+
+// RejectQuarantined removes the given resources from quarantine and
+// remembers the rejected versions.
+func (m *Manager) RejectQuarantined(entries []QuarantineEntry, reviewer, reason string, dryRun bool) error {
+	now := time.Now().UTC().Truncate(time.Second)
+	refs := make(map[string]bool, len(entries))
+	rejected := make([]RejectedVersion, 0, len(entries))
+	for _, entry := range entries {
+		refs[entry.ResourceRef()] = true
+		rejected = append(rejected, RejectedVersion{
+			Name:       entry.Name,
+			Type:       entry.Type,
+			Digest:     entry.Digest,
+			Reviewer:   reviewer,
+			RejectedAt: now,
+			Reason:     reason,
+		})
+	}
+	return m.removeFromQuarantine(refs, rejected, dryRun)
+}
+
+// removeFromQuarantine deletes the content and pending entries of refs and
+// appends rejected versions to the index.
+func (m *Manager) removeFromQuarantine(refs map[string]bool, rejected []RejectedVersion, dryRun bool) error {
+	if dryRun || len(refs) == 0 {
+		return nil
+	}
+	index, err := m.loadQuarantineIndex()
+	if err != nil {
+		return err
+	}
+
+	pending := index.Pending[:0]
+	for _, entry := range index.Pending {
+		if !refs[entry.ResourceRef()] {
+			pending = append(pending, entry)
+			continue
+		}
+		if err := os.RemoveAll(m.QuarantinePath(entry.Name, entry.Type)); err != nil {
+			return fmt.Errorf("failed to remove quarantined %s: %w", entry.ResourceRef(), err)
+		}
+	}
+	index.Pending = pending
+	index.Rejected = append(index.Rejected, rejected...)
+	return m.saveQuarantineIndex(index)
+}
+
+// MergeBulkResults appends the outcome of src to dst, for callers that import
+// in several AddBulk calls but report one result.
+func MergeBulkResults(dst, src *BulkImportResult) {
+	dst.Added = append(dst.Added, src.Added...)
+	dst.Updated = append(dst.Updated, src.Updated...)
+	dst.Skipped = append(dst.Skipped, src.Skipped...)
+	dst.Failed = append(dst.Failed, src.Failed...)
+	dst.CommandCount += src.CommandCount
+	dst.SkillCount += src.SkillCount
+	dst.AgentCount += src.AgentCount
+	dst.PackageCount += src.PackageCount
+	dst.LocalChanges = append(dst.LocalChanges, src.LocalChanges...)
+	dst.Warnings = append(dst.Warnings, src.Warnings...)
+	dst.Quarantined = append(dst.Quarantined, src.Quarantined...)
+}
@@ -0,0 +1,221 @@
+//go:build unit
+
+package repo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/test/testutil"
+)
+
+func setupQuarantineRepo(t *testing.T) (*Manager, string) {
+	t.Helper()
+
+	repoDir := t.TempDir()
+	setupGitRepo(t, repoDir)
+	manager := NewManagerWithPath(repoDir)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	upstream := t.TempDir()
+	for _, dir := range []string{"commands", filepath.Join("skills", "notes")} {
+		if err := os.MkdirAll(filepath.Join(upstream, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	testutil.WriteFile(t, filepath.Join(upstream, "commands", "review.md"), "---\ndescription: Review code\n---\nReview.\n")
+	testutil.WriteFile(t, filepath.Join(upstream, "skills", "notes", "SKILL.md"), localChangesBaseSkill)
+	return manager, upstream
+}
+
+func quarantineOpts() BulkImportOptions {
+	return BulkImportOptions{
+		ImportMode: "copy",
+		Force:      true,
+		SourceName: "upstream",
+		SourceURL:  "https://example.com/upstream.git",
+		SourceType: "git-url",
+		Quarantine: true,
+	}
+}
+
+func TestAddBulk_QuarantineHoldsNewAndChangedResources(t *testing.T) {
+	manager, upstream := setupQuarantineRepo(t)
+	skillPath := filepath.Join(upstream, "skills", "notes")
+	commandPath := filepath.Join(upstream, "commands", "review.md")
+
+	result, err := manager.AddBulk([]string{skillPath, commandPath}, quarantineOpts())
+	if err != nil {
+		t.Fatalf("AddBulk() error = %v", err)
+	}
+	if len(result.Quarantined) != 2 || len(result.Added) != 0 {
+		t.Fatalf("Quarantined = %v, Added = %v; want 2 quarantined, none added", result.Quarantined, result.Added)
+	}
+	if _, err := os.Stat(manager.GetPath("notes", resource.Skill)); !os.IsNotExist(err) {
+		t.Fatalf("quarantined skill must not be in the repository, stat error = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(manager.QuarantinePath("notes", resource.Skill), "SKILL.md")); err != nil {
+		t.Fatalf("quarantined skill content missing: %v", err)
+	}
+
+	entries, err := manager.ListQuarantine()
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("ListQuarantine() = %v, %v", entries, err)
+	}
+	if entries[0].ResourceRef() != "command/review" || entries[0].Change != QuarantineNew || entries[0].SourceName != "upstream" {
+		t.Errorf("entry = %+v", entries[0])
+	}
+
+	// Approve the skill: it is copied like its source and the approval recorded.
+	approval := metadata.Review{Reviewer: "alice", ApprovedAt: time.Now(), Note: "looks fine"}
+	approveResult, err := manager.ApproveQuarantined(entries[1:], approval, BulkImportOptions{})
+	if err != nil || len(approveResult.Added) != 1 {
+		t.Fatalf("ApproveQuarantined() = %+v, %v", approveResult, err)
+	}
+	info, err := os.Lstat(manager.GetPath("notes", resource.Skill))
+	if err != nil || info.Mode()&os.ModeSymlink != 0 {
+		t.Fatalf("approved skill must be a copy, lstat = %v, %v", info, err)
+	}
+	meta, err := manager.GetMetadata("notes", resource.Skill)
+	if err != nil {
+		t.Fatalf("GetMetadata() error = %v", err)
+	}
+	if meta.Review == nil || meta.Review.Reviewer != "alice" || meta.Review.Digest != entries[1].Digest || meta.SourceName != "upstream" {
+		t.Errorf("metadata = %+v, review = %+v", meta, meta.Review)
+	}
+
+	// Reject the command; the same version is not quarantined again.
+	if err := manager.RejectQuarantined(entries[:1], "bob", "too broad", false); err != nil {
+		t.Fatalf("RejectQuarantined() error = %v", err)
+	}
+	if remaining, _ := manager.ListQuarantine(); len(remaining) != 0 {
+		t.Fatalf("quarantine after review = %v, want empty", remaining)
+	}
+	if _, err := os.Stat(manager.QuarantinePath("review", resource.Command)); !os.IsNotExist(err) {
+		t.Errorf("rejected command content must be removed, stat error = %v", err)
+	}
+
+	result, err = manager.AddBulk([]string{skillPath, commandPath}, quarantineOpts())
+	if err != nil {
+		t.Fatalf("AddBulk() second run error = %v", err)
+	}
+	if len(result.Quarantined) != 0 || len(result.Skipped) != 2 {
+		t.Fatalf("second run Quarantined = %v, Skipped = %v; want approved skill unchanged and rejected command skipped", result.Quarantined, result.Skipped)
+	}
+
+	// A changed upstream version is quarantined as a change.
+	testutil.WriteFile(t, filepath.Join(skillPath, "SKILL.md"), strings.Replace(localChangesBaseSkill, "intro", "new intro", 1))
+	result, err = manager.AddBulk([]string{skillPath}, quarantineOpts())
+	if err != nil || len(result.Quarantined) != 1 || result.Quarantined[0].Change != QuarantineChanged {
+		t.Fatalf("changed skill = %+v, %v; want one changed entry", result.Quarantined, err)
+	}
+	if data, _ := os.ReadFile(filepath.Join(manager.GetPath("notes", resource.Skill), "SKILL.md")); strings.Contains(string(data), "new intro") {
+		t.Error("changed version must not reach the repository before approval")
+	}
+}
+
+func TestAddBulk_QuarantineDryRun(t *testing.T) {
+	manager, upstream := setupQuarantineRepo(t)
+	opts := quarantineOpts()
+	opts.DryRun = true
+
+	result, err := manager.AddBulk([]string{filepath.Join(upstream, "skills", "notes")}, opts)
+	if err != nil || len(result.Quarantined) != 1 {
+		t.Fatalf("AddBulk() = %+v, %v", result, err)
+	}
+	if entries, _ := manager.ListQuarantine(); len(entries) != 0 {
+		t.Errorf("dry run wrote quarantine entries: %v", entries)
+	}
+}
+
+func TestApproveQuarantined_KeepsSymlinkMode(t *testing.T) {
+	manager, upstream := setupQuarantineRepo(t)
+	skillPath := filepath.Join(upstream, "skills", "notes")
+	commandPath := filepath.Join(upstream, "commands", "review.md")
+	opts := quarantineOpts()
+	opts.ImportMode = "symlink"
+
+	if _, err := manager.AddBulk([]string{skillPath, commandPath}, opts); err != nil {
+		t.Fatalf("AddBulk() error = %v", err)
+	}
+	entries, err := manager.ListQuarantine()
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("ListQuarantine() = %v, %v", entries, err)
+	}
+	if entries[1].ImportMode != "symlink" || entries[1].SourcePath != skillPath {
+		t.Fatalf("entry = %+v, want symlink mode and the source path", entries[1])
+	}
+
+	// The command changed at its source after review: it stays in quarantine.
+	testutil.WriteFile(t, commandPath, "---\ndescription: Review code\n---\nReview everything.\n")
+	result, err := manager.ApproveQuarantined(entries, metadata.Review{Reviewer: "alice", ApprovedAt: time.Now()}, BulkImportOptions{})
+	if err != nil {
+		t.Fatalf("ApproveQuarantined() error = %v", err)
+	}
+	if len(result.Added) != 1 || len(result.Failed) != 1 || !strings.Contains(result.Failed[0].Message, "changed at its source") {
+		t.Fatalf("ApproveQuarantined() = %+v, want the skill added and the changed command refused", result)
+	}
+
+	target, err := os.Readlink(manager.GetPath("notes", resource.Skill))
+	if err != nil || target != skillPath {
+		t.Fatalf("approved skill = %q, %v; want a symlink to %s", target, err, skillPath)
+	}
+	if remaining, _ := manager.ListQuarantine(); len(remaining) != 1 || remaining[0].ResourceRef() != "command/review" {
+		t.Errorf("quarantine after approval = %v, want only the changed command", remaining)
+	}
+}
+
+func TestAddBulk_QuarantineHoldsPackages(t *testing.T) {
+	manager, _ := setupQuarantineRepo(t)
+	pkgDir := t.TempDir()
+	if err := resource.SavePackage(&resource.Package{Name: "starter", Description: "Starter kit", Resources: []string{"command/review"}}, pkgDir); err != nil {
+		t.Fatal(err)
+	}
+	pkgPath := resource.GetPackagePath("starter", pkgDir)
+
+	result, err := manager.AddBulk([]string{pkgPath}, quarantineOpts())
+	if err != nil || len(result.Quarantined) != 1 || len(result.Added) != 0 {
+		t.Fatalf("AddBulk() = %+v, %v; want the package quarantined", result, err)
+	}
+	if _, err := os.Stat(manager.GetPath("starter", resource.PackageType)); !os.IsNotExist(err) {
+		t.Fatalf("quarantined package must not be in the repository, stat error = %v", err)
+	}
+
+	entries, err := manager.ListQuarantine()
+	if err != nil || len(entries) != 1 || entries[0].ResourceRef() != "package/starter" {
+		t.Fatalf("ListQuarantine() = %v, %v", entries, err)
+	}
+	approveResult, err := manager.ApproveQuarantined(entries, metadata.Review{Reviewer: "alice", ApprovedAt: time.Now()}, BulkImportOptions{})
+	if err != nil || len(approveResult.Added) != 1 {
+		t.Fatalf("ApproveQuarantined() = %+v, %v", approveResult, err)
+	}
+	meta, err := metadata.LoadPackageMetadata("starter", manager.GetRepoPath())
+	if err != nil || meta.Review == nil || meta.Review.Reviewer != "alice" || meta.SourceName != "upstream" {
+		t.Fatalf("package metadata = %+v, %v; want the approval recorded", meta, err)
+	}
+}
+
+func TestMergeBulkResults(t *testing.T) {
+	dst := &BulkImportResult{Added: []string{"a"}, CommandCount: 1}
+	MergeBulkResults(dst, &BulkImportResult{
+		Added:       []string{"b"},
+		Failed:      []ImportError{{Path: "c"}},
+		SkillCount:  1,
+		Warnings:    []ImportError{{Path: "b"}},
+		Quarantined: []QuarantineEntry{{Name: "d", Type: resource.Command}},
+	})
+
+	if len(dst.Added) != 2 || len(dst.Failed) != 1 || len(dst.Warnings) != 1 || dst.CommandCount != 1 || dst.SkillCount != 1 {
+		t.Errorf("unexpected merged result: %+v", dst)
+	}
+	if len(dst.Quarantined) != 1 || dst.Quarantined[0].ResourceRef() != "command/d" {
+		t.Errorf("Quarantined = %+v, want command/d", dst.Quarantined)
+	}
+}
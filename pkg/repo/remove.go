@@ -104,6 +104,8 @@ func (m *Manager) Remove(name string, resourceType resource.ResourceType) error
 		fmt.Fprintf(os.Stderr, "Warning: failed to commit changes: %v\n", err)
 	}
 
+	m.updateSearchIndex()
+
 	return nil
 }
 
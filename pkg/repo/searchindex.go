@@ -0,0 +1,18 @@
+package repo
+
+import (
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/search"
+)
+
+// updateSearchIndex brings .metadata/search-index.json up to date after
+// resources were added, updated or removed. Only changed resources are
+// re-read. Failures are logged and otherwise ignored: 'aimgr search'
+// refreshes the index itself before searching.
+func (m *Manager) updateSearchIndex() {
+	if err := search.Update(m.repoPath); err != nil && m.logger != nil {
+		m.logger.Warn("failed to update search index", "error", err.Error())
+	}
+}
+
+// searchIndexIgnore is the .gitignore entry for the local search index.
+const searchIndexIgnore = "# aimgr search index (rebuilt locally)\n.metadata/" + search.IndexFileName + "\n"
@@ -0,0 +1,35 @@
+//go:build unit
+
+package repo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/search"
+)
+
+func TestSearchIndex_UpdatedOnAddAndRemove(t *testing.T) {
+	manager := newSnapshotTestManager(t)
+	importHistoryTestCommand(t, manager, "deploy", "Deploy to staging")
+
+	results := search.Load(manager.GetRepoPath()).Search(search.Query{Text: "staging"})
+	if len(results) != 1 || results[0].Ref != "command/deploy" {
+		t.Fatalf("search after import = %+v, want command/deploy", results)
+	}
+
+	if err := manager.Remove("deploy", resource.Command); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if results := search.Load(manager.GetRepoPath()).Search(search.Query{Text: "staging"}); len(results) != 0 {
+		t.Fatalf("search after remove = %+v, want none", results)
+	}
+
+	gitignore, err := os.ReadFile(filepath.Join(manager.GetRepoPath(), ".gitignore"))
+	if err != nil || !strings.Contains(string(gitignore), ".metadata/"+search.IndexFileName) {
+		t.Errorf(".gitignore should exclude the search index, got %q (%v)", gitignore, err)
+	}
+}
@@ -0,0 +1,42 @@
+package repo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	pkgerrors "github.com/dynatrace-oss/ai-config-manager/v3/pkg/errors"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/secrets"
+)
+
+// maxListedSecrets is how many findings an import error spells out.
+const maxListedSecrets = 3
+
+// checkSecrets scans a resource before import. Under the block policy a
+// resource with findings is recorded as failed and an error is returned;
+// otherwise the findings are recorded as a warning and the import goes on.
+func checkSecrets(sourcePath string, opts BulkImportOptions, result *BulkImportResult) error {
+	if opts.Secrets == nil {
+		return nil
+	}
+
+	findings, err := opts.Secrets.ScanPath(sourcePath)
+	if err != nil {
+		typedErr := pkgerrors.Resource(err, "failed to scan for secrets")
+		result.Failed = append(result.Failed, ImportError{Path: sourcePath, Message: typedErr.Error()})
+		return typedErr
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("possible secrets: %s (allowlist false positives in %s)",
+		secrets.Summarize(findings, maxListedSecrets), secrets.AllowlistFileName)
+	if opts.SecretsPolicy == config.SecretsBlock {
+		typedErr := pkgerrors.Validation(errors.New(message), "import blocked")
+		result.Failed = append(result.Failed, ImportError{Path: sourcePath, Message: typedErr.Error()})
+		return typedErr
+	}
+	result.Warnings = append(result.Warnings, ImportError{Path: sourcePath, Message: message})
+	return nil
+}
@@ -0,0 +1,69 @@
+//go:build unit
+
+package repo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/secrets"
+)
+
+func TestAddBulk_SecretsPolicy(t *testing.T) {
+	source := t.TempDir()
+	skillDir := filepath.Join(source, "skills", "leaky")
+	if err := os.MkdirAll(skillDir, 0755); err != nil {
+		t.Fatalf("Failed to create skill: %v", err)
+	}
+	content := "---\nname: leaky\ndescription: Leaky skill\n---\n\nexport KEY=" + "AKIA" + "IOSFODNN7EXAMPLE\n"
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write skill: %v", err)
+	}
+
+	scanner, err := secrets.NewScanner(source, secrets.Options{})
+	if err != nil {
+		t.Fatalf("NewScanner() error = %v", err)
+	}
+
+	tests := []struct {
+		policy       string
+		wantImported int
+		wantFailed   int
+		wantWarnings int
+	}{
+		{config.SecretsWarn, 1, 0, 1},
+		{config.SecretsBlock, 0, 1, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			repoDir := t.TempDir()
+			setupGitRepo(t, repoDir)
+			manager := NewManagerWithPath(repoDir)
+			if err := manager.Init(); err != nil {
+				t.Fatalf("Init() error = %v", err)
+			}
+
+			result, err := manager.AddBulk([]string{skillDir}, BulkImportOptions{
+				ImportMode:    "copy",
+				Secrets:       scanner,
+				SecretsPolicy: tt.policy,
+			})
+			if tt.policy == config.SecretsWarn && err != nil {
+				t.Fatalf("AddBulk() error = %v", err)
+			}
+			if len(result.Added) != tt.wantImported || len(result.Failed) != tt.wantFailed || len(result.Warnings) != tt.wantWarnings {
+				t.Fatalf("AddBulk() added=%d failed=%v warnings=%v", len(result.Added), result.Failed, result.Warnings)
+			}
+			messages := append(result.Failed, result.Warnings...)
+			if !strings.Contains(messages[0].Message, "skills/leaky/SKILL.md:6: aws-access-key-id") {
+				t.Errorf("message = %q, want the finding location", messages[0].Message)
+			}
+			if strings.Contains(messages[0].Message, "IOSFODNN7EXAMPLE") {
+				t.Errorf("message leaks the secret: %q", messages[0].Message)
+			}
+		})
+	}
+}
@@ -0,0 +1,399 @@
+package repo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// snapshotTagPrefix namespaces snapshot tags in the repository's git history.
+	snapshotTagPrefix = "aimgr-snapshot/"
+	// snapshotsDir holds directory snapshots for repositories without git.
+	snapshotsDir = ".snapshots"
+	// snapshotInfoFile describes a directory snapshot.
+	snapshotInfoFile = "snapshot.json"
+)
+
+// snapshotPaths are the repository paths captured by a snapshot: resource
+// content, metadata, the source manifest and tool-specific modifications.
+var snapshotPaths = []string{"commands", "skills", "agents", "packages", ".metadata", "ai.repo.yaml", ".modifications"}
+
+var snapshotNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Snapshot is a named, restorable state of the repository.
+type Snapshot struct {
+	Name string `json:"name" yaml:"name"`
+	// Commit is the tagged commit for git repositories; empty for directory
+	// snapshots.
+	Commit string    `json:"commit,omitempty" yaml:"commit,omitempty"`
+	Time   time.Time `json:"time" yaml:"time"`
+}
+
+// SnapshotRestoreResult describes a snapshot restore (or a dry-run preview).
+type SnapshotRestoreResult struct {
+	Snapshot  Snapshot `json:"snapshot" yaml:"snapshot"`
+	Resources []string `json:"resources,omitempty" yaml:"resources,omitempty"`
+	// SourcesChanged reports whether ai.repo.yaml differs from the snapshot.
+	SourcesChanged bool `json:"sources_changed" yaml:"sources_changed"`
+	Restored       bool `json:"restored" yaml:"restored"`
+}
+
+// ValidateSnapshotName checks that name can be used as a snapshot name.
+func ValidateSnapshotName(name string) error {
+	if !snapshotNamePattern.MatchString(name) || strings.Contains(name, "..") || strings.HasSuffix(name, ".lock") {
+		return fmt.Errorf("invalid snapshot name %q: use letters, digits, '.', '_' and '-', starting with a letter or digit", name)
+	}
+	return nil
+}
+
+// SaveSnapshot records the current repository state under name. In git
+// repositories pending changes to the snapshot paths are committed and the
+// commit is tagged; otherwise the paths are copied under .snapshots/. Callers
+// must hold the repository write lock.
+func (m *Manager) SaveSnapshot(name string) (*Snapshot, error) {
+	if err := ValidateSnapshotName(name); err != nil {
+		return nil, err
+	}
+	if _, err := m.GetSnapshot(name); err == nil {
+		return nil, fmt.Errorf("snapshot %q already exists", name)
+	}
+
+	if !m.isGitRepo() {
+		return m.saveDirectorySnapshot(name)
+	}
+
+	if err := m.CommitChangesForPaths(fmt.Sprintf("%ssnapshot %s", historyCommitPrefix, name), snapshotPaths); err != nil {
+		return nil, err
+	}
+	if _, err := m.runGit("tag", snapshotTagPrefix+name, "HEAD"); err != nil {
+		return nil, fmt.Errorf("failed to tag snapshot %q: %w", name, err)
+	}
+
+	return m.GetSnapshot(name)
+}
+
+// ListSnapshots returns all snapshots, newest first.
+func (m *Manager) ListSnapshots() ([]Snapshot, error) {
+	var snapshots []Snapshot
+	if m.isGitRepo() {
+		output, err := m.runGit("for-each-ref", "--format=%(refname)%1f%(objectname:short)%1f%(creatordate:unix)", "refs/tags/"+snapshotTagPrefix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list snapshots: %w", err)
+		}
+		for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+			if snapshot, ok := parseSnapshotRef(line); ok {
+				snapshots = append(snapshots, snapshot)
+			}
+		}
+	} else {
+		entries, err := os.ReadDir(filepath.Join(m.repoPath, snapshotsDir))
+		if err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to list snapshots: %w", err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			snapshot, err := m.loadDirectorySnapshot(entry.Name())
+			if err != nil {
+				return nil, err
+			}
+			snapshots = append(snapshots, *snapshot)
+		}
+	}
+
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		if !snapshots[i].Time.Equal(snapshots[j].Time) {
+			return snapshots[i].Time.After(snapshots[j].Time)
+		}
+		return snapshots[i].Name < snapshots[j].Name
+	})
+	return snapshots, nil
+}
+
+// GetSnapshot returns the snapshot with the given name.
+func (m *Manager) GetSnapshot(name string) (*Snapshot, error) {
+	if err := ValidateSnapshotName(name); err != nil {
+		return nil, err
+	}
+
+	if !m.isGitRepo() {
+		return m.loadDirectorySnapshot(name)
+	}
+
+	output, err := m.runGit("for-each-ref", "--format=%(refname)%1f%(objectname:short)%1f%(creatordate:unix)", "refs/tags/"+snapshotTagPrefix+name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot %q: %w", name, err)
+	}
+	snapshot, ok := parseSnapshotRef(strings.TrimSpace(output))
+	if !ok {
+		return nil, fmt.Errorf("snapshot %q not found", name)
+	}
+	return &snapshot, nil
+}
+
+// RestoreSnapshot restores the snapshot paths to their state in the named
+// snapshot. In git repositories the restore is recorded as a new commit. With
+// dryRun, it only reports what would change. Callers must hold the repository
+// write lock.
+func (m *Manager) RestoreSnapshot(name string, dryRun bool) (*SnapshotRestoreResult, error) {
+	snapshot, err := m.GetSnapshot(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if !m.isGitRepo() {
+		return m.restoreDirectorySnapshot(snapshot, dryRun)
+	}
+
+	ref := "refs/tags/" + snapshotTagPrefix + name
+	var paths []string
+	for _, path := range snapshotPaths {
+		if m.pathInCommit(ref, path) || m.pathTracked(path) {
+			paths = append(paths, path)
+		}
+	}
+
+	result := &SnapshotRestoreResult{Snapshot: *snapshot}
+	if len(paths) == 0 {
+		return result, nil
+	}
+
+	diffArgs := append([]string{"diff", "--name-only", ref, "--"}, paths...)
+	changed, err := m.runGit(diffArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare with snapshot %q: %w", name, err)
+	}
+	changedPaths := parseGitNameOnlyOutput([]byte(changed))
+	result.Resources = resourceRefsForPaths(changedPaths)
+	result.SourcesChanged = containsPath(changedPaths, "ai.repo.yaml")
+	if dryRun || len(changedPaths) == 0 {
+		return result, nil
+	}
+
+	restoreArgs := append([]string{"restore", "--source=" + ref, "--staged", "--worktree", "--"}, paths...)
+	if _, err := m.runGit(restoreArgs...); err != nil {
+		return nil, fmt.Errorf("failed to restore snapshot %q: %w", name, err)
+	}
+	if err := m.CommitChangesForPaths(fmt.Sprintf("%srestore snapshot %s", historyCommitPrefix, name), paths); err != nil {
+		return nil, err
+	}
+	result.Restored = true
+
+	return result, nil
+}
+
+func parseSnapshotRef(line string) (Snapshot, bool) {
+	fields := strings.Split(line, "\x1f")
+	if len(fields) != 3 || !strings.HasPrefix(fields[0], "refs/tags/"+snapshotTagPrefix) {
+		return Snapshot{}, false
+	}
+	seconds, err := strconv.ParseInt(fields[2], 10, 64)
+	if err != nil {
+		return Snapshot{}, false
+	}
+	return Snapshot{
+		Name:   strings.TrimPrefix(fields[0], "refs/tags/"+snapshotTagPrefix),
+		Commit: fields[1],
+		Time:   time.Unix(seconds, 0),
+	}, true
+}
+
+func containsPath(paths []string, want string) bool {
+	for _, path := range paths {
+		if path == want {
+			return true
+		}
+	}
+	return false
+}
+
+// saveDirectorySnapshot copies the snapshot paths to .snapshots/<name>/,
+// keeping symlinked resources as symlinks.
+func (m *Manager) saveDirectorySnapshot(name string) (*Snapshot, error) {
+	dir := filepath.Join(m.repoPath, snapshotsDir, name)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	for _, path := range snapshotPaths {
+		if err := copyTree(filepath.Join(m.repoPath, path), filepath.Join(dir, path)); err != nil {
+			_ = os.RemoveAll(dir)
+			return nil, fmt.Errorf("failed to snapshot %s: %w", path, err)
+		}
+	}
+
+	snapshot := &Snapshot{Name: name, Time: time.Now().Truncate(time.Second)}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode snapshot info: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, snapshotInfoFile), data, 0644); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, fmt.Errorf("failed to write snapshot info: %w", err)
+	}
+
+	return snapshot, nil
+}
+
+func (m *Manager) loadDirectorySnapshot(name string) (*Snapshot, error) {
+	data, err := os.ReadFile(filepath.Join(m.repoPath, snapshotsDir, name, snapshotInfoFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("snapshot %q not found", name)
+		}
+		return nil, fmt.Errorf("failed to read snapshot %q: %w", name, err)
+	}
+
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("failed to parse snapshot %q: %w", name, err)
+	}
+	snapshot.Name = name
+	return &snapshot, nil
+}
+
+func (m *Manager) restoreDirectorySnapshot(snapshot *Snapshot, dryRun bool) (*SnapshotRestoreResult, error) {
+	dir := filepath.Join(m.repoPath, snapshotsDir, snapshot.Name)
+	result := &SnapshotRestoreResult{Snapshot: *snapshot}
+
+	var changedPaths []string
+	for _, path := range snapshotPaths {
+		current, err := treeEntries(m.repoPath, path)
+		if err != nil {
+			return nil, err
+		}
+		saved, err := treeEntries(dir, path)
+		if err != nil {
+			return nil, err
+		}
+		changedPaths = append(changedPaths, changedEntries(current, saved)...)
+	}
+	result.Resources = resourceRefsForPaths(changedPaths)
+	result.SourcesChanged = containsPath(changedPaths, "ai.repo.yaml")
+	if dryRun || len(changedPaths) == 0 {
+		return result, nil
+	}
+
+	for _, path := range snapshotPaths {
+		target := filepath.Join(m.repoPath, path)
+		if err := os.RemoveAll(target); err != nil {
+			return nil, fmt.Errorf("failed to remove %s: %w", path, err)
+		}
+		if err := copyTree(filepath.Join(dir, path), target); err != nil {
+			return nil, fmt.Errorf("failed to restore %s: %w", path, err)
+		}
+	}
+	result.Restored = true
+
+	return result, nil
+}
+
+// copyTree copies src to dst, recreating symlinks instead of following them
+// and keeping file modes. A missing src is not an error.
+func copyTree(src, dst string) error {
+	if _, err := os.Lstat(src); os.IsNotExist(err) {
+		return nil
+	}
+
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		info, err := os.Lstat(path)
+		if err != nil {
+			return err
+		}
+		switch {
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+		default:
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(target, data, info.Mode().Perm())
+		}
+	})
+}
+
+// treeEntries maps the files and symlinks under root/path to a content key,
+// keyed by slash-separated paths relative to root.
+func treeEntries(root, path string) (map[string]string, error) {
+	entries := make(map[string]string)
+	start := filepath.Join(root, path)
+	if _, err := os.Lstat(start); os.IsNotExist(err) {
+		return entries, nil
+	}
+
+	err := filepath.WalkDir(start, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+
+		if d.Type()&os.ModeSymlink != 0 {
+			link, err := os.Readlink(p)
+			if err != nil {
+				return err
+			}
+			entries[filepath.ToSlash(rel)] = "link:" + link
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		sum := sha256.Sum256(data)
+		entries[filepath.ToSlash(rel)] = hex.EncodeToString(sum[:])
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", start, err)
+	}
+	return entries, nil
+}
+
+func changedEntries(a, b map[string]string) []string {
+	var changed []string
+	for path, key := range a {
+		if b[path] != key {
+			changed = append(changed, path)
+		}
+	}
+	for path := range b {
+		if _, ok := a[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
@@ -0,0 +1,138 @@
+//go:build unit
+
+package repo
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+)
+
+func newSnapshotTestManager(t *testing.T) *Manager {
+	t.Helper()
+
+	tmpDir := t.TempDir()
+	setupGitRepo(t, tmpDir)
+	manager := NewManagerWithPath(tmpDir)
+	if err := manager.Init(); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	return manager
+}
+
+// writeSnapshotTestCommand changes a command without going through an import,
+// which would re-initialize git in a repository without it.
+func writeSnapshotTestCommand(t *testing.T, manager *Manager, name, description string) {
+	t.Helper()
+	content := "---\ndescription: " + description + "\n---\n\n# " + name + "\n"
+	if err := os.WriteFile(manager.GetPath(name, resource.Command), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write command: %v", err)
+	}
+}
+
+func TestSnapshot_SaveListRestore(t *testing.T) {
+	for _, tt := range []struct {
+		name string
+		git  bool
+	}{
+		{name: "git", git: true},
+		{name: "directory", git: false},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			manager := newSnapshotTestManager(t)
+			importHistoryTestCommand(t, manager, "alpha", "first")
+			if !tt.git {
+				if err := os.RemoveAll(filepath.Join(manager.GetRepoPath(), ".git")); err != nil {
+					t.Fatalf("Failed to remove .git: %v", err)
+				}
+			}
+
+			snapshot, err := manager.SaveSnapshot("before")
+			if err != nil {
+				t.Fatalf("SaveSnapshot() error = %v", err)
+			}
+			if tt.git != (snapshot.Commit != "") {
+				t.Errorf("snapshot commit = %q for git=%v", snapshot.Commit, tt.git)
+			}
+			if _, err := manager.SaveSnapshot("before"); err == nil {
+				t.Error("expected an error when saving an existing snapshot name")
+			}
+
+			if tt.git {
+				importHistoryTestCommand(t, manager, "alpha", "changed")
+				importHistoryTestCommand(t, manager, "beta", "new")
+			} else {
+				writeSnapshotTestCommand(t, manager, "alpha", "changed")
+				writeSnapshotTestCommand(t, manager, "beta", "new")
+			}
+			manifestPath := filepath.Join(manager.GetRepoPath(), "ai.repo.yaml")
+			if err := os.WriteFile(manifestPath, []byte("version: 1\nsources: []\n# edited\n"), 0644); err != nil {
+				t.Fatalf("Failed to edit ai.repo.yaml: %v", err)
+			}
+
+			snapshots, err := manager.ListSnapshots()
+			if err != nil {
+				t.Fatalf("ListSnapshots() error = %v", err)
+			}
+			if len(snapshots) != 1 || snapshots[0].Name != "before" {
+				t.Fatalf("ListSnapshots() = %+v, want [before]", snapshots)
+			}
+
+			preview, err := manager.RestoreSnapshot("before", true)
+			if err != nil {
+				t.Fatalf("RestoreSnapshot(dry-run) error = %v", err)
+			}
+			if got := strings.Join(preview.Resources, ","); got != "command/alpha,command/beta" || !preview.SourcesChanged || preview.Restored {
+				t.Fatalf("dry-run result = %+v", preview)
+			}
+			if _, err := os.Stat(manager.GetPath("beta", resource.Command)); err != nil {
+				t.Fatalf("dry-run must not remove command/beta: %v", err)
+			}
+
+			result, err := manager.RestoreSnapshot("before", false)
+			if err != nil {
+				t.Fatalf("RestoreSnapshot() error = %v", err)
+			}
+			if !result.Restored {
+				t.Fatalf("expected the snapshot to be restored, got %+v", result)
+			}
+			if _, err := os.Stat(manager.GetPath("beta", resource.Command)); !os.IsNotExist(err) {
+				t.Errorf("command/beta should be removed by the restore, stat error = %v", err)
+			}
+			data, err := os.ReadFile(manager.GetPath("alpha", resource.Command))
+			if err != nil || !strings.Contains(string(data), "description: first") {
+				t.Errorf("command/alpha not restored: %q, %v", data, err)
+			}
+			if data, _ := os.ReadFile(manifestPath); strings.Contains(string(data), "# edited") {
+				t.Error("ai.repo.yaml not restored")
+			}
+			if tt.git && getLastCommitMessage(t, manager.GetRepoPath()) != "aimgr: restore snapshot before" {
+				t.Errorf("unexpected last commit %q", getLastCommitMessage(t, manager.GetRepoPath()))
+			}
+
+			again, err := manager.RestoreSnapshot("before", false)
+			if err != nil {
+				t.Fatalf("second RestoreSnapshot() error = %v", err)
+			}
+			if again.Restored || len(again.Resources) != 0 {
+				t.Errorf("second restore should be a no-op, got %+v", again)
+			}
+		})
+	}
+}
+
+func TestSnapshot_Errors(t *testing.T) {
+	manager := newSnapshotTestManager(t)
+
+	for _, name := range []string{"", "a/b", "-x", "a..b", "x.lock"} {
+		if _, err := manager.SaveSnapshot(name); err == nil {
+			t.Errorf("SaveSnapshot(%q) should fail", name)
+		}
+	}
+	if _, err := manager.RestoreSnapshot("missing", true); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("RestoreSnapshot(missing) error = %v, want not found", err)
+	}
+}
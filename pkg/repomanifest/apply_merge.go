@@ -2,14 +2,15 @@ package repomanifest
 
 import "fmt"
 
-// IncludeMergeMode controls how include filters are handled when an incoming
-// source matches an existing source by name and canonical location.
+// IncludeMergeMode controls how include and exclude filters are handled when
+// an incoming source matches an existing source by name and canonical location.
 type IncludeMergeMode string
 
 const (
-	// IncludeMergeReplace replaces existing include filters with incoming values.
+	// IncludeMergeReplace replaces existing include and exclude filters with
+	// incoming values.
 	IncludeMergeReplace IncludeMergeMode = "replace"
-	// IncludeMergePreserve keeps existing include filters unchanged.
+	// IncludeMergePreserve keeps existing include and exclude filters unchanged.
 	IncludeMergePreserve IncludeMergeMode = "preserve"
 )
 
@@ -175,70 +176,106 @@ func MergeForApply(current, incoming *Manifest, opts ApplyMergeOptions) (*Manife
 			continue
 		}
 
-		refUpdated := false
-		if existingByName.Ref != in.Ref {
-			existingByName.Ref = in.Ref
-			refUpdated = true
+		priorityUpdated := existingByName.Priority != in.Priority
+		existingByName.Priority = in.Priority
+		verifyUpdated := !equalVerifyConfig(existingByName.Verify, in.Verify)
+		existingByName.Verify = cloneVerifyConfig(in.Verify)
+		reviewUpdated := existingByName.Review != in.Review
+		existingByName.Review = in.Review
+
+		change := mergeExistingSource(existingByName, in, mode)
+		if priorityUpdated {
+			appendApplyChange(&change, fmt.Sprintf("updated source priority to %d", in.Priority))
+		}
+		if verifyUpdated {
+			appendApplyChange(&change, "updated signature verification")
 		}
+		if reviewUpdated {
+			appendApplyChange(&change, fmt.Sprintf("updated source review to %t", in.Review))
+		}
+		report.Changes = append(report.Changes, change)
+	}
 
-		if equalStringSlices(existingByName.Include, in.Include) {
-			if refUpdated {
-				report.Changes = append(report.Changes, ApplyChange{
-					Name:    in.Name,
-					Action:  ApplyActionUpdate,
-					Message: "updated source ref",
-				})
-				continue
-			}
+	if err := merged.Validate(); err != nil {
+		return nil, nil, fmt.Errorf("merged manifest invalid: %w", err)
+	}
 
-			report.Changes = append(report.Changes, ApplyChange{
-				Name:    in.Name,
-				Action:  ApplyActionNoOp,
-				Message: "identical source already configured",
-			})
-			continue
-		}
+	return merged, report, nil
+}
 
-		if mode == IncludeMergePreserve {
-			if refUpdated {
-				report.Changes = append(report.Changes, ApplyChange{
-					Name:    in.Name,
-					Action:  ApplyActionUpdate,
-					Message: "updated source ref; kept existing include filters (preserve mode)",
-				})
-				continue
-			}
+// mergeExistingSource applies ref and filter (include/exclude) updates from an
+// incoming source to an existing source with the same name and canonical location.
+func mergeExistingSource(existing, in *Source, mode IncludeMergeMode) ApplyChange {
+	refUpdated := false
+	if existing.Ref != in.Ref {
+		existing.Ref = in.Ref
+		refUpdated = true
+	}
 
-			report.Changes = append(report.Changes, ApplyChange{
+	includeChanged := !equalStringSlices(existing.Include, in.Include)
+	excludeChanged := !equalStringSlices(existing.Exclude, in.Exclude)
+
+	if !includeChanged && !excludeChanged {
+		if refUpdated {
+			return ApplyChange{
 				Name:    in.Name,
-				Action:  ApplyActionNoOp,
-				Message: "kept existing include filters (preserve mode)",
-			})
-			continue
+				Action:  ApplyActionUpdate,
+				Message: "updated source ref",
+			}
 		}
 
-		existingByName.Include = copyStringSlice(in.Include)
+		return ApplyChange{
+			Name:    in.Name,
+			Action:  ApplyActionNoOp,
+			Message: "identical source already configured",
+		}
+	}
+
+	filters := describeChangedFilters(includeChanged, excludeChanged)
+
+	if mode == IncludeMergePreserve {
 		if refUpdated {
-			report.Changes = append(report.Changes, ApplyChange{
+			return ApplyChange{
 				Name:    in.Name,
 				Action:  ApplyActionUpdate,
-				Message: "updated source ref and replaced include filters",
-			})
-			continue
+				Message: fmt.Sprintf("updated source ref; kept existing %s (preserve mode)", filters),
+			}
 		}
 
-		report.Changes = append(report.Changes, ApplyChange{
+		return ApplyChange{
+			Name:    in.Name,
+			Action:  ApplyActionNoOp,
+			Message: fmt.Sprintf("kept existing %s (preserve mode)", filters),
+		}
+	}
+
+	existing.Include = copyStringSlice(in.Include)
+	existing.Exclude = copyStringSlice(in.Exclude)
+	if refUpdated {
+		return ApplyChange{
 			Name:    in.Name,
 			Action:  ApplyActionUpdate,
-			Message: "replaced include filters",
-		})
+			Message: fmt.Sprintf("updated source ref and replaced %s", filters),
+		}
 	}
 
-	if err := merged.Validate(); err != nil {
-		return nil, nil, fmt.Errorf("merged manifest invalid: %w", err)
+	return ApplyChange{
+		Name:    in.Name,
+		Action:  ApplyActionUpdate,
+		Message: fmt.Sprintf("replaced %s", filters),
 	}
+}
 
-	return merged, report, nil
+// describeChangedFilters names the filter lists that differ, for merge messages.
+func describeChangedFilters(includeChanged, excludeChanged bool) string {
+	switch {
+	case includeChanged && excludeChanged:
+		return "include and exclude filters"
+	case excludeChanged:
+		return "exclude filters"
+	default:
+		return "include filters"
+	}
 }
 
 func canonicalSourceIdentity(source *Source) string {
@@ -296,19 +333,49 @@ func cloneManifest(m *Manifest) *Manifest {
 	return cloned
 }
 
+// appendApplyChange adds an update message to a change, turning a no-op into
+// an update.
+func appendApplyChange(change *ApplyChange, message string) {
+	if change.Action == ApplyActionNoOp {
+		change.Action = ApplyActionUpdate
+		change.Message = message
+		return
+	}
+	change.Message += "; " + message
+}
+
+func cloneVerifyConfig(v *VerifyConfig) *VerifyConfig {
+	if v == nil {
+		return nil
+	}
+	clone := *v
+	return &clone
+}
+
+func equalVerifyConfig(a, b *VerifyConfig) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
 func cloneSource(s *Source) *Source {
 	if s == nil {
 		return nil
 	}
 
 	return &Source{
-		ID:      s.ID,
-		Name:    s.Name,
-		Path:    s.Path,
-		URL:     s.URL,
-		Ref:     s.Ref,
-		Subpath: s.Subpath,
-		Include: copyStringSlice(s.Include),
+		ID:       s.ID,
+		Name:     s.Name,
+		Path:     s.Path,
+		URL:      s.URL,
+		Ref:      s.Ref,
+		Subpath:  s.Subpath,
+		Include:  copyStringSlice(s.Include),
+		Exclude:  copyStringSlice(s.Exclude),
+		Priority: s.Priority,
+		Verify:   cloneVerifyConfig(s.Verify),
+		Review:   s.Review,
 
 		OverrideOriginalURL:     s.OverrideOriginalURL,
 		OverrideOriginalRef:     s.OverrideOriginalRef,
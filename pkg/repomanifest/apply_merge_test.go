@@ -327,3 +327,115 @@ func TestMergeForApply_OverrideSourceIncomingMatches_NoOp(t *testing.T) {
 		t.Fatalf("expected overridden local source to remain unchanged, got %+v", merged.Sources)
 	}
 }
+
+func TestMergeForApply_UpdatesPriority(t *testing.T) {
+	current := &Manifest{Version: 1, Sources: []*Source{{
+		Name: "platform",
+		URL:  "https://github.com/example/platform",
+	}}}
+	incoming := &Manifest{Version: 1, Sources: []*Source{{
+		Name:     "platform",
+		URL:      "https://github.com/example/platform",
+		Priority: 10,
+	}}}
+
+	merged, report, err := MergeForApply(current, incoming, ApplyMergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeForApply() error = %v", err)
+	}
+	if got := merged.Sources[0].Priority; got != 10 {
+		t.Fatalf("expected priority 10, got %d", got)
+	}
+	if report.Updated() != 1 || report.NoOp() != 0 {
+		t.Fatalf("unexpected report counts: update=%d noop=%d", report.Updated(), report.NoOp())
+	}
+	if !strings.Contains(report.Changes[0].Message, "updated source priority to 10") {
+		t.Fatalf("expected priority update message, got %q", report.Changes[0].Message)
+	}
+
+	again, reportAgain, err := MergeForApply(merged, incoming, ApplyMergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeForApply() second run error = %v", err)
+	}
+	if again.Sources[0].Priority != 10 || reportAgain.NoOp() != 1 {
+		t.Fatalf("re-apply should be a no-op keeping priority, got priority=%d noop=%d", again.Sources[0].Priority, reportAgain.NoOp())
+	}
+}
+
+func TestMergeForApply_UpdatesReview(t *testing.T) {
+	current := &Manifest{Version: 1, Sources: []*Source{{
+		Name: "third-party",
+		URL:  "https://github.com/example/third-party",
+	}}}
+	incoming := &Manifest{Version: 1, Sources: []*Source{{
+		Name:   "third-party",
+		URL:    "https://github.com/example/third-party",
+		Review: true,
+	}}}
+
+	merged, report, err := MergeForApply(current, incoming, ApplyMergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeForApply() error = %v", err)
+	}
+	if !merged.Sources[0].Review {
+		t.Fatal("expected review to be enabled")
+	}
+	if report.Updated() != 1 || !strings.Contains(report.Changes[0].Message, "updated source review to true") {
+		t.Fatalf("unexpected report: update=%d changes=%+v", report.Updated(), report.Changes)
+	}
+
+	again, reportAgain, err := MergeForApply(merged, incoming, ApplyMergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeForApply() second run error = %v", err)
+	}
+	if !again.Sources[0].Review || reportAgain.NoOp() != 1 {
+		t.Fatalf("re-apply should be a no-op keeping review, got review=%t noop=%d", again.Sources[0].Review, reportAgain.NoOp())
+	}
+}
+
+func TestMergeForApply_ExcludeReplaceAndPreserve(t *testing.T) {
+	current := &Manifest{Version: 1, Sources: []*Source{{
+		Name:    "team-tools",
+		URL:     "https://github.com/example/tools",
+		Include: []string{"skill/*"},
+	}}}
+	incoming := &Manifest{Version: 1, Sources: []*Source{{
+		Name:    "team-tools",
+		URL:     "https://github.com/example/tools",
+		Include: []string{"skill/*"},
+		Exclude: []string{"skill/experimental-*"},
+	}}}
+
+	mergedReplace, reportReplace, err := MergeForApply(current, incoming, ApplyMergeOptions{IncludeMode: IncludeMergeReplace})
+	if err != nil {
+		t.Fatalf("replace merge error = %v", err)
+	}
+	if reportReplace.Updated() != 1 {
+		t.Fatalf("expected 1 update in replace mode, got %d", reportReplace.Updated())
+	}
+	if got := strings.Join(mergedReplace.Sources[0].Exclude, ","); got != "skill/experimental-*" {
+		t.Fatalf("replace mode exclude mismatch: %s", got)
+	}
+	if got := reportReplace.Changes[0].Message; got != "replaced exclude filters" {
+		t.Fatalf("unexpected replace message: %q", got)
+	}
+
+	mergedPreserve, reportPreserve, err := MergeForApply(current, incoming, ApplyMergeOptions{IncludeMode: IncludeMergePreserve})
+	if err != nil {
+		t.Fatalf("preserve merge error = %v", err)
+	}
+	if reportPreserve.NoOp() != 1 {
+		t.Fatalf("expected noop in preserve mode, got noop=%d update=%d", reportPreserve.NoOp(), reportPreserve.Updated())
+	}
+	if len(mergedPreserve.Sources[0].Exclude) != 0 {
+		t.Fatalf("preserve mode should keep existing exclude, got %v", mergedPreserve.Sources[0].Exclude)
+	}
+
+	again, reportAgain, err := MergeForApply(mergedReplace, incoming, ApplyMergeOptions{})
+	if err != nil {
+		t.Fatalf("MergeForApply() second run error = %v", err)
+	}
+	if reportAgain.NoOp() != 1 || len(again.Sources[0].Exclude) != 1 {
+		t.Fatalf("re-apply should be a no-op keeping exclude, got noop=%d exclude=%v", reportAgain.NoOp(), again.Sources[0].Exclude)
+	}
+}
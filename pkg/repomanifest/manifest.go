@@ -50,6 +50,19 @@ type Source struct {
 	// Missing values default to "auto" for backward compatibility.
 	Discovery string   `yaml:"discovery,omitempty"`
 	Include   []string `yaml:"include,omitempty"`
+	// Exclude drops matching resources after Include has been applied, using
+	// the same pattern syntax.
+	Exclude []string `yaml:"exclude,omitempty"`
+	// Priority decides which source wins when several sources provide the same
+	// resource. Higher values win; the losing sources are shadowed for that
+	// resource. Missing values default to 0.
+	Priority int `yaml:"priority,omitempty"`
+	// Verify requires the checked-out commit of a remote source to carry a
+	// signature by a trusted signer before its resources are imported.
+	Verify *VerifyConfig `yaml:"verify,omitempty"`
+	// Review quarantines new and changed resources from this source instead of
+	// importing them directly; they are promoted with "aimgr repo review".
+	Review bool `yaml:"review,omitempty"`
 
 	// Override breadcrumbs are runtime-only on Source and persisted locally in
 	// .metadata/sources.json (not in shareable ai.repo.yaml output).
@@ -58,6 +71,28 @@ type Source struct {
 	OverrideOriginalSubpath string `yaml:"-"`
 }
 
+// VerifyConfig configures commit signature verification for a source.
+type VerifyConfig struct {
+	// Signers is an SSH allowed_signers file or a GPG keyring of trusted
+	// public keys. Relative paths are resolved against the repository.
+	Signers string `yaml:"signers"`
+}
+
+// SignersPath returns the signers file as an absolute path, expanding ~ and
+// resolving relative paths against repoPath.
+func (v *VerifyConfig) SignersPath(repoPath string) string {
+	path := v.Signers
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, path[2:])
+		}
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(repoPath, path)
+	}
+	return filepath.Clean(path)
+}
+
 // MarshalYAML writes shareable source config to ai.repo.yaml.
 // Local-only runtime state (ID) is intentionally omitted.
 func (s *Source) MarshalYAML() (interface{}, error) {
@@ -69,6 +104,11 @@ func (s *Source) MarshalYAML() (interface{}, error) {
 		Subpath   string   `yaml:"subpath,omitempty"`
 		Discovery string   `yaml:"discovery,omitempty"`
 		Include   []string `yaml:"include,omitempty"`
+		Exclude   []string `yaml:"exclude,omitempty"`
+		Priority  int      `yaml:"priority,omitempty"`
+
+		Verify *VerifyConfig `yaml:"verify,omitempty"`
+		Review bool          `yaml:"review,omitempty"`
 	}
 
 	if s == nil {
@@ -83,6 +123,10 @@ func (s *Source) MarshalYAML() (interface{}, error) {
 		Subpath:   s.Subpath,
 		Discovery: normalizeDiscoveryMode(s.Discovery),
 		Include:   s.Include,
+		Exclude:   s.Exclude,
+		Priority:  s.Priority,
+		Verify:    s.Verify,
+		Review:    s.Review,
 	}, nil
 }
 
@@ -471,6 +515,17 @@ func validateSource(source *Source) error {
 		}
 	}
 
+	// Signatures are checked on git checkouts; a source temporarily overridden
+	// to a local path keeps its setting for when it is restored.
+	if source.Verify != nil {
+		if strings.TrimSpace(source.Verify.Signers) == "" {
+			return fmt.Errorf("verify.signers cannot be empty")
+		}
+		if source.URL == "" && !hasOverrideBreadcrumbs {
+			return fmt.Errorf("verify requires a url source")
+		}
+	}
+
 	// Validate include patterns
 	for _, entry := range source.Include {
 		if _, err := pattern.NewMatcher(entry); err != nil {
@@ -478,6 +533,13 @@ func validateSource(source *Source) error {
 		}
 	}
 
+	// Validate exclude patterns
+	for _, entry := range source.Exclude {
+		if _, err := pattern.NewMatcher(entry); err != nil {
+			return fmt.Errorf("invalid exclude pattern %q: %w", entry, err)
+		}
+	}
+
 	return nil
 }
 
@@ -1134,6 +1134,33 @@ func TestValidateSource(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "git source with signers",
+			source: &Source{
+				Name:   "test",
+				URL:    "https://github.com/user/repo",
+				Verify: &VerifyConfig{Signers: "~/.config/aimgr/allowed_signers"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty signers",
+			source: &Source{
+				Name:   "test",
+				URL:    "https://github.com/user/repo",
+				Verify: &VerifyConfig{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "verify on local source",
+			source: &Source{
+				Name:   "test",
+				Path:   "/test",
+				Verify: &VerifyConfig{Signers: "allowed_signers"},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1614,6 +1641,47 @@ func TestValidateSource_Include_ErrorContainsPattern(t *testing.T) {
 	}
 }
 
+func TestSourceExclude_SaveRoundtripAndValidation(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	original := &Manifest{
+		Version: 1,
+		Sources: []*Source{
+			{
+				Name:    "filtered-source",
+				URL:     "https://github.com/user/repo",
+				Include: []string{"skill/*"},
+				Exclude: []string{"skill/experimental-*", "skill/draft"},
+			},
+		},
+	}
+
+	if err := original.Save(tmpDir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load() after Save() error = %v", err)
+	}
+	if got := strings.Join(loaded.Sources[0].Exclude, ","); got != "skill/experimental-*,skill/draft" {
+		t.Fatalf("exclude mismatch after roundtrip: %s", got)
+	}
+
+	invalid := &Source{
+		Name:    "test",
+		URL:     "https://github.com/user/repo",
+		Exclude: []string{"skill/[invalid"},
+	}
+	err = validateSource(invalid)
+	if err == nil {
+		t.Fatal("validateSource() expected error for invalid exclude pattern, got nil")
+	}
+	if !strings.Contains(err.Error(), "invalid exclude pattern") {
+		t.Errorf("expected exclude pattern error, got: %s", err.Error())
+	}
+}
+
 func TestValidateSource_OverrideBreadcrumbs(t *testing.T) {
 	t.Run("valid overridden local source", func(t *testing.T) {
 		s := &Source{
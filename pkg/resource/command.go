@@ -5,6 +5,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/permissions"
 )
 
 // CommandResource represents a command resource
@@ -187,7 +189,7 @@ func LoadCommandResourceWithBase(filePath string, basePath string) (*CommandReso
 		},
 		Agent:        frontmatter.GetString("agent"),
 		Model:        frontmatter.GetString("model"),
-		AllowedTools: frontmatter.GetStringSlice("allowed-tools"),
+		AllowedTools: permissions.Split(frontmatter["allowed-tools"]),
 		Content:      content,
 	}
 
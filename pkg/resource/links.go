@@ -0,0 +1,359 @@
+package resource
+
+import (
+	"bufio"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Link issue kinds reported by CheckLinks.
+const (
+	LinkIssueBroken       = "broken_link"       // relative link or path whose target does not exist
+	LinkIssueOutside      = "outside_resource"  // relative link leaving the skill directory
+	LinkIssueUnreferenced = "unreferenced_file" // file in scripts/, references/ or assets/ nothing points to
+)
+
+// LinkIssue is a broken reference or an unreferenced file in a resource.
+type LinkIssue struct {
+	Kind    string `json:"kind" yaml:"kind"`
+	File    string `json:"file" yaml:"file"` // relative to the resource root
+	Line    int    `json:"line,omitempty" yaml:"line,omitempty"`
+	Target  string `json:"target,omitempty" yaml:"target,omitempty"`
+	Message string `json:"message" yaml:"message"`
+}
+
+// String formats the issue as "file:line: message".
+func (i LinkIssue) String() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", i.File, i.Line, i.Message)
+	}
+	return fmt.Sprintf("%s: %s", i.File, i.Message)
+}
+
+var (
+	// markdownLinkPattern matches inline links and images: [text](target "title").
+	markdownLinkPattern = regexp.MustCompile(`!?\[[^\]]*\]\(\s*<?([^)\s>]+)>?(?:\s+["'(][^)]*)?\)`)
+	// referenceLinkPattern matches reference definitions: [id]: target
+	referenceLinkPattern = regexp.MustCompile(`^\s{0,3}\[[^\]]+\]:\s*<?(\S+?)>?(?:\s|$)`)
+	// inlineCodePattern matches `code` spans.
+	inlineCodePattern = regexp.MustCompile("`([^`]+)`")
+	// bundledPathPattern matches paths into a skill's bundled directories as
+	// they appear in code, e.g. "python scripts/fill.py" or
+	// "{baseDir}/references/api.md".
+	bundledPathPattern = regexp.MustCompile(`(?:^|[\s"'(=:]|\}/)((?:\./)?(?:scripts|references|assets)/[\w./-]*[\w-])`)
+)
+
+// bundledDirs are the skill subdirectories checked for unreferenced files.
+var bundledDirs = []string{"scripts", "references", "assets"}
+
+// fileReference is a relative path found in a markdown file.
+type fileReference struct {
+	line   int
+	target string // as written, without anchor or query
+	inCode bool   // found in a code span or fence rather than a link
+}
+
+// CheckLinks verifies that relative links and bundled paths in the skill's
+// markdown files point at files inside the skill, and reports files in
+// scripts/, references/ and assets/ that nothing references.
+func (s *SkillResource) CheckLinks() ([]LinkIssue, error) {
+	// Skills from local sources are symlinks into the source tree, and
+	// WalkDir does not descend into a symlinked root.
+	root, err := filepath.EvalSymlinks(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan skill %s: %w", s.Name, err)
+	}
+	var markdownFiles []string
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && p != root && skipBundledEntry(d.Name()) {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() && strings.EqualFold(filepath.Ext(p), ".md") {
+			markdownFiles = append(markdownFiles, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan skill %s: %w", s.Name, err)
+	}
+	sort.Strings(markdownFiles)
+
+	var issues []LinkIssue
+	referenced := make(map[string]bool)
+	for _, file := range markdownFiles {
+		refs, err := scanFileReferences(file)
+		if err != nil {
+			return nil, err
+		}
+		relFile := slashRel(root, file)
+		for _, ref := range refs {
+			// Code paths are written relative to the skill root; links are
+			// relative to the file containing them.
+			base := filepath.Dir(file)
+			if ref.inCode {
+				base = root
+			}
+			resolved := filepath.Join(base, filepath.FromSlash(ref.target))
+			rel := slashRel(root, resolved)
+			if rel == ".." || strings.HasPrefix(rel, "../") {
+				issues = append(issues, LinkIssue{
+					Kind:    LinkIssueOutside,
+					File:    relFile,
+					Line:    ref.line,
+					Target:  ref.target,
+					Message: fmt.Sprintf("link %s points outside the skill directory and is not imported with it", ref.target),
+				})
+				continue
+			}
+			if _, err := os.Stat(resolved); err != nil {
+				issues = append(issues, LinkIssue{
+					Kind:    LinkIssueBroken,
+					File:    relFile,
+					Line:    ref.line,
+					Target:  ref.target,
+					Message: fmt.Sprintf("%s does not exist", ref.target),
+				})
+				continue
+			}
+			referenced[rel] = true
+		}
+	}
+
+	present := map[string]bool{
+		"scripts":    s.HasScripts,
+		"references": s.HasReferences,
+		"assets":     s.HasAssets,
+	}
+	for _, dir := range bundledDirs {
+		if !present[dir] {
+			continue
+		}
+		unreferenced, err := unreferencedFiles(root, dir, referenced)
+		if err != nil {
+			return nil, err
+		}
+		for _, rel := range unreferenced {
+			issues = append(issues, LinkIssue{
+				Kind:    LinkIssueUnreferenced,
+				File:    rel,
+				Message: "not referenced from any markdown file in the skill",
+			})
+		}
+	}
+
+	return issues, nil
+}
+
+// CheckFileLinks verifies that relative links in a single-file resource
+// (command or agent) point at existing files.
+func CheckFileLinks(filePath string) ([]LinkIssue, error) {
+	refs, err := scanFileReferences(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var issues []LinkIssue
+	for _, ref := range refs {
+		if ref.inCode {
+			// Bundled directory paths only have a meaning inside skills.
+			continue
+		}
+		resolved := filepath.Join(filepath.Dir(filePath), filepath.FromSlash(ref.target))
+		if _, err := os.Stat(resolved); err != nil {
+			issues = append(issues, LinkIssue{
+				Kind:    LinkIssueBroken,
+				File:    filepath.Base(filePath),
+				Line:    ref.line,
+				Target:  ref.target,
+				Message: fmt.Sprintf("%s does not exist", ref.target),
+			})
+		}
+	}
+	return issues, nil
+}
+
+// CheckResourceLinks runs CheckLinks for skills and CheckFileLinks for
+// commands and agents.
+func CheckResourceLinks(res *Resource) ([]LinkIssue, error) {
+	if res.Type == Skill {
+		skill, err := LoadSkillResource(res.Path)
+		if err != nil {
+			return nil, err
+		}
+		return skill.CheckLinks()
+	}
+	return CheckFileLinks(res.Path)
+}
+
+// scanFileReferences returns the relative file references in a markdown
+// file: link targets outside code, and bundled directory paths inside code
+// fences and code spans.
+func scanFileReferences(filePath string) ([]fileReference, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	var refs []fileReference
+	seen := make(map[string]bool)
+	add := func(line int, target string, inCode bool) {
+		target, ok := normalizeLinkTarget(target)
+		if !ok {
+			return
+		}
+		key := fmt.Sprintf("%d:%s", line, target)
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		refs = append(refs, fileReference{line: line, target: target, inCode: inCode})
+	}
+	addCodePaths := func(line int, text string) {
+		for _, m := range bundledPathPattern.FindAllStringSubmatch(text, -1) {
+			add(line, m[1], true)
+		}
+	}
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	inFence := false
+	fence := ""
+	inFrontmatter := false
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if lineNo == 1 && trimmed == "---" {
+			inFrontmatter = true
+			continue
+		}
+		if inFrontmatter {
+			if trimmed == "---" {
+				inFrontmatter = false
+			}
+			continue
+		}
+
+		if marker := fenceMarker(trimmed); marker != "" {
+			if !inFence {
+				inFence, fence = true, marker
+				continue
+			}
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+				inFence = false
+				continue
+			}
+		}
+		if inFence {
+			addCodePaths(lineNo, line)
+			continue
+		}
+
+		for _, m := range inlineCodePattern.FindAllStringSubmatch(line, -1) {
+			addCodePaths(lineNo, m[1])
+		}
+		prose := inlineCodePattern.ReplaceAllString(line, "")
+		for _, m := range markdownLinkPattern.FindAllStringSubmatch(prose, -1) {
+			add(lineNo, m[1], false)
+		}
+		if m := referenceLinkPattern.FindStringSubmatch(prose); m != nil {
+			add(lineNo, m[1], false)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+	}
+	return refs, nil
+}
+
+// fenceMarker returns the ``` or ~~~ run opening or closing a code fence.
+func fenceMarker(trimmed string) string {
+	for _, ch := range []string{"`", "~"} {
+		if strings.HasPrefix(trimmed, ch+ch+ch) {
+			n := len(trimmed) - len(strings.TrimLeft(trimmed, ch))
+			return strings.Repeat(ch, n)
+		}
+	}
+	return ""
+}
+
+// normalizeLinkTarget strips anchors and queries and rejects targets that
+// are not relative file paths (URLs, anchors, absolute paths, templates).
+func normalizeLinkTarget(target string) (string, bool) {
+	if target == "" || strings.HasPrefix(target, "#") || strings.HasPrefix(target, "/") {
+		return "", false
+	}
+	if strings.Contains(target, "://") || strings.ContainsAny(target, "{}$<>") {
+		return "", false
+	}
+	if i := strings.IndexAny(target, "#?"); i >= 0 {
+		target = target[:i]
+	}
+	if u, err := url.Parse(target); err != nil || u.Scheme != "" {
+		return "", false
+	}
+	if unescaped, err := url.PathUnescape(target); err == nil {
+		target = unescaped
+	}
+	target = strings.TrimSuffix(path.Clean(target), "/")
+	if target == "." || target == "" {
+		return "", false
+	}
+	return target, true
+}
+
+// unreferencedFiles lists files below root/dir that are neither referenced
+// themselves nor inside a referenced directory.
+func unreferencedFiles(root, dir string, referenced map[string]bool) ([]string, error) {
+	var out []string
+	err := filepath.WalkDir(filepath.Join(root, dir), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if skipBundledEntry(d.Name()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel := slashRel(root, p)
+		for candidate := rel; candidate != "." && candidate != "/"; candidate = path.Dir(candidate) {
+			if referenced[candidate] {
+				return nil
+			}
+		}
+		out = append(out, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+	return out, nil
+}
+
+// skipBundledEntry reports hidden files and tool caches that are never
+// referenced on purpose.
+func skipBundledEntry(name string) bool {
+	return strings.HasPrefix(name, ".") || name == "__pycache__" || name == "node_modules"
+}
+
+func slashRel(root, p string) string {
+	rel, err := filepath.Rel(root, p)
+	if err != nil {
+		return filepath.ToSlash(p)
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,113 @@
+package resource
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/test/testutil"
+)
+
+func TestSkillCheckLinks(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "pdf-forms")
+	testutil.WriteFile(t, filepath.Join(root, "SKILL.md"), strings.Join([]string{
+		"---",
+		"name: pdf-forms",
+		"description: Fill PDF forms",
+		"---",
+		"# PDF forms",
+		"",
+		"See [the API notes](references/api.md#fields) and [old notes](references/old.md).",
+		"Online docs: [site](https://example.com/docs.md), [top](#pdf-forms).",
+		"Shared helpers live in [common](../common/README.md).",
+		"",
+		"```bash",
+		"python scripts/fill.py input.pdf",
+		"python {baseDir}/scripts/missing.py",
+		"```",
+		"",
+		"Templates are in `assets/templates/`.",
+		"",
+		"[logo]: assets/logo.png",
+	}, "\n"))
+	testutil.WriteFile(t, filepath.Join(root, "references/api.md"), "# API\n\nBack to [the skill](../SKILL.md).\n")
+	testutil.WriteFile(t, filepath.Join(root, "references/unused.md"), "# Unused\n")
+	testutil.WriteFile(t, filepath.Join(root, "scripts/fill.py"), "print('fill')\n")
+	testutil.WriteFile(t, filepath.Join(root, "scripts/helper.py"), "pass\n")
+	testutil.WriteFile(t, filepath.Join(root, "scripts/__pycache__/fill.cpython-312.pyc"), "")
+	testutil.WriteFile(t, filepath.Join(root, "assets/templates/form.pdf"), "")
+	testutil.WriteFile(t, filepath.Join(root, "assets/logo.png"), "")
+
+	skill, err := LoadSkillResource(root)
+	if err != nil {
+		t.Fatalf("LoadSkillResource() error = %v", err)
+	}
+	issues, err := skill.CheckLinks()
+	if err != nil {
+		t.Fatalf("CheckLinks() error = %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.Kind+" "+issue.String())
+	}
+	sort.Strings(got)
+	want := []string{
+		"broken_link SKILL.md:13: scripts/missing.py does not exist",
+		"broken_link SKILL.md:7: references/old.md does not exist",
+		"outside_resource SKILL.md:9: link ../common/README.md points outside the skill directory and is not imported with it",
+		"unreferenced_file references/unused.md: not referenced from any markdown file in the skill",
+		"unreferenced_file scripts/helper.py: not referenced from any markdown file in the skill",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("CheckLinks() issues:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+}
+
+func TestSkillCheckLinks_SymlinkedSkill(t *testing.T) {
+	tmp := t.TempDir()
+	source := filepath.Join(tmp, "source", "tools")
+	testutil.WriteFile(t, filepath.Join(source, "SKILL.md"), "---\nname: tools\ndescription: Tools\n---\nRun `scripts/run.sh`.\n")
+	testutil.WriteFile(t, filepath.Join(source, "scripts/run.sh"), "echo hi\n")
+	link := filepath.Join(tmp, "repo", "tools")
+	if err := os.MkdirAll(filepath.Dir(link), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(source, link); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+
+	skill, err := LoadSkillResource(link)
+	if err != nil {
+		t.Fatalf("LoadSkillResource() error = %v", err)
+	}
+	issues, err := skill.CheckLinks()
+	if err != nil {
+		t.Fatalf("CheckLinks() error = %v", err)
+	}
+	if len(issues) != 0 {
+		t.Errorf("CheckLinks() = %v, want no issues", issues)
+	}
+}
+
+func TestCheckFileLinks(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFile(t, filepath.Join(root, "commands/deploy.md"), strings.Join([]string{
+		"---",
+		"description: Deploy",
+		"---",
+		"Follow [the checklist](checklist.md) and [runbook](./runbook.md).",
+		"Run `scripts/deploy.sh` from the project root.",
+	}, "\n"))
+	testutil.WriteFile(t, filepath.Join(root, "commands/checklist.md"), "- [ ] tests\n")
+
+	issues, err := CheckFileLinks(filepath.Join(root, "commands", "deploy.md"))
+	if err != nil {
+		t.Fatalf("CheckFileLinks() error = %v", err)
+	}
+	if len(issues) != 1 || issues[0].Kind != LinkIssueBroken || issues[0].Target != "runbook.md" || issues[0].Line != 4 {
+		t.Errorf("CheckFileLinks() = %+v, want one broken link to runbook.md on line 4", issues)
+	}
+}
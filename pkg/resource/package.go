@@ -30,6 +30,21 @@ type PackageMetadata struct {
 	OriginalFormat string    `json:"original_format,omitempty"` // Original format if converted (e.g., "claude-plugin")
 }
 
+// SourceQualifierSeparator separates an optional source name from the resource
+// name in source-qualified references such as "skill/platform:code-review".
+const SourceQualifierSeparator = ":"
+
+// SplitSourceQualifier splits a possibly source-qualified name ("source:name")
+// into the source name and the bare resource name. Unqualified names return an
+// empty source.
+func SplitSourceQualifier(name string) (string, string) {
+	sourceName, bare, found := strings.Cut(name, SourceQualifierSeparator)
+	if !found {
+		return "", name
+	}
+	return sourceName, bare
+}
+
 // ParseResourceReference parses a resource reference in "type/name" format.
 // Returns the resource type, name, and error if invalid.
 //
@@ -38,18 +53,39 @@ type PackageMetadata struct {
 //   - "skill/name"
 //   - "agent/name"
 //
+// Source-qualified references ("skill/source:name") are accepted; the source
+// qualifier is dropped. Use ParseQualifiedResourceReference to keep it.
+//
 // Examples:
 //
 //	ParseResourceReference("command/test") // => Command, "test", nil
 //	ParseResourceReference("skill/pdf")    // => Skill, "pdf", nil
 //	ParseResourceReference("invalid")      // => "", "", error
 func ParseResourceReference(ref string) (ResourceType, string, error) {
+	resourceType, _, name, err := ParseQualifiedResourceReference(ref)
+	return resourceType, name, err
+}
+
+// ParseQualifiedResourceReference parses a resource reference in
+// "type/name" or "type/source:name" format.
+// Returns the resource type, source name (empty when unqualified), name, and
+// error if invalid.
+//
+// Examples:
+//
+//	ParseQualifiedResourceReference("skill/platform:code-review") // => Skill, "platform", "code-review", nil
+//	ParseQualifiedResourceReference("command/test")               // => Command, "", "test", nil
+func ParseQualifiedResourceReference(ref string) (ResourceType, string, string, error) {
 	parts := strings.SplitN(ref, "/", 2)
 	if len(parts) != 2 {
-		return "", "", fmt.Errorf("invalid resource format: %q (expected type/name)", ref)
+		return "", "", "", fmt.Errorf("invalid resource format: %q (expected type/name)", ref)
 	}
 
-	typeStr, name := parts[0], parts[1]
+	typeStr, qualifiedName := parts[0], parts[1]
+	sourceName, name := SplitSourceQualifier(qualifiedName)
+	if strings.Contains(qualifiedName, SourceQualifierSeparator) && (sourceName == "" || strings.Contains(sourceName, "/")) {
+		return "", "", "", fmt.Errorf("invalid source qualifier in: %q (expected type/source:name)", ref)
+	}
 
 	var resourceType ResourceType
 	switch typeStr {
@@ -60,14 +96,14 @@ func ParseResourceReference(ref string) (ResourceType, string, error) {
 	case "agent":
 		resourceType = Agent
 	default:
-		return "", "", fmt.Errorf("invalid resource type: %q (expected command/skill/agent)", typeStr)
+		return "", "", "", fmt.Errorf("invalid resource type: %q (expected command/skill/agent)", typeStr)
 	}
 
 	if name == "" {
-		return "", "", fmt.Errorf("resource name cannot be empty in: %q", ref)
+		return "", "", "", fmt.Errorf("resource name cannot be empty in: %q", ref)
 	}
 
-	return resourceType, name, nil
+	return resourceType, sourceName, name, nil
 }
 
 // LoadPackage loads a package from a .package.json file.
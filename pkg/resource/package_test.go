@@ -180,6 +180,49 @@ func TestParseResourceReference(t *testing.T) {
 }
 
 // TestLoadPackage tests the LoadPackage function
+
+func TestParseQualifiedResourceReference(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantType   ResourceType
+		wantSource string
+		wantName   string
+		wantError  string
+	}{
+		{input: "skill/platform:code-review", wantType: Skill, wantSource: "platform", wantName: "code-review"},
+		{input: "command/team:api/deploy", wantType: Command, wantSource: "team", wantName: "api/deploy"},
+		{input: "agent/reviewer", wantType: Agent, wantName: "reviewer"},
+		{input: "skill/:code-review", wantError: "invalid source qualifier"},
+		{input: "command/api/team:deploy", wantError: "invalid source qualifier"},
+		{input: "skill/platform:", wantError: "resource name cannot be empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			gotType, gotSource, gotName, err := ParseQualifiedResourceReference(tt.input)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Fatalf("ParseQualifiedResourceReference(%q) error = %v, want containing %q", tt.input, err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseQualifiedResourceReference(%q) unexpected error: %v", tt.input, err)
+			}
+			if gotType != tt.wantType || gotSource != tt.wantSource || gotName != tt.wantName {
+				t.Errorf("ParseQualifiedResourceReference(%q) = (%v, %q, %q), want (%v, %q, %q)",
+					tt.input, gotType, gotSource, gotName, tt.wantType, tt.wantSource, tt.wantName)
+			}
+		})
+	}
+
+	// ParseResourceReference drops the qualifier
+	resType, name, err := ParseResourceReference("skill/platform:code-review")
+	if err != nil || resType != Skill || name != "code-review" {
+		t.Errorf("ParseResourceReference() = (%v, %q, %v), want (skill, code-review, nil)", resType, name, err)
+	}
+}
+
 func TestLoadPackage(t *testing.T) {
 	tests := []struct {
 		name         string
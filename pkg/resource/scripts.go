@@ -0,0 +1,236 @@
+package resource
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Script issue kinds reported by CheckScripts.
+const (
+	ScriptIssueNotExecutable      = "script_not_executable" // shebang but no exec bit
+	ScriptIssueCRLF               = "script_crlf"           // Windows line endings
+	ScriptIssueMissingInterpreter = "missing_interpreter"   // shebang interpreter not found on this machine
+)
+
+// scriptsDir is the skill subdirectory holding executable helpers.
+const scriptsDir = "scripts"
+
+// maxScriptSize is the largest file checked for line endings.
+const maxScriptSize = 1 << 20
+
+// shellExtensions are scripts that break on CRLF line endings even when run
+// through an explicit interpreter, e.g. "bash scripts/run.sh".
+var shellExtensions = map[string]bool{".sh": true, ".bash": true, ".zsh": true, ".ksh": true}
+
+// lookPath resolves interpreters named by "#!/usr/bin/env <name>"; tests
+// replace it.
+var lookPath = exec.LookPath
+
+// ScriptIssue is a problem with a file in a skill's scripts/ directory.
+type ScriptIssue struct {
+	Kind        string `json:"kind" yaml:"kind"`
+	File        string `json:"file" yaml:"file"` // relative to the skill root
+	Interpreter string `json:"interpreter,omitempty" yaml:"interpreter,omitempty"`
+	Message     string `json:"message" yaml:"message"`
+}
+
+// String formats the issue as "file: message".
+func (i ScriptIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.File, i.Message)
+}
+
+// CheckScripts inspects the files in the skill's scripts/ directory: scripts
+// with a shebang must be executable and their interpreter must exist, and
+// scripts must not use CRLF line endings, which turn "#!/bin/sh" into a
+// lookup for "/bin/sh\r".
+func (s *SkillResource) CheckScripts() ([]ScriptIssue, error) {
+	if !s.HasScripts {
+		return nil, nil
+	}
+	root, err := filepath.EvalSymlinks(s.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan skill %s: %w", s.Name, err)
+	}
+
+	var issues []ScriptIssue
+	err = walkScripts(root, func(p, rel string, info fs.FileInfo) error {
+		data, err := readScriptHead(p, info)
+		if err != nil {
+			return err
+		}
+		shebang := parseShebang(data)
+
+		if shebang != "" && runtime.GOOS != "windows" && info.Mode().Perm()&0111 == 0 {
+			issues = append(issues, ScriptIssue{
+				Kind:    ScriptIssueNotExecutable,
+				File:    rel,
+				Message: fmt.Sprintf("has a shebang but is not executable (mode %04o)", info.Mode().Perm()),
+			})
+		}
+
+		if bytes.Contains(data, []byte("\r\n")) && (shebang != "" || shellExtensions[strings.ToLower(filepath.Ext(p))]) {
+			issues = append(issues, ScriptIssue{
+				Kind:    ScriptIssueCRLF,
+				File:    rel,
+				Message: "uses CRLF line endings; shells and the kernel read the \\r as part of each line",
+			})
+		}
+
+		if interpreter := shebangInterpreter(shebang); interpreter != "" && !interpreterExists(interpreter) {
+			issues = append(issues, ScriptIssue{
+				Kind:        ScriptIssueMissingInterpreter,
+				File:        rel,
+				Interpreter: interpreter,
+				Message:     fmt.Sprintf("interpreter %s from the shebang is not installed on this machine", interpreter),
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan scripts of skill %s: %w", s.Name, err)
+	}
+	return issues, nil
+}
+
+// CheckResourceScripts runs CheckScripts for skills; commands and agents
+// have no scripts.
+func CheckResourceScripts(res *Resource) ([]ScriptIssue, error) {
+	if res.Type != Skill {
+		return nil, nil
+	}
+	skill, err := LoadSkillResource(res.Path)
+	if err != nil {
+		return nil, err
+	}
+	return skill.CheckScripts()
+}
+
+// FixScriptModes makes every script with a shebang in skillDir/scripts
+// executable by whoever can read it, and returns the fixed files relative to
+// the skill root. With dryRun it only reports what it would change.
+func FixScriptModes(skillDir string, dryRun bool) ([]string, error) {
+	var fixed []string
+	err := walkScripts(skillDir, func(p, rel string, info fs.FileInfo) error {
+		perm := info.Mode().Perm()
+		if perm&0111 != 0 {
+			return nil
+		}
+		data, err := readScriptHead(p, info)
+		if err != nil {
+			return err
+		}
+		if parseShebang(data) == "" {
+			return nil
+		}
+		if !dryRun {
+			// Mirror the read bits: 0644 becomes 0755, 0600 becomes 0700.
+			if err := os.Chmod(p, perm|(perm&0444)>>2); err != nil {
+				return err
+			}
+		}
+		fixed = append(fixed, rel)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fix script modes in %s: %w", skillDir, err)
+	}
+	return fixed, nil
+}
+
+// walkScripts calls fn for each regular file below root/scripts, skipping
+// hidden entries and tool caches.
+func walkScripts(root string, fn func(p, rel string, info fs.FileInfo) error) error {
+	dir := filepath.Join(root, scriptsDir)
+	if _, err := os.Stat(dir); os.IsNotExist(err) {
+		return nil
+	}
+	return filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if skipBundledEntry(d.Name()) {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || !info.Mode().IsRegular() {
+			return err
+		}
+		return fn(p, slashRel(root, p), info)
+	})
+}
+
+// readScriptHead reads a script for inspection; files above maxScriptSize
+// are only read far enough to see the shebang line.
+func readScriptHead(p string, info fs.FileInfo) ([]byte, error) {
+	if info.Size() <= maxScriptSize {
+		return os.ReadFile(p)
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+	buf := make([]byte, 4096)
+	n, err := f.Read(buf)
+	if err != nil {
+		return nil, err
+	}
+	return buf[:n], nil
+}
+
+// parseShebang returns the shebang line without "#!" and line ending, or ""
+// when the file does not start with one.
+func parseShebang(data []byte) string {
+	if !bytes.HasPrefix(data, []byte("#!")) {
+		return ""
+	}
+	line := data[2:]
+	if i := bytes.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	return strings.TrimSpace(string(line))
+}
+
+// shebangInterpreter returns the program a shebang runs: the absolute path,
+// or the command name for "/usr/bin/env [-S] name".
+func shebangInterpreter(shebang string) string {
+	fields := strings.Fields(shebang)
+	if len(fields) == 0 {
+		return ""
+	}
+	if filepath.Base(fields[0]) != "env" {
+		return fields[0]
+	}
+	for _, field := range fields[1:] {
+		if strings.HasPrefix(field, "-") || strings.Contains(field, "=") {
+			continue
+		}
+		return field
+	}
+	return fields[0]
+}
+
+func interpreterExists(interpreter string) bool {
+	if filepath.IsAbs(interpreter) || strings.HasPrefix(interpreter, "/") {
+		if runtime.GOOS == "windows" {
+			// Absolute Unix paths are resolved by the shell (e.g. Git Bash).
+			return true
+		}
+		info, err := os.Stat(interpreter)
+		return err == nil && !info.IsDir()
+	}
+	_, err := lookPath(interpreter)
+	return err == nil
+}
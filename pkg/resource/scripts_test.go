@@ -0,0 +1,131 @@
+package resource
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/test/testutil"
+)
+
+func writeScriptTestFile(t *testing.T, root, rel, content string, mode os.FileMode) {
+	t.Helper()
+	path := filepath.Join(root, rel)
+	testutil.WriteFile(t, path, content)
+	if err := os.Chmod(path, mode); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSkillCheckScripts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not checked on Windows")
+	}
+	orig := lookPath
+	lookPath = func(name string) (string, error) {
+		if name == "python3" || name == "node" {
+			return "/usr/bin/" + name, nil
+		}
+		return "", errors.New("not found")
+	}
+	t.Cleanup(func() { lookPath = orig })
+
+	root := filepath.Join(t.TempDir(), "tools")
+	testutil.WriteFile(t, filepath.Join(root, "SKILL.md"), "---\nname: tools\ndescription: Tools\n---\nRun the scripts.\n")
+	writeScriptTestFile(t, root, "scripts/ok.py", "#!/usr/bin/env python3\nprint('ok')\n", 0755)
+	writeScriptTestFile(t, root, "scripts/noexec.py", "#!/usr/bin/env python3\nprint('x')\n", 0644)
+	writeScriptTestFile(t, root, "scripts/crlf.sh", "#!/bin/sh\r\necho hi\r\n", 0755)
+	writeScriptTestFile(t, root, "scripts/crlf-noshebang.bash", "echo hi\r\n", 0644)
+	writeScriptTestFile(t, root, "scripts/data.csv", "a,b\r\n1,2\r\n", 0644)
+	writeScriptTestFile(t, root, "scripts/deno.ts", "#!/usr/bin/env -S deno run\nconsole.log(1)\n", 0755)
+	writeScriptTestFile(t, root, "scripts/missing.sh", "#!/opt/nowhere/bin/zsh\necho hi\n", 0755)
+	writeScriptTestFile(t, root, "scripts/lib.py", "def helper():\n    pass\n", 0644)
+
+	skill, err := LoadSkillResource(root)
+	if err != nil {
+		t.Fatalf("LoadSkillResource() error = %v", err)
+	}
+	issues, err := skill.CheckScripts()
+	if err != nil {
+		t.Fatalf("CheckScripts() error = %v", err)
+	}
+
+	var got []string
+	for _, issue := range issues {
+		got = append(got, issue.Kind+" "+issue.File+" "+issue.Interpreter)
+	}
+	sort.Strings(got)
+	want := []string{
+		"missing_interpreter scripts/deno.ts deno",
+		"missing_interpreter scripts/missing.sh /opt/nowhere/bin/zsh",
+		"script_crlf scripts/crlf-noshebang.bash ",
+		"script_crlf scripts/crlf.sh ",
+		"script_not_executable scripts/noexec.py ",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("CheckScripts() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("issue %d = %q, want %q", i, got[i], want[i])
+		}
+	}
+}
+
+func TestFixScriptModes(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("file modes are not checked on Windows")
+	}
+	root := filepath.Join(t.TempDir(), "tools")
+	testutil.WriteFile(t, filepath.Join(root, "SKILL.md"), "---\nname: tools\ndescription: Tools\n---\n")
+	writeScriptTestFile(t, root, "scripts/run.sh", "#!/bin/sh\necho hi\n", 0644)
+	writeScriptTestFile(t, root, "scripts/private.sh", "#!/bin/sh\necho hi\n", 0600)
+	writeScriptTestFile(t, root, "scripts/lib.py", "pass\n", 0644)
+
+	fixed, err := FixScriptModes(root, true)
+	if err != nil {
+		t.Fatalf("FixScriptModes(dryRun) error = %v", err)
+	}
+	if len(fixed) != 2 {
+		t.Fatalf("FixScriptModes(dryRun) = %v, want 2 files", fixed)
+	}
+	if info, _ := os.Stat(filepath.Join(root, "scripts", "run.sh")); info.Mode().Perm() != 0644 {
+		t.Errorf("dry run changed mode to %v", info.Mode().Perm())
+	}
+
+	if _, err := FixScriptModes(root, false); err != nil {
+		t.Fatalf("FixScriptModes() error = %v", err)
+	}
+	for rel, want := range map[string]os.FileMode{
+		"scripts/run.sh":     0755,
+		"scripts/private.sh": 0700,
+		"scripts/lib.py":     0644,
+	} {
+		info, err := os.Stat(filepath.Join(root, filepath.FromSlash(rel)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != want {
+			t.Errorf("%s mode = %v, want %v", rel, info.Mode().Perm(), want)
+		}
+	}
+}
+
+func TestShebangInterpreter(t *testing.T) {
+	tests := map[string]string{
+		"/bin/bash":                "/bin/bash",
+		"/usr/bin/env python3":     "python3",
+		"/usr/bin/env -S deno run": "deno",
+		"/usr/bin/env FOO=1 node":  "node",
+		"/usr/bin/python3 -u":      "/usr/bin/python3",
+		"":                         "",
+	}
+	for shebang, want := range tests {
+		if got := shebangInterpreter(shebang); got != want {
+			t.Errorf("shebangInterpreter(%q) = %q, want %q", shebang, got, want)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+// Package resourcediff compares two versions of a resource.
+//
+// A resource version is loaded as a FileSet (relative path -> content), so the
+// same comparison works for the repository copy, the upstream copy in the
+// workspace cache and a copy read from git history. Compare produces a
+// unified diff per file plus a frontmatter-aware summary of the resource's
+// main markdown file (SKILL.md, or the command/agent file itself), which
+// lets callers call out changed fields such as model or allowed-tools
+// separately from body edits.
+//
+// Digest fingerprints a FileSet so local edits to an imported resource can be
+// detected, and MergeFileSets/Merge3 perform the three-way merge used to
+// combine such edits with new upstream content.
+package resourcediff
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/frontmatter"
+)
+
+// FileSet maps slash-separated paths relative to the resource root to file
+// contents.
+type FileSet map[string][]byte
+
+// Status describes how a file changed between two versions.
+type Status string
+
+const (
+	StatusAdded    Status = "added"
+	StatusRemoved  Status = "removed"
+	StatusModified Status = "modified"
+)
+
+// FieldKind describes how a frontmatter field changed.
+type FieldKind string
+
+const (
+	FieldAdded   FieldKind = "added"
+	FieldRemoved FieldKind = "removed"
+	FieldChanged FieldKind = "changed"
+)
+
+// FileDiff is the difference for a single file.
+type FileDiff struct {
+	Path    string `json:"path" yaml:"path"`
+	Status  Status `json:"status" yaml:"status"`
+	Binary  bool   `json:"binary,omitempty" yaml:"binary,omitempty"`
+	Unified string `json:"diff,omitempty" yaml:"diff,omitempty"`
+}
+
+// FieldChange is a changed frontmatter field of the main file.
+type FieldChange struct {
+	Field string    `json:"field" yaml:"field"`
+	Kind  FieldKind `json:"kind" yaml:"kind"`
+	Old   string    `json:"old,omitempty" yaml:"old,omitempty"`
+	New   string    `json:"new,omitempty" yaml:"new,omitempty"`
+}
+
+// Result is the comparison of two versions of a resource.
+type Result struct {
+	Files       []FileDiff    `json:"files" yaml:"files"`
+	Frontmatter []FieldChange `json:"frontmatter,omitempty" yaml:"frontmatter,omitempty"`
+	BodyChanged bool          `json:"body_changed" yaml:"body_changed"`
+}
+
+// Empty reports whether the two versions are identical.
+func (r *Result) Empty() bool {
+	return len(r.Files) == 0
+}
+
+// Counts returns the number of added, removed and modified files.
+func (r *Result) Counts() (added, removed, modified int) {
+	for _, f := range r.Files {
+		switch f.Status {
+		case StatusAdded:
+			added++
+		case StatusRemoved:
+			removed++
+		case StatusModified:
+			modified++
+		}
+	}
+	return added, removed, modified
+}
+
+// LoadPath loads a resource from disk. A file is keyed by its base name; a
+// directory is walked recursively, skipping .git.
+func LoadPath(path string) (FileSet, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	files := FileSet{}
+	if !info.IsDir() {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		files[filepath.Base(path)] = data
+		return files, nil
+	}
+
+	// WalkDir does not descend into a symlinked root (e.g. a skill imported
+	// from a local source), so resolve it first.
+	root, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		// Follow symlinks like the importer does; skip anything else.
+		info, err := os.Stat(p)
+		if err != nil || info.IsDir() || !info.Mode().IsRegular() {
+			return nil
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", p, err)
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s: %w", path, err)
+	}
+	return files, nil
+}
+
+// Compare compares two versions of a resource. Unified diffs are labelled
+// with oldPrefix/newPrefix joined with the file path (e.g. "a/skills/x").
+// mainFile names the file whose frontmatter is summarised ("" to skip).
+func Compare(oldFiles, newFiles FileSet, oldPrefix, newPrefix, mainFile string) *Result {
+	paths := make(map[string]bool, len(oldFiles)+len(newFiles))
+	for p := range oldFiles {
+		paths[p] = true
+	}
+	for p := range newFiles {
+		paths[p] = true
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	result := &Result{Files: []FileDiff{}}
+	for _, p := range sorted {
+		oldData, inOld := oldFiles[p]
+		newData, inNew := newFiles[p]
+		if inOld && inNew && bytes.Equal(oldData, newData) {
+			continue
+		}
+
+		fd := FileDiff{Path: p, Status: StatusModified}
+		oldLabel, newLabel := joinLabel(oldPrefix, p), joinLabel(newPrefix, p)
+		switch {
+		case !inOld:
+			fd.Status = StatusAdded
+			oldLabel = "/dev/null"
+		case !inNew:
+			fd.Status = StatusRemoved
+			newLabel = "/dev/null"
+		}
+
+		if isBinary(oldData) || isBinary(newData) {
+			fd.Binary = true
+		} else {
+			fd.Unified = Unified(oldLabel, newLabel, oldData, newData, DefaultContext)
+		}
+		result.Files = append(result.Files, fd)
+	}
+
+	if mainFile != "" {
+		result.Frontmatter, result.BodyChanged = compareMainFile(oldFiles[mainFile], newFiles[mainFile])
+	}
+	return result
+}
+
+func joinLabel(prefix, path string) string {
+	if prefix == "" {
+		return path
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + path
+}
+
+// isBinary uses the same heuristic as git: a NUL byte in the first 8000 bytes.
+func isBinary(data []byte) bool {
+	if len(data) > 8000 {
+		data = data[:8000]
+	}
+	return bytes.IndexByte(data, 0) >= 0
+}
+
+// compareMainFile returns the changed frontmatter fields and whether the
+// markdown body changed.
+func compareMainFile(oldData, newData []byte) ([]FieldChange, bool) {
+	oldFields, oldBody := splitMainFile(oldData)
+	newFields, newBody := splitMainFile(newData)
+
+	keys := make(map[string]bool, len(oldFields)+len(newFields))
+	for k := range oldFields {
+		keys[k] = true
+	}
+	for k := range newFields {
+		keys[k] = true
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var changes []FieldChange
+	for _, k := range sorted {
+		oldVal, inOld := oldFields[k]
+		newVal, inNew := newFields[k]
+		switch {
+		case !inOld:
+			changes = append(changes, FieldChange{Field: k, Kind: FieldAdded, New: formatValue(newVal)})
+		case !inNew:
+			changes = append(changes, FieldChange{Field: k, Kind: FieldRemoved, Old: formatValue(oldVal)})
+		case !reflect.DeepEqual(oldVal, newVal):
+			changes = append(changes, FieldChange{Field: k, Kind: FieldChanged, Old: formatValue(oldVal), New: formatValue(newVal)})
+		}
+	}
+
+	return changes, oldBody != newBody
+}
+
+// splitMainFile splits markdown into frontmatter fields and body. Files
+// without (valid) frontmatter are treated as all body.
+func splitMainFile(data []byte) (map[string]interface{}, string) {
+	if data == nil {
+		return nil, ""
+	}
+	fm, err := frontmatter.Parse(data)
+	if err != nil || fm == nil {
+		return nil, string(data)
+	}
+	return fm.Fields, fm.Content
+}
+
+// formatValue renders a frontmatter value on a single line.
+func formatValue(v interface{}) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case []interface{}:
+		parts := make([]string, len(val))
+		for i, item := range val {
+			parts[i] = formatValue(item)
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		parts := make([]string, len(keys))
+		for i, k := range keys {
+			parts[i] = k + ": " + formatValue(val[k])
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	default:
+		return fmt.Sprint(val)
+	}
+}
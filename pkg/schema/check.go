@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/frontmatter"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/modifications"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
+)
+
+// Result is the outcome of checking a resource for one tool.
+type Result struct {
+	Tool string `json:"tool" yaml:"tool"`
+
+	// Supported is false when aimgr does not install the resource type into
+	// the tool; such resources are skipped for the tool and not checked.
+	Supported bool `json:"supported" yaml:"supported"`
+
+	// MappedFields are the fields changed by the tool's field mappings
+	// before the check.
+	MappedFields []string `json:"mapped_fields,omitempty" yaml:"mapped_fields,omitempty"`
+
+	Issues []Issue `json:"issues,omitempty" yaml:"issues,omitempty"`
+}
+
+// IsMapped reports whether a field was changed by the field mappings.
+func (r Result) IsMapped(field string) bool {
+	for _, f := range r.MappedFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckResource checks a command, skill or agent against a tool's schema, as
+// the tool will see it: with the tool's field mappings from aimgr.yaml
+// applied, like the variant generated in .modifications.
+func CheckResource(res *resource.Resource, tool tools.Tool, mappings config.TypeMappings) (Result, error) {
+	result := Result{Tool: tool.String()}
+	s, err := For(tool, res.Type)
+	if err != nil || s == nil {
+		return result, err
+	}
+	result.Supported = true
+
+	path := res.Path
+	if res.Type == resource.Skill {
+		path = filepath.Join(res.Path, "SKILL.md")
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return result, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	fm, err := frontmatter.Parse(content)
+	if err != nil {
+		return result, fmt.Errorf("failed to parse frontmatter of %s: %w", path, err)
+	}
+	if fm == nil {
+		fm = &frontmatter.Frontmatter{}
+	}
+
+	gen := modifications.NewGenerator("", mappings, nil)
+	result.MappedFields = gen.ApplyMappings(res, fm, tool.String())
+	result.Issues = s.Validate(fm.Fields)
+	return result, nil
+}
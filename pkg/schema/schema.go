@@ -0,0 +1,395 @@
+// Package schema checks resource frontmatter against the schema of each tool
+// a resource is installed into.
+//
+// aimgr's own validation accepts the union of what the supported tools
+// understand, so an agent can be valid for aimgr and still carry a field one
+// tool rejects, e.g. a Claude-style "tools: Read, Grep" string where OpenCode
+// expects a map. The schemas in schemas/<tool>/<type>.json describe the
+// fields each tool reads; fields a tool does not know are ignored by the tools
+// and therefore allowed.
+//
+// Only the subset of JSON Schema these files use is implemented: type, enum,
+// pattern, minLength, maxLength, minimum, maximum, properties, required,
+// additionalProperties, items and anyOf.
+package schema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
+)
+
+//go:embed schemas
+var schemaFiles embed.FS
+
+// Schema is a compiled JSON Schema.
+type Schema struct {
+	Title       string             `json:"title,omitempty"`
+	Description string             `json:"description,omitempty"`
+	Type        typeList           `json:"type,omitempty"`
+	Enum        []interface{}      `json:"enum,omitempty"`
+	Pattern     string             `json:"pattern,omitempty"`
+	MinLength   *int               `json:"minLength,omitempty"`
+	MaxLength   *int               `json:"maxLength,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"`
+	Maximum     *float64           `json:"maximum,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	AnyOf       []*Schema          `json:"anyOf,omitempty"`
+
+	// AdditionalProperties is true, false or a schema for the values of
+	// keys not listed in Properties.
+	AdditionalProperties json.RawMessage `json:"additionalProperties,omitempty"`
+
+	pattern      *regexp.Regexp
+	additional   *Schema
+	noAdditional bool
+}
+
+// Issue is a field a tool would reject.
+type Issue struct {
+	Field   string `json:"field" yaml:"field"` // dotted path, e.g. "permission.bash"
+	Message string `json:"message" yaml:"message"`
+}
+
+func (i Issue) String() string {
+	if i.Field == "" {
+		return i.Message
+	}
+	return fmt.Sprintf("%s: %s", i.Field, i.Message)
+}
+
+// typeList is a JSON Schema "type", which is a string or a list of strings.
+type typeList []string
+
+func (t *typeList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = typeList{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("type must be a string or a list of strings")
+	}
+	*t = list
+	return nil
+}
+
+var (
+	loadOnce sync.Once
+	loaded   map[string]*Schema
+	loadErr  error
+)
+
+// For returns the frontmatter schema of a resource type for a tool, or nil
+// when aimgr does not install that type into the tool.
+func For(tool tools.Tool, resType resource.ResourceType) (*Schema, error) {
+	loadOnce.Do(func() {
+		loaded, loadErr = loadAll()
+	})
+	if loadErr != nil {
+		return nil, loadErr
+	}
+	return loaded[key(tool.String(), string(resType))], nil
+}
+
+func key(toolName, resType string) string {
+	return toolName + "/" + resType
+}
+
+func loadAll() (map[string]*Schema, error) {
+	schemas := make(map[string]*Schema)
+	err := fs.WalkDir(schemaFiles, "schemas", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := schemaFiles.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		s, err := Parse(data)
+		if err != nil {
+			return fmt.Errorf("invalid schema %s: %w", path, err)
+		}
+		// schemas/<tool>/<type>.json
+		parts := strings.Split(strings.TrimSuffix(path, ".json"), "/")
+		schemas[key(parts[1], parts[2])] = s
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
+
+// Parse parses and compiles a JSON Schema.
+func Parse(data []byte) (*Schema, error) {
+	s := &Schema{}
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, err
+	}
+	if err := s.compile(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *Schema) compile() error {
+	var err error
+	if s.Pattern != "" {
+		if s.pattern, err = regexp.Compile(s.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+	}
+	switch raw := strings.TrimSpace(string(s.AdditionalProperties)); raw {
+	case "", "true":
+	case "false":
+		s.noAdditional = true
+	default:
+		s.additional = &Schema{}
+		if err := json.Unmarshal(s.AdditionalProperties, s.additional); err != nil {
+			return fmt.Errorf("additionalProperties: %w", err)
+		}
+	}
+
+	children := make([]*Schema, 0, len(s.Properties)+len(s.AnyOf)+2)
+	for _, p := range s.Properties {
+		children = append(children, p)
+	}
+	children = append(children, s.AnyOf...)
+	children = append(children, s.Items, s.additional)
+	for _, child := range children {
+		if child == nil {
+			continue
+		}
+		if err := child.compile(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate checks frontmatter fields and returns the issues sorted by field.
+func (s *Schema) Validate(fields map[string]interface{}) []Issue {
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+	issues := s.validate("", fields)
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Field < issues[j].Field })
+	return issues
+}
+
+func (s *Schema) validate(path string, value interface{}) []Issue {
+	if len(s.AnyOf) > 0 {
+		var first []Issue
+		for _, alt := range s.AnyOf {
+			issues := alt.validate(path, value)
+			if len(issues) == 0 {
+				first = nil
+				break
+			}
+			if first == nil {
+				first = issues
+			}
+		}
+		if first != nil {
+			return []Issue{{Field: path, Message: fmt.Sprintf("%s does not match any allowed form (%s)", typeName(value), describeAlternatives(s.AnyOf))}}
+		}
+	}
+
+	if len(s.Type) > 0 && !s.matchesType(value) {
+		return []Issue{{Field: path, Message: fmt.Sprintf("expected %s, got %s", joinTypes(s.Type), typeName(value))}}
+	}
+	if len(s.Enum) > 0 && !inEnum(s.Enum, value) {
+		return []Issue{{Field: path, Message: fmt.Sprintf("%s is not one of %s", formatValue(value), formatEnum(s.Enum))}}
+	}
+
+	var issues []Issue
+	switch v := value.(type) {
+	case string:
+		length := len([]rune(v))
+		if s.MinLength != nil && length < *s.MinLength {
+			if *s.MinLength == 1 {
+				issues = append(issues, Issue{Field: path, Message: "must not be empty"})
+			} else {
+				issues = append(issues, Issue{Field: path, Message: fmt.Sprintf("must be at least %d characters, got %d", *s.MinLength, length)})
+			}
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			issues = append(issues, Issue{Field: path, Message: fmt.Sprintf("must be at most %d characters, got %d", *s.MaxLength, length)})
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			message := fmt.Sprintf("%q does not match %s", v, s.Pattern)
+			if s.Description != "" {
+				message += " (" + s.Description + ")"
+			}
+			issues = append(issues, Issue{Field: path, Message: message})
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				issues = append(issues, s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item)...)
+			}
+		}
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				issues = append(issues, Issue{Field: join(path, name), Message: "is required"})
+			}
+		}
+		for name, child := range v {
+			if prop, ok := s.Properties[name]; ok {
+				issues = append(issues, prop.validate(join(path, name), child)...)
+				continue
+			}
+			if s.noAdditional {
+				issues = append(issues, Issue{Field: join(path, name), Message: "is not a known field"})
+			} else if s.additional != nil {
+				issues = append(issues, s.additional.validate(join(path, name), child)...)
+			}
+		}
+	default:
+		if n, ok := toFloat(value); ok {
+			if s.Minimum != nil && n < *s.Minimum {
+				issues = append(issues, Issue{Field: path, Message: fmt.Sprintf("must be at least %v, got %v", *s.Minimum, n)})
+			}
+			if s.Maximum != nil && n > *s.Maximum {
+				issues = append(issues, Issue{Field: path, Message: fmt.Sprintf("must be at most %v, got %v", *s.Maximum, n)})
+			}
+		}
+	}
+	return issues
+}
+
+func (s *Schema) matchesType(value interface{}) bool {
+	actual := jsonType(value)
+	for _, t := range s.Type {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonType maps a decoded YAML value to its JSON Schema type.
+func jsonType(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return "string"
+	case bool:
+		return "boolean"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	case int, int64, uint64:
+		return "integer"
+	case float64:
+		if v == math.Trunc(v) && !math.IsInf(v, 0) {
+			return "integer"
+		}
+		return "number"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// typeName describes a value's type in YAML terms for diagnostics.
+func typeName(value interface{}) string {
+	return yamlTypeName(jsonType(value))
+}
+
+func yamlTypeName(jsonType string) string {
+	switch jsonType {
+	case "object":
+		return "map"
+	case "array":
+		return "list"
+	case "null":
+		return "empty value"
+	default:
+		return jsonType
+	}
+}
+
+func joinTypes(types typeList) string {
+	names := make([]string, len(types))
+	for i, t := range types {
+		names[i] = yamlTypeName(t)
+	}
+	return strings.Join(names, " or ")
+}
+
+func describeAlternatives(alts []*Schema) string {
+	parts := make([]string, 0, len(alts))
+	for _, alt := range alts {
+		switch {
+		case len(alt.Enum) > 0:
+			parts = append(parts, formatEnum(alt.Enum))
+		case len(alt.Type) > 0:
+			parts = append(parts, joinTypes(alt.Type))
+		}
+	}
+	return strings.Join(parts, " or ")
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, allowed := range enum {
+		if fmt.Sprint(allowed) == fmt.Sprint(value) && jsonType(value) != "object" && jsonType(value) != "array" {
+			return true
+		}
+	}
+	return false
+}
+
+func formatEnum(enum []interface{}) string {
+	parts := make([]string, len(enum))
+	for i, e := range enum {
+		parts[i] = fmt.Sprint(e)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func formatValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%q", s)
+	}
+	if t := jsonType(value); t == "object" || t == "array" {
+		return "a " + yamlTypeName(t)
+	}
+	return fmt.Sprint(value)
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
@@ -0,0 +1,124 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/config"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
+)
+
+func TestFor(t *testing.T) {
+	for _, tool := range tools.AllTools() {
+		info := tools.GetToolInfo(tool)
+		for resType, supported := range map[resource.ResourceType]bool{
+			resource.Command: info.SupportsCommands,
+			resource.Skill:   info.SupportsSkills,
+			resource.Agent:   info.SupportsAgents,
+		} {
+			s, err := For(tool, resType)
+			if err != nil {
+				t.Fatalf("For(%s, %s) error = %v", tool, resType, err)
+			}
+			if (s != nil) != supported {
+				t.Errorf("For(%s, %s) has schema = %v, want %v", tool, resType, s != nil, supported)
+			}
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s, err := Parse([]byte(`{
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"name": {"type": "string", "pattern": "^[a-z-]+$", "maxLength": 10},
+			"mode": {"enum": ["a", "b"]},
+			"tools": {"type": "object", "additionalProperties": {"type": "boolean"}},
+			"list": {"type": ["string", "array"], "items": {"type": "string"}},
+			"level": {"type": "number", "minimum": 0, "maximum": 1},
+			"either": {"anyOf": [{"enum": ["x"]}, {"type": "object"}]},
+			"strict": {"type": "object", "additionalProperties": false, "properties": {"ok": {"type": "string"}}}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		fields map[string]interface{}
+		want   []string // "field: message substring"
+	}{
+		{"valid", map[string]interface{}{"name": "ok", "mode": "a", "tools": map[string]interface{}{"x": true}, "list": []interface{}{"a"}, "level": 0.5, "either": "x"}, nil},
+		{"unknown fields allowed", map[string]interface{}{"name": "ok", "extra": 1}, nil},
+		{"missing required", map[string]interface{}{}, []string{"name: is required"}},
+		{"pattern and length", map[string]interface{}{"name": "Not_Valid_At_All"}, []string{"name: must be at most 10", "name: \"Not_Valid_At_All\" does not match"}},
+		{"enum", map[string]interface{}{"name": "ok", "mode": "c"}, []string{"mode: \"c\" is not one of a, b"}},
+		{"wrong shape", map[string]interface{}{"name": "ok", "tools": "Read, Grep"}, []string{"tools: expected map, got string"}},
+		{"nested values", map[string]interface{}{"name": "ok", "tools": map[string]interface{}{"write": "no"}}, []string{"tools.write: expected boolean, got string"}},
+		{"list items", map[string]interface{}{"name": "ok", "list": []interface{}{"a", 3}}, []string{"list[1]: expected string, got integer"}},
+		{"range", map[string]interface{}{"name": "ok", "level": 2}, []string{"level: must be at most 1"}},
+		{"any of", map[string]interface{}{"name": "ok", "either": "y"}, []string{"either: string does not match any allowed form"}},
+		{"no additional", map[string]interface{}{"name": "ok", "strict": map[string]interface{}{"nope": "1"}}, []string{"strict.nope: is not a known field"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues := s.Validate(tt.fields)
+			if len(issues) != len(tt.want) {
+				t.Fatalf("Validate() = %v, want %d issue(s) %v", issues, len(tt.want), tt.want)
+			}
+			for i, want := range tt.want {
+				if !strings.Contains(issues[i].String(), want) {
+					t.Errorf("issue %d = %q, want it to contain %q", i, issues[i].String(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestCheckResource(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "reviewer.md")
+	content := "---\nname: reviewer\ndescription: Reviews code\ntools: Read, Grep\nmode: subagent\nmodel: fast\n---\nReview.\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	res := &resource.Resource{Name: "reviewer", Type: resource.Agent, Path: path}
+
+	claude, err := CheckResource(res, tools.Claude, config.TypeMappings{})
+	if err != nil {
+		t.Fatalf("CheckResource(claude) error = %v", err)
+	}
+	if !claude.Supported || len(claude.Issues) != 0 {
+		t.Errorf("CheckResource(claude) = %+v, want no issues", claude)
+	}
+
+	mappings := config.TypeMappings{Agent: config.FieldMappings{"model": {"fast": {"opencode": "anthropic/claude-haiku-4-5"}}}}
+	opencode, err := CheckResource(res, tools.OpenCode, mappings)
+	if err != nil {
+		t.Fatalf("CheckResource(opencode) error = %v", err)
+	}
+	if !opencode.IsMapped("model") {
+		t.Errorf("MappedFields = %v, want model", opencode.MappedFields)
+	}
+	if len(opencode.Issues) != 1 || opencode.Issues[0].Field != "tools" {
+		t.Errorf("CheckResource(opencode) issues = %v, want only tools", opencode.Issues)
+	}
+
+	unmapped, err := CheckResource(res, tools.OpenCode, config.TypeMappings{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unmapped.Issues) != 2 {
+		t.Errorf("CheckResource(opencode, no mappings) issues = %v, want model and tools", unmapped.Issues)
+	}
+
+	windsurf, err := CheckResource(res, tools.Windsurf, config.TypeMappings{})
+	if err != nil || windsurf.Supported {
+		t.Errorf("CheckResource(windsurf) = %+v, %v, want unsupported", windsurf, err)
+	}
+}
@@ -0,0 +1,394 @@
+// Package search provides ranked full-text search over repository resources.
+//
+// The index lives in .metadata/search-index.json. Each document records a
+// fingerprint of the files it was built from, so Refresh only re-reads
+// resources that were added, changed or removed since the last update.
+package search
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/fileutil"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+)
+
+// IndexFileName is the index file under the repository's .metadata directory.
+const IndexFileName = "search-index.json"
+
+// indexVersion is bumped when the document format or tokenizer changes;
+// older indexes are rebuilt from scratch.
+const indexVersion = 1
+
+// Indexed fields, in the order of Document.Terms counts.
+const (
+	fieldName = iota
+	fieldTags
+	fieldDescription
+	fieldBody
+	fieldCount
+)
+
+var fieldNames = [fieldCount]string{"name", "tags", "description", "body"}
+
+// Index is the persisted search index of a repository.
+type Index struct {
+	Version   int                  `json:"version"`
+	Documents map[string]*Document `json:"documents"` // keyed by "type/name"
+}
+
+// Document is one indexed resource or package.
+type Document struct {
+	Ref         string                `json:"ref"`
+	Type        resource.ResourceType `json:"type"`
+	Name        string                `json:"name"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Source      string                `json:"source,omitempty"`
+	Members     []string              `json:"members,omitempty"` // package resource refs
+	Fingerprint string                `json:"fingerprint"`
+	Length      int                   `json:"length"`
+	// Terms maps each token to its count in name, tags, description and body
+	Terms map[string][fieldCount]int `json:"terms"`
+}
+
+// IndexPath returns the index file path for a repository.
+func IndexPath(repoPath string) string {
+	return filepath.Join(repoPath, ".metadata", IndexFileName)
+}
+
+// Load reads the index of a repository. A missing, unreadable or outdated
+// index yields an empty index, which Refresh rebuilds.
+func Load(repoPath string) *Index {
+	empty := &Index{Version: indexVersion, Documents: map[string]*Document{}}
+
+	data, err := os.ReadFile(IndexPath(repoPath))
+	if err != nil {
+		return empty
+	}
+	var idx Index
+	if err := json.Unmarshal(data, &idx); err != nil || idx.Version != indexVersion || idx.Documents == nil {
+		return empty
+	}
+	return &idx
+}
+
+// Save writes the index to .metadata/search-index.json.
+func (idx *Index) Save(repoPath string) error {
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return fmt.Errorf("failed to marshal search index: %w", err)
+	}
+	path := IndexPath(repoPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+	if err := fileutil.AtomicWrite(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write search index: %w", err)
+	}
+	return nil
+}
+
+// Update refreshes the persisted index of a repository and saves it when
+// anything changed. Callers should hold the repository write lock.
+func Update(repoPath string) error {
+	idx := Load(repoPath)
+	changed, err := idx.Refresh(repoPath)
+	if err != nil {
+		return err
+	}
+	if changed == 0 {
+		if _, err := os.Stat(IndexPath(repoPath)); err == nil {
+			return nil
+		}
+	}
+	return idx.Save(repoPath)
+}
+
+// indexEntry is a resource found on disk with the files that make up its
+// fingerprint.
+type indexEntry struct {
+	ref      string
+	resType  resource.ResourceType
+	name     string
+	path     string // resource path as passed to the resource loaders
+	mainFile string // file holding frontmatter and body
+}
+
+// Refresh brings the index in line with the repository on disk: documents of
+// new or changed resources are rebuilt and documents of removed resources
+// dropped. It returns the number of documents added, rebuilt or dropped.
+func (idx *Index) Refresh(repoPath string) (int, error) {
+	entries, err := scanRepository(repoPath)
+	if err != nil {
+		return 0, err
+	}
+
+	changed := 0
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		seen[entry.ref] = true
+		fingerprint := fingerprintFiles(entry.mainFile, metadataPath(repoPath, entry))
+		if doc, ok := idx.Documents[entry.ref]; ok && doc.Fingerprint == fingerprint {
+			continue
+		}
+		doc, err := buildDocument(repoPath, entry)
+		if err != nil {
+			// Invalid resources are not listed either; leave them out.
+			if _, ok := idx.Documents[entry.ref]; ok {
+				delete(idx.Documents, entry.ref)
+				changed++
+			}
+			continue
+		}
+		doc.Fingerprint = fingerprint
+		idx.Documents[entry.ref] = doc
+		changed++
+	}
+	for ref := range idx.Documents {
+		if !seen[ref] {
+			delete(idx.Documents, ref)
+			changed++
+		}
+	}
+	return changed, nil
+}
+
+// scanRepository lists the resources and packages of a repository without
+// parsing them.
+func scanRepository(repoPath string) ([]indexEntry, error) {
+	var entries []indexEntry
+
+	commandsDir := filepath.Join(repoPath, "commands")
+	err := filepath.WalkDir(commandsDir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && path == commandsDir {
+				return filepath.SkipDir
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		rel, err := filepath.Rel(commandsDir, path)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(filepath.ToSlash(rel), ".md")
+		entries = append(entries, indexEntry{
+			ref: "command/" + name, resType: resource.Command, name: name, path: path, mainFile: path,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan commands: %w", err)
+	}
+
+	dirEntries, err := readDirIfExists(filepath.Join(repoPath, "skills"))
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range dirEntries {
+		path := filepath.Join(repoPath, "skills", e.Name())
+		if info, err := os.Stat(path); err != nil || !info.IsDir() {
+			continue
+		}
+		entries = append(entries, indexEntry{
+			ref: "skill/" + e.Name(), resType: resource.Skill, name: e.Name(), path: path,
+			mainFile: filepath.Join(path, "SKILL.md"),
+		})
+	}
+
+	dirEntries, err = readDirIfExists(filepath.Join(repoPath, "agents"))
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range dirEntries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".md") {
+			continue
+		}
+		path := filepath.Join(repoPath, "agents", e.Name())
+		name := strings.TrimSuffix(e.Name(), ".md")
+		entries = append(entries, indexEntry{
+			ref: "agent/" + name, resType: resource.Agent, name: name, path: path, mainFile: path,
+		})
+	}
+
+	dirEntries, err = readDirIfExists(filepath.Join(repoPath, "packages"))
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range dirEntries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".package.json") {
+			continue
+		}
+		path := filepath.Join(repoPath, "packages", e.Name())
+		name := strings.TrimSuffix(e.Name(), ".package.json")
+		entries = append(entries, indexEntry{
+			ref: "package/" + name, resType: resource.PackageType, name: name, path: path, mainFile: path,
+		})
+	}
+
+	return entries, nil
+}
+
+func readDirIfExists(dir string) ([]os.DirEntry, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	return entries, nil
+}
+
+func metadataPath(repoPath string, entry indexEntry) string {
+	if entry.resType == resource.PackageType {
+		return metadata.GetPackageMetadataPath(entry.name, repoPath)
+	}
+	return metadata.GetMetadataPath(entry.name, entry.resType, repoPath)
+}
+
+// fingerprintFiles combines size and modification time of the given files;
+// missing files contribute a fixed marker.
+func fingerprintFiles(paths ...string) string {
+	parts := make([]string, 0, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			parts = append(parts, "-")
+			continue
+		}
+		parts = append(parts, fmt.Sprintf("%d:%d", info.Size(), info.ModTime().UnixNano()))
+	}
+	return strings.Join(parts, "|")
+}
+
+// buildDocument parses a resource and tokenizes its searchable fields.
+func buildDocument(repoPath string, entry indexEntry) (*Document, error) {
+	doc := &Document{Ref: entry.ref, Type: entry.resType, Name: entry.name}
+	var body string
+
+	switch entry.resType {
+	case resource.PackageType:
+		pkg, err := resource.LoadPackageLenient(entry.path)
+		if err != nil {
+			return nil, err
+		}
+		doc.Description = pkg.Description
+		doc.Members = pkg.Resources
+		body = strings.Join(pkg.Resources, " ")
+		// Package metadata has no source name; derive it from the URL.
+		if meta, err := metadata.LoadPackageMetadata(entry.name, repoPath); err == nil && meta.SourceURL != "" {
+			doc.Source = metadata.DeriveSourceName(meta.SourceURL)
+		}
+	default:
+		var res *resource.Resource
+		var err error
+		switch entry.resType {
+		case resource.Command:
+			res, err = resource.LoadCommandWithBase(entry.path, filepath.Join(repoPath, "commands"))
+		case resource.Skill:
+			res, err = resource.LoadSkill(entry.path)
+		case resource.Agent:
+			res, err = resource.LoadAgent(entry.path)
+		}
+		if err != nil {
+			return nil, err
+		}
+		fm, content, err := resource.ParseFrontmatter(entry.mainFile)
+		if err != nil {
+			return nil, err
+		}
+		doc.Description = res.Description
+		doc.Tags = frontmatterTags(fm)
+		body = content
+		if meta, err := metadata.Load(entry.name, entry.resType, repoPath); err == nil {
+			doc.Source = meta.SourceName
+		}
+	}
+
+	doc.Terms = make(map[string][fieldCount]int)
+	add := func(field int, text string) {
+		for _, token := range tokenize(text) {
+			counts := doc.Terms[token]
+			counts[field]++
+			doc.Terms[token] = counts
+			doc.Length++
+		}
+	}
+	add(fieldName, doc.Name)
+	add(fieldTags, strings.Join(doc.Tags, " "))
+	add(fieldDescription, doc.Description)
+	add(fieldBody, body)
+
+	return doc, nil
+}
+
+// frontmatterTags reads tags from a top-level "tags" field or from
+// "metadata.tags", as a YAML list or a comma-separated string.
+func frontmatterTags(fm resource.Frontmatter) []string {
+	value, ok := fm["tags"]
+	if !ok {
+		switch meta := fm["metadata"].(type) {
+		case map[string]interface{}:
+			value = meta["tags"]
+		case resource.Frontmatter:
+			value = meta["tags"]
+		}
+	}
+
+	var tags []string
+	switch v := value.(type) {
+	case string:
+		for _, tag := range strings.Split(v, ",") {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				tags = append(tags, tag)
+			}
+		}
+	case []interface{}:
+		for _, item := range v {
+			if tag, ok := item.(string); ok && strings.TrimSpace(tag) != "" {
+				tags = append(tags, strings.TrimSpace(tag))
+			}
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// stopWords are dropped from documents and queries.
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "are": true, "as": true, "at": true, "be": true,
+	"by": true, "for": true, "from": true, "in": true, "is": true, "it": true, "of": true,
+	"on": true, "or": true, "that": true, "the": true, "this": true, "to": true, "with": true,
+}
+
+// tokenize lowercases text, splits it into words of letters and digits and
+// reduces plurals to their singular form.
+func tokenize(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	tokens := words[:0]
+	for _, word := range words {
+		if !stopWords[word] {
+			tokens = append(tokens, stem(word))
+		}
+	}
+	return tokens
+}
+
+// stem strips a plural "s" ("forms" -> "form") but keeps words such as
+// "class" or "status" intact.
+func stem(word string) string {
+	if len(word) > 3 && strings.HasSuffix(word, "s") &&
+		!strings.HasSuffix(word, "ss") && !strings.HasSuffix(word, "us") && !strings.HasSuffix(word, "is") {
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
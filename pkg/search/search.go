@@ -0,0 +1,245 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
+)
+
+// Field weights: a hit in the name counts more than one in the body.
+var fieldWeights = [fieldCount]float64{
+	fieldName:        4,
+	fieldTags:        3,
+	fieldDescription: 2,
+	fieldBody:        1,
+}
+
+// BM25 parameters.
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// prefixWeight scales matches where a query term is only a prefix of an
+// indexed word ("form" matching "forms").
+const prefixWeight = 0.5
+
+// minPrefixLength is the shortest query term that also matches by prefix.
+const minPrefixLength = 3
+
+// Query selects and filters search results.
+type Query struct {
+	Text    string
+	Types   []resource.ResourceType // empty: all types
+	Sources []string                // empty: all sources
+	Tools   []tools.Tool            // resources usable by every listed tool
+	Limit   int                     // 0: no limit
+}
+
+// Result is a ranked search hit.
+type Result struct {
+	Ref         string                `json:"ref" yaml:"ref"`
+	Type        resource.ResourceType `json:"type" yaml:"type"`
+	Name        string                `json:"name" yaml:"name"`
+	Description string                `json:"description,omitempty" yaml:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Source      string                `json:"source,omitempty" yaml:"source,omitempty"`
+	Score       float64               `json:"score" yaml:"score"`
+	Matched     []string              `json:"matched" yaml:"matched"` // fields containing a query term
+}
+
+// Search ranks the documents matching q with BM25 over weighted fields.
+// Every document containing at least one query term is returned, best first.
+func (idx *Index) Search(q Query) []Result {
+	terms := uniqueTokens(q.Text)
+	if len(terms) == 0 {
+		return []Result{}
+	}
+
+	candidates := make([]*Document, 0, len(idx.Documents))
+	totalLength := 0
+	for _, doc := range idx.Documents {
+		totalLength += doc.Length
+		if q.matches(doc) {
+			candidates = append(candidates, doc)
+		}
+	}
+	if len(idx.Documents) == 0 {
+		return []Result{}
+	}
+	avgLength := float64(totalLength) / float64(len(idx.Documents))
+	if avgLength == 0 {
+		avgLength = 1
+	}
+
+	// Document frequency is taken over the whole index so filters do not
+	// change how rare a term is.
+	idf := make(map[string]float64, len(terms))
+	for _, term := range terms {
+		df := 0
+		for _, doc := range idx.Documents {
+			if _, ok := termFrequency(doc, term); ok {
+				df++
+			}
+		}
+		n := float64(len(idx.Documents))
+		idf[term] = math.Log(1 + (n-float64(df)+0.5)/(float64(df)+0.5))
+	}
+
+	results := []Result{}
+	for _, doc := range candidates {
+		score := 0.0
+		var matched [fieldCount]bool
+		norm := bm25K1 * (1 - bm25B + bm25B*float64(doc.Length)/avgLength)
+		for _, term := range terms {
+			counts, ok := termFrequency(doc, term)
+			if !ok {
+				continue
+			}
+			tf := 0.0
+			for field, count := range counts {
+				if count > 0 {
+					tf += fieldWeights[field] * count
+					matched[field] = true
+				}
+			}
+			score += idf[term] * tf * (bm25K1 + 1) / (tf + norm)
+		}
+		if score == 0 {
+			continue
+		}
+
+		result := Result{
+			Ref:         doc.Ref,
+			Type:        doc.Type,
+			Name:        doc.Name,
+			Description: doc.Description,
+			Tags:        doc.Tags,
+			Source:      doc.Source,
+			Score:       math.Round(score*1000) / 1000,
+			Matched:     []string{},
+		}
+		for field, ok := range matched {
+			if ok {
+				result.Matched = append(result.Matched, fieldNames[field])
+			}
+		}
+		results = append(results, result)
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Ref < results[j].Ref
+	})
+	if q.Limit > 0 && len(results) > q.Limit {
+		results = results[:q.Limit]
+	}
+	return results
+}
+
+// termFrequency returns the per-field counts of a query term in a document,
+// with prefix matches scaled down.
+func termFrequency(doc *Document, term string) ([fieldCount]float64, bool) {
+	var counts [fieldCount]float64
+	found := false
+	if exact, ok := doc.Terms[term]; ok {
+		for field, count := range exact {
+			counts[field] += float64(count)
+		}
+		found = true
+	}
+	if len(term) >= minPrefixLength {
+		for token, tokenCounts := range doc.Terms {
+			if token == term || !strings.HasPrefix(token, term) {
+				continue
+			}
+			for field, count := range tokenCounts {
+				counts[field] += prefixWeight * float64(count)
+			}
+			found = true
+		}
+	}
+	return counts, found
+}
+
+// matches applies the type, source and tool filters.
+func (q Query) matches(doc *Document) bool {
+	if len(q.Types) > 0 && !containsType(q.Types, doc.Type) {
+		return false
+	}
+	if len(q.Sources) > 0 {
+		ok := false
+		for _, source := range q.Sources {
+			if strings.EqualFold(source, doc.Source) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	for _, tool := range q.Tools {
+		if !compatible(doc, tool) {
+			return false
+		}
+	}
+	return true
+}
+
+// compatible reports whether a tool can install the document. A package is
+// compatible when the tool supports at least one of its members.
+func compatible(doc *Document, tool tools.Tool) bool {
+	if doc.Type != resource.PackageType {
+		return SupportsType(tool, doc.Type)
+	}
+	for _, member := range doc.Members {
+		resType, _, err := resource.ParseResourceReference(member)
+		if err == nil && SupportsType(tool, resType) {
+			return true
+		}
+	}
+	return false
+}
+
+// SupportsType reports whether a tool can install resources of a type.
+func SupportsType(tool tools.Tool, resType resource.ResourceType) bool {
+	info := tools.GetToolInfo(tool)
+	switch resType {
+	case resource.Command:
+		return info.SupportsCommands
+	case resource.Skill:
+		return info.SupportsSkills
+	case resource.Agent:
+		return info.SupportsAgents
+	default:
+		return false
+	}
+}
+
+func containsType(types []resource.ResourceType, resType resource.ResourceType) bool {
+	for _, t := range types {
+		if t == resType {
+			return true
+		}
+	}
+	return false
+}
+
+// uniqueTokens tokenizes a query, keeping the first occurrence of each term.
+func uniqueTokens(text string) []string {
+	seen := map[string]bool{}
+	var terms []string
+	for _, token := range tokenize(text) {
+		if !seen[token] {
+			seen[token] = true
+			terms = append(terms, token)
+		}
+	}
+	return terms
+}
@@ -0,0 +1,126 @@
+package search
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/metadata"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/tools"
+	"github.com/dynatrace-oss/ai-config-manager/v3/test/testutil"
+)
+
+// setupSearchRepo creates a repository with a skill, a command, an agent and
+// a package.
+func setupSearchRepo(t *testing.T) string {
+	t.Helper()
+	repoPath := t.TempDir()
+
+	testutil.WriteFile(t, filepath.Join(repoPath, "skills", "pdf-forms", "SKILL.md"),
+		"---\nname: pdf-forms\ndescription: Fill and extract PDF forms\ntags: [pdf, documents]\n---\n\n# PDF forms\n\nHandles fillable form fields.\n")
+	testutil.WriteFile(t, filepath.Join(repoPath, "skills", "spreadsheets", "SKILL.md"),
+		"---\nname: spreadsheets\ndescription: Work with spreadsheets\nmetadata:\n  tags: excel, csv\n---\n\nExport tables to PDF when needed.\n")
+	testutil.WriteFile(t, filepath.Join(repoPath, "commands", "review.md"),
+		"---\ndescription: Review the current diff\n---\n\nReview code changes.\n")
+	testutil.WriteFile(t, filepath.Join(repoPath, "agents", "reviewer.md"),
+		"---\nname: reviewer\ndescription: Code review agent\n---\n\nReviews pull requests.\n")
+	testutil.WriteFile(t, filepath.Join(repoPath, "packages", "docs.package.json"),
+		`{"name":"docs","description":"Document tooling","resources":["skill/pdf-forms","command/review"]}`)
+
+	meta := &metadata.ResourceMetadata{Name: "pdf-forms", Type: resource.Skill, SourceType: "local", SourceURL: "/src"}
+	if err := metadata.Save(meta, repoPath, "team"); err != nil {
+		t.Fatal(err)
+	}
+	return repoPath
+}
+
+func searchRefs(results []Result) string {
+	refs := make([]string, len(results))
+	for i, r := range results {
+		refs[i] = r.Ref
+	}
+	return strings.Join(refs, ",")
+}
+
+func TestSearch_RanksAndFilters(t *testing.T) {
+	repoPath := setupSearchRepo(t)
+	if err := Update(repoPath); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+	idx := Load(repoPath)
+	if len(idx.Documents) != 5 {
+		t.Fatalf("indexed %d documents, want 5", len(idx.Documents))
+	}
+
+	results := idx.Search(Query{Text: "handles PDF forms"})
+	// The package matches through its member refs.
+	if got := searchRefs(results); got != "skill/pdf-forms,package/docs,skill/spreadsheets" {
+		t.Fatalf("Search() = %s", got)
+	}
+	top := results[0]
+	if top.Source != "team" || strings.Join(top.Matched, ",") != "name,tags,description,body" {
+		t.Errorf("top result = %+v", top)
+	}
+
+	if got := searchRefs(idx.Search(Query{Text: "excel"})); got != "skill/spreadsheets" {
+		t.Errorf("metadata.tags search = %s", got)
+	}
+	if got := searchRefs(idx.Search(Query{Text: "review", Types: []resource.ResourceType{resource.Agent}})); got != "agent/reviewer" {
+		t.Errorf("type filter = %s", got)
+	}
+	if got := searchRefs(idx.Search(Query{Text: "pdf", Sources: []string{"team"}})); got != "skill/pdf-forms" {
+		t.Errorf("source filter = %s", got)
+	}
+	// Copilot cannot install commands; the package still has a skill member.
+	if got := searchRefs(idx.Search(Query{Text: "review", Tools: []tools.Tool{tools.Copilot}})); got != "agent/reviewer,package/docs" {
+		t.Errorf("tool filter = %s", got)
+	}
+	if got := idx.Search(Query{Text: "the and"}); len(got) != 0 {
+		t.Errorf("stop-word query returned %v", got)
+	}
+	if got := idx.Search(Query{Text: "pdf", Limit: 1}); len(got) != 1 {
+		t.Errorf("limit returned %d results", len(got))
+	}
+}
+
+func TestRefresh_Incremental(t *testing.T) {
+	repoPath := setupSearchRepo(t)
+	idx := Load(repoPath)
+	if changed, err := idx.Refresh(repoPath); err != nil || changed != 5 {
+		t.Fatalf("first Refresh() = %d, %v; want 5", changed, err)
+	}
+	if changed, err := idx.Refresh(repoPath); err != nil || changed != 0 {
+		t.Fatalf("second Refresh() = %d, %v; want 0", changed, err)
+	}
+
+	agentPath := filepath.Join(repoPath, "agents", "reviewer.md")
+	testutil.WriteFile(t, agentPath, "---\nname: reviewer\ndescription: Security auditor\n---\n\nFinds vulnerabilities.\n")
+	future := time.Now().Add(time.Minute)
+	if err := os.Chtimes(agentPath, future, future); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.RemoveAll(filepath.Join(repoPath, "commands")); err != nil {
+		t.Fatal(err)
+	}
+
+	if changed, err := idx.Refresh(repoPath); err != nil || changed != 2 {
+		t.Fatalf("Refresh() after edits = %d, %v; want 2", changed, err)
+	}
+	if got := searchRefs(idx.Search(Query{Text: "vulnerabilities"})); got != "agent/reviewer" {
+		t.Errorf("edited agent not re-indexed: %s", got)
+	}
+	if _, ok := idx.Documents["command/review"]; ok {
+		t.Error("removed command still indexed")
+	}
+}
+
+func TestLoad_IgnoresOutdatedIndex(t *testing.T) {
+	repoPath := t.TempDir()
+	testutil.WriteFile(t, IndexPath(repoPath), `{"version":0,"documents":{"skill/x":{}}}`)
+	if idx := Load(repoPath); len(idx.Documents) != 0 {
+		t.Errorf("outdated index should load empty, got %d documents", len(idx.Documents))
+	}
+}
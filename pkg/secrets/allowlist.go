@@ -0,0 +1,118 @@
+package secrets
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+)
+
+// AllowlistFileName is the allowlist looked up at the root of a source.
+const AllowlistFileName = ".aimgr-secrets-allowlist"
+
+// Allowlist suppresses findings that are known false positives. Each
+// non-comment line of an allowlist file is one of:
+//
+//	sha256:0123456789abcdef      a value, by the fingerprint shown in findings
+//	skills/demo/scripts/*.py     every finding in matching files
+//	skills/demo/**:github-token  one rule in matching files
+//
+// Path patterns are relative to the source root; "**" as the last segment
+// matches everything below a directory.
+type Allowlist struct {
+	fingerprints map[string]bool
+	entries      []allowEntry
+}
+
+type allowEntry struct {
+	pattern string
+	rule    string // empty: any rule
+}
+
+// LoadAllowlist reads and merges allowlist files. Missing files are skipped,
+// so callers can pass optional locations.
+func LoadAllowlist(paths ...string) (*Allowlist, error) {
+	list := &Allowlist{fingerprints: make(map[string]bool)}
+	for _, p := range paths {
+		if p == "" {
+			continue
+		}
+		f, err := os.Open(p)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secrets allowlist: %w", err)
+		}
+		scanner := bufio.NewScanner(f)
+		for lineNo := 1; scanner.Scan(); lineNo++ {
+			if err := list.add(scanner.Text()); err != nil {
+				_ = f.Close()
+				return nil, fmt.Errorf("%s:%d: %w", p, lineNo, err)
+			}
+		}
+		err = scanner.Err()
+		_ = f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read secrets allowlist: %w", err)
+		}
+	}
+	return list, nil
+}
+
+func (a *Allowlist) add(line string) error {
+	if i := strings.Index(line, " #"); i >= 0 {
+		line = line[:i]
+	}
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil
+	}
+	if strings.HasPrefix(line, "sha256:") {
+		a.fingerprints[line] = true
+		return nil
+	}
+
+	entry := allowEntry{pattern: line}
+	if i := strings.LastIndex(line, ":"); i >= 0 {
+		entry.pattern, entry.rule = line[:i], line[i+1:]
+	}
+	if _, err := path.Match(strings.TrimSuffix(entry.pattern, "/**"), ""); err != nil {
+		return fmt.Errorf("invalid pattern %q: %w", entry.pattern, err)
+	}
+	a.entries = append(a.entries, entry)
+	return nil
+}
+
+// allows reports whether a finding is allowlisted.
+func (a *Allowlist) allows(f Finding) bool {
+	if a == nil {
+		return false
+	}
+	if a.fingerprints[f.Fingerprint] {
+		return true
+	}
+	for _, entry := range a.entries {
+		if entry.rule != "" && entry.rule != f.Rule {
+			continue
+		}
+		if matchAllowPattern(entry.pattern, f.File) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAllowPattern(pattern, file string) bool {
+	if dir, ok := strings.CutSuffix(pattern, "/**"); ok {
+		for p := path.Dir(file); p != "." && p != "/"; p = path.Dir(p) {
+			if matched, _ := path.Match(dir, p); matched {
+				return true
+			}
+		}
+		return false
+	}
+	matched, _ := path.Match(pattern, file)
+	return matched
+}
@@ -0,0 +1,310 @@
+// Package secrets detects credentials in files before they are imported into
+// the repository: high-confidence token formats, configured patterns and,
+// optionally, high-entropy string literals.
+package secrets
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxFileSize is the largest file scanned; bigger files are assumed to be
+// data rather than code or prose.
+const maxFileSize = 2 << 20
+
+// Entropy defaults for quoted string literals.
+const (
+	DefaultEntropyThreshold = 4.5
+	minEntropyLength        = 20
+)
+
+// EntropyRuleID is the rule reported for high-entropy strings.
+const EntropyRuleID = "high-entropy-string"
+
+// Rule is a named secret pattern. When the pattern has a capture group, the
+// first group is the secret; otherwise the whole match is.
+type Rule struct {
+	ID      string
+	Pattern *regexp.Regexp
+}
+
+// builtinRules are formats with a distinctive prefix or structure, so a match
+// is very likely a real credential.
+var builtinRules = []Rule{
+	{"aws-access-key-id", regexp.MustCompile(`\b((?:AKIA|ASIA)[0-9A-Z]{16})\b`)},
+	{"github-token", regexp.MustCompile(`\b(gh[pousr]_[A-Za-z0-9]{36,255})\b`)},
+	{"github-fine-grained-token", regexp.MustCompile(`\b(github_pat_[A-Za-z0-9_]{82})\b`)},
+	{"gitlab-token", regexp.MustCompile(`\b(glpat-[A-Za-z0-9_-]{20})\b`)},
+	{"slack-token", regexp.MustCompile(`\b(xox[abprs]-[0-9A-Za-z-]{10,})\b`)},
+	{"slack-webhook", regexp.MustCompile(`(https://hooks\.slack\.com/services/T[A-Z0-9]+/B[A-Z0-9]+/[A-Za-z0-9]+)`)},
+	{"google-api-key", regexp.MustCompile(`\b(AIza[0-9A-Za-z_-]{35})\b`)},
+	{"stripe-secret-key", regexp.MustCompile(`\b((?:sk|rk)_live_[0-9A-Za-z]{24,})\b`)},
+	{"openai-api-key", regexp.MustCompile(`\b(sk-(?:proj-|svcacct-)?[A-Za-z0-9_-]{20,}T3BlbkFJ[A-Za-z0-9_-]{20,})\b`)},
+	{"anthropic-api-key", regexp.MustCompile(`\b(sk-ant-(?:api|admin)\d{2}-[A-Za-z0-9_-]{80,})\b`)},
+	{"npm-token", regexp.MustCompile(`\b(npm_[A-Za-z0-9]{36})\b`)},
+	{"private-key", regexp.MustCompile(`(-----BEGIN (?:RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY(?: BLOCK)?-----)`)},
+}
+
+// quotedStringPattern finds string literals checked for entropy.
+var quotedStringPattern = regexp.MustCompile(`["'\x60]([A-Za-z0-9+/=_\-]{20,})["'\x60]`)
+
+// Pattern is a custom rule as configured in aimgr.yaml.
+type Pattern struct {
+	ID    string
+	Regex string
+}
+
+// Options configures a Scanner.
+type Options struct {
+	// Patterns are added to the built-in rules.
+	Patterns []Pattern
+	// EntropyThreshold is the Shannon entropy (bits per character) above which
+	// quoted strings are reported. Zero uses DefaultEntropyThreshold; a
+	// negative value disables entropy detection.
+	EntropyThreshold float64
+	// Allowlist suppresses known false positives.
+	Allowlist *Allowlist
+}
+
+// Finding is a likely secret in a file.
+type Finding struct {
+	Rule        string `json:"rule" yaml:"rule"`
+	File        string `json:"file" yaml:"file"` // relative to the scan root
+	Line        int    `json:"line" yaml:"line"`
+	Redacted    string `json:"redacted" yaml:"redacted"`
+	Fingerprint string `json:"fingerprint" yaml:"fingerprint"` // for allowlisting the value
+}
+
+// String formats the finding as "file:line: rule (redacted, fingerprint)".
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: %s (%s, %s)", f.File, f.Line, f.Rule, f.Redacted, f.Fingerprint)
+}
+
+// Scanner scans files below a root directory.
+type Scanner struct {
+	root             string
+	rules            []Rule
+	entropyThreshold float64
+	allowlist        *Allowlist
+}
+
+// NewScanner returns a scanner for files below root. Paths in findings and
+// allowlist entries are relative to root.
+func NewScanner(root string, opts Options) (*Scanner, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", root, err)
+	}
+	rules := append([]Rule(nil), builtinRules...)
+	for _, p := range opts.Patterns {
+		re, err := regexp.Compile(p.Regex)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret pattern %q: %w", p.ID, err)
+		}
+		rules = append(rules, Rule{ID: p.ID, Pattern: re})
+	}
+	threshold := opts.EntropyThreshold
+	if threshold == 0 {
+		threshold = DefaultEntropyThreshold
+	}
+	return &Scanner{root: absRoot, rules: rules, entropyThreshold: threshold, allowlist: opts.Allowlist}, nil
+}
+
+// ScanPath scans a file, or every file below a directory. Directories are
+// walked like the repository copies them on import: symlinks are followed and
+// no subdirectory is skipped, so everything that is imported is scanned.
+func (s *Scanner) ScanPath(path string) ([]Finding, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", path, err)
+	}
+	if !info.IsDir() {
+		return s.scanFile(path)
+	}
+	return s.scanDir(path)
+}
+
+func (s *Scanner) scanDir(dir string) ([]Finding, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", dir, err)
+	}
+
+	var findings []Finding
+	for _, entry := range entries {
+		p := filepath.Join(dir, entry.Name())
+		info, err := os.Stat(p)
+		if err != nil {
+			continue // dangling links are not copied either
+		}
+		var found []Finding
+		if info.IsDir() {
+			found, err = s.scanDir(p)
+		} else {
+			found, err = s.scanFile(p)
+		}
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, found...)
+	}
+	return findings, nil
+}
+
+func (s *Scanner) scanFile(path string) ([]Finding, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", path, err)
+	}
+	if info.Size() > maxFileSize {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", path, err)
+	}
+	if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+		return nil, nil // binary
+	}
+
+	rel, err := filepath.Rel(s.root, path)
+	if err != nil {
+		rel = path
+	}
+	rel = filepath.ToSlash(rel)
+
+	var findings []Finding
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), maxFileSize)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := scanner.Text()
+		reported := make(map[string]bool)
+		report := func(rule, secret string) {
+			if reported[secret] {
+				return
+			}
+			reported[secret] = true
+			f := Finding{
+				Rule:        rule,
+				File:        rel,
+				Line:        lineNo,
+				Redacted:    redact(secret),
+				Fingerprint: Fingerprint(secret),
+			}
+			if s.allowlist.allows(f) {
+				return
+			}
+			findings = append(findings, f)
+		}
+
+		for _, rule := range s.rules {
+			for _, m := range rule.Pattern.FindAllStringSubmatch(line, -1) {
+				secret := m[0]
+				if len(m) > 1 && m[1] != "" {
+					secret = m[1]
+				}
+				report(rule.ID, secret)
+			}
+		}
+		if s.entropyThreshold > 0 {
+			for _, m := range quotedStringPattern.FindAllStringSubmatch(line, -1) {
+				if isHighEntropy(m[1], s.entropyThreshold) {
+					report(EntropyRuleID, m[1])
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to scan %s: %w", path, err)
+	}
+	return findings, nil
+}
+
+// isHighEntropy reports whether a candidate looks like random key material
+// rather than an identifier or path.
+func isHighEntropy(candidate string, threshold float64) bool {
+	if len(candidate) < minEntropyLength {
+		return false
+	}
+	var upper, lower, digit bool
+	for _, r := range candidate {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			upper = true
+		case r >= 'a' && r <= 'z':
+			lower = true
+		case r >= '0' && r <= '9':
+			digit = true
+		}
+	}
+	// Identifiers like SOME_LONG_CONSTANT_NAME or kebab-case words are not
+	// keys, even when long.
+	if !(upper && lower && digit) {
+		return false
+	}
+	return shannonEntropy(candidate) >= threshold
+}
+
+// shannonEntropy returns the entropy of s in bits per character.
+func shannonEntropy(s string) float64 {
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+	n := float64(len([]rune(s)))
+	var entropy float64
+	for _, c := range counts {
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// Fingerprint identifies a secret value without revealing it, for use in
+// allowlist files.
+func Fingerprint(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return "sha256:" + hex.EncodeToString(sum[:])[:16]
+}
+
+// redact keeps the first four characters of a secret.
+func redact(secret string) string {
+	runes := []rune(secret)
+	if len(runes) <= 8 {
+		return strings.Repeat("*", len(runes))
+	}
+	return string(runes[:4]) + strings.Repeat("*", 8)
+}
+
+// Summarize formats findings for an import error, listing at most limit.
+func Summarize(findings []Finding, limit int) string {
+	sorted := append([]Finding(nil), findings...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].File != sorted[j].File {
+			return sorted[i].File < sorted[j].File
+		}
+		return sorted[i].Line < sorted[j].Line
+	})
+	parts := make([]string, 0, limit+1)
+	for i, f := range sorted {
+		if i == limit {
+			parts = append(parts, fmt.Sprintf("and %d more", len(sorted)-limit))
+			break
+		}
+		parts = append(parts, f.String())
+	}
+	return strings.Join(parts, "; ")
+}
@@ -0,0 +1,158 @@
+package secrets
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/test/testutil"
+)
+
+// Fixtures are split so the test file itself does not look like a leak.
+var (
+	fakeAWSKey    = "AKIA" + "IOSFODNN7EXAMPLE"
+	fakeGitHubPAT = "ghp_" + strings.Repeat("a1B2c3D4e5", 3) + "F6g7H8"
+	fakeEntropy   = "Zx9Qv2Lm8Rt4Wp6Ny1Kb7Hs3Jd5Fg0Ac"
+)
+
+func findingKeys(findings []Finding) []string {
+	var out []string
+	for _, f := range findings {
+		out = append(out, f.String()[:strings.Index(f.String(), " (")])
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestScanPath(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFile(t, filepath.Join(root, "skills/demo/SKILL.md"), strings.Join([]string{
+		"---",
+		"name: demo",
+		"description: Demo skill",
+		"---",
+		"Export AWS_ACCESS_KEY_ID=" + fakeAWSKey + " before running.",
+		"Use `" + fakeGitHubPAT + "` for the API.",
+		"Constants like \"SOME_VERY_LONG_CONSTANT_NAME_HERE\" are fine.",
+	}, "\n"))
+	testutil.WriteFile(t, filepath.Join(root, "skills/demo/scripts/run.py"), "TOKEN = \""+fakeEntropy+"\"\nPATH = \"references/some-long-file-name.md\"\n")
+	testutil.WriteFile(t, filepath.Join(root, "skills/demo/assets/blob.bin"), "AKIA\x00"+fakeAWSKey)
+	testutil.WriteFile(t, filepath.Join(root, "skills/demo/.git/config"), fakeAWSKey)
+	// Symlinks are followed because imports copy their targets.
+	outside := t.TempDir()
+	testutil.WriteFile(t, filepath.Join(outside, "notes.md"), "key: "+fakeAWSKey+"\n")
+	testutil.WriteFile(t, filepath.Join(outside, "refs/token.md"), "token: "+fakeGitHubPAT+"\n")
+	if err := os.Symlink(filepath.Join(outside, "notes.md"), filepath.Join(root, "skills", "demo", "notes.md")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(outside, "refs"), filepath.Join(root, "skills", "demo", "refs")); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(outside, "missing.md"), filepath.Join(root, "skills", "demo", "dangling.md")); err != nil {
+		t.Fatal(err)
+	}
+
+	scanner, err := NewScanner(root, Options{})
+	if err != nil {
+		t.Fatalf("NewScanner() error = %v", err)
+	}
+	findings, err := scanner.ScanPath(filepath.Join(root, "skills", "demo"))
+	if err != nil {
+		t.Fatalf("ScanPath() error = %v", err)
+	}
+
+	got := findingKeys(findings)
+	want := []string{
+		"skills/demo/.git/config:1: aws-access-key-id",
+		"skills/demo/SKILL.md:5: aws-access-key-id",
+		"skills/demo/SKILL.md:6: github-token",
+		"skills/demo/notes.md:1: aws-access-key-id",
+		"skills/demo/refs/token.md:1: github-token",
+		"skills/demo/scripts/run.py:1: high-entropy-string",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("findings:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	for _, f := range findings {
+		if strings.Contains(f.String(), fakeAWSKey) || strings.Contains(f.String(), fakeEntropy) {
+			t.Errorf("finding leaks the secret: %s", f)
+		}
+	}
+}
+
+func TestScanPath_Options(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFile(t, filepath.Join(root, "commands/deploy.md"), "Call with `"+fakeEntropy+"` and internal key ACME-1234-5678-9012.\n")
+
+	scanner, err := NewScanner(root, Options{
+		Patterns:         []Pattern{{ID: "acme-key", Regex: `ACME-\d{4}-\d{4}-\d{4}`}},
+		EntropyThreshold: -1,
+	})
+	if err != nil {
+		t.Fatalf("NewScanner() error = %v", err)
+	}
+	findings, err := scanner.ScanPath(filepath.Join(root, "commands", "deploy.md"))
+	if err != nil {
+		t.Fatalf("ScanPath() error = %v", err)
+	}
+	if got := findingKeys(findings); len(got) != 1 || got[0] != "commands/deploy.md:1: acme-key" {
+		t.Errorf("findings = %v, want only the custom pattern", got)
+	}
+
+	if _, err := NewScanner(root, Options{Patterns: []Pattern{{ID: "bad", Regex: "("}}}); err == nil {
+		t.Error("NewScanner() accepted an invalid pattern")
+	}
+}
+
+func TestAllowlist(t *testing.T) {
+	root := t.TempDir()
+	testutil.WriteFile(t, filepath.Join(root, "skills/a/SKILL.md"), "key "+fakeAWSKey+"\n")
+	testutil.WriteFile(t, filepath.Join(root, "skills/b/scripts/x.py"), "k = \""+fakeEntropy+"\"\nt = \""+fakeGitHubPAT+"\"\n")
+	testutil.WriteFile(t, filepath.Join(root, "skills/c/references/notes.md"), "old key "+fakeAWSKey+"\n")
+	testutil.WriteFile(t, filepath.Join(root, "skills/d/SKILL.md"), "token "+fakeGitHubPAT+"\n")
+
+	allowPath := filepath.Join(root, AllowlistFileName)
+	testutil.WriteFile(t, filepath.Join(root, AllowlistFileName), strings.Join([]string{
+		"# documented example values",
+		Fingerprint(fakeAWSKey) + "  # AWS docs example",
+		"skills/b/scripts/*.py:high-entropy-string",
+		"skills/d/**",
+		"",
+	}, "\n"))
+
+	allowlist, err := LoadAllowlist(allowPath, filepath.Join(root, "missing"))
+	if err != nil {
+		t.Fatalf("LoadAllowlist() error = %v", err)
+	}
+	scanner, err := NewScanner(root, Options{Allowlist: allowlist})
+	if err != nil {
+		t.Fatalf("NewScanner() error = %v", err)
+	}
+	findings, err := scanner.ScanPath(root)
+	if err != nil {
+		t.Fatalf("ScanPath() error = %v", err)
+	}
+	if got := findingKeys(findings); len(got) != 1 || got[0] != "skills/b/scripts/x.py:2: github-token" {
+		t.Errorf("findings = %v, want only the github token in skills/b", got)
+	}
+
+	testutil.WriteFile(t, filepath.Join(root, "bad-allowlist"), "skills/[a:rule\n")
+	if _, err := LoadAllowlist(filepath.Join(root, "bad-allowlist")); err == nil || !strings.Contains(err.Error(), "bad-allowlist:1") {
+		t.Errorf("LoadAllowlist() error = %v, want the offending line", err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	findings := []Finding{
+		{Rule: "r", File: "b.md", Line: 1, Redacted: "****", Fingerprint: "sha256:2"},
+		{Rule: "r", File: "a.md", Line: 9, Redacted: "****", Fingerprint: "sha256:1"},
+		{Rule: "r", File: "a.md", Line: 2, Redacted: "****", Fingerprint: "sha256:0"},
+	}
+	got := Summarize(findings, 2)
+	want := "a.md:2: r (****, sha256:0); a.md:9: r (****, sha256:1); and 1 more"
+	if got != want {
+		t.Errorf("Summarize() = %q, want %q", got, want)
+	}
+}
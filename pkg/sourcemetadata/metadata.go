@@ -21,6 +21,29 @@ type SourceState struct {
 	OverrideOriginalURL     string `json:"override_original_url,omitempty"`
 	OverrideOriginalRef     string `json:"override_original_ref,omitempty"`
 	OverrideOriginalSubpath string `json:"override_original_subpath,omitempty"`
+
+	// Last remote fetch failure, cleared on the next successful sync.
+	// FetchCredentialProvider is a secret-free provider description
+	// (e.g. "env:GITLAB_TOKEN"); empty means git's own credential setup was used.
+	LastFetchError          string `json:"last_fetch_error,omitempty"`
+	FetchCredentialProvider string `json:"fetch_credential_provider,omitempty"`
+
+	// Signature of the last synced commit, recorded for sources with a
+	// verify setting: the SSH principal or GPG user ID that signed it, the
+	// signature format ("ssh" or "gpg") and the commit.
+	VerifiedSigner  string `json:"verified_signer,omitempty"`
+	SignatureFormat string `json:"signature_format,omitempty"`
+	VerifiedCommit  string `json:"verified_commit,omitempty"`
+
+	// Shadowed maps resource references this source provides (e.g. "skill/code-review")
+	// to the higher-priority source that won them during the last sync.
+	Shadowed map[string]string `json:"shadowed,omitempty"`
+}
+
+// ClearFetchFailure resets the recorded fetch failure state
+func (s *SourceState) ClearFetchFailure() {
+	s.LastFetchError = ""
+	s.FetchCredentialProvider = ""
 }
 
 // SourceMetadata tracks state for all sources in a repository
@@ -99,6 +122,53 @@ func (m *SourceMetadata) SetLastSynced(sourceName string, t time.Time) {
 	m.Sources[sourceName].LastSynced = t
 }
 
+// SetFetchFailure records a failed remote fetch and the credential provider used
+func (m *SourceMetadata) SetFetchFailure(sourceName, fetchErr, provider string) {
+	if m.Sources[sourceName] == nil {
+		m.Sources[sourceName] = &SourceState{}
+	}
+	m.Sources[sourceName].LastFetchError = fetchErr
+	m.Sources[sourceName].FetchCredentialProvider = provider
+}
+
+// SetVerifiedSignature records the signer of the last synced commit. An
+// empty signer clears the record.
+func (m *SourceMetadata) SetVerifiedSignature(sourceName, signer, format, commit string) {
+	if m.Sources[sourceName] == nil {
+		m.Sources[sourceName] = &SourceState{}
+	}
+	state := m.Sources[sourceName]
+	if signer == "" {
+		format, commit = "", ""
+	}
+	state.VerifiedSigner = signer
+	state.SignatureFormat = format
+	state.VerifiedCommit = commit
+}
+
+// SetShadowed records which of a source's resources are shadowed and by which
+// source. An empty map clears the record.
+func (m *SourceMetadata) SetShadowed(sourceName string, shadowed map[string]string) {
+	if m.Sources[sourceName] == nil {
+		m.Sources[sourceName] = &SourceState{}
+	}
+	if len(shadowed) == 0 {
+		m.Sources[sourceName].Shadowed = nil
+		return
+	}
+	m.Sources[sourceName].Shadowed = shadowed
+}
+
+// ShadowedBy returns the source that shadows resourceRef for sourceName, if any.
+func (m *SourceMetadata) ShadowedBy(sourceName, resourceRef string) (string, bool) {
+	state := m.Sources[sourceName]
+	if state == nil {
+		return "", false
+	}
+	winner, ok := state.Shadowed[resourceRef]
+	return winner, ok
+}
+
 // Delete removes a source from the metadata
 func (m *SourceMetadata) Delete(sourceName string) {
 	delete(m.Sources, sourceName)
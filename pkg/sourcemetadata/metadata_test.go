@@ -165,3 +165,43 @@ func TestSaveAndLoad_OverrideBreadcrumbs(t *testing.T) {
 		t.Fatalf("override breadcrumbs did not round-trip: %+v", state)
 	}
 }
+
+func TestSetShadowed(t *testing.T) {
+	metadata := &SourceMetadata{Version: 1, Sources: make(map[string]*SourceState)}
+
+	metadata.SetShadowed("team", map[string]string{"skill/code-review": "platform"})
+	if winner, ok := metadata.ShadowedBy("team", "skill/code-review"); !ok || winner != "platform" {
+		t.Fatalf("ShadowedBy() = (%q, %v), want (platform, true)", winner, ok)
+	}
+	if _, ok := metadata.ShadowedBy("platform", "skill/code-review"); ok {
+		t.Fatal("ShadowedBy() for unknown source should be false")
+	}
+
+	metadata.SetShadowed("team", nil)
+	if metadata.Get("team").Shadowed != nil {
+		t.Fatalf("SetShadowed(nil) should clear the record, got %v", metadata.Get("team").Shadowed)
+	}
+}
+
+func TestSetVerifiedSignature(t *testing.T) {
+	tmpDir := t.TempDir()
+	metadata := &SourceMetadata{Version: 1, Sources: make(map[string]*SourceState)}
+
+	metadata.SetVerifiedSignature("team", "alice@example.com", "ssh", "0123456789abcdef")
+	if err := metadata.Save(tmpDir); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := Load(tmpDir)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	state := loaded.Get("team")
+	if state.VerifiedSigner != "alice@example.com" || state.SignatureFormat != "ssh" || state.VerifiedCommit != "0123456789abcdef" {
+		t.Fatalf("loaded state = %+v, want the recorded signature", state)
+	}
+
+	loaded.SetVerifiedSignature("team", "", "ssh", "0123456789abcdef")
+	if state := loaded.Get("team"); state.VerifiedSigner != "" || state.SignatureFormat != "" || state.VerifiedCommit != "" {
+		t.Fatalf("SetVerifiedSignature with empty signer should clear the record, got %+v", state)
+	}
+}
@@ -0,0 +1,182 @@
+// Package tokens estimates how many model tokens resources cost in context.
+//
+// The estimate approximates the byte-pair encodings used by current models
+// without shipping a vocabulary: words, numbers and punctuation runs are
+// costed by length, and ideographic scripts by character. It is typically
+// within 15% of real tokenizers for English prose, markdown and code, which is
+// enough to compare resources and track budgets, not to bill by.
+package tokens
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+)
+
+// maxFileSize is the largest reference file counted; bigger files are data
+// an agent would not read into context whole.
+const maxFileSize = 1 << 20
+
+// piecePattern splits text into words, numbers, punctuation runs and line
+// breaks. Spaces and tabs are folded into the following piece, as BPE
+// vocabularies do.
+var piecePattern = regexp.MustCompile(`[\p{L}\p{M}]+|\p{N}+|[^\s\p{L}\p{M}\p{N}]+|\n+`)
+
+// Estimate returns the approximate token count of text.
+func Estimate(text string) int {
+	total := 0
+	for _, piece := range piecePattern.FindAllString(text, -1) {
+		total += estimatePiece(piece)
+	}
+	return total
+}
+
+func estimatePiece(piece string) int {
+	first := []rune(piece)[0]
+	switch {
+	case first == '\n':
+		return 1
+	case unicode.IsLetter(first) || unicode.IsMark(first):
+		return estimateWord(piece)
+	case unicode.IsNumber(first):
+		return ceilDiv(len(piece), 3)
+	default:
+		return ceilDiv(len([]rune(piece)), 2)
+	}
+}
+
+// estimateWord costs Latin-script words at roughly 4.5 characters per token,
+// other alphabets at two characters per token, and ideographs and syllabaries
+// at one token each.
+func estimateWord(word string) int {
+	ascii, other, ideographic := 0, 0, 0
+	for _, r := range word {
+		switch {
+		case r < unicode.MaxASCII:
+			ascii++
+		case unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul):
+			ideographic++
+		default:
+			other++
+		}
+	}
+	n := ideographic + ceilDiv(other, 2)
+	if ascii > 0 {
+		n += max(1, int(math.Round(float64(ascii)/4.5)))
+	}
+	return n
+}
+
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
+
+// Breakdown is the estimated cost of a resource, split by when each part is
+// loaded: the description is always in context so the model can pick the
+// resource, the body when it is invoked, and references only when the body
+// points the model at them.
+type Breakdown struct {
+	Description    int `json:"description" yaml:"description"`
+	Body           int `json:"body" yaml:"body"`
+	References     int `json:"references" yaml:"references"`
+	ReferenceFiles int `json:"reference_files,omitempty" yaml:"reference_files,omitempty"`
+
+	// Total is the cost of loading everything.
+	Total int `json:"total" yaml:"total"`
+}
+
+// Add returns the sum of two breakdowns.
+func (b Breakdown) Add(other Breakdown) Breakdown {
+	return Breakdown{
+		Description:    b.Description + other.Description,
+		Body:           b.Body + other.Body,
+		References:     b.References + other.References,
+		ReferenceFiles: b.ReferenceFiles + other.ReferenceFiles,
+		Total:          b.Total + other.Total,
+	}
+}
+
+// EstimateResource estimates a command, skill or agent. For skills the
+// references are the text files besides SKILL.md, except scripts and
+// assets, which are run or copied rather than read.
+func EstimateResource(res *resource.Resource) (Breakdown, error) {
+	mainFile := res.Path
+	if res.Type == resource.Skill {
+		mainFile = filepath.Join(res.Path, "SKILL.md")
+	}
+	frontmatter, body, err := resource.ParseFrontmatter(mainFile)
+	if err != nil {
+		return Breakdown{}, fmt.Errorf("failed to read %s/%s: %w", res.Type, res.Name, err)
+	}
+
+	b := Breakdown{
+		Description: Estimate(res.Name + ": " + frontmatter.GetString("description")),
+		Body:        Estimate(body),
+	}
+	b.Total = b.Description + b.Body
+	if res.Type != resource.Skill {
+		return b, nil
+	}
+
+	// Skills from local sources are symlinks into the source tree.
+	root, err := filepath.EvalSymlinks(res.Path)
+	if err != nil {
+		return Breakdown{}, fmt.Errorf("failed to read skill %s: %w", res.Name, err)
+	}
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(root, p)
+		if d.IsDir() {
+			if p != root && (strings.HasPrefix(d.Name(), ".") || rel == "scripts" || rel == "assets" || d.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if rel == "SKILL.md" || strings.HasPrefix(d.Name(), ".") {
+			return nil
+		}
+		text, ok, err := readText(p)
+		if err != nil || !ok {
+			return err
+		}
+		n := Estimate(text)
+		b.References += n
+		b.Total += n
+		b.ReferenceFiles++
+		return nil
+	})
+	if err != nil {
+		return Breakdown{}, fmt.Errorf("failed to read skill %s: %w", res.Name, err)
+	}
+	return b, nil
+}
+
+// readText returns the content of a text file; ok is false for binary and
+// oversized files.
+func readText(path string) (string, bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", false, err
+	}
+	if !info.Mode().IsRegular() || info.Size() > maxFileSize {
+		return "", false, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false, err
+	}
+	if bytes.IndexByte(data[:min(len(data), 8000)], 0) >= 0 {
+		return "", false, nil
+	}
+	return string(data), true, nil
+}
@@ -0,0 +1,95 @@
+package tokens
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/resource"
+	"github.com/dynatrace-oss/ai-config-manager/v3/test/testutil"
+)
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		min, max int
+	}{
+		{"empty", "", 0, 0},
+		{"short words", "the cat sat on the mat", 6, 6},
+		{"long word", "internationalization", 3, 6},
+		{"numbers", "2026 10 18", 3, 6},
+		{"punctuation", "a, b; c.", 5, 7},
+		{"code", "func main() {\n\tfmt.Println(\"hi\")\n}\n", 10, 18},
+		{"ideographs", "日本語のテキスト", 8, 8},
+		{"cyrillic", "привет мир", 4, 6},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Estimate(tt.text)
+			if got < tt.min || got > tt.max {
+				t.Errorf("Estimate(%q) = %d, want %d..%d", tt.text, got, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestEstimate_Prose(t *testing.T) {
+	// About 75 tokens with cl100k-style tokenizers.
+	text := "Use this skill when the user asks to extract text or tables from PDF files, " +
+		"fill in PDF forms, or merge several documents into one. It relies on the " +
+		"bundled scripts for the heavy lifting and reads the reference guide only " +
+		"when a form has unusual field types."
+	if got := Estimate(text); got < 60 || got > 90 {
+		t.Errorf("Estimate(prose) = %d, want 60..90", got)
+	}
+}
+
+func TestEstimateResource(t *testing.T) {
+	dir := t.TempDir()
+
+	commandPath := filepath.Join(dir, "review.md")
+	testutil.WriteFile(t, commandPath, "---\ndescription: Review the staged changes\n---\nRead the diff and list problems.\n")
+	got, err := EstimateResource(&resource.Resource{Type: resource.Command, Name: "review", Path: commandPath})
+	if err != nil {
+		t.Fatalf("EstimateResource(command) error = %v", err)
+	}
+	if got.Description == 0 || got.Body == 0 || got.References != 0 {
+		t.Errorf("EstimateResource(command) = %+v, want description and body only", got)
+	}
+
+	skillPath := filepath.Join(dir, "pdf")
+	testutil.WriteFile(t, filepath.Join(skillPath, "SKILL.md"), "---\nname: pdf\ndescription: Work with PDF files\n---\nSee references/forms.md.\n")
+	testutil.WriteFile(t, filepath.Join(skillPath, "references", "forms.md"), "Forms have fields. Fill them in order.\n")
+	testutil.WriteFile(t, filepath.Join(skillPath, "guide.md"), "Extra guidance.\n")
+	testutil.WriteFile(t, filepath.Join(skillPath, "scripts", "fill.py"), "print('not counted')\n")
+	testutil.WriteFile(t, filepath.Join(skillPath, "assets", "template.txt"), "not counted either\n")
+	testutil.WriteFile(t, filepath.Join(skillPath, "references", "logo.png"), "\x89PNG\x00\x00binary")
+
+	got, err = EstimateResource(&resource.Resource{Type: resource.Skill, Name: "pdf", Path: skillPath})
+	if err != nil {
+		t.Fatalf("EstimateResource(skill) error = %v", err)
+	}
+	if got.ReferenceFiles != 2 {
+		t.Errorf("ReferenceFiles = %d, want 2 (forms.md and guide.md)", got.ReferenceFiles)
+	}
+	want := Estimate("Forms have fields. Fill them in order.\n") + Estimate("Extra guidance.\n")
+	if got.References != want {
+		t.Errorf("References = %d, want %d", got.References, want)
+	}
+	if got.Total != got.Description+got.Body+got.References {
+		t.Errorf("Total = %d, want sum of parts", got.Total)
+	}
+
+	link := filepath.Join(dir, "linked")
+	if err := os.Symlink(skillPath, link); err != nil {
+		t.Fatal(err)
+	}
+	linked, err := EstimateResource(&resource.Resource{Type: resource.Skill, Name: "pdf", Path: link})
+	if err != nil {
+		t.Fatalf("EstimateResource(symlinked skill) error = %v", err)
+	}
+	if linked != got {
+		t.Errorf("EstimateResource(symlinked skill) = %+v, want %+v", linked, got)
+	}
+}
@@ -0,0 +1,215 @@
+package workspace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Reasons a cache is evicted by GC.
+const (
+	EvictIdle = "idle" // not accessed within MaxAge
+	EvictSize = "size" // least recently used while over MaxSize
+)
+
+// CacheUsage describes one cached repository on disk.
+type CacheUsage struct {
+	Hash         string    `json:"hash" yaml:"hash"`
+	URL          string    `json:"url" yaml:"url"`
+	Ref          string    `json:"ref,omitempty" yaml:"ref,omitempty"`
+	Path         string    `json:"path" yaml:"path"`
+	SizeBytes    int64     `json:"size_bytes" yaml:"size_bytes"`
+	LastAccessed time.Time `json:"last_accessed" yaml:"last_accessed"`
+	// Evicted is the reason the cache was (or would be) removed, empty if kept
+	Evicted string `json:"evicted,omitempty" yaml:"evicted,omitempty"`
+}
+
+// GCOptions is the cache budget applied by GC. Zero values disable a limit.
+type GCOptions struct {
+	MaxSize int64         // Maximum total size of all caches in bytes
+	MaxAge  time.Duration // Maximum time since a cache was last accessed
+	DryRun  bool          // Report evictions without removing anything
+	Now     time.Time     // Reference time for MaxAge (defaults to time.Now)
+}
+
+// GCResult reports the caches inspected by GC, most recently used first.
+type GCResult struct {
+	Caches      []CacheUsage `json:"caches" yaml:"caches"`
+	TotalBytes  int64        `json:"total_bytes" yaml:"total_bytes"`
+	EvictCount  int          `json:"evict_count" yaml:"evict_count"`
+	EvictBytes  int64        `json:"evict_bytes" yaml:"evict_bytes"`
+	RemainBytes int64        `json:"remain_bytes" yaml:"remain_bytes"`
+	Failed      []string     `json:"failed,omitempty" yaml:"failed,omitempty"`
+	DryRun      bool         `json:"dry_run" yaml:"dry_run"`
+}
+
+// Usage returns every cached repository with its size and last access time,
+// most recently used first. Caches without a metadata entry are reported with
+// a zero LastAccessed, so they are the first candidates for eviction.
+func (m *Manager) Usage() ([]CacheUsage, error) {
+	entries, err := os.ReadDir(m.workspaceDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return []CacheUsage{}, nil
+		}
+		return nil, fmt.Errorf("failed to read workspace directory: %w", err)
+	}
+
+	metadata, err := m.loadMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	caches := []CacheUsage{}
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		cachePath := filepath.Join(m.workspaceDir, entry.Name())
+		if !m.isValidCache(cachePath) {
+			continue
+		}
+
+		usage := CacheUsage{Hash: entry.Name(), Path: cachePath}
+		if meta, ok := metadata.Caches[entry.Name()]; ok {
+			usage.URL = meta.URL
+			usage.Ref = meta.Ref
+			usage.LastAccessed = meta.LastAccessed
+		} else if url, err := m.getRemoteURL(cachePath); err == nil {
+			usage.URL = normalizeURL(url)
+		}
+		size, err := dirSize(cachePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to measure cache %s: %w", entry.Name(), err)
+		}
+		usage.SizeBytes = size
+		caches = append(caches, usage)
+	}
+
+	sort.SliceStable(caches, func(i, j int) bool {
+		if !caches[i].LastAccessed.Equal(caches[j].LastAccessed) {
+			return caches[i].LastAccessed.After(caches[j].LastAccessed)
+		}
+		return caches[i].Hash < caches[j].Hash
+	})
+	return caches, nil
+}
+
+// GC enforces a cache budget: caches idle for longer than MaxAge are removed,
+// then the least recently used caches are removed until the total size fits
+// MaxSize. Evicted caches are simply cloned again on their next use.
+//
+// Locking:
+//   - Each removal takes the per-cache lock and the workspace metadata lock,
+//     like Remove. Callers should hold the repository write lock so no sync
+//     is using a cache while it is evicted.
+func (m *Manager) GC(opts GCOptions) (*GCResult, error) {
+	caches, err := m.Usage()
+	if err != nil {
+		return nil, err
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	result := &GCResult{Caches: caches, DryRun: opts.DryRun}
+	for _, cache := range caches {
+		result.TotalBytes += cache.SizeBytes
+	}
+
+	remaining := result.TotalBytes
+	if opts.MaxAge > 0 {
+		for i := range caches {
+			if now.Sub(caches[i].LastAccessed) > opts.MaxAge {
+				caches[i].Evicted = EvictIdle
+				remaining -= caches[i].SizeBytes
+			}
+		}
+	}
+	if opts.MaxSize > 0 {
+		// Caches are ordered most recently used first; evict from the end.
+		for i := len(caches) - 1; i >= 0 && remaining > opts.MaxSize; i-- {
+			if caches[i].Evicted == "" {
+				caches[i].Evicted = EvictSize
+				remaining -= caches[i].SizeBytes
+			}
+		}
+	}
+
+	for i := range caches {
+		if caches[i].Evicted == "" {
+			continue
+		}
+		if !opts.DryRun {
+			if err := m.removeCache(caches[i].Hash); err != nil {
+				result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", caches[i].URL, err))
+				caches[i].Evicted = ""
+				continue
+			}
+		}
+		result.EvictCount++
+		result.EvictBytes += caches[i].SizeBytes
+	}
+	result.RemainBytes = result.TotalBytes - result.EvictBytes
+
+	return result, nil
+}
+
+// removeCache deletes the cache directory with the given hash and its
+// metadata entry.
+func (m *Manager) removeCache(hash string) error {
+	cacheLock, err := m.acquireCacheLock(context.Background(), hash)
+	if err != nil {
+		return fmt.Errorf("failed to acquire cache lock at %s: %w", m.locks.CacheLockPath(hash), err)
+	}
+	defer func() {
+		_ = cacheLock.Unlock()
+	}()
+
+	// #nosec G703 -- hash is a directory name read from workspaceDir.
+	if err := os.RemoveAll(filepath.Join(m.workspaceDir, hash)); err != nil {
+		return fmt.Errorf("failed to remove cache directory: %w", err)
+	}
+
+	metadataLock, err := m.acquireWorkspaceMetadataLock(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to acquire workspace metadata lock at %s: %w", m.locks.WorkspaceMetadataLockPath(), err)
+	}
+	defer func() {
+		_ = metadataLock.Unlock()
+	}()
+
+	metadata, err := m.loadMetadata()
+	if err != nil {
+		return err
+	}
+	if _, ok := metadata.Caches[hash]; !ok {
+		return nil
+	}
+	delete(metadata.Caches, hash)
+	return m.saveMetadata(metadata)
+}
+
+// dirSize returns the total size of the regular files below path.
+func dirSize(path string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(path, func(_ string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
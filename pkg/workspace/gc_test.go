@@ -0,0 +1,137 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// createFakeCache creates a cache directory holding size bytes and records
+// its last access time in the cache metadata.
+func createFakeCache(t *testing.T, mgr *Manager, url string, size int, lastAccessed time.Time) string {
+	t.Helper()
+
+	hash := computeHash(url)
+	cachePath := filepath.Join(mgr.workspaceDir, hash)
+	if err := os.MkdirAll(filepath.Join(cachePath, ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(cachePath, "data"), []byte(strings.Repeat("x", size)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	metadata, err := mgr.loadMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadata.Caches[hash] = CacheEntry{URL: normalizeURL(url), Ref: "main", LastAccessed: lastAccessed}
+	if err := mgr.saveMetadata(metadata); err != nil {
+		t.Fatal(err)
+	}
+	return cachePath
+}
+
+func TestGC(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		opts        GCOptions
+		wantEvicted map[string]string
+	}{
+		{name: "no budget", opts: GCOptions{}, wantEvicted: map[string]string{}},
+		{
+			name:        "max age",
+			opts:        GCOptions{MaxAge: 7 * 24 * time.Hour},
+			wantEvicted: map[string]string{"old": EvictIdle},
+		},
+		{
+			name:        "max size evicts least recently used",
+			opts:        GCOptions{MaxSize: 250},
+			wantEvicted: map[string]string{"old": EvictSize, "mid": EvictSize},
+		},
+		{
+			name:        "idle first then size",
+			opts:        GCOptions{MaxAge: 7 * 24 * time.Hour, MaxSize: 250},
+			wantEvicted: map[string]string{"old": EvictIdle, "mid": EvictSize},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, dryRun := range []bool{true, false} {
+			t.Run(tt.name, func(t *testing.T) {
+				mgr, err := NewManager(t.TempDir())
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := mgr.Init(); err != nil {
+					t.Fatal(err)
+				}
+				paths := map[string]string{
+					"new": createFakeCache(t, mgr, "https://example.com/new", 200, now.Add(-time.Hour)),
+					"mid": createFakeCache(t, mgr, "https://example.com/mid", 100, now.Add(-48*time.Hour)),
+					"old": createFakeCache(t, mgr, "https://example.com/old", 100, now.Add(-30*24*time.Hour)),
+				}
+
+				opts := tt.opts
+				opts.Now = now
+				opts.DryRun = dryRun
+				result, err := mgr.GC(opts)
+				if err != nil {
+					t.Fatalf("GC() error = %v", err)
+				}
+
+				if len(result.Caches) != 3 || !strings.HasSuffix(result.Caches[0].URL, "/new") {
+					t.Fatalf("caches = %+v, want 3 ordered by last access", result.Caches)
+				}
+				if result.TotalBytes != 400 {
+					t.Errorf("TotalBytes = %d, want 400", result.TotalBytes)
+				}
+				for _, cache := range result.Caches {
+					name := cache.URL[strings.LastIndex(cache.URL, "/")+1:]
+					if cache.Evicted != tt.wantEvicted[name] {
+						t.Errorf("%s evicted = %q, want %q", name, cache.Evicted, tt.wantEvicted[name])
+					}
+					_, statErr := os.Stat(paths[name])
+					if removed := os.IsNotExist(statErr); removed != (!dryRun && tt.wantEvicted[name] != "") {
+						t.Errorf("%s removed = %v (dry-run %v)", name, removed, dryRun)
+					}
+				}
+				if result.EvictCount != len(tt.wantEvicted) {
+					t.Errorf("EvictCount = %d, want %d", result.EvictCount, len(tt.wantEvicted))
+				}
+
+				if !dryRun {
+					urls, err := mgr.ListCached()
+					if err != nil {
+						t.Fatal(err)
+					}
+					if len(urls) != 3-len(tt.wantEvicted) {
+						t.Errorf("ListCached() after GC = %v", urls)
+					}
+				}
+			})
+		}
+	}
+}
+
+func TestUsage_CacheWithoutMetadata(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	createFakeCache(t, mgr, "https://example.com/tracked", 10, time.Now())
+	if err := os.MkdirAll(filepath.Join(mgr.workspaceDir, "untracked", ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	caches, err := mgr.Usage()
+	if err != nil {
+		t.Fatalf("Usage() error = %v", err)
+	}
+	if len(caches) != 2 || caches[1].Hash != "untracked" || !caches[1].LastAccessed.IsZero() {
+		t.Fatalf("Usage() = %+v, want the untracked cache last", caches)
+	}
+}
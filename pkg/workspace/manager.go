@@ -72,6 +72,8 @@ The Manager provides methods for:
 - ListCached: Enumerate all cached repositories
 - Prune: Remove unused cached repos
 - Remove: Delete a specific cached repo
+- GC: Evict idle or least recently used caches to stay within a size budget
+- VerifyCommit: Check the checked-out commit's SSH or GPG signature against trusted signers
 
 All methods handle edge cases:
 - Corrupted cache (missing .git directory)
@@ -111,6 +113,11 @@ When requesting a different ref (branch/tag/commit) than last used:
 - Clone/fetch/checkout failures are surfaced as command errors
 - Callers receive the underlying git failure context
 
+### 7. Private HTTPS sources
+- Optional credential providers (see pkg/gitauth) are matched by URL host
+- Tokens are passed to clone/fetch/pull via GIT_CONFIG_* environment entries
+- Tokens are never written to .git/config, command arguments, or logs
+
 ## Thread Safety
 
 The Manager is designed to be safe for concurrent use from multiple goroutines.
@@ -147,6 +154,7 @@ import (
 	"time"
 
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/fileutil"
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/gitauth"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/giturl"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repolock"
 )
@@ -304,6 +312,7 @@ type Manager struct {
 	workspaceDir       string // Path to .workspace directory
 	locks              *repolock.Manager
 	lockAcquireTimeout time.Duration
+	credentials        *gitauth.Resolver // Optional HTTPS credential providers
 }
 
 // CacheEntry represents metadata for a single cached repository.
@@ -331,6 +340,40 @@ func NewManager(repoPath string) (*Manager, error) {
 	}, nil
 }
 
+// SetCredentialResolver configures HTTPS credential providers used for
+// clone/fetch/pull. A nil resolver restores the default behavior of relying on
+// the machine's git credential setup.
+func (m *Manager) SetCredentialResolver(r *gitauth.Resolver) {
+	m.credentials = r
+}
+
+// CredentialProvider returns the secret-free description of the credential
+// provider configured for url (e.g. "env:GITLAB_TOKEN"), or "" when none matches.
+func (m *Manager) CredentialProvider(url string) string {
+	rule, ok := m.credentials.Match(url)
+	if !ok {
+		return ""
+	}
+	return rule.Describe()
+}
+
+// gitEnvForURL resolves credentials for url and returns the environment
+// entries to pass to git. Resolution failures are returned with the provider
+// description so callers can report which provider was used.
+func (m *Manager) gitEnvForURL(url string) ([]string, error) {
+	cred, err := m.credentials.Resolve(url)
+	if err != nil {
+		return nil, err
+	}
+	if cred == nil {
+		return nil, nil
+	}
+	if logger != nil {
+		logger.Debug("using configured git credentials", "url", url, "provider", cred.Provider)
+	}
+	return cred.GitEnv(url), nil
+}
+
 // Init initializes the workspace cache directory structure.
 // Creates the .workspace directory if it doesn't exist.
 func (m *Manager) Init() error {
@@ -419,7 +462,7 @@ func (m *Manager) GetOrClone(url string, ref string) (string, error) {
 		if ref != "" {
 			if err := m.checkoutRef(cachePath, ref); err != nil {
 				// If checkout fails, try to recover by fetching
-				if fetchErr := m.fetchRepo(cachePath); fetchErr != nil {
+				if fetchErr := m.fetchRepo(cachePath, url); fetchErr != nil {
 					// Fetch failed - cache may be corrupted, remove and re-clone
 					// #nosec G703 -- cachePath is derived from normalized URL hash under workspaceDir.
 					if removeErr := os.RemoveAll(cachePath); removeErr != nil {
@@ -552,7 +595,7 @@ func (m *Manager) Update(url string, ref string) error {
 	}
 
 	// Fetch latest refs from remote
-	if err := m.fetchRepo(cachePath); err != nil {
+	if err := m.fetchRepo(cachePath, url); err != nil {
 		return fmt.Errorf("failed to fetch from remote: %w", err)
 	}
 
@@ -567,7 +610,7 @@ func (m *Manager) Update(url string, ref string) error {
 		}
 	} else {
 		// No ref specified, pull current branch
-		if err := m.pullCurrentBranch(cachePath); err != nil {
+		if err := m.pullCurrentBranch(cachePath, url); err != nil {
 			return fmt.Errorf("failed to pull current branch: %w", err)
 		}
 	}
@@ -774,6 +817,84 @@ func (m *Manager) Remove(url string) error {
 	return nil
 }
 
+// CachedPath returns the cache directory for a URL and whether it holds a
+// valid cached repository. It does not clone, fetch or lock anything.
+func (m *Manager) CachedPath(url string) (string, bool) {
+	cachePath, _ := m.resolveCacheLocation(url)
+	return cachePath, m.isValidCache(cachePath)
+}
+
+// ImportCache installs a repository cache prepared elsewhere (for example one
+// extracted from an offline bundle) as the cache for url.
+//
+// Parameters:
+//   - url: Git repository URL the cache was cloned from
+//   - ref: Ref checked out in the cache (recorded in metadata)
+//   - srcDir: Directory containing the cached repository (with .git/)
+//
+// Returns:
+//   - bool: True if the cache was installed, false if a valid cache already existed
+//   - error: Non-nil if srcDir is not a git repository or cannot be cloned
+//
+// Behavior:
+//   - An existing valid cache is kept untouched
+//   - srcDir is untrusted: the cache is a fresh clone of it, so its
+//     .git/config (fsmonitor, sshCommand, credential helpers) and hooks are
+//     not carried over. The origin remote is then pointed at url.
+//   - srcDir is left in place; the caller removes it
+//
+// Locking:
+//   - This method is self-locking like GetOrClone: per-cache lock for the move,
+//     workspace metadata lock for the metadata update.
+func (m *Manager) ImportCache(url string, ref string, srcDir string) (bool, error) {
+	if err := m.Init(); err != nil {
+		return false, err
+	}
+	if url == "" {
+		return false, fmt.Errorf("url cannot be empty")
+	}
+	if !m.isValidCache(srcDir) {
+		return false, fmt.Errorf("not a git repository: %s", srcDir)
+	}
+
+	cachePath, cacheHash := m.resolveCacheLocation(url)
+
+	cacheLock, err := m.acquireCacheLock(context.Background(), cacheHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to acquire cache lock at %s: %w", m.locks.CacheLockPath(cacheHash), err)
+	}
+	defer func() {
+		_ = cacheLock.Unlock()
+	}()
+
+	if m.isValidCache(cachePath) {
+		return false, nil
+	}
+
+	// Remove leftovers of an interrupted clone before moving the new cache in.
+	// #nosec G703 -- cachePath is derived from normalized URL hash under workspaceDir.
+	if err := os.RemoveAll(cachePath); err != nil {
+		return false, fmt.Errorf("failed to remove incomplete cache: %w", err)
+	}
+	if _, err := runGitCommand("", "clone", "--quiet", "--no-local", "--no-hardlinks", srcDir, cachePath); err != nil {
+		// #nosec G703 -- cachePath is derived from normalized URL hash under workspaceDir.
+		_ = os.RemoveAll(cachePath)
+		return false, fmt.Errorf("failed to install cache: %w", err)
+	}
+	if _, err := runGitCommand(cachePath, "remote", "set-url", "origin", url); err != nil {
+		// #nosec G703 -- cachePath is derived from normalized URL hash under workspaceDir.
+		_ = os.RemoveAll(cachePath)
+		return false, fmt.Errorf("failed to install cache: %w", err)
+	}
+
+	if err := m.updateMetadataEntryForHash(url, ref, "clone", cacheHash); err != nil {
+		// Log warning but don't fail - metadata is optional
+		fmt.Fprintf(os.Stderr, "warning: failed to update metadata: %v\n", err)
+	}
+
+	return true, nil
+}
+
 func (m *Manager) acquireCacheLock(ctx context.Context, cacheHash string) (*repolock.Lock, error) {
 	return repolock.Acquire(ctx, m.locks.CacheLockPath(cacheHash), m.lockAcquireTimeout)
 }
@@ -804,6 +925,12 @@ func (m *Manager) acquireWorkspaceMetadataLock(ctx context.Context) (*repolock.L
 // runGitCommand executes a git command with comprehensive logging.
 // Logs command execution, output, and failures at appropriate levels.
 func runGitCommand(workDir string, args ...string) (string, error) {
+	return runGitCommandWithEnv(workDir, nil, args...)
+}
+
+// runGitCommandWithEnv executes a git command with extra environment entries.
+// The extra environment may carry credentials and is therefore never logged.
+func runGitCommandWithEnv(workDir string, extraEnv []string, args ...string) (string, error) {
 	// Log command execution at DEBUG level
 	if logger != nil {
 		logger.Debug("executing git command",
@@ -818,6 +945,9 @@ func runGitCommand(workDir string, args ...string) (string, error) {
 	if workDir != "" {
 		cmd.Dir = workDir
 	}
+	if len(extraEnv) > 0 {
+		cmd.Env = append(os.Environ(), extraEnv...)
+	}
 
 	// Execute command and capture combined output
 	output, err := cmd.CombinedOutput()
@@ -1047,9 +1177,17 @@ func (m *Manager) cloneRepo(url string, cachePath string, ref string) error {
 
 	args = append(args, url, cachePath)
 
+	gitEnv, err := m.gitEnvForURL(url)
+	if err != nil {
+		return err
+	}
+
 	// Execute git clone (using parent directory as workdir since target doesn't exist yet)
 	// #nosec G702 -- git subcommand arguments are constructed from validated workspace inputs.
 	cmd := exec.Command("git", args...)
+	if len(gitEnv) > 0 {
+		cmd.Env = append(os.Environ(), gitEnv...)
+	}
 	output, err := cmd.CombinedOutput()
 
 	// Manual logging since we can't use runGitCommand (cachePath doesn't exist yet)
@@ -1127,12 +1265,17 @@ func (m *Manager) checkoutRef(cachePath string, ref string) error {
 }
 
 // fetchRepo fetches the latest refs from the remote repository.
-func (m *Manager) fetchRepo(cachePath string) error {
+func (m *Manager) fetchRepo(cachePath string, url string) error {
 	if logger != nil {
 		logger.Debug("fetching from remote", "cache_path", cachePath)
 	}
 
-	_, err := runGitCommand(cachePath, "fetch", "--all")
+	gitEnv, err := m.gitEnvForURL(url)
+	if err != nil {
+		return err
+	}
+
+	_, err = runGitCommandWithEnv(cachePath, gitEnv, "fetch", "--all")
 	if err != nil {
 		return fmt.Errorf("git fetch failed: %w", err)
 	}
@@ -1218,13 +1361,18 @@ func (m *Manager) resetToOrigin(cachePath string, ref string) error {
 }
 
 // pullCurrentBranch pulls the latest changes for the currently checked out branch.
-func (m *Manager) pullCurrentBranch(cachePath string) error {
+func (m *Manager) pullCurrentBranch(cachePath string, url string) error {
 	if logger != nil {
 		logger.Debug("pulling current branch", "cache_path", cachePath)
 	}
 
-	// Use runGitCommand for consistent logging
-	_, err := runGitCommand(cachePath, "pull")
+	gitEnv, err := m.gitEnvForURL(url)
+	if err != nil {
+		return err
+	}
+
+	// Use runGitCommandWithEnv for consistent logging
+	_, err = runGitCommandWithEnv(cachePath, gitEnv, "pull")
 	if err != nil {
 		return fmt.Errorf("git pull failed: %w", err)
 	}
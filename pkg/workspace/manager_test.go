@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/gitauth"
 	"github.com/dynatrace-oss/ai-config-manager/v3/pkg/repolock"
 )
 
@@ -138,6 +139,54 @@ func TestNewManager(t *testing.T) {
 	}
 }
 
+// TestCredentialResolver verifies credential provider lookup and git env injection
+func TestCredentialResolver(t *testing.T) {
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+
+	// Without a resolver, no provider is reported and no env is injected
+	if got := mgr.CredentialProvider("https://gitlab.example.com/team/repo"); got != "" {
+		t.Errorf("CredentialProvider() without resolver = %q; want empty", got)
+	}
+	env, err := mgr.gitEnvForURL("https://gitlab.example.com/team/repo")
+	if err != nil || env != nil {
+		t.Fatalf("gitEnvForURL() without resolver = (%v, %v); want (nil, nil)", env, err)
+	}
+
+	t.Setenv("AIMGR_WS_TEST_TOKEN", "tok")
+	resolver, err := gitauth.NewResolver([]gitauth.Rule{
+		{Host: "gitlab.example.com", Provider: gitauth.ProviderEnv, Env: "AIMGR_WS_TEST_TOKEN"},
+	})
+	if err != nil {
+		t.Fatalf("NewResolver failed: %v", err)
+	}
+	mgr.SetCredentialResolver(resolver)
+
+	if got := mgr.CredentialProvider("https://gitlab.example.com/team/repo"); got != "env:AIMGR_WS_TEST_TOKEN" {
+		t.Errorf("CredentialProvider() = %q; want %q", got, "env:AIMGR_WS_TEST_TOKEN")
+	}
+	if got := mgr.CredentialProvider("https://github.com/org/repo"); got != "" {
+		t.Errorf("CredentialProvider() for unmatched host = %q; want empty", got)
+	}
+
+	env, err = mgr.gitEnvForURL("https://gitlab.example.com/team/repo")
+	if err != nil {
+		t.Fatalf("gitEnvForURL() failed: %v", err)
+	}
+	if len(env) == 0 || !strings.Contains(strings.Join(env, "\n"), "http.https://gitlab.example.com/.extraHeader") {
+		t.Errorf("gitEnvForURL() = %v; want scoped extraHeader entry", env)
+	}
+
+	// Clone must fail fast (before invoking git) when the provider cannot resolve
+	t.Setenv("AIMGR_WS_TEST_TOKEN", "")
+	err = mgr.cloneRepo("https://gitlab.example.com/team/repo", filepath.Join(t.TempDir(), "clone"), "")
+	if err == nil || !strings.Contains(err.Error(), "env:AIMGR_WS_TEST_TOKEN") {
+		t.Errorf("cloneRepo() error = %v; want provider resolution failure", err)
+	}
+}
+
 // TestInit verifies workspace directory initialization
 func TestInit(t *testing.T) {
 	// Use temporary directory for testing
@@ -511,6 +560,87 @@ func TestRemove_NonExistent(t *testing.T) {
 	}
 }
 
+// TestImportCache verifies installing a prepared cache and keeping existing ones
+func TestImportCache(t *testing.T) {
+	tempDir := t.TempDir()
+	mgr, err := NewManager(tempDir)
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	if err := mgr.Init(); err != nil {
+		t.Fatalf("Init failed: %v", err)
+	}
+
+	url := "https://github.com/example/bundled"
+	remote := createLocalGitRemoteForWorkspaceTest(t)
+	runGit := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, string(output))
+		}
+	}
+	// newCache prepares an untrusted cache as found in a bundle: it carries
+	// a marker commit, a hook and config that runs commands.
+	newCache := func(marker string) string {
+		dir := filepath.Join(t.TempDir(), "cache")
+		runGit("", "clone", "--quiet", remote, dir)
+		if err := os.WriteFile(filepath.Join(dir, "marker"), []byte(marker), 0644); err != nil {
+			t.Fatalf("failed to write marker: %v", err)
+		}
+		runGit(dir, "add", "marker")
+		runGit(dir, "-c", "user.name=Test", "-c", "user.email=test@example.com", "commit", "-m", marker)
+		runGit(dir, "config", "core.fsmonitor", "touch pwned")
+		if err := os.WriteFile(filepath.Join(dir, ".git", "hooks", "post-checkout"), []byte("#!/bin/sh\ntouch pwned\n"), 0755); err != nil {
+			t.Fatalf("failed to write hook: %v", err)
+		}
+		return dir
+	}
+
+	if _, err := mgr.ImportCache(url, "v1", filepath.Join(tempDir, "missing")); err == nil {
+		t.Error("ImportCache should reject a directory without .git")
+	}
+
+	installed, err := mgr.ImportCache(url, "v1", newCache("first"))
+	if err != nil || !installed {
+		t.Fatalf("ImportCache() = %v, %v; want installed", installed, err)
+	}
+	cachePath, ok := mgr.CachedPath(url)
+	if !ok {
+		t.Fatal("CachedPath should report the imported cache as valid")
+	}
+	if data, err := os.ReadFile(filepath.Join(cachePath, "marker")); err != nil || string(data) != "first" {
+		t.Errorf("imported cache should hold the bundled commit, marker = %q (%v)", data, err)
+	}
+	if _, err := os.Stat(filepath.Join(cachePath, ".git", "hooks", "post-checkout")); !os.IsNotExist(err) {
+		t.Errorf("bundled hooks must not be installed, stat error = %v", err)
+	}
+	if out, err := runGitCommand(cachePath, "config", "--get", "core.fsmonitor"); err == nil {
+		t.Errorf("bundled git config must not be installed, core.fsmonitor = %q", out)
+	}
+	if out, err := runGitCommand(cachePath, "remote", "get-url", "origin"); err != nil || out != url {
+		t.Errorf("origin = %q, %v; want %s", out, err, url)
+	}
+
+	metadata, err := mgr.loadMetadata()
+	if err != nil {
+		t.Fatalf("loadMetadata failed: %v", err)
+	}
+	if entry := metadata.Caches[computeHash(url)]; entry.Ref != "v1" || entry.LastUpdated.IsZero() {
+		t.Errorf("unexpected metadata entry: %+v", entry)
+	}
+
+	installed, err = mgr.ImportCache(url, "v2", newCache("second"))
+	if err != nil || installed {
+		t.Fatalf("ImportCache() over existing cache = %v, %v; want kept", installed, err)
+	}
+	data, err := os.ReadFile(filepath.Join(cachePath, "marker"))
+	if err != nil || string(data) != "first" {
+		t.Errorf("existing cache should be kept, marker = %q (%v)", data, err)
+	}
+}
+
 // TestPrune verifies pruning unreferenced caches
 func TestPrune(t *testing.T) {
 	// This is a unit test that doesn't need real Git repos
@@ -0,0 +1,206 @@
+package workspace
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Signature formats.
+const (
+	SignatureSSH = "ssh"
+	SignatureGPG = "gpg"
+)
+
+// ErrSignatureVerification is returned (wrapped) when the checked-out commit
+// is unsigned or not signed by a trusted signer.
+var ErrSignatureVerification = errors.New("commit signature verification failed")
+
+// Signature describes a verified commit signature.
+type Signature struct {
+	Commit string `json:"commit" yaml:"commit"`
+	Format string `json:"format" yaml:"format"` // SignatureSSH or SignatureGPG
+	Signer string `json:"signer" yaml:"signer"` // SSH principal or GPG user ID
+	Key    string `json:"key,omitempty" yaml:"key,omitempty"`
+}
+
+// String returns the signer with its format, e.g. "alice@example.com (ssh)".
+func (s *Signature) String() string {
+	if s == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s (%s)", s.Signer, s.Format)
+}
+
+// VerifyCommit verifies the signature of the commit checked out in the cache
+// for url. signers is an SSH allowed_signers file or a GPG keyring (binary or
+// ASCII-armored public keys); the signature must be of the matching kind and
+// made by one of its keys. Call it after GetOrClone/Update and before
+// discovering resources in the checkout.
+//
+// Locking:
+//   - Takes the per-cache lock while git reads the checkout, like Update.
+func (m *Manager) VerifyCommit(url string, signers string) (*Signature, error) {
+	if url == "" {
+		return nil, fmt.Errorf("url cannot be empty")
+	}
+	keyData, err := os.ReadFile(signers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read signers file: %w", err)
+	}
+	format := signersFormat(signers, keyData)
+
+	cachePath, cacheHash := m.resolveCacheLocation(url)
+	cacheLock, err := m.acquireCacheLock(context.Background(), cacheHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to acquire cache lock at %s: %w", m.locks.CacheLockPath(cacheHash), err)
+	}
+	defer func() {
+		_ = cacheLock.Unlock()
+	}()
+
+	if !m.isValidCache(cachePath) {
+		return nil, fmt.Errorf("cache does not exist for URL: %s (use GetOrClone first)", url)
+	}
+
+	commit, err := runGitCommand(cachePath, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve checked-out commit: %w", err)
+	}
+	raw, err := runGitCommand(cachePath, "cat-file", "commit", commit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commit %s: %w", commit, err)
+	}
+	signed := commitSignatureFormat(raw)
+	if signed == "" {
+		return nil, fmt.Errorf("%w: commit %s is not signed", ErrSignatureVerification, shortCommit(commit))
+	}
+	if signed != format {
+		return nil, fmt.Errorf("%w: commit %s has a %s signature but the trusted signers are %s keys",
+			ErrSignatureVerification, shortCommit(commit), signed, format)
+	}
+
+	var args, env []string
+	switch format {
+	case SignatureSSH:
+		absSigners, err := filepath.Abs(signers)
+		if err != nil {
+			return nil, fmt.Errorf("invalid signers path: %w", err)
+		}
+		args = append(args, "-c", "gpg.ssh.allowedSignersFile="+absSigners)
+	case SignatureGPG:
+		home, cleanup, err := gpgHomeWithKeys(signers)
+		if err != nil {
+			return nil, err
+		}
+		defer cleanup()
+		env = append(env, "GNUPGHOME="+home)
+	}
+
+	// %G? is G only for a good signature by a trusted key: a listed SSH
+	// principal, or any key of the keyring (see gpgHomeWithKeys).
+	args = append(args, "log", "-1", "--format=%G?%n%GS%n%GF", commit)
+	out, err := runGitCommandWithEnv(cachePath, env, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check signature of commit %s: %w", shortCommit(commit), err)
+	}
+	fields := strings.SplitN(out, "\n", 3)
+	for len(fields) < 3 {
+		fields = append(fields, "")
+	}
+	status, signer, key := strings.TrimSpace(fields[0]), strings.TrimSpace(fields[1]), strings.TrimSpace(fields[2])
+	if status != "G" {
+		return nil, fmt.Errorf("%w: commit %s: %s", ErrSignatureVerification, shortCommit(commit), describeSignatureStatus(status))
+	}
+
+	return &Signature{Commit: commit, Format: format, Signer: signer, Key: key}, nil
+}
+
+// signersFormat tells an SSH allowed_signers file from a GPG keyring.
+func signersFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gpg", ".pgp", ".asc", ".kbx":
+		return SignatureGPG
+	}
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("-----BEGIN PGP")) || (len(trimmed) > 0 && trimmed[0]&0x80 != 0) {
+		return SignatureGPG
+	}
+	return SignatureSSH
+}
+
+// commitSignatureFormat returns the kind of signature in a raw commit object,
+// or "" if it is unsigned.
+func commitSignatureFormat(raw string) string {
+	for _, line := range strings.Split(raw, "\n") {
+		if line == "" {
+			break // end of headers
+		}
+		if !strings.HasPrefix(line, "gpgsig ") {
+			continue
+		}
+		if strings.Contains(line, "SSH SIGNATURE") {
+			return SignatureSSH
+		}
+		return SignatureGPG
+	}
+	return ""
+}
+
+// gpgHomeWithKeys imports a keyring into a temporary GnuPG home, so only
+// those keys are known while git checks the signature.
+func gpgHomeWithKeys(keyring string) (string, func(), error) {
+	home, err := os.MkdirTemp("", "aimgr-gpg-")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create GnuPG home: %w", err)
+	}
+	// The keyring is the trust anchor, so every key in it is fully trusted.
+	if err := os.WriteFile(filepath.Join(home, "gpg.conf"), []byte("trust-model always\n"), 0600); err != nil {
+		_ = os.RemoveAll(home)
+		return "", nil, fmt.Errorf("failed to configure GnuPG home: %w", err)
+	}
+	cleanup := func() {
+		// #nosec G204 -- home is the temporary directory created above.
+		_ = exec.Command("gpgconf", "--homedir", home, "--kill", "all").Run()
+		_ = os.RemoveAll(home)
+	}
+
+	// #nosec G204 -- keyring is the configured signers file.
+	cmd := exec.Command("gpg", "--homedir", home, "--batch", "--quiet", "--import", keyring)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		cleanup()
+		return "", nil, fmt.Errorf("failed to import GPG keyring %s: %w\nOutput: %s", keyring, err, strings.TrimSpace(string(output)))
+	}
+	return home, cleanup, nil
+}
+
+func describeSignatureStatus(status string) string {
+	switch status {
+	case "B":
+		return "bad signature"
+	case "X", "Y":
+		return "signature made with an expired key"
+	case "R":
+		return "signature made with a revoked key"
+	case "E":
+		return "signed by a key that is not in the trusted signers"
+	case "U":
+		return "signing key is not in the trusted signers"
+	case "N":
+		return "no signature"
+	default:
+		return fmt.Sprintf("signature not trusted (status %q)", status)
+	}
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 12 {
+		return commit[:12]
+	}
+	return commit
+}
@@ -0,0 +1,195 @@
+package workspace
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newSSHSigningKey generates an ed25519 key and returns the private key path
+// and an allowed_signers line for principal.
+func newSSHSigningKey(t *testing.T, principal string) (string, string) {
+	t.Helper()
+	keyPath := filepath.Join(t.TempDir(), "id_ed25519")
+	if output, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", principal, "-f", keyPath).CombinedOutput(); err != nil {
+		t.Fatalf("ssh-keygen failed: %v\n%s", err, output)
+	}
+	pub, err := os.ReadFile(keyPath + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := strings.Fields(string(pub))
+	return keyPath, principal + " " + fields[0] + " " + fields[1] + "\n"
+}
+
+// createSignedRemote creates a bare repository whose only commit is signed
+// with signingKey, or unsigned if signingKey is empty.
+func createSignedRemote(t *testing.T, signingKey string) string {
+	if signingKey == "" {
+		return createSignedRemoteWith(t, nil)
+	}
+	return createSignedRemoteWith(t, nil, "-c", "gpg.format=ssh", "-c", "user.signingkey="+signingKey)
+}
+
+// createSignedRemoteWith creates a bare repository with one commit. The
+// commit is signed when signing config is given, using the extra environment.
+func createSignedRemoteWith(t *testing.T, env []string, signingConfig ...string) string {
+	t.Helper()
+	seed := filepath.Join(t.TempDir(), "seed")
+	runGit := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = seed
+		cmd.Env = append(os.Environ(), env...)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, output)
+		}
+	}
+	if err := os.MkdirAll(seed, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit("init", "-b", "main")
+	if err := os.WriteFile(filepath.Join(seed, "README.md"), []byte("signed\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit("add", "README.md")
+	commit := []string{"-c", "user.name=Test", "-c", "user.email=test@example.com"}
+	if len(signingConfig) > 0 {
+		commit = append(append(commit, signingConfig...), "commit", "-S")
+	} else {
+		commit = append(commit, "commit")
+	}
+	runGit(append(commit, "-m", "initial")...)
+
+	bare := filepath.Join(t.TempDir(), "remote.git")
+	if output, err := exec.Command("git", "clone", "--bare", seed, bare).CombinedOutput(); err != nil {
+		t.Fatalf("git clone --bare failed: %v\n%s", err, output)
+	}
+	return bare
+}
+
+func TestVerifyCommit_SSH(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+
+	trustedKey, trustedLine := newSSHSigningKey(t, "alice@example.com")
+	otherKey, _ := newSSHSigningKey(t, "mallory@example.com")
+	signers := filepath.Join(t.TempDir(), "allowed_signers")
+	if err := os.WriteFile(signers, []byte(trustedLine), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		signingKey string
+		wantSigner string
+	}{
+		{name: "trusted signer", signingKey: trustedKey, wantSigner: "alice@example.com"},
+		{name: "untrusted signer", signingKey: otherKey},
+		{name: "unsigned commit"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mgr, err := NewManager(t.TempDir())
+			if err != nil {
+				t.Fatalf("NewManager failed: %v", err)
+			}
+			url := createSignedRemote(t, tt.signingKey)
+			if _, err := mgr.GetOrClone(url, "main"); err != nil {
+				t.Fatalf("GetOrClone failed: %v", err)
+			}
+
+			sig, err := mgr.VerifyCommit(url, signers)
+			if tt.wantSigner == "" {
+				if !errors.Is(err, ErrSignatureVerification) {
+					t.Fatalf("VerifyCommit() error = %v, want ErrSignatureVerification", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyCommit() error = %v", err)
+			}
+			if sig.Signer != tt.wantSigner || sig.Format != SignatureSSH || len(sig.Commit) != 40 {
+				t.Errorf("VerifyCommit() = %+v, want ssh signature by %s", sig, tt.wantSigner)
+			}
+		})
+	}
+}
+
+func TestVerifyCommit_GPG(t *testing.T) {
+	if _, err := exec.LookPath("gpg"); err != nil {
+		t.Skip("gpg not available")
+	}
+
+	// Keys live in a short temporary home; GnuPG sockets need short paths.
+	home, err := os.MkdirTemp("", "gpgtest-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = exec.Command("gpgconf", "--homedir", home, "--kill", "all").Run()
+		_ = os.RemoveAll(home)
+	})
+	gpg := func(args ...string) []byte {
+		t.Helper()
+		output, err := exec.Command("gpg", append([]string{"--homedir", home, "--batch", "--quiet", "--passphrase", ""}, args...)...).Output()
+		if err != nil {
+			t.Fatalf("gpg %s failed: %v", strings.Join(args, " "), err)
+		}
+		return output
+	}
+	gpg("--quick-gen-key", "Bob <bob@example.com>", "ed25519", "sign", "never")
+	signers := filepath.Join(t.TempDir(), "trusted.asc")
+	if err := os.WriteFile(signers, gpg("--export", "--armor", "bob@example.com"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mgr, err := NewManager(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewManager failed: %v", err)
+	}
+	url := createSignedRemoteWith(t, []string{"GNUPGHOME=" + home}, "-c", "user.signingkey=bob@example.com")
+	if _, err := mgr.GetOrClone(url, "main"); err != nil {
+		t.Fatalf("GetOrClone failed: %v", err)
+	}
+
+	sig, err := mgr.VerifyCommit(url, signers)
+	if err != nil {
+		t.Fatalf("VerifyCommit() error = %v", err)
+	}
+	if sig.Format != SignatureGPG || !strings.Contains(sig.Signer, "bob@example.com") {
+		t.Errorf("VerifyCommit() = %+v, want gpg signature by bob@example.com", sig)
+	}
+
+	// An SSH allowed_signers file does not accept a GPG signature.
+	sshSigners := filepath.Join(t.TempDir(), "allowed_signers")
+	if err := os.WriteFile(sshSigners, []byte("bob@example.com ssh-ed25519 AAAA\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := mgr.VerifyCommit(url, sshSigners); !errors.Is(err, ErrSignatureVerification) {
+		t.Errorf("VerifyCommit() with SSH signers error = %v, want ErrSignatureVerification", err)
+	}
+}
+
+func TestSignersFormat(t *testing.T) {
+	tests := []struct {
+		path string
+		data string
+		want string
+	}{
+		{"allowed_signers", "alice@example.com ssh-ed25519 AAAA\n", SignatureSSH},
+		{"keys.asc", "-----BEGIN PGP PUBLIC KEY BLOCK-----\n", SignatureGPG},
+		{"trusted", "-----BEGIN PGP PUBLIC KEY BLOCK-----\n", SignatureGPG},
+		{"pubring", "\x99\x01\x0d", SignatureGPG},
+		{"keyring.gpg", "", SignatureGPG},
+	}
+	for _, tt := range tests {
+		if got := signersFormat(tt.path, []byte(tt.data)); got != tt.want {
+			t.Errorf("signersFormat(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package testutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// WriteFile writes content to path, creating missing parent directories.
+// The test fails immediately if either step fails.
+//
+// Example:
+//
+//	root := t.TempDir()
+//	WriteFile(t, filepath.Join(root, "skills", "demo", "SKILL.md"), "---\nname: demo\n---\n")
+func WriteFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create directory for %s: %v", path, err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}